@@ -75,6 +75,12 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error { // nolint
 	}
 
 	dst.Spec.NetworkSpec.APIServerLB = restored.Spec.NetworkSpec.APIServerLB
+	dst.Spec.NetworkSpec.LoadBalancers = restored.Spec.NetworkSpec.LoadBalancers
+	dst.Spec.NetworkSpec.NodeOutboundLB = restored.Spec.NetworkSpec.NodeOutboundLB
+	dst.Spec.PrivateLink = restored.Spec.PrivateLink
+	dst.Spec.PrivateDNSZone = restored.Spec.PrivateDNSZone
+	dst.Spec.ProximityPlacementGroup = restored.Spec.ProximityPlacementGroup
+	dst.Status.LongRunningOperationStates = restored.Status.LongRunningOperationStates
 
 	// Manually convert conditions
 	dst.SetConditions(restored.GetConditions())
@@ -42,6 +42,7 @@ func (src *AzureMachine) ConvertTo(dstRaw conversion.Hub) error { // nolint
 
 	// Manual conversion for conditions
 	dst.SetConditions(restored.GetConditions())
+	dst.Status.LongRunningOperationState = restored.Status.LongRunningOperationState
 
 	return nil
 }
@@ -54,17 +55,26 @@ func restoreAzureMachineSpec(restored, dst *infrav1alpha3.AzureMachineSpec) {
 		dst.UserAssignedIdentities = restored.UserAssignedIdentities
 	}
 	dst.RoleAssignmentName = restored.RoleAssignmentName
+	if len(restored.RoleAssignments) != 0 {
+		dst.RoleAssignments = restored.RoleAssignments
+	}
 	if restored.AcceleratedNetworking != nil {
 		dst.AcceleratedNetworking = restored.AcceleratedNetworking
 	}
 	dst.FailureDomain = restored.FailureDomain
 	dst.EnableIPForwarding = restored.EnableIPForwarding
+	if len(restored.LoadBalancerBackendPools) != 0 {
+		dst.LoadBalancerBackendPools = restored.LoadBalancerBackendPools
+	}
 	if restored.SpotVMOptions != nil {
 		dst.SpotVMOptions = restored.SpotVMOptions.DeepCopy()
 	}
 	if restored.SecurityProfile != nil {
 		dst.SecurityProfile = restored.SecurityProfile.DeepCopy()
 	}
+	dst.DedicatedHostGroupID = restored.DedicatedHostGroupID
+	dst.VMExtensions = restored.VMExtensions
+	dst.BootstrapData = restored.BootstrapData
 	if len(restored.DataDisks) != 0 {
 		dst.DataDisks = restored.DataDisks
 	}
@@ -384,6 +384,9 @@ func autoConvert_v1alpha3_AzureClusterSpec_To_v1alpha2_AzureClusterSpec(in *v1al
 	out.Location = in.Location
 	// WARNING: in.ControlPlaneEndpoint requires manual conversion: does not exist in peer-type
 	out.AdditionalTags = *(*Tags)(unsafe.Pointer(&in.AdditionalTags))
+	// WARNING: in.PrivateLink requires manual conversion: does not exist in peer-type
+	// WARNING: in.PrivateDNSZone requires manual conversion: does not exist in peer-type
+	// WARNING: in.ProximityPlacementGroup requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -399,6 +402,7 @@ func autoConvert_v1alpha3_AzureClusterStatus_To_v1alpha2_AzureClusterStatus(in *
 	// WARNING: in.FailureDomains requires manual conversion: does not exist in peer-type
 	out.Ready = in.Ready
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
+	// WARNING: in.LongRunningOperationStates requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -520,6 +524,7 @@ func autoConvert_v1alpha3_AzureMachineSpec_To_v1alpha2_AzureMachineSpec(in *v1al
 	// WARNING: in.Identity requires manual conversion: does not exist in peer-type
 	// WARNING: in.UserAssignedIdentities requires manual conversion: does not exist in peer-type
 	// WARNING: in.RoleAssignmentName requires manual conversion: does not exist in peer-type
+	// WARNING: in.RoleAssignments requires manual conversion: does not exist in peer-type
 	if err := Convert_v1alpha3_OSDisk_To_v1alpha2_OSDisk(&in.OSDisk, &out.OSDisk, s); err != nil {
 		return err
 	}
@@ -529,9 +534,13 @@ func autoConvert_v1alpha3_AzureMachineSpec_To_v1alpha2_AzureMachineSpec(in *v1al
 	out.AdditionalTags = *(*Tags)(unsafe.Pointer(&in.AdditionalTags))
 	out.AllocatePublicIP = in.AllocatePublicIP
 	// WARNING: in.EnableIPForwarding requires manual conversion: does not exist in peer-type
+	// WARNING: in.LoadBalancerBackendPools requires manual conversion: does not exist in peer-type
 	// WARNING: in.AcceleratedNetworking requires manual conversion: does not exist in peer-type
 	// WARNING: in.SpotVMOptions requires manual conversion: does not exist in peer-type
 	// WARNING: in.SecurityProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.DedicatedHostGroupID requires manual conversion: does not exist in peer-type
+	// WARNING: in.VMExtensions requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootstrapData requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -551,6 +560,7 @@ func autoConvert_v1alpha3_AzureMachineStatus_To_v1alpha2_AzureMachineStatus(in *
 	// WARNING: in.FailureReason requires manual conversion: does not exist in peer-type
 	// WARNING: in.FailureMessage requires manual conversion: does not exist in peer-type
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
+	// WARNING: in.LongRunningOperationState requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -794,6 +804,8 @@ func autoConvert_v1alpha3_NetworkSpec_To_v1alpha2_NetworkSpec(in *v1alpha3.Netwo
 		out.Subnets = nil
 	}
 	// WARNING: in.APIServerLB requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeOutboundLB requires manual conversion: does not exist in peer-type
+	// WARNING: in.LoadBalancers requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -18,6 +18,7 @@ package v1alpha3
 
 import (
 	"fmt"
+	"strings"
 )
 
 const (
@@ -34,12 +35,15 @@ const (
 func (c *AzureCluster) setDefaults() {
 	c.setResourceGroupDefault()
 	c.setNetworkSpecDefaults()
+	c.setPrivateLinkDefaults()
 }
 
 func (c *AzureCluster) setNetworkSpecDefaults() {
 	c.setVnetDefaults()
 	c.setSubnetDefaults()
 	c.setAPIServerLBDefaults()
+	c.setNodeOutboundLBDefaults()
+	c.setLoadBalancersDefaults()
 }
 
 func (c *AzureCluster) setResourceGroupDefault() {
@@ -48,6 +52,12 @@ func (c *AzureCluster) setResourceGroupDefault() {
 	}
 }
 
+func (c *AzureCluster) setPrivateLinkDefaults() {
+	if c.Spec.PrivateLink != nil && c.Spec.PrivateLink.Name == "" {
+		c.Spec.PrivateLink.Name = generatePrivateLinkName(c.ObjectMeta.Name)
+	}
+}
+
 func (c *AzureCluster) setVnetDefaults() {
 	if c.Spec.NetworkSpec.Vnet.ResourceGroup == "" {
 		c.Spec.NetworkSpec.Vnet.ResourceGroup = c.Spec.ResourceGroup
@@ -82,6 +92,10 @@ func (c *AzureCluster) setSubnetDefaults() {
 	if cpSubnet.SecurityGroup.Name == "" {
 		cpSubnet.SecurityGroup.Name = generateControlPlaneSecurityGroupName(c.ObjectMeta.Name)
 	}
+	// The control plane subnet only gets a route table when it has user-defined routes.
+	if cpSubnet.RouteTable.Name == "" && len(cpSubnet.RouteTable.Routes) > 0 {
+		cpSubnet.RouteTable.Name = generateControlPlaneRouteTableName(c.ObjectMeta.Name)
+	}
 
 	if nodeSubnet.Name == "" {
 		nodeSubnet.Name = generateNodeSubnetName(c.ObjectMeta.Name)
@@ -134,6 +148,54 @@ func (c *AzureCluster) setAPIServerLBDefaults() {
 			}
 		}
 	}
+	setPublicIPNameDefaults(lb.FrontendIPs)
+}
+
+func (c *AzureCluster) setNodeOutboundLBDefaults() {
+	if lb := c.Spec.NetworkSpec.NodeOutboundLB; lb != nil {
+		setPublicIPNameDefaults(lb.FrontendIPs)
+	}
+}
+
+func (c *AzureCluster) setLoadBalancersDefaults() {
+	for i := range c.Spec.NetworkSpec.LoadBalancers {
+		lb := &c.Spec.NetworkSpec.LoadBalancers[i]
+		if lb.Type == "" {
+			lb.Type = Internal
+		}
+		if lb.SKU == "" {
+			lb.SKU = SKUStandard
+		}
+		if len(lb.FrontendIPs) == 0 {
+			frontendIP := FrontendIP{
+				Name: generateFrontendIPConfigName(lb.Name),
+			}
+			if lb.Type == Public {
+				frontendIP.PublicIP = &PublicIPSpec{
+					Name: generateLoadBalancerPublicIPName(c.ObjectMeta.Name, lb.Name),
+				}
+			}
+			lb.FrontendIPs = []FrontendIP{frontendIP}
+		}
+		setPublicIPNameDefaults(lb.FrontendIPs)
+		if len(lb.BackendPools) == 0 {
+			lb.BackendPools = []BackendPool{
+				{
+					Name: generateBackendAddressPoolName(lb.Name),
+				},
+			}
+		}
+	}
+}
+
+// setPublicIPNameDefaults sets the name of public IPs referenced by resource ID to the name of the existing public IP.
+func setPublicIPNameDefaults(frontendIPs []FrontendIP) {
+	for i := range frontendIPs {
+		ip := frontendIPs[i].PublicIP
+		if ip != nil && ip.ID != "" && ip.Name == "" {
+			ip.Name = ip.ID[strings.LastIndex(ip.ID, "/")+1:]
+		}
+	}
 }
 
 // generateVnetName generates a virtual network name, based on the cluster name.
@@ -161,11 +223,21 @@ func generateNodeSecurityGroupName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "node-nsg")
 }
 
+// generateControlPlaneRouteTableName generates a control plane route table name, based on the cluster name.
+func generateControlPlaneRouteTableName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "controlplane-routetable")
+}
+
 // generateNodeRouteTableName generates a node route table name, based on the cluster name.
 func generateNodeRouteTableName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "node-routetable")
 }
 
+// generatePrivateLinkName generates a Private Link Service name, based on the cluster name.
+func generatePrivateLinkName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "apiserver-pls")
+}
+
 // generateInternalLBName generates a internal load balancer name, based on the cluster name.
 func generateInternalLBName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "internal-lb")
@@ -185,3 +257,13 @@ func generatePublicIPName(clusterName string) string {
 func generateFrontendIPConfigName(lbName string) string {
 	return fmt.Sprintf("%s-%s", lbName, "frontEnd")
 }
+
+// generateBackendAddressPoolName generates a load balancer backend address pool name.
+func generateBackendAddressPoolName(lbName string) string {
+	return fmt.Sprintf("%s-%s", lbName, "backendPool")
+}
+
+// generateLoadBalancerPublicIPName generates a public IP name for an additional load balancer, based on the cluster name and the load balancer name.
+func generateLoadBalancerPublicIPName(clusterName, lbName string) string {
+	return fmt.Sprintf("pip-%s-%s", clusterName, lbName)
+}
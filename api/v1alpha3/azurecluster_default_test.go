@@ -424,6 +424,59 @@ func TestSubnetDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "control plane subnet routes specified",
+			cluster: &AzureCluster{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								Role: SubnetControlPlane,
+								Name: "cluster-test-controlplane-subnet",
+								RouteTable: RouteTable{
+									Routes: []Route{{Name: "egress", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance, NextHopIPAddress: "10.1.0.4"}},
+								},
+							},
+							{
+								Role: SubnetNode,
+								Name: "cluster-test-node-subnet",
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								Role:          SubnetControlPlane,
+								Name:          "cluster-test-controlplane-subnet",
+								CIDRBlocks:    []string{DefaultControlPlaneSubnetCIDR},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-controlplane-nsg"},
+								RouteTable: RouteTable{
+									Name:   "cluster-test-controlplane-routetable",
+									Routes: []Route{{Name: "egress", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance, NextHopIPAddress: "10.1.0.4"}},
+								},
+							},
+							{
+								Role:          SubnetNode,
+								Name:          "cluster-test-node-subnet",
+								CIDRBlocks:    []string{DefaultNodeSubnetCIDR},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-node-nsg"},
+								RouteTable:    RouteTable{Name: "cluster-test-node-routetable"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "only node subnet specified",
 			cluster: &AzureCluster{
@@ -578,6 +631,51 @@ func TestAPIServerLBDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "public lb with existing public IP",
+			cluster: &AzureCluster{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							FrontendIPs: []FrontendIP{
+								{
+									Name: "my-frontend",
+									PublicIP: &PublicIPSpec{
+										ID: "/subscriptions/123/resourceGroups/my-ip-rg/providers/Microsoft.Network/publicIPAddresses/my-reserved-ip",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							Name: "cluster-test-public-lb",
+							SKU:  SKUStandard,
+							FrontendIPs: []FrontendIP{
+								{
+									Name: "my-frontend",
+									PublicIP: &PublicIPSpec{
+										Name: "my-reserved-ip",
+										ID:   "/subscriptions/123/resourceGroups/my-ip-rg/providers/Microsoft.Network/publicIPAddresses/my-reserved-ip",
+									},
+								},
+							},
+							Type: Public,
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "internal lb",
 			cluster: &AzureCluster{
@@ -628,3 +726,182 @@ func TestAPIServerLBDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadBalancersDefaults(t *testing.T) {
+	cases := []struct {
+		name    string
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		{
+			name: "no additional lbs",
+			cluster: &AzureCluster{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{},
+				},
+			},
+		},
+		{
+			name: "internal lb",
+			cluster: &AzureCluster{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						LoadBalancers: []LoadBalancerSpec{
+							{
+								Name: "ingress-lb",
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						LoadBalancers: []LoadBalancerSpec{
+							{
+								Name: "ingress-lb",
+								SKU:  SKUStandard,
+								FrontendIPs: []FrontendIP{
+									{
+										Name: "ingress-lb-frontEnd",
+									},
+								},
+								Type: Internal,
+								BackendPools: []BackendPool{
+									{
+										Name: "ingress-lb-backendPool",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "public lb with custom backend pools",
+			cluster: &AzureCluster{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						LoadBalancers: []LoadBalancerSpec{
+							{
+								Name: "ingress-lb",
+								Type: Public,
+								BackendPools: []BackendPool{
+									{
+										Name: "web",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						LoadBalancers: []LoadBalancerSpec{
+							{
+								Name: "ingress-lb",
+								SKU:  SKUStandard,
+								FrontendIPs: []FrontendIP{
+									{
+										Name: "ingress-lb-frontEnd",
+										PublicIP: &PublicIPSpec{
+											Name: "pip-cluster-test-ingress-lb",
+										},
+									},
+								},
+								Type: Public,
+								BackendPools: []BackendPool{
+									{
+										Name: "web",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.cluster.setLoadBalancersDefaults()
+			if !reflect.DeepEqual(tc.cluster, tc.output) {
+				expected, _ := json.MarshalIndent(tc.output, "", "\t")
+				actual, _ := json.MarshalIndent(tc.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestPrivateLinkDefaults(t *testing.T) {
+	cases := []struct {
+		name    string
+		cluster *AzureCluster
+		output  *PrivateLinkSpec
+	}{
+		{
+			name:    "no private link",
+			cluster: &AzureCluster{ObjectMeta: v1.ObjectMeta{Name: "cluster-test"}},
+			output:  nil,
+		},
+		{
+			name: "private link name defaulted",
+			cluster: &AzureCluster{
+				ObjectMeta: v1.ObjectMeta{Name: "cluster-test"},
+				Spec:       AzureClusterSpec{PrivateLink: &PrivateLinkSpec{}},
+			},
+			output: &PrivateLinkSpec{Name: "cluster-test-apiserver-pls"},
+		},
+		{
+			name: "private link name specified",
+			cluster: &AzureCluster{
+				ObjectMeta: v1.ObjectMeta{Name: "cluster-test"},
+				Spec:       AzureClusterSpec{PrivateLink: &PrivateLinkSpec{Name: "my-pls"}},
+			},
+			output: &PrivateLinkSpec{Name: "my-pls"},
+		},
+	}
+
+	for _, c := range cases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.cluster.setPrivateLinkDefaults()
+			if !reflect.DeepEqual(tc.cluster.Spec.PrivateLink, tc.output) {
+				expected, _ := json.MarshalIndent(tc.output, "", "\t")
+				actual, _ := json.MarshalIndent(tc.cluster.Spec.PrivateLink, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
@@ -48,6 +48,22 @@ type AzureClusterSpec struct {
 	// ones added by default.
 	// +optional
 	AdditionalTags Tags `json:"additionalTags,omitempty"`
+
+	// PrivateLink publishes the internal API server load balancer as a Private Link Service, so that it can be reached
+	// through private endpoints from virtual networks which are not peered with the cluster virtual network.
+	// Only allowed with a private API server.
+	// +optional
+	PrivateLink *PrivateLinkSpec `json:"privateLink,omitempty"`
+
+	// PrivateDNSZone configures the private DNS zone resolving the API server of a private cluster. Defaults to a zone
+	// named <cluster name>.capz.io, created in the cluster resource group.
+	// +optional
+	PrivateDNSZone *PrivateDNSZoneSpec `json:"privateDNSZone,omitempty"`
+
+	// ProximityPlacementGroup places the machines of the cluster in a proximity placement group created with the
+	// cluster. Machine pools with their own proximity placement group are placed in that one instead.
+	// +optional
+	ProximityPlacementGroup *ProximityPlacementGroup `json:"proximityPlacementGroup,omitempty"`
 }
 
 // AzureClusterStatus defines the observed state of AzureCluster
@@ -67,6 +83,11 @@ type AzureClusterStatus struct {
 	// Conditions defines current service state of the AzureCluster.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// LongRunningOperationStates saves the states of the Azure long running operations of the cluster services, such
+	// as load balancers, so they can be continued on the next reconciliation loop.
+	// +optional
+	LongRunningOperationStates []Future `json:"longRunningOperationStates,omitempty"`
 }
 
 // +kubebuilder:object:root=true
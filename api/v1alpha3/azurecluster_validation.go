@@ -19,10 +19,13 @@ package v1alpha3
 import (
 	"fmt"
 	"net"
+	"reflect"
 	"regexp"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -38,6 +41,16 @@ const (
 	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules
 	subnetRegex       = `^[-\w\._]+$`
 	loadBalancerRegex = `^[-\w\._]+$`
+	// resource ID of a public IP, e.g. /subscriptions/<id>/resourceGroups/<rg>/providers/Microsoft.Network/publicIPAddresses/<name>
+	publicIPIDRegex = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Network/publicIPAddresses/[^/]+$`
+	// resource ID of a subnet, e.g. /subscriptions/<id>/resourceGroups/<rg>/providers/Microsoft.Network/virtualNetworks/<vnet>/subnets/<name>
+	subnetIDRegex = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Network/virtualNetworks/[^/]+/subnets/[^/]+$`
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules
+	proximityPlacementGroupRegex = `^[a-zA-Z0-9]([-\w\.]{0,78}\w)?$`
+	// resource ID of a dedicated host group, e.g. /subscriptions/<id>/resourceGroups/<rg>/providers/Microsoft.Compute/hostGroups/<name>
+	hostGroupIDRegex = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Compute/hostGroups/[^/]+$`
+	// maxNodeOutboundFrontendIPs is the maximum number of frontend IPs of the node outbound load balancer.
+	maxNodeOutboundFrontendIPs = 16
 )
 
 // validateCluster validates a cluster
@@ -56,14 +69,70 @@ func (c *AzureCluster) validateCluster(old *AzureCluster) error {
 
 // validateClusterSpec validates a ClusterSpec
 func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
+	var allErrs field.ErrorList
 	var oldNetworkSpec NetworkSpec
 	if old != nil {
 		oldNetworkSpec = old.Spec.NetworkSpec
 	}
-	return validateNetworkSpec(
+	allErrs = append(allErrs, validateNetworkSpec(
 		c.Spec.NetworkSpec,
 		oldNetworkSpec,
-		field.NewPath("spec").Child("networkSpec"))
+		field.NewPath("spec").Child("networkSpec"))...)
+
+	// The node outbound LB is named after the cluster, and the control plane outbound LB
+	// is only created for private clusters, but both names are reserved regardless.
+	reservedLBNames := []string{
+		c.Spec.NetworkSpec.APIServerLB.Name,
+		c.Name,
+		fmt.Sprintf("%s-outbound-lb", c.Name),
+	}
+	var nodeCIDRBlocks []string
+	if subnet := c.Spec.NetworkSpec.GetNodeSubnet(); subnet != nil {
+		nodeCIDRBlocks = subnet.CIDRBlocks
+	}
+	allErrs = append(allErrs, validateLoadBalancers(
+		c.Spec.NetworkSpec.LoadBalancers,
+		oldNetworkSpec.LoadBalancers,
+		reservedLBNames,
+		nodeCIDRBlocks,
+		field.NewPath("spec").Child("networkSpec").Child("loadBalancers"))...)
+	if c.Spec.PrivateLink != nil {
+		allErrs = append(allErrs, validatePrivateLink(
+			*c.Spec.PrivateLink,
+			c.Spec.NetworkSpec.APIServerLB,
+			field.NewPath("spec").Child("privateLink"))...)
+	}
+	if c.Spec.PrivateDNSZone != nil {
+		allErrs = append(allErrs, validatePrivateDNSZone(
+			*c.Spec.PrivateDNSZone,
+			c.Spec.NetworkSpec.APIServerLB,
+			field.NewPath("spec").Child("privateDNSZone"))...)
+	}
+	if c.Spec.ProximityPlacementGroup != nil {
+		allErrs = append(allErrs, ValidateProximityPlacementGroup(
+			*c.Spec.ProximityPlacementGroup,
+			field.NewPath("spec").Child("proximityPlacementGroup"))...)
+	}
+	// Machines can't leave their proximity placement group.
+	if old != nil && !reflect.DeepEqual(c.Spec.ProximityPlacementGroup, old.Spec.ProximityPlacementGroup) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("proximityPlacementGroup"), c.Spec.ProximityPlacementGroup,
+			"proximity placement group should not be modified after AzureCluster creation."))
+	}
+	// The private DNS zone is part of the API server endpoint, which can't change.
+	if old != nil && !reflect.DeepEqual(c.Spec.PrivateDNSZone, old.Spec.PrivateDNSZone) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("privateDNSZone"), c.Spec.PrivateDNSZone,
+			"private DNS zone should not be modified after AzureCluster creation."))
+	}
+	// Private endpoints removed from the spec would be left behind, so the private link can't change either, apart
+	// from the addresses of its endpoints, which are recorded by the controller.
+	if old != nil && !reflect.DeepEqual(withoutPrivateIPs(c.Spec.PrivateLink), withoutPrivateIPs(old.Spec.PrivateLink)) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("privateLink"), c.Spec.PrivateLink,
+			"private link should not be modified after AzureCluster creation."))
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
 }
 
 // validateClusterName validates ClusterName
@@ -102,6 +171,9 @@ func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *fiel
 		cidrBlocks = subnet.CIDRBlocks
 	}
 	allErrs = append(allErrs, validateAPIServerLB(networkSpec.APIServerLB, old.APIServerLB, cidrBlocks, fldPath.Child("apiServerLB"))...)
+	if networkSpec.NodeOutboundLB != nil {
+		allErrs = append(allErrs, validateNodeOutboundLB(*networkSpec.NodeOutboundLB, fldPath.Child("nodeOutboundLB"))...)
+	}
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -149,6 +221,7 @@ func validateSubnets(subnets Subnets, fldPath *field.Path) field.ErrorList {
 				}
 			}
 		}
+		allErrs = append(allErrs, validateRoutes(subnet.RouteTable.Routes, fldPath.Index(i).Child("routeTable").Child("routes"))...)
 	}
 	for k, v := range requiredSubnetRoles {
 		if v == false {
@@ -179,6 +252,11 @@ func validateLoadBalancerName(name string, fldPath *field.Path) *field.Error {
 
 // validateInternalLBIPAddress validates a InternalLBIPAddress.
 func validateInternalLBIPAddress(address string, cidrs []string, fldPath *field.Path) *field.Error {
+	return validateLBIPAddressInSubnet(address, cidrs, "control plane", fldPath)
+}
+
+// validateLBIPAddressInSubnet validates that a load balancer private IP address is within the given subnet ranges.
+func validateLBIPAddressInSubnet(address string, cidrs []string, subnetRole string, fldPath *field.Path) *field.Error {
 	ip := net.ParseIP(address)
 	if ip == nil {
 		return field.Invalid(fldPath, address,
@@ -191,7 +269,99 @@ func validateInternalLBIPAddress(address string, cidrs []string, fldPath *field.
 		}
 	}
 	return field.Invalid(fldPath, address,
-		fmt.Sprintf("Internal LB IP address needs to be in control plane subnet range (%s)", cidrs))
+		fmt.Sprintf("Internal LB IP address needs to be in %s subnet range (%s)", subnetRole, cidrs))
+}
+
+// validateRoutes validates the user-defined routes of a route table.
+func validateRoutes(routes []Route, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := make(map[string]bool, len(routes))
+	for i, route := range routes {
+		if names[route.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), route.Name))
+		}
+		names[route.Name] = true
+		if strings.Contains(route.AddressPrefix, "/") {
+			if _, _, err := net.ParseCIDR(route.AddressPrefix); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("addressPrefix"), route.AddressPrefix,
+					"address prefix isn't a valid CIDR"))
+			}
+		}
+		switch {
+		case route.NextHopType == RouteNextHopTypeVirtualAppliance && route.NextHopIPAddress == "":
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("nextHopIPAddress"),
+				"next hop IP address is required for VirtualAppliance routes"))
+		case route.NextHopType == RouteNextHopTypeVirtualAppliance && net.ParseIP(route.NextHopIPAddress) == nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("nextHopIPAddress"), route.NextHopIPAddress,
+				"next hop IP address isn't a valid IPv4 or IPv6 address"))
+		case route.NextHopType != RouteNextHopTypeVirtualAppliance && route.NextHopIPAddress != "":
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("nextHopIPAddress"),
+				fmt.Sprintf("next hop IP address is not allowed for %s routes", route.NextHopType)))
+		}
+	}
+	return allErrs
+}
+
+// validatePrivateLink validates the Private Link Service of the API server.
+func validatePrivateLink(privateLink PrivateLinkSpec, apiServerLB LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if apiServerLB.Type != Internal {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			"private link is only allowed for an Internal API server load balancer"))
+	}
+	names := make(map[string]bool, len(privateLink.PrivateEndpoints))
+	for i, endpoint := range privateLink.PrivateEndpoints {
+		if names[endpoint.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("privateEndpoints").Index(i).Child("name"), endpoint.Name))
+		}
+		names[endpoint.Name] = true
+		if success, _ := regexp.MatchString(subnetIDRegex, endpoint.SubnetID); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("privateEndpoints").Index(i).Child("subnetID"), endpoint.SubnetID,
+				fmt.Sprintf("subnet ID doesn't match regex %s", subnetIDRegex)))
+		}
+	}
+	return allErrs
+}
+
+// withoutPrivateIPs returns a copy of a private link without the addresses of its private endpoints.
+func withoutPrivateIPs(privateLink *PrivateLinkSpec) *PrivateLinkSpec {
+	if privateLink == nil {
+		return nil
+	}
+	out := privateLink.DeepCopy()
+	for i := range out.PrivateEndpoints {
+		out.PrivateEndpoints[i].PrivateIP = ""
+	}
+	return out
+}
+
+// validatePrivateDNSZone validates the private DNS zone of the API server.
+func validatePrivateDNSZone(zone PrivateDNSZoneSpec, apiServerLB LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if apiServerLB.Type != Internal {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			"private DNS zone is only allowed for an Internal API server load balancer"))
+	}
+	if zone.Name != "" {
+		if errs := validation.IsDNS1123Subdomain(zone.Name); len(errs) > 0 || !strings.Contains(zone.Name, ".") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), zone.Name,
+				"private DNS zone name should be a lowercase domain name with at least two labels"))
+		}
+	}
+	if zone.ResourceGroup != "" {
+		if err := validateResourceGroup(zone.ResourceGroup, fldPath.Child("resourceGroup")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if zone.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("name"),
+				"name of an existing private DNS zone is required"))
+		}
+	}
+	if zone.SubscriptionID != "" && zone.ResourceGroup == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("resourceGroup"),
+			"resource group is required for a private DNS zone in another subscription"))
+	}
+	return allErrs
 }
 
 // validateIngressRule validates an IngressRule
@@ -259,8 +429,358 @@ func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []stri
 				allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPConfigs").Index(0).Child("privateIP"),
 					fmt.Sprintf("Public Load Balancers cannot have a Private IP")))
 			}
+			if lb.FrontendIPs[0].PublicIP != nil {
+				allErrs = append(allErrs, validatePublicIP(*lb.FrontendIPs[0].PublicIP,
+					fldPath.Child("frontendIPConfigs").Index(0).Child("publicIP"))...)
+			}
+		}
+
+		if lb.FrontendIPs[0].PublicIPPrefix != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPConfigs").Index(0).Child("publicIPPrefix"),
+				"public IP prefixes are only supported by the node outbound load balancer"))
+		}
+	}
+	if lb.FrontendIPsCount != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPsCount"),
+			"frontendIPsCount is only supported by the node outbound load balancer"))
+	}
+
+	if lb.HealthProbe != nil {
+		allErrs = append(allErrs, validateHealthProbe(*lb.HealthProbe, fldPath.Child("healthProbe"))...)
+	}
+	allErrs = append(allErrs, validateLBRuleSettings(lb, fldPath)...)
+
+	return allErrs
+}
+
+// validateNodeOutboundLB validates the node outbound load balancer settings.
+func validateNodeOutboundLB(lb LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb.HealthProbe != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("healthProbe"),
+			"the node outbound load balancer does not have a health probe"))
+	}
+	if lb.EnableFloatingIP != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableFloatingIP"),
+			"the node outbound load balancer does not have load balancing rules"))
+	}
+	if lb.Type != "" && lb.Type != Public {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), lb.Type, []string{string(Public)}))
+	}
+	allErrs = append(allErrs, validateLBRuleSettings(lb, fldPath)...)
+
+	if lb.FrontendIPsCount != nil {
+		if *lb.FrontendIPsCount < 1 || *lb.FrontendIPsCount > maxNodeOutboundFrontendIPs {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendIPsCount"), *lb.FrontendIPsCount,
+				fmt.Sprintf("frontendIPsCount should be between 1 and %d", maxNodeOutboundFrontendIPs)))
+		}
+		if len(lb.FrontendIPs) != 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPsCount"),
+				"frontendIPsCount and frontendIPs are mutually exclusive"))
+		}
+	}
+
+	frontendPath := fldPath.Child("frontendIPs")
+	if len(lb.FrontendIPs) > maxNodeOutboundFrontendIPs {
+		allErrs = append(allErrs, field.TooMany(frontendPath, len(lb.FrontendIPs), maxNodeOutboundFrontendIPs))
+	}
+	names := make(map[string]bool, len(lb.FrontendIPs))
+	for i, ip := range lb.FrontendIPs {
+		if ip.Name == "" {
+			allErrs = append(allErrs, field.Required(frontendPath.Index(i).Child("name"), "name is required"))
+		}
+		if names[ip.Name] {
+			allErrs = append(allErrs, field.Duplicate(frontendPath.Index(i).Child("name"), ip.Name))
+		}
+		names[ip.Name] = true
+
+		if ip.PrivateIPAddress != "" {
+			allErrs = append(allErrs, field.Forbidden(frontendPath.Index(i).Child("privateIP"),
+				"Public Load Balancers cannot have a Private IP"))
+		}
+		if (ip.PublicIP == nil) == (ip.PublicIPPrefix == nil) {
+			allErrs = append(allErrs, field.Invalid(frontendPath.Index(i), ip.Name,
+				"frontend IP should have exactly one of publicIP or publicIPPrefix"))
+		}
+		if ip.PublicIP != nil {
+			allErrs = append(allErrs, validatePublicIP(*ip.PublicIP, frontendPath.Index(i).Child("publicIP"))...)
+		}
+		if ip.PublicIPPrefix != nil && (ip.PublicIPPrefix.PrefixLength < 28 || ip.PublicIPPrefix.PrefixLength > 31) {
+			allErrs = append(allErrs, field.Invalid(frontendPath.Index(i).Child("publicIPPrefix", "prefixLength"),
+				ip.PublicIPPrefix.PrefixLength, "prefix length should be between 28 and 31"))
+		}
+	}
+	return allErrs
+}
+
+// validatePublicIP validates a public IP referenced by a load balancer frontend IP.
+func validatePublicIP(ip PublicIPSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if ip.ID == "" {
+		return allErrs
+	}
+	if success, _ := regexp.MatchString(publicIPIDRegex, ip.ID); !success {
+		return append(allErrs, field.Invalid(fldPath.Child("id"), ip.ID,
+			"id should be the resource ID of a public IP: /subscriptions/<subscription ID>/resourceGroups/<resource group>/providers/Microsoft.Network/publicIPAddresses/<name>"))
+	}
+	if name := ip.ID[strings.LastIndex(ip.ID, "/")+1:]; !strings.EqualFold(ip.Name, name) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), ip.Name,
+			fmt.Sprintf("name should match the name of the public IP referenced by id, %s", name)))
+	}
+	return allErrs
+}
+
+// validateHealthProbe validates the health probe of the API server load balancer.
+func validateHealthProbe(probe HealthProbe, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	switch probe.Protocol {
+	case "", ProbeProtocolHTTPS:
+		if probe.RequestPath != "" && !strings.HasPrefix(probe.RequestPath, "/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("requestPath"), probe.RequestPath,
+				"request path should start with /"))
+		}
+	case ProbeProtocolTCP:
+		if probe.RequestPath != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("requestPath"),
+				"request path is not allowed for Tcp probes"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("protocol"), probe.Protocol,
+			[]string{string(ProbeProtocolTCP), string(ProbeProtocolHTTPS)}))
+	}
+	if probe.IntervalInSeconds != nil && *probe.IntervalInSeconds < 5 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("intervalInSeconds"), *probe.IntervalInSeconds,
+			"probe interval should be at least 5 seconds"))
+	}
+	if probe.NumberOfProbes != nil && *probe.NumberOfProbes < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("numberOfProbes"), *probe.NumberOfProbes,
+			"number of probes should be at least 1"))
+	}
+	return allErrs
+}
+
+// validateLBRuleSettings validates the settings applied to the load balancing and outbound rules of a load balancer.
+func validateLBRuleSettings(lb LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb.IdleTimeoutInMinutes != nil && (*lb.IdleTimeoutInMinutes < 4 || *lb.IdleTimeoutInMinutes > 30) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutInMinutes"), *lb.IdleTimeoutInMinutes,
+			"idle timeout should be between 4 and 30 minutes"))
+	}
+	if lb.AllocatedOutboundPorts != nil {
+		ports := *lb.AllocatedOutboundPorts
+		if ports < 0 || ports > 64000 || ports%8 != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allocatedOutboundPorts"), ports,
+				"allocated outbound ports should be a multiple of 8 between 0 and 64000"))
+		}
+		if lb.Type == Internal {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("allocatedOutboundPorts"),
+				"Internal Load Balancers do not have outbound rules"))
+		}
+	}
+	return allErrs
+}
+
+// validateLoadBalancers validates the additional load balancers of a NetworkSpec.
+func validateLoadBalancers(lbs []LoadBalancerSpec, old []LoadBalancerSpec, reservedNames []string, nodeCIDRs []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	oldLBs := make(map[string]LoadBalancerSpec, len(old))
+	for _, lb := range old {
+		oldLBs[lb.Name] = lb
+	}
+	names := make(map[string]bool, len(lbs))
+	for _, reserved := range reservedNames {
+		names[reserved] = true
+	}
+
+	for i, lb := range lbs {
+		lbPath := fldPath.Index(i)
+		if err := validateLoadBalancerName(lb.Name, lbPath.Child("name")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if names[lb.Name] {
+			allErrs = append(allErrs, field.Duplicate(lbPath.Child("name"), lb.Name))
+		}
+		names[lb.Name] = true
+
+		if lb.SKU != SKUStandard {
+			allErrs = append(allErrs, field.NotSupported(lbPath.Child("sku"), lb.SKU, []string{string(SKUStandard)}))
+		}
+		if lb.Type != Internal && lb.Type != Public {
+			allErrs = append(allErrs, field.NotSupported(lbPath.Child("type"), lb.Type,
+				[]string{string(Public), string(Internal)}))
+		}
+		if oldLB, ok := oldLBs[lb.Name]; ok {
+			if oldLB.SKU != lb.SKU {
+				allErrs = append(allErrs, field.Invalid(lbPath.Child("sku"), lb.SKU, "load balancer SKU should not be modified after creation."))
+			}
+			if oldLB.Type != lb.Type {
+				allErrs = append(allErrs, field.Invalid(lbPath.Child("type"), lb.Type, "load balancer type should not be modified after creation."))
+			}
 		}
+
+		if lb.HealthProbe != nil {
+			allErrs = append(allErrs, field.Forbidden(lbPath.Child("healthProbe"),
+				"additional load balancers define their health probes in probes"))
+		}
+		if lb.AllocatedOutboundPorts != nil {
+			allErrs = append(allErrs, field.Forbidden(lbPath.Child("allocatedOutboundPorts"),
+				"additional load balancers do not have outbound rules"))
+		}
+		if lb.FrontendIPsCount != nil {
+			allErrs = append(allErrs, field.Forbidden(lbPath.Child("frontendIPsCount"),
+				"frontendIPsCount is only supported by the node outbound load balancer"))
+		}
+		allErrs = append(allErrs, validateLBRuleSettings(lb, lbPath)...)
+
+		allErrs = append(allErrs, validateLoadBalancerFrontendIPs(lb, nodeCIDRs, lbPath.Child("frontendIPs"))...)
+		allErrs = append(allErrs, validateLoadBalancerBackendPools(lb.BackendPools, lbPath.Child("backendPools"))...)
+		allErrs = append(allErrs, validateLoadBalancerProbes(lb.Probes, lbPath.Child("probes"))...)
+		allErrs = append(allErrs, validateLoadBalancingRules(lb, lbPath.Child("rules"))...)
 	}
+	return allErrs
+}
+
+// validateLoadBalancerFrontendIPs validates the frontend IP configurations of an additional load balancer.
+func validateLoadBalancerFrontendIPs(lb LoadBalancerSpec, nodeCIDRs []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(lb.FrontendIPs) == 0 {
+		return append(allErrs, field.Required(fldPath, "load balancer should have at least 1 Frontend IP configuration"))
+	}
+	names := make(map[string]bool, len(lb.FrontendIPs))
+	for i, ip := range lb.FrontendIPs {
+		if names[ip.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), ip.Name))
+		}
+		names[ip.Name] = true
 
+		if ip.PublicIPPrefix != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("publicIPPrefix"),
+				"public IP prefixes are only supported by the node outbound load balancer"))
+		}
+
+		switch lb.Type {
+		case Internal:
+			if ip.PublicIP != nil {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("publicIP"),
+					"Internal Load Balancers cannot have a Public IP"))
+			}
+			if ip.PrivateIPAddress != "" {
+				if err := validateLBIPAddressInSubnet(ip.PrivateIPAddress, nodeCIDRs, "node", fldPath.Index(i).Child("privateIP")); err != nil {
+					allErrs = append(allErrs, err)
+				}
+			}
+		case Public:
+			if ip.PrivateIPAddress != "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("privateIP"),
+					"Public Load Balancers cannot have a Private IP"))
+			}
+			if ip.PublicIP == nil {
+				allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("publicIP"),
+					"Public Load Balancers should have a Public IP"))
+			} else {
+				allErrs = append(allErrs, validatePublicIP(*ip.PublicIP, fldPath.Index(i).Child("publicIP"))...)
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateLoadBalancerBackendPools validates the backend pools of an additional load balancer.
+func validateLoadBalancerBackendPools(pools []BackendPool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(pools) == 0 {
+		return append(allErrs, field.Required(fldPath, "load balancer should have at least 1 backend pool"))
+	}
+	names := make(map[string]bool, len(pools))
+	for i, pool := range pools {
+		if names[pool.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), pool.Name))
+		}
+		names[pool.Name] = true
+	}
+	return allErrs
+}
+
+// validateLoadBalancerProbes validates the health probes of an additional load balancer.
+func validateLoadBalancerProbes(probes []LoadBalancerProbe, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := make(map[string]bool, len(probes))
+	for i, probe := range probes {
+		if names[probe.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), probe.Name))
+		}
+		names[probe.Name] = true
+		if err := validatePort(probe.Port, fldPath.Index(i).Child("port")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if probe.Protocol != ProbeProtocolTCP && probe.RequestPath == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("requestPath"),
+				fmt.Sprintf("request path is required for %s probes", probe.Protocol)))
+		}
+		if probe.Protocol == ProbeProtocolTCP && probe.RequestPath != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("requestPath"),
+				"request path is not allowed for Tcp probes"))
+		}
+	}
+	return allErrs
+}
+
+// validateLoadBalancingRules validates the load balancing rules of an additional load balancer.
+func validateLoadBalancingRules(lb LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	frontendIPs := make(map[string]bool, len(lb.FrontendIPs))
+	for _, ip := range lb.FrontendIPs {
+		frontendIPs[ip.Name] = true
+	}
+	pools := make(map[string]bool, len(lb.BackendPools))
+	for _, pool := range lb.BackendPools {
+		pools[pool.Name] = true
+	}
+	probes := make(map[string]bool, len(lb.Probes))
+	for _, probe := range lb.Probes {
+		probes[probe.Name] = true
+	}
+
+	names := make(map[string]bool, len(lb.Rules))
+	for i, rule := range lb.Rules {
+		rulePath := fldPath.Index(i)
+		if names[rule.Name] {
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("name"), rule.Name))
+		}
+		names[rule.Name] = true
+
+		if rule.FrontendIPName != "" && !frontendIPs[rule.FrontendIPName] {
+			allErrs = append(allErrs, field.NotFound(rulePath.Child("frontendIPName"), rule.FrontendIPName))
+		}
+		if rule.BackendPoolName != "" && !pools[rule.BackendPoolName] {
+			allErrs = append(allErrs, field.NotFound(rulePath.Child("backendPoolName"), rule.BackendPoolName))
+		}
+		if rule.ProbeName != "" && !probes[rule.ProbeName] {
+			allErrs = append(allErrs, field.NotFound(rulePath.Child("probeName"), rule.ProbeName))
+		}
+
+		// Port 0 on both ends means HA ports, which is only supported for the All protocol on internal load balancers.
+		if rule.FrontendPort == 0 && rule.BackendPort == 0 {
+			if rule.Protocol != TransportProtocolAll || lb.Type != Internal {
+				allErrs = append(allErrs, field.Invalid(rulePath.Child("frontendPort"), rule.FrontendPort,
+					"HA ports (port 0) are only supported with the All protocol on Internal load balancers"))
+			}
+			continue
+		}
+		if err := validatePort(rule.FrontendPort, rulePath.Child("frontendPort")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if err := validatePort(rule.BackendPort, rulePath.Child("backendPort")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
 	return allErrs
 }
+
+// validatePort validates a TCP or UDP port number.
+func validatePort(port int32, fldPath *field.Path) *field.Error {
+	if port < 1 || port > 65535 {
+		return field.Invalid(fldPath, port, "port should be between 1 and 65535")
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ package v1alpha3
 import (
 	"testing"
 
+	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -589,6 +590,76 @@ func TestIngressRules(t *testing.T) {
 	}
 }
 
+func TestValidateRoutes(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name    string
+		routes  []Route
+		wantErr bool
+	}{
+		{
+			name: "valid routes",
+			routes: []Route{
+				{Name: "egress", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance, NextHopIPAddress: "10.1.0.4"},
+				{Name: "storage", AddressPrefix: "Storage", NextHopType: RouteNextHopTypeInternet},
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicate route names",
+			routes: []Route{
+				{Name: "egress", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeInternet},
+				{Name: "egress", AddressPrefix: "10.0.0.0/8", NextHopType: RouteNextHopTypeVnetLocal},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid address prefix",
+			routes: []Route{
+				{Name: "egress", AddressPrefix: "0.0.0.0/33", NextHopType: RouteNextHopTypeInternet},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing next hop IP address",
+			routes: []Route{
+				{Name: "egress", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid next hop IP address",
+			routes: []Route{
+				{Name: "egress", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeVirtualAppliance, NextHopIPAddress: "firewall"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "next hop IP address not allowed",
+			routes: []Route{
+				{Name: "egress", AddressPrefix: "0.0.0.0/0", NextHopType: RouteNextHopTypeInternet, NextHopIPAddress: "10.1.0.4"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			errs := validateRoutes(
+				testCase.routes,
+				field.NewPath("spec").Child("networkSpec").Child("subnets").Index(0).Child("routeTable").Child("routes"),
+			)
+			if testCase.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestValidateAPIServerLB(t *testing.T) {
 	g := NewWithT(t)
 
@@ -600,6 +671,72 @@ func TestValidateAPIServerLB(t *testing.T) {
 		wantErr     bool
 		expectedErr field.Error
 	}{
+		{
+			name: "public lb with existing public IP",
+			lb: LoadBalancerSpec{
+				Name: "my-lb",
+				SKU:  SKUStandard,
+				Type: Public,
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-config",
+						PublicIP: &PublicIPSpec{
+							Name: "my-reserved-ip",
+							ID:   "/subscriptions/123/resourceGroups/my-ip-rg/providers/Microsoft.Network/publicIPAddresses/my-reserved-ip",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid public IP ID",
+			lb: LoadBalancerSpec{
+				Name: "my-lb",
+				SKU:  SKUStandard,
+				Type: Public,
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-config",
+						PublicIP: &PublicIPSpec{
+							Name: "my-reserved-ip",
+							ID:   "/subscriptions/123/resourceGroups/my-ip-rg/providers/Microsoft.Network/virtualNetworks/my-reserved-ip",
+						},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.frontendIPConfigs[0].publicIP.id",
+				BadValue: "/subscriptions/123/resourceGroups/my-ip-rg/providers/Microsoft.Network/virtualNetworks/my-reserved-ip",
+				Detail:   "id should be the resource ID of a public IP: /subscriptions/<subscription ID>/resourceGroups/<resource group>/providers/Microsoft.Network/publicIPAddresses/<name>",
+			},
+		},
+		{
+			name: "public IP name does not match ID",
+			lb: LoadBalancerSpec{
+				Name: "my-lb",
+				SKU:  SKUStandard,
+				Type: Public,
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-config",
+						PublicIP: &PublicIPSpec{
+							Name: "my-ip",
+							ID:   "/subscriptions/123/resourceGroups/my-ip-rg/providers/Microsoft.Network/publicIPAddresses/my-reserved-ip",
+						},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.frontendIPConfigs[0].publicIP.name",
+				BadValue: "my-ip",
+				Detail:   "name should match the name of the public IP referenced by id, my-reserved-ip",
+			},
+		},
 		{
 			name: "invalid SKU",
 			lb: LoadBalancerSpec{
@@ -750,6 +887,76 @@ func TestValidateAPIServerLB(t *testing.T) {
 				Detail:   "Internal LB IP address needs to be in control plane subnet range ([10.0.0.0/24 10.1.0.0/24])",
 			},
 		},
+		{
+			name: "tcp health probe with request path",
+			lb: LoadBalancerSpec{
+				Type: Public,
+				HealthProbe: &HealthProbe{
+					Protocol:    ProbeProtocolTCP,
+					RequestPath: "/readyz",
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.healthProbe.requestPath",
+				Detail: "request path is not allowed for Tcp probes",
+			},
+		},
+		{
+			name: "invalid idle timeout",
+			lb: LoadBalancerSpec{
+				Type:                 Public,
+				IdleTimeoutInMinutes: to.Int32Ptr(60),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.idleTimeoutInMinutes",
+				BadValue: int32(60),
+				Detail:   "idle timeout should be between 4 and 30 minutes",
+			},
+		},
+		{
+			name: "allocated outbound ports not a multiple of 8",
+			lb: LoadBalancerSpec{
+				Type:                   Public,
+				AllocatedOutboundPorts: to.Int32Ptr(1020),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.allocatedOutboundPorts",
+				BadValue: int32(1020),
+				Detail:   "allocated outbound ports should be a multiple of 8 between 0 and 64000",
+			},
+		},
+		{
+			name: "public LB with readyz health probe",
+			lb: LoadBalancerSpec{
+				Type: Public,
+				SKU:  SKUStandard,
+				Name: "my-public-lb",
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						PublicIP: &PublicIPSpec{
+							Name: "pip-1",
+						},
+					},
+				},
+				HealthProbe: &HealthProbe{
+					Protocol:          ProbeProtocolHTTPS,
+					RequestPath:       "/readyz",
+					IntervalInSeconds: to.Int32Ptr(5),
+					NumberOfProbes:    to.Int32Ptr(2),
+				},
+				IdleTimeoutInMinutes:   to.Int32Ptr(15),
+				EnableTCPReset:         to.BoolPtr(true),
+				AllocatedOutboundPorts: to.Int32Ptr(1024),
+			},
+			wantErr: false,
+		},
 		{
 			name: "internal LB with in range private IP",
 			lb: LoadBalancerSpec{
@@ -789,6 +996,334 @@ func TestValidateAPIServerLB(t *testing.T) {
 	}
 }
 
+func TestValidateNodeOutboundLB(t *testing.T) {
+	g := NewWithT(t)
+
+	testcases := []struct {
+		name        string
+		lb          LoadBalancerSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid frontend IPs count",
+			lb: LoadBalancerSpec{
+				FrontendIPsCount:       to.Int32Ptr(3),
+				AllocatedOutboundPorts: to.Int32Ptr(8000),
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid public IP and public IP prefix frontends",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name:     "outbound-ip",
+						PublicIP: &PublicIPSpec{Name: "pip-outbound"},
+					},
+					{
+						Name:           "outbound-prefix",
+						PublicIPPrefix: &PublicIPPrefixSpec{Name: "ippre-outbound", PrefixLength: 30},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "too many frontend IPs",
+			lb: LoadBalancerSpec{
+				FrontendIPsCount: to.Int32Ptr(17),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "nodeOutboundLB.frontendIPsCount",
+				BadValue: int32(17),
+				Detail:   "frontendIPsCount should be between 1 and 16",
+			},
+		},
+		{
+			name: "frontend IPs count and frontend IPs",
+			lb: LoadBalancerSpec{
+				FrontendIPsCount: to.Int32Ptr(2),
+				FrontendIPs: []FrontendIP{
+					{
+						Name:     "outbound-ip",
+						PublicIP: &PublicIPSpec{Name: "pip-outbound"},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "nodeOutboundLB.frontendIPsCount",
+				BadValue: "",
+				Detail:   "frontendIPsCount and frontendIPs are mutually exclusive",
+			},
+		},
+		{
+			name: "frontend with both public IP and public IP prefix",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name:           "outbound-ip",
+						PublicIP:       &PublicIPSpec{Name: "pip-outbound"},
+						PublicIPPrefix: &PublicIPPrefixSpec{Name: "ippre-outbound", PrefixLength: 30},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "nodeOutboundLB.frontendIPs[0]",
+				BadValue: "outbound-ip",
+				Detail:   "frontend IP should have exactly one of publicIP or publicIPPrefix",
+			},
+		},
+		{
+			name: "invalid public IP prefix length",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name:           "outbound-prefix",
+						PublicIPPrefix: &PublicIPPrefixSpec{Name: "ippre-outbound", PrefixLength: 24},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "nodeOutboundLB.frontendIPs[0].publicIPPrefix.prefixLength",
+				BadValue: int32(24),
+				Detail:   "prefix length should be between 28 and 31",
+			},
+		},
+		{
+			name: "internal node outbound lb",
+			lb: LoadBalancerSpec{
+				Type: Internal,
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueNotSupported",
+				Field:    "nodeOutboundLB.type",
+				BadValue: Internal,
+				Detail:   "supported values: \"Public\"",
+			},
+		},
+		{
+			name: "health probe",
+			lb: LoadBalancerSpec{
+				HealthProbe: &HealthProbe{},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "nodeOutboundLB.healthProbe",
+				BadValue: "",
+				Detail:   "the node outbound load balancer does not have a health probe",
+			},
+		},
+	}
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateNodeOutboundLB(test.lb, field.NewPath("nodeOutboundLB"))
+			if test.wantErr {
+				g.Expect(err).NotTo(HaveLen(0))
+				found := false
+				for _, actual := range err {
+					if actual.Error() == test.expectedErr.Error() {
+						found = true
+					}
+				}
+				g.Expect(found).To(BeTrue())
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateLoadBalancers(t *testing.T) {
+	g := NewWithT(t)
+
+	testcases := []struct {
+		name        string
+		lbs         []LoadBalancerSpec
+		old         []LoadBalancerSpec
+		nodeCIDRs   []string
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:    "valid internal lb",
+			lbs:     []LoadBalancerSpec{createValidInternalLB()},
+			wantErr: false,
+		},
+		{
+			name: "name collides with the API server lb",
+			lbs: []LoadBalancerSpec{
+				func() LoadBalancerSpec {
+					lb := createValidInternalLB()
+					lb.Name = "my-lb"
+					return lb
+				}(),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "loadBalancers[0].name",
+				BadValue: "my-lb",
+			},
+		},
+		{
+			name: "health probe on an additional lb",
+			lbs: []LoadBalancerSpec{
+				func() LoadBalancerSpec {
+					lb := createValidInternalLB()
+					lb.HealthProbe = &HealthProbe{Protocol: ProbeProtocolTCP}
+					return lb
+				}(),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "loadBalancers[0].healthProbe",
+				BadValue: "",
+				Detail:   "additional load balancers define their health probes in probes",
+			},
+		},
+		{
+			name: "type modified",
+			lbs: []LoadBalancerSpec{
+				func() LoadBalancerSpec {
+					lb := createValidInternalLB()
+					lb.Type = Public
+					lb.FrontendIPs[0].PublicIP = &PublicIPSpec{Name: "pip-ingress"}
+					return lb
+				}(),
+			},
+			old:     []LoadBalancerSpec{createValidInternalLB()},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "loadBalancers[0].type",
+				BadValue: Public,
+				Detail:   "load balancer type should not be modified after creation.",
+			},
+		},
+		{
+			name: "public lb without public IP",
+			lbs: []LoadBalancerSpec{
+				func() LoadBalancerSpec {
+					lb := createValidInternalLB()
+					lb.Type = Public
+					return lb
+				}(),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueRequired",
+				Field:  "loadBalancers[0].frontendIPs[0].publicIP",
+				Detail: "Public Load Balancers should have a Public IP",
+			},
+		},
+		{
+			name: "internal lb with out of range private IP",
+			lbs: []LoadBalancerSpec{
+				func() LoadBalancerSpec {
+					lb := createValidInternalLB()
+					lb.FrontendIPs[0].PrivateIPAddress = "10.0.0.10"
+					return lb
+				}(),
+			},
+			nodeCIDRs: []string{"10.1.0.0/16"},
+			wantErr:   true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "loadBalancers[0].frontendIPs[0].privateIP",
+				BadValue: "10.0.0.10",
+				Detail:   "Internal LB IP address needs to be in node subnet range ([10.1.0.0/16])",
+			},
+		},
+		{
+			name: "http probe without request path",
+			lbs: []LoadBalancerSpec{
+				func() LoadBalancerSpec {
+					lb := createValidInternalLB()
+					lb.Probes[0].Protocol = ProbeProtocolHTTP
+					return lb
+				}(),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueRequired",
+				Field:  "loadBalancers[0].probes[0].requestPath",
+				Detail: "request path is required for Http probes",
+			},
+		},
+		{
+			name: "rule referencing an unknown backend pool",
+			lbs: []LoadBalancerSpec{
+				func() LoadBalancerSpec {
+					lb := createValidInternalLB()
+					lb.Rules[0].BackendPoolName = "foo"
+					return lb
+				}(),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueNotFound",
+				Field:    "loadBalancers[0].rules[0].backendPoolName",
+				BadValue: "foo",
+			},
+		},
+		{
+			name: "HA ports rule on public lb",
+			lbs: []LoadBalancerSpec{
+				func() LoadBalancerSpec {
+					lb := createValidInternalLB()
+					lb.Type = Public
+					lb.FrontendIPs[0].PublicIP = &PublicIPSpec{Name: "pip-ingress"}
+					lb.Rules[0].Protocol = TransportProtocolAll
+					lb.Rules[0].FrontendPort = 0
+					lb.Rules[0].BackendPort = 0
+					return lb
+				}(),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "loadBalancers[0].rules[0].frontendPort",
+				BadValue: int32(0),
+				Detail:   "HA ports (port 0) are only supported with the All protocol on Internal load balancers",
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateLoadBalancers(test.lbs, test.old, []string{"my-lb", "test-cluster"}, test.nodeCIDRs, field.NewPath("loadBalancers"))
+			if test.wantErr {
+				g.Expect(err).NotTo(HaveLen(0))
+				found := false
+				for _, actual := range err {
+					if actual.Error() == test.expectedErr.Error() {
+						found = true
+					}
+				}
+				g.Expect(found).To(BeTrue())
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
 func createValidCluster() *AzureCluster {
 	return &AzureCluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -840,3 +1375,162 @@ func createValidAPIServerLB() LoadBalancerSpec {
 		Type: Public,
 	}
 }
+
+func createValidInternalLB() LoadBalancerSpec {
+	return LoadBalancerSpec{
+		Name: "ingress-lb",
+		SKU:  SKUStandard,
+		FrontendIPs: []FrontendIP{
+			{
+				Name: "ingress-lb-frontEnd",
+			},
+		},
+		Type: Internal,
+		BackendPools: []BackendPool{
+			{
+				Name: "ingress-lb-backendPool",
+			},
+		},
+		Probes: []LoadBalancerProbe{
+			{
+				Name:     "http-probe",
+				Protocol: ProbeProtocolTCP,
+				Port:     80,
+			},
+		},
+		Rules: []LoadBalancingRule{
+			{
+				Name:         "http",
+				Protocol:     TransportProtocolTCP,
+				FrontendPort: 80,
+				BackendPort:  30080,
+				ProbeName:    "http-probe",
+			},
+		},
+	}
+}
+
+func TestValidatePrivateLink(t *testing.T) {
+	g := NewWithT(t)
+
+	subnetID := "/subscriptions/123/resourceGroups/consumer-rg/providers/Microsoft.Network/virtualNetworks/consumer-vnet/subnets/consumer-subnet"
+	tests := []struct {
+		name        string
+		privateLink PrivateLinkSpec
+		lbType      LBType
+		wantErr     bool
+	}{
+		{
+			name: "valid private link",
+			privateLink: PrivateLinkSpec{
+				Name:             "my-pls",
+				PrivateEndpoints: []PrivateEndpointSpec{{Name: "consumer", SubnetID: subnetID}},
+			},
+			lbType:  Internal,
+			wantErr: false,
+		},
+		{
+			name:        "public API server",
+			privateLink: PrivateLinkSpec{Name: "my-pls"},
+			lbType:      Public,
+			wantErr:     true,
+		},
+		{
+			name: "duplicate private endpoint names",
+			privateLink: PrivateLinkSpec{
+				Name: "my-pls",
+				PrivateEndpoints: []PrivateEndpointSpec{
+					{Name: "consumer", SubnetID: subnetID},
+					{Name: "consumer", SubnetID: subnetID},
+				},
+			},
+			lbType:  Internal,
+			wantErr: true,
+		},
+		{
+			name: "invalid subnet ID",
+			privateLink: PrivateLinkSpec{
+				Name:             "my-pls",
+				PrivateEndpoints: []PrivateEndpointSpec{{Name: "consumer", SubnetID: "consumer-subnet"}},
+			},
+			lbType:  Internal,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			errs := validatePrivateLink(test.privateLink, LoadBalancerSpec{Type: test.lbType}, field.NewPath("spec").Child("privateLink"))
+			if test.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidatePrivateDNSZone(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name    string
+		zone    PrivateDNSZoneSpec
+		lbType  LBType
+		wantErr bool
+	}{
+		{
+			name:    "custom zone name",
+			zone:    PrivateDNSZoneSpec{Name: "my-cluster.k8s.example.com"},
+			lbType:  Internal,
+			wantErr: false,
+		},
+		{
+			name:    "existing zone in another subscription",
+			zone:    PrivateDNSZoneSpec{Name: "k8s.example.com", ResourceGroup: "dns-rg", SubscriptionID: "456"},
+			lbType:  Internal,
+			wantErr: false,
+		},
+		{
+			name:    "public API server",
+			zone:    PrivateDNSZoneSpec{Name: "k8s.example.com"},
+			lbType:  Public,
+			wantErr: true,
+		},
+		{
+			name:    "single label zone name",
+			zone:    PrivateDNSZoneSpec{Name: "example"},
+			lbType:  Internal,
+			wantErr: true,
+		},
+		{
+			name:    "invalid zone name",
+			zone:    PrivateDNSZoneSpec{Name: "K8s_Example.com"},
+			lbType:  Internal,
+			wantErr: true,
+		},
+		{
+			name:    "existing zone without name",
+			zone:    PrivateDNSZoneSpec{ResourceGroup: "dns-rg"},
+			lbType:  Internal,
+			wantErr: true,
+		},
+		{
+			name:    "subscription without resource group",
+			zone:    PrivateDNSZoneSpec{Name: "k8s.example.com", SubscriptionID: "456"},
+			lbType:  Internal,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			errs := validatePrivateDNSZone(test.zone, LoadBalancerSpec{Type: test.lbType}, field.NewPath("spec").Child("privateDNSZone"))
+			if test.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
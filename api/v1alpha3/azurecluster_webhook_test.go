@@ -157,6 +157,24 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			}(),
 			wantErr: true,
 		},
+		{
+			name: "azurecluster with private DNS zone set after creation",
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.PrivateDNSZone = &PrivateDNSZoneSpec{Name: "k8s.example.com"}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azurecluster with proximity placement group set after creation",
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.ProximityPlacementGroup = &ProximityPlacementGroup{}
+				return cluster
+			}(),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -169,3 +187,85 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureCluster_ValidateUpdatePrivateLink(t *testing.T) {
+	g := NewWithT(t)
+
+	createPrivateLinkCluster := func() *AzureCluster {
+		cluster := createValidCluster()
+		cluster.Spec.NetworkSpec.APIServerLB.Type = Internal
+		cluster.Spec.NetworkSpec.APIServerLB.FrontendIPs = []FrontendIP{{Name: "ip-config", PrivateIPAddress: "10.0.0.100"}}
+		cluster.Spec.NetworkSpec.Subnets[0].CIDRBlocks = []string{"10.0.0.0/16"}
+		cluster.Spec.PrivateLink = &PrivateLinkSpec{
+			Name: "my-pls",
+			PrivateEndpoints: []PrivateEndpointSpec{
+				{
+					Name:     "my-endpoint",
+					SubnetID: "/subscriptions/123/resourceGroups/consumer-rg/providers/Microsoft.Network/virtualNetworks/consumer-vnet/subnets/consumer-subnet",
+				},
+			},
+		}
+		return cluster
+	}
+
+	tests := []struct {
+		name    string
+		cluster *AzureCluster
+		wantErr bool
+	}{
+		{
+			name:    "azurecluster with unchanged private link",
+			cluster: createPrivateLinkCluster(),
+			wantErr: false,
+		},
+		{
+			name: "azurecluster with private endpoint address recorded",
+			cluster: func() *AzureCluster {
+				cluster := createPrivateLinkCluster()
+				cluster.Spec.PrivateLink.PrivateEndpoints[0].PrivateIP = "10.1.0.4"
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "azurecluster with private endpoint removed",
+			cluster: func() *AzureCluster {
+				cluster := createPrivateLinkCluster()
+				cluster.Spec.PrivateLink.PrivateEndpoints = nil
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azurecluster with private endpoint added",
+			cluster: func() *AzureCluster {
+				cluster := createPrivateLinkCluster()
+				cluster.Spec.PrivateLink.PrivateEndpoints = append(cluster.Spec.PrivateLink.PrivateEndpoints, PrivateEndpointSpec{
+					Name:     "other-endpoint",
+					SubnetID: "/subscriptions/123/resourceGroups/consumer-rg/providers/Microsoft.Network/virtualNetworks/consumer-vnet/subnets/other-subnet",
+				})
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azurecluster with private link removed",
+			cluster: func() *AzureCluster {
+				cluster := createPrivateLinkCluster()
+				cluster.Spec.PrivateLink = nil
+				return cluster
+			}(),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cluster.ValidateUpdate(createPrivateLinkCluster())
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
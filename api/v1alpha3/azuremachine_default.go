@@ -70,7 +70,15 @@ func (m *AzureMachine) SetDataDisksDefaults() {
 			}
 		}
 		if disk.CachingType == "" {
-			m.Spec.DataDisks[i].CachingType = "ReadWrite"
+			// ultra disks don't support caching.
+			if disk.ManagedDisk != nil && disk.ManagedDisk.StorageAccountType == "UltraSSD_LRS" {
+				m.Spec.DataDisks[i].CachingType = "None"
+			} else {
+				m.Spec.DataDisks[i].CachingType = "ReadWrite"
+			}
+		}
+		if disk.DeletionPolicy == "" {
+			m.Spec.DataDisks[i].DeletionPolicy = DiskDeletionPolicyDelete
 		}
 	}
 }
@@ -81,5 +89,18 @@ func (m *AzureMachine) SetIdentityDefaults() {
 		if m.Spec.RoleAssignmentName == "" {
 			m.Spec.RoleAssignmentName = string(uuid.NewUUID())
 		}
+		SetRoleAssignmentDefaults(m.Spec.RoleAssignments)
+	}
+}
+
+// SetRoleAssignmentDefaults sets the defaults for a list of role assignments.
+func SetRoleAssignmentDefaults(roleAssignments []RoleAssignment) {
+	for i := range roleAssignments {
+		if roleAssignments[i].Name == "" {
+			roleAssignments[i].Name = string(uuid.NewUUID())
+		}
+		if roleAssignments[i].Scope == "" {
+			roleAssignments[i].Scope = RoleAssignmentScopeResourceGroup
+		}
 	}
 }
@@ -79,6 +79,32 @@ func TestAzureMachine_SetIdentityDefaults(t *testing.T) {
 	g.Expect(notSystemAssignedTest.machine.Spec.RoleAssignmentName).To(BeEmpty())
 }
 
+func TestAzureMachine_SetRoleAssignmentDefaults(t *testing.T) {
+	g := NewWithT(t)
+
+	existingName := "42862306-e485-4319-9bf0-35dbc6f6fe9c"
+	machine := &AzureMachine{Spec: AzureMachineSpec{
+		Identity: VMIdentitySystemAssigned,
+		RoleAssignments: []RoleAssignment{
+			{
+				Name:             existingName,
+				RoleDefinitionID: "acdd72a7-3385-48ef-bd42-f606fba81ae7",
+				Scope:            RoleAssignmentScopeSubscription,
+			},
+			{
+				RoleDefinitionID: "4d97b98b-1d4f-4787-a291-c67834d212e7",
+			},
+		},
+	}}
+
+	machine.SetIdentityDefaults()
+	g.Expect(machine.Spec.RoleAssignments[0].Name).To(Equal(existingName))
+	g.Expect(machine.Spec.RoleAssignments[0].Scope).To(Equal(RoleAssignmentScopeSubscription))
+	_, err := uuid.Parse(machine.Spec.RoleAssignments[1].Name)
+	g.Expect(err).To(Not(HaveOccurred()))
+	g.Expect(machine.Spec.RoleAssignments[1].Scope).To(Equal(RoleAssignmentScopeResourceGroup))
+}
+
 func TestAzureMachine_SetDataDisksDefaults(t *testing.T) {
 	cases := []struct {
 		name   string
@@ -106,16 +132,18 @@ func TestAzureMachine_SetDataDisksDefaults(t *testing.T) {
 			},
 			output: []DataDisk{
 				{
-					NameSuffix:  "testdisk1",
-					DiskSizeGB:  30,
-					Lun:         to.Int32Ptr(0),
-					CachingType: "ReadWrite",
+					NameSuffix:     "testdisk1",
+					DiskSizeGB:     30,
+					Lun:            to.Int32Ptr(0),
+					CachingType:    "ReadWrite",
+					DeletionPolicy: DiskDeletionPolicyDelete,
 				},
 				{
-					NameSuffix:  "testdisk2",
-					DiskSizeGB:  30,
-					Lun:         to.Int32Ptr(1),
-					CachingType: "ReadWrite",
+					NameSuffix:     "testdisk2",
+					DiskSizeGB:     30,
+					Lun:            to.Int32Ptr(1),
+					CachingType:    "ReadWrite",
+					DeletionPolicy: DiskDeletionPolicyDelete,
 				},
 			},
 		},
@@ -137,16 +165,18 @@ func TestAzureMachine_SetDataDisksDefaults(t *testing.T) {
 			},
 			output: []DataDisk{
 				{
-					NameSuffix:  "testdisk1",
-					DiskSizeGB:  30,
-					Lun:         to.Int32Ptr(5),
-					CachingType: "ReadWrite",
+					NameSuffix:     "testdisk1",
+					DiskSizeGB:     30,
+					Lun:            to.Int32Ptr(5),
+					CachingType:    "ReadWrite",
+					DeletionPolicy: DiskDeletionPolicyDelete,
 				},
 				{
-					NameSuffix:  "testdisk2",
-					DiskSizeGB:  30,
-					Lun:         to.Int32Ptr(3),
-					CachingType: "ReadWrite",
+					NameSuffix:     "testdisk2",
+					DiskSizeGB:     30,
+					Lun:            to.Int32Ptr(3),
+					CachingType:    "ReadWrite",
+					DeletionPolicy: DiskDeletionPolicyDelete,
 				},
 			},
 		},
@@ -178,28 +208,32 @@ func TestAzureMachine_SetDataDisksDefaults(t *testing.T) {
 			},
 			output: []DataDisk{
 				{
-					NameSuffix:  "testdisk1",
-					DiskSizeGB:  30,
-					Lun:         to.Int32Ptr(0),
-					CachingType: "ReadWrite",
+					NameSuffix:     "testdisk1",
+					DiskSizeGB:     30,
+					Lun:            to.Int32Ptr(0),
+					CachingType:    "ReadWrite",
+					DeletionPolicy: DiskDeletionPolicyDelete,
 				},
 				{
-					NameSuffix:  "testdisk2",
-					DiskSizeGB:  30,
-					Lun:         to.Int32Ptr(2),
-					CachingType: "ReadWrite",
+					NameSuffix:     "testdisk2",
+					DiskSizeGB:     30,
+					Lun:            to.Int32Ptr(2),
+					CachingType:    "ReadWrite",
+					DeletionPolicy: DiskDeletionPolicyDelete,
 				},
 				{
-					NameSuffix:  "testdisk3",
-					DiskSizeGB:  30,
-					Lun:         to.Int32Ptr(1),
-					CachingType: "ReadWrite",
+					NameSuffix:     "testdisk3",
+					DiskSizeGB:     30,
+					Lun:            to.Int32Ptr(1),
+					CachingType:    "ReadWrite",
+					DeletionPolicy: DiskDeletionPolicyDelete,
 				},
 				{
-					NameSuffix:  "testdisk4",
-					DiskSizeGB:  30,
-					Lun:         to.Int32Ptr(3),
-					CachingType: "ReadWrite",
+					NameSuffix:     "testdisk4",
+					DiskSizeGB:     30,
+					Lun:            to.Int32Ptr(3),
+					CachingType:    "ReadWrite",
+					DeletionPolicy: DiskDeletionPolicyDelete,
 				},
 			},
 		},
@@ -218,17 +252,40 @@ func TestAzureMachine_SetDataDisksDefaults(t *testing.T) {
 				},
 			},
 			output: []DataDisk{
+				{
+					NameSuffix:     "testdisk1",
+					DiskSizeGB:     30,
+					Lun:            to.Int32Ptr(0),
+					CachingType:    "ReadWrite",
+					DeletionPolicy: DiskDeletionPolicyDelete,
+				},
+				{
+					NameSuffix:     "testdisk2",
+					DiskSizeGB:     30,
+					Lun:            to.Int32Ptr(2),
+					CachingType:    "ReadWrite",
+					DeletionPolicy: DiskDeletionPolicyDelete,
+				},
+			},
+		},
+		{
+			name: "CachingType unspecified for ultra disk",
+			disks: []DataDisk{
 				{
 					NameSuffix:  "testdisk1",
 					DiskSizeGB:  30,
 					Lun:         to.Int32Ptr(0),
-					CachingType: "ReadWrite",
+					ManagedDisk: &ManagedDisk{StorageAccountType: "UltraSSD_LRS"},
 				},
+			},
+			output: []DataDisk{
 				{
-					NameSuffix:  "testdisk2",
-					DiskSizeGB:  30,
-					Lun:         to.Int32Ptr(2),
-					CachingType: "ReadWrite",
+					NameSuffix:     "testdisk1",
+					DiskSizeGB:     30,
+					Lun:            to.Int32Ptr(0),
+					ManagedDisk:    &ManagedDisk{StorageAccountType: "UltraSSD_LRS"},
+					CachingType:    "None",
+					DeletionPolicy: DiskDeletionPolicyDelete,
 				},
 			},
 		},
@@ -238,7 +295,7 @@ func TestAzureMachine_SetDataDisksDefaults(t *testing.T) {
 		tc := c
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			machine := hardcodedAzureMachineWithSSHKey(generateSSHPublicKey(true))
+			machine := hardcodedAzureMachineWithSSHKey(validSSHPublicKey)
 			machine.Spec.DataDisks = tc.disks
 			machine.SetDataDisksDefaults()
 			if !reflect.DeepEqual(machine.Spec.DataDisks, tc.output) {
@@ -257,7 +314,7 @@ func createMachineWithSSHPublicKey(t *testing.T, sshPublicKey string) *AzureMach
 }
 
 func createMachineWithUserAssignedIdentities(t *testing.T, identitiesList []UserAssignedIdentity) *AzureMachine {
-	machine := hardcodedAzureMachineWithSSHKey(generateSSHPublicKey(true))
+	machine := hardcodedAzureMachineWithSSHKey(validSSHPublicKey)
 	machine.Spec.Identity = VMIdentityUserAssigned
 	machine.Spec.UserAssignedIdentities = identitiesList
 	return machine
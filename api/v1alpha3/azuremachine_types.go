@@ -68,10 +68,15 @@ type AzureMachineSpec struct {
 	UserAssignedIdentities []UserAssignedIdentity `json:"userAssignedIdentities,omitempty"`
 
 	// RoleAssignmentName is the name of the role assignment to create for a system assigned identity. It can be any valid GUID.
-	// If not specified, a random GUID will be generated.
+	// If not specified, a random GUID will be generated. It can't be set in an AzureMachineTemplate.
 	// +optional
 	RoleAssignmentName string `json:"roleAssignmentName,omitempty"`
 
+	// RoleAssignments is the list of roles to assign to a system assigned identity.
+	// When empty, the identity is assigned the Contributor role on the subscription using RoleAssignmentName.
+	// +optional
+	RoleAssignments []RoleAssignment `json:"roleAssignments,omitempty"`
+
 	// OSDisk specifies the parameters for the operating system disk of the machine
 	OSDisk OSDisk `json:"osDisk"`
 
@@ -99,6 +104,11 @@ type AzureMachineSpec struct {
 	// +optional
 	EnableIPForwarding bool `json:"enableIPForwarding,omitempty"`
 
+	// LoadBalancerBackendPools is a list of backend pools of the cluster's additional load balancers
+	// the machine's network interface should join.
+	// +optional
+	LoadBalancerBackendPools []LoadBalancerBackendPoolReference `json:"loadBalancerBackendPools,omitempty"`
+
 	// AcceleratedNetworking enables or disables Azure accelerated networking. If omitted, it will be set based on
 	// whether the requested VMSize supports accelerated networking.
 	// If AcceleratedNetworking is set to true with a VMSize that does not support it, Azure will return an error.
@@ -113,6 +123,20 @@ type AzureMachineSpec struct {
 	// SecurityProfile specifies the Security profile settings for a virtual machine.
 	// +optional
 	SecurityProfile *SecurityProfile `json:"securityProfile,omitempty"`
+
+	// DedicatedHostGroupID is the resource ID of an existing dedicated host group the virtual machine is placed in.
+	// The host group must support automatic placement. Machines on dedicated hosts are not part of an availability set.
+	// +optional
+	DedicatedHostGroupID string `json:"dedicatedHostGroupID,omitempty"`
+
+	// VMExtensions are the VM extensions installed on the virtual machine. Extensions removed from the list are
+	// uninstalled.
+	// +optional
+	VMExtensions []VMExtension `json:"vmExtensions,omitempty"`
+
+	// BootstrapData defines how the bootstrap data is passed to the virtual machine.
+	// +optional
+	BootstrapData *BootstrapData `json:"bootstrapData,omitempty"`
 }
 
 // SpotVMOptions defines the options relevant to running the Machine on Spot VMs
@@ -177,6 +201,11 @@ type AzureMachineStatus struct {
 	// Conditions defines current service state of the AzureMachine.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// LongRunningOperationState saves the state for an Azure long running operations so it can be continued on the
+	// next reconciliation loop.
+	// +optional
+	LongRunningOperationState *Future `json:"longRunningOperationState,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -18,7 +18,11 @@ package v1alpha3
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
 
 	"github.com/google/uuid"
 
@@ -63,6 +67,83 @@ func ValidateSystemAssignedIdentity(identityType VMIdentity, old, new string, fl
 	return allErrs
 }
 
+// ValidateRoleAssignments validates the list of role assignments for a system-assigned identity.
+func ValidateRoleAssignments(identityType VMIdentity, old, new []RoleAssignment, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(new) == 0 {
+		return allErrs
+	}
+
+	if identityType != VMIdentitySystemAssigned {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "Role assignments should only be set when using system assigned identity."))
+		return allErrs
+	}
+
+	names := make(map[string]struct{}, len(new))
+	for i, ra := range new {
+		if _, err := uuid.Parse(ra.Name); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), ra.Name, "Role assignment name must be a valid GUID. It is optional and will be auto-generated when not specified."))
+		}
+		if _, ok := names[ra.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), ra.Name))
+		}
+		names[ra.Name] = struct{}{}
+
+		if errs := validateRoleDefinitionID(ra.RoleDefinitionID, fldPath.Index(i).Child("roleDefinitionID")); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+		}
+
+		scopeIDPath := fldPath.Index(i).Child("scopeID")
+		switch ra.Scope {
+		case RoleAssignmentScopeCustom:
+			if ra.ScopeID == "" {
+				allErrs = append(allErrs, field.Required(scopeIDPath, "must be specified for the 'Custom' scope"))
+			} else if !strings.HasPrefix(strings.ToLower(ra.ScopeID), "/subscriptions/") {
+				allErrs = append(allErrs, field.Invalid(scopeIDPath, ra.ScopeID, "must be an Azure resource ID starting with /subscriptions/"))
+			}
+		case RoleAssignmentScopeResourceGroup, RoleAssignmentScopeVirtualNetwork, RoleAssignmentScopeSubscription:
+			if ra.ScopeID != "" {
+				allErrs = append(allErrs, field.Forbidden(scopeIDPath, "should only be set for the 'Custom' scope"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i).Child("scope"), ra.Scope,
+				[]string{string(RoleAssignmentScopeResourceGroup), string(RoleAssignmentScopeVirtualNetwork), string(RoleAssignmentScopeSubscription), string(RoleAssignmentScopeCustom)}))
+		}
+	}
+
+	if len(old) != 0 && !reflect.DeepEqual(old, new) {
+		allErrs = append(allErrs, field.Invalid(fldPath, new, "Role assignments should not be modified after creation."))
+	}
+
+	return allErrs
+}
+
+// validateRoleDefinitionID validates that a role definition is either a GUID or a full role definition resource ID.
+func validateRoleDefinitionID(id string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if id == "" {
+		allErrs = append(allErrs, field.Required(fldPath, "role definition ID must be specified"))
+		return allErrs
+	}
+
+	guid := id
+	if strings.HasPrefix(id, "/") {
+		idx := strings.LastIndex(strings.ToLower(id), "/providers/microsoft.authorization/roledefinitions/")
+		if idx < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath, id, "role definition ID must be a GUID or a resource ID of the form .../providers/Microsoft.Authorization/roleDefinitions/{GUID}"))
+			return allErrs
+		}
+		guid = id[idx+len("/providers/microsoft.authorization/roledefinitions/"):]
+	}
+	if _, err := uuid.Parse(guid); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, id, "role definition ID must be a GUID or a resource ID of the form .../providers/Microsoft.Authorization/roleDefinitions/{GUID}"))
+	}
+
+	return allErrs
+}
+
 // ValidateUserAssignedIdentity validates the user-assigned identities list
 func ValidateUserAssignedIdentity(identityType VMIdentity, userAssignedIdenteties []UserAssignedIdentity, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -73,11 +154,96 @@ func ValidateUserAssignedIdentity(identityType VMIdentity, userAssignedIdentetie
 	return allErrs
 }
 
+// ValidateProximityPlacementGroup validates the name of a proximity placement group.
+func ValidateProximityPlacementGroup(ppg ProximityPlacementGroup, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ppg.Name == "" {
+		return allErrs
+	}
+	if success, _ := regexp.MatchString(proximityPlacementGroupRegex, ppg.Name); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), ppg.Name,
+			fmt.Sprintf("name of proximity placement group doesn't match regex %s", proximityPlacementGroupRegex)))
+	}
+	return allErrs
+}
+
+// ValidateDedicatedHostGroupID validates the dedicated host group of a machine.
+func ValidateDedicatedHostGroupID(id string, spotVMOptions *SpotVMOptions, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if id == "" {
+		return allErrs
+	}
+	if success, _ := regexp.MatchString(hostGroupIDRegex, id); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath, id,
+			"should be the resource ID of a dedicated host group: /subscriptions/<subscription ID>/resourceGroups/<resource group>/providers/Microsoft.Compute/hostGroups/<name>"))
+	}
+	if spotVMOptions != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, id, "spot VMs cannot be placed on dedicated hosts"))
+	}
+	return allErrs
+}
+
+// ValidateVMExtensions validates a list of VM extensions.
+func ValidateVMExtensions(extensions []VMExtension, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := make(map[string]struct{})
+	for i, extension := range extensions {
+		extPath := fldPath.Index(i)
+		if extension.Name == "" {
+			allErrs = append(allErrs, field.Required(extPath.Child("name"), "name is required"))
+		} else if _, ok := names[extension.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(extPath.Child("name"), extension.Name))
+		} else {
+			names[extension.Name] = struct{}{}
+		}
+		if extension.Publisher == "" {
+			allErrs = append(allErrs, field.Required(extPath.Child("publisher"), "publisher is required"))
+		}
+		if extension.Type == "" {
+			allErrs = append(allErrs, field.Required(extPath.Child("type"), "type is required"))
+		}
+		if extension.Version == "" {
+			allErrs = append(allErrs, field.Required(extPath.Child("version"), "version is required"))
+		}
+		if extension.Settings != "" {
+			var settings map[string]interface{}
+			if err := json.Unmarshal([]byte(extension.Settings), &settings); err != nil {
+				allErrs = append(allErrs, field.Invalid(extPath.Child("settings"), extension.Settings, "settings should be a JSON object"))
+			}
+		}
+		if ref := extension.ProtectedSettingsSecretRef; ref != nil {
+			if ref.Name == "" {
+				allErrs = append(allErrs, field.Required(extPath.Child("protectedSettingsSecretRef", "name"), "the name of the secret is required"))
+			}
+			if ref.Key == "" {
+				allErrs = append(allErrs, field.Required(extPath.Child("protectedSettingsSecretRef", "key"), "the key of the secret is required"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// ValidateSharedDataDisks validates the data disks of a spec shared by several machines, such as the ones of a
+// machine template. An existing disk can only be attached to a single machine.
+func ValidateSharedDataDisks(dataDisks []DataDisk, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, disk := range dataDisks {
+		if disk.DiskName != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Index(i).Child("diskName"),
+				"an existing disk can only be attached to a single machine, set diskName on the AzureMachine instead"))
+		}
+	}
+	return allErrs
+}
+
 // ValidateDataDisks validates a list of data disks
 func ValidateDataDisks(dataDisks []DataDisk, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	lunSet := make(map[int32]struct{})
 	nameSet := make(map[string]struct{})
+	diskNameSet := make(map[string]struct{})
 	for _, disk := range dataDisks {
 		// validate that the disk size is between 4 and 32767.
 		if disk.DiskSizeGB < 4 || disk.DiskSizeGB > 32767 {
@@ -93,6 +259,13 @@ func ValidateDataDisks(dataDisks []DataDisk, fieldPath *field.Path) field.ErrorL
 		} else {
 			nameSet[disk.NameSuffix] = struct{}{}
 		}
+		if disk.DiskName != "" {
+			if _, ok := diskNameSet[disk.DiskName]; ok {
+				allErrs = append(allErrs, field.Duplicate(fieldPath.Child("DiskName"), disk.DiskName))
+			} else {
+				diskNameSet[disk.DiskName] = struct{}{}
+			}
+		}
 
 		// validate that all LUNs are unique and between 0 and 63.
 		if disk.Lun == nil {
@@ -107,6 +280,33 @@ func ValidateDataDisks(dataDisks []DataDisk, fieldPath *field.Path) field.ErrorL
 
 		// validate cachingType
 		allErrs = append(allErrs, validateCachingType(disk.CachingType, fieldPath)...)
+
+		if disk.ManagedDisk != nil {
+			allErrs = append(allErrs, validateStorageAccountType(disk.ManagedDisk.StorageAccountType, fieldPath)...)
+		}
+
+		// validate that the disk performance is only set on ultra disks, which are not cached.
+		isUltra := disk.ManagedDisk != nil && disk.ManagedDisk.StorageAccountType == string(compute.UltraSSDLRS)
+		if !isUltra {
+			if disk.DiskIOPSReadWrite != nil {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("DiskIOPSReadWrite"), *disk.DiskIOPSReadWrite, fmt.Sprintf("disk IOPS can only be set with the %s storage account type", compute.UltraSSDLRS)))
+			}
+			if disk.DiskMBpsReadWrite != nil {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("DiskMBpsReadWrite"), *disk.DiskMBpsReadWrite, fmt.Sprintf("disk bandwidth can only be set with the %s storage account type", compute.UltraSSDLRS)))
+			}
+		} else if disk.CachingType != string(compute.CachingTypesNone) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("CachingType"), disk.CachingType, fmt.Sprintf("the %s storage account type requires a caching type of None", compute.UltraSSDLRS)))
+		}
+
+		// validate that write accelerator is only enabled on premium disks that are not write cached.
+		if disk.WriteAcceleratorEnabled != nil && *disk.WriteAcceleratorEnabled {
+			if disk.ManagedDisk == nil || disk.ManagedDisk.StorageAccountType != string(compute.PremiumLRS) {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("WriteAcceleratorEnabled"), disk.WriteAcceleratorEnabled, fmt.Sprintf("write accelerator requires the %s storage account type", compute.PremiumLRS)))
+			}
+			if disk.CachingType == string(compute.CachingTypesReadWrite) {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("WriteAcceleratorEnabled"), disk.WriteAcceleratorEnabled, "write accelerator requires a caching type of None or ReadOnly"))
+			}
+		}
 	}
 	return allErrs
 }
@@ -124,6 +324,10 @@ func ValidateOSDisk(osDisk OSDisk, fieldPath *field.Path) field.ErrorList {
 	}
 
 	allErrs = append(allErrs, validateStorageAccountType(osDisk.ManagedDisk.StorageAccountType, fieldPath)...)
+	if osDisk.ManagedDisk.StorageAccountType == string(compute.UltraSSDLRS) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("ManagedDisk").Child("StorageAccountType"), osDisk.ManagedDisk.StorageAccountType,
+			fmt.Sprintf("the %s storage account type is only supported for data disks", compute.UltraSSDLRS)))
+	}
 
 	allErrs = append(allErrs, validateCachingType(osDisk.CachingType, fieldPath)...)
 
@@ -208,6 +412,118 @@ func validateDiffDiskSettingsUpdate(old, new *DiffDiskSettings, fieldPath *field
 	return allErrs
 }
 
+// validateImageUpdate validates that the image is not changed after machine creation.
+func validateImageUpdate(old, new *Image, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !reflect.DeepEqual(old, new) {
+		allErrs = append(allErrs, field.Invalid(fldPath, new, "changing the image after machine creation is not allowed"))
+	}
+
+	return allErrs
+}
+
+// validateOSDiskUpdate validates that the OS disk properties not covered by the managed disk and ephemeral OS
+// validations are not changed after machine creation.
+func validateOSDiskUpdate(old, new OSDisk, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if old.OSType != new.OSType {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("osType"), new.OSType, "changing the OS type after machine creation is not allowed"))
+	}
+	if old.DiskSizeGB != new.DiskSizeGB {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("diskSizeGB"), new.DiskSizeGB, "changing the OS disk size after machine creation is not allowed"))
+	}
+	if old.CachingType != new.CachingType {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cachingType"), new.CachingType, "changing the OS disk caching type after machine creation is not allowed"))
+	}
+	if !reflect.DeepEqual(old.ManagedDisk.DiskEncryptionSet, new.ManagedDisk.DiskEncryptionSet) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("managedDisk").Child("diskEncryptionSet"), new.ManagedDisk.DiskEncryptionSet, "changing the disk encryption set after machine creation is not allowed"))
+	}
+
+	return allErrs
+}
+
+// validateImmutable validates that a field is not changed after machine creation.
+func validateImmutable(old, new interface{}, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !reflect.DeepEqual(old, new) {
+		allErrs = append(allErrs, field.Invalid(fldPath, new, "field is immutable"))
+	}
+
+	return allErrs
+}
+
+// validateFailureDomainUpdate validates that the failure domain is not changed after machine creation.
+// Setting an unset failure domain from the deprecated availability zone is allowed.
+func validateFailureDomainUpdate(old, new *string, availabilityZone AvailabilityZone, fldPath *field.Path) field.ErrorList {
+	if old == nil && new != nil && availabilityZone.ID != nil && *new == *availabilityZone.ID {
+		return field.ErrorList{}
+	}
+
+	return validateImmutable(old, new, fldPath)
+}
+
+// validateVMSizeUpdate validates that the VM size of a Spot VM is not changed after machine creation.
+// Other VMs are resized in place.
+func validateVMSizeUpdate(old, new string, spotVMOptions *SpotVMOptions, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spotVMOptions != nil && old != new {
+		allErrs = append(allErrs, field.Invalid(fldPath, new, "changing the VM size of a Spot VM after machine creation is not allowed"))
+	}
+
+	return allErrs
+}
+
+// validateDataDisksUpdate validates that data disks are only added or grown after machine creation.
+// Existing data disks cannot be removed, shrunk or otherwise modified, and ultra disks can only be added to
+// machines created with one.
+func validateDataDisksUpdate(old, new []DataDisk, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	newDisks := make(map[string]DataDisk, len(new))
+	for _, disk := range new {
+		newDisks[disk.NameSuffix] = disk
+	}
+
+	for _, oldDisk := range old {
+		newDisk, ok := newDisks[oldDisk.NameSuffix]
+		if !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath, new, fmt.Sprintf("removing data disk %s after machine creation is not allowed", oldDisk.NameSuffix)))
+			continue
+		}
+		if newDisk.DiskSizeGB < oldDisk.DiskSizeGB {
+			allErrs = append(allErrs, field.Invalid(fldPath, new, fmt.Sprintf("shrinking data disk %s after machine creation is not allowed", oldDisk.NameSuffix)))
+		}
+		// the disk size can grow and the performance of ultra disks can change, every other setting is immutable.
+		newDisk.DiskSizeGB = oldDisk.DiskSizeGB
+		newDisk.DiskIOPSReadWrite = oldDisk.DiskIOPSReadWrite
+		newDisk.DiskMBpsReadWrite = oldDisk.DiskMBpsReadWrite
+		if !reflect.DeepEqual(oldDisk, newDisk) {
+			allErrs = append(allErrs, field.Invalid(fldPath, new, fmt.Sprintf("changing data disk %s after machine creation is not allowed", oldDisk.NameSuffix)))
+		}
+	}
+
+	// The ultra SSD capability of a VM is only enabled at creation, changing it requires deallocating the VM.
+	if !hasUltraDataDisk(old) && hasUltraDataDisk(new) {
+		allErrs = append(allErrs, field.Invalid(fldPath, new, fmt.Sprintf("adding the first %s data disk after machine creation is not allowed", compute.UltraSSDLRS)))
+	}
+
+	return allErrs
+}
+
+// hasUltraDataDisk returns true if one of the data disks has the UltraSSD_LRS storage account type.
+func hasUltraDataDisk(disks []DataDisk) bool {
+	for _, disk := range disks {
+		if disk.ManagedDisk != nil && disk.ManagedDisk.StorageAccountType == string(compute.UltraSSDLRS) {
+			return true
+		}
+	}
+	return false
+}
+
 func validateStorageAccountType(storageAccountType string, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	storageAccTypeChildPath := fieldPath.Child("ManagedDisk").Child("StorageAccountType")
@@ -217,6 +533,12 @@ func validateStorageAccountType(storageAccountType string, fieldPath *field.Path
 		return allErrs
 	}
 
+	// Premium SSD v2 disks can't be created with the compute API version used by the provider.
+	if storageAccountType == "PremiumV2_LRS" {
+		allErrs = append(allErrs, field.Invalid(storageAccTypeChildPath, storageAccountType, "Premium SSD v2 disks are not supported yet"))
+		return allErrs
+	}
+
 	for _, possibleStorageAccountType := range compute.PossibleDiskStorageAccountTypesValues() {
 		if string(possibleStorageAccountType) == storageAccountType {
 			return allErrs
@@ -21,6 +21,7 @@ import (
 	"crypto/rsa"
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -30,6 +31,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -132,6 +134,18 @@ func TestAzureMachine_ValidateOSDisk(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "ultra os disk",
+			wantErr: true,
+			osDisk: OSDisk{
+				DiskSizeGB:  30,
+				CachingType: "None",
+				OSType:      "blah",
+				ManagedDisk: ManagedDisk{
+					StorageAccountType: string(compute.UltraSSDLRS),
+				},
+			},
+		},
 	}
 	testcases = append(testcases, generateNegativeTestCases()...)
 
@@ -348,6 +362,168 @@ func TestAzureMachine_ValidateDataDisks(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid managed disk and write accelerator",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.CachingTypesNone),
+					ManagedDisk: &ManagedDisk{
+						StorageAccountType: string(compute.PremiumLRS),
+						DiskEncryptionSet: &DiskEncryptionSetParameters{
+							ID: "my-des-id",
+						},
+					},
+					WriteAcceleratorEnabled: to.BoolPtr(true),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid managed disk storage account type",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.CachingTypesNone),
+					ManagedDisk: &ManagedDisk{
+						StorageAccountType: "invalid",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "premium ssd v2 storage account type",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.CachingTypesNone),
+					ManagedDisk: &ManagedDisk{
+						StorageAccountType: "PremiumV2_LRS",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "write accelerator without premium storage",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.CachingTypesNone),
+					ManagedDisk: &ManagedDisk{
+						StorageAccountType: string(compute.StandardSSDLRS),
+					},
+					WriteAcceleratorEnabled: to.BoolPtr(true),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "write accelerator with read write caching",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.CachingTypesReadWrite),
+					ManagedDisk: &ManagedDisk{
+						StorageAccountType: string(compute.PremiumLRS),
+					},
+					WriteAcceleratorEnabled: to.BoolPtr(true),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid ultra disk with performance settings",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.CachingTypesNone),
+					ManagedDisk: &ManagedDisk{
+						StorageAccountType: string(compute.UltraSSDLRS),
+					},
+					DiskIOPSReadWrite: to.Int64Ptr(2000),
+					DiskMBpsReadWrite: to.Int64Ptr(200),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ultra disk with read only caching",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.CachingTypesReadOnly),
+					ManagedDisk: &ManagedDisk{
+						StorageAccountType: string(compute.UltraSSDLRS),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "disk IOPS without ultra storage",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.CachingTypesNone),
+					ManagedDisk: &ManagedDisk{
+						StorageAccountType: string(compute.PremiumLRS),
+					},
+					DiskIOPSReadWrite: to.Int64Ptr(2000),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "disk bandwidth without managed disk",
+			disks: []DataDisk{
+				{
+					NameSuffix:        "my_disk",
+					DiskSizeGB:        64,
+					Lun:               to.Int32Ptr(0),
+					CachingType:       string(compute.CachingTypesNone),
+					DiskMBpsReadWrite: to.Int64Ptr(200),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate disk names",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.CachingTypesNone),
+					DiskName:    "retained-disk",
+				},
+				{
+					NameSuffix:  "my_other_disk",
+					DiskSizeGB:  64,
+					Lun:         to.Int32Ptr(1),
+					CachingType: string(compute.CachingTypesNone),
+					DiskName:    "retained-disk",
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, test := range testcases {
@@ -362,6 +538,17 @@ func TestAzureMachine_ValidateDataDisks(t *testing.T) {
 	}
 }
 
+func TestAzureMachine_ValidateStorageAccountType(t *testing.T) {
+	g := NewWithT(t)
+
+	errs := validateStorageAccountType("PremiumV2_LRS", field.NewPath("osDisk"))
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+	g.Expect(errs[0].Detail).To(Equal("Premium SSD v2 disks are not supported yet"))
+
+	g.Expect(validateStorageAccountType(string(compute.UltraSSDLRS), field.NewPath("osDisk"))).To(BeEmpty())
+}
+
 func TestAzureMachine_ValidateSystemAssignedIdentity(t *testing.T) {
 	g := NewWithT(t)
 
@@ -416,3 +603,351 @@ func TestAzureMachine_ValidateSystemAssignedIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureMachine_ValidateRoleAssignments(t *testing.T) {
+	g := NewWithT(t)
+
+	name := uuid.New().String()
+	valid := []RoleAssignment{
+		{
+			Name:             name,
+			RoleDefinitionID: "acdd72a7-3385-48ef-bd42-f606fba81ae7",
+			Scope:            RoleAssignmentScopeResourceGroup,
+		},
+	}
+
+	tests := []struct {
+		name            string
+		roleAssignments []RoleAssignment
+		old             []RoleAssignment
+		Identity        VMIdentity
+		wantErr         bool
+	}{
+		{
+			name:            "valid role assignment",
+			roleAssignments: valid,
+			Identity:        VMIdentitySystemAssigned,
+			wantErr:         false,
+		},
+		{
+			name:     "no role assignments",
+			Identity: VMIdentityNone,
+			wantErr:  false,
+		},
+		{
+			name:            "wrong Identity type",
+			roleAssignments: valid,
+			Identity:        VMIdentityUserAssigned,
+			wantErr:         true,
+		},
+		{
+			name: "valid role definition resource ID and custom scope",
+			roleAssignments: []RoleAssignment{
+				{
+					Name:             name,
+					RoleDefinitionID: "/subscriptions/123/providers/Microsoft.Authorization/roleDefinitions/acdd72a7-3385-48ef-bd42-f606fba81ae7",
+					Scope:            RoleAssignmentScopeCustom,
+					ScopeID:          "/subscriptions/123/resourceGroups/shared-rg",
+				},
+			},
+			Identity: VMIdentitySystemAssigned,
+			wantErr:  false,
+		},
+		{
+			name: "missing role definition",
+			roleAssignments: []RoleAssignment{
+				{
+					Name:  name,
+					Scope: RoleAssignmentScopeResourceGroup,
+				},
+			},
+			Identity: VMIdentitySystemAssigned,
+			wantErr:  true,
+		},
+		{
+			name: "invalid role definition",
+			roleAssignments: []RoleAssignment{
+				{
+					Name:             name,
+					RoleDefinitionID: "/subscriptions/123/providers/Microsoft.Authorization/roleDefinitions/contributor",
+					Scope:            RoleAssignmentScopeResourceGroup,
+				},
+			},
+			Identity: VMIdentitySystemAssigned,
+			wantErr:  true,
+		},
+		{
+			name: "not a valid UUID",
+			roleAssignments: []RoleAssignment{
+				{
+					Name:             "notaguid",
+					RoleDefinitionID: "acdd72a7-3385-48ef-bd42-f606fba81ae7",
+					Scope:            RoleAssignmentScopeResourceGroup,
+				},
+			},
+			Identity: VMIdentitySystemAssigned,
+			wantErr:  true,
+		},
+		{
+			name: "duplicate names",
+			roleAssignments: []RoleAssignment{
+				valid[0],
+				valid[0],
+			},
+			Identity: VMIdentitySystemAssigned,
+			wantErr:  true,
+		},
+		{
+			name: "custom scope without scope ID",
+			roleAssignments: []RoleAssignment{
+				{
+					Name:             name,
+					RoleDefinitionID: "acdd72a7-3385-48ef-bd42-f606fba81ae7",
+					Scope:            RoleAssignmentScopeCustom,
+				},
+			},
+			Identity: VMIdentitySystemAssigned,
+			wantErr:  true,
+		},
+		{
+			name: "scope ID with non-custom scope",
+			roleAssignments: []RoleAssignment{
+				{
+					Name:             name,
+					RoleDefinitionID: "acdd72a7-3385-48ef-bd42-f606fba81ae7",
+					Scope:            RoleAssignmentScopeVirtualNetwork,
+					ScopeID:          "/subscriptions/123/resourceGroups/shared-rg",
+				},
+			},
+			Identity: VMIdentitySystemAssigned,
+			wantErr:  true,
+		},
+		{
+			name:            "unchanged",
+			roleAssignments: valid,
+			old:             valid,
+			Identity:        VMIdentitySystemAssigned,
+			wantErr:         false,
+		},
+		{
+			name: "changed",
+			roleAssignments: []RoleAssignment{
+				{
+					Name:             name,
+					RoleDefinitionID: "acdd72a7-3385-48ef-bd42-f606fba81ae7",
+					Scope:            RoleAssignmentScopeSubscription,
+				},
+			},
+			old:      valid,
+			Identity: VMIdentitySystemAssigned,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRoleAssignments(tc.Identity, tc.old, tc.roleAssignments, field.NewPath("roleAssignments"))
+			if tc.wantErr {
+				g.Expect(err).ToNot(HaveLen(0))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateProximityPlacementGroup(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name    string
+		ppg     ProximityPlacementGroup
+		wantErr bool
+	}{
+		{
+			name:    "default name",
+			ppg:     ProximityPlacementGroup{},
+			wantErr: false,
+		},
+		{
+			name:    "valid name",
+			ppg:     ProximityPlacementGroup{Name: "my-cluster.ppg_1"},
+			wantErr: false,
+		},
+		{
+			name:    "name starting with a hyphen",
+			ppg:     ProximityPlacementGroup{Name: "-my-ppg"},
+			wantErr: true,
+		},
+		{
+			name:    "name ending with a period",
+			ppg:     ProximityPlacementGroup{Name: "my-ppg."},
+			wantErr: true,
+		},
+		{
+			name:    "name too long",
+			ppg:     ProximityPlacementGroup{Name: strings.Repeat("a", 81)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateProximityPlacementGroup(tc.ppg, field.NewPath("proximityPlacementGroup"))
+			if tc.wantErr {
+				g.Expect(err).ToNot(HaveLen(0))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateDedicatedHostGroupID(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name          string
+		id            string
+		spotVMOptions *SpotVMOptions
+		wantErr       bool
+	}{
+		{
+			name:    "no host group",
+			id:      "",
+			wantErr: false,
+		},
+		{
+			name:    "valid host group",
+			id:      "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group",
+			wantErr: false,
+		},
+		{
+			name:    "not a host group",
+			id:      "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hosts/my-host",
+			wantErr: true,
+		},
+		{
+			name:          "spot VM on a host group",
+			id:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group",
+			spotVMOptions: &SpotVMOptions{},
+			wantErr:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateDedicatedHostGroupID(tc.id, tc.spotVMOptions, field.NewPath("dedicatedHostGroupID"))
+			if tc.wantErr {
+				g.Expect(err).ToNot(HaveLen(0))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateVMExtensions(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name       string
+		extensions []VMExtension
+		wantErr    bool
+	}{
+		{
+			name:       "no extensions",
+			extensions: nil,
+			wantErr:    false,
+		},
+		{
+			name: "valid extensions",
+			extensions: []VMExtension{
+				{
+					Name:      "monitor",
+					Publisher: "Microsoft.Azure.Monitor",
+					Type:      "AzureMonitorLinuxAgent",
+					Version:   "1.5",
+					Settings:  `{"workspaceId": "my-workspace"}`,
+					ProtectedSettingsSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "monitor-secret"},
+						Key:                  "settings",
+					},
+				},
+				{
+					Name:      "script",
+					Publisher: "Microsoft.Azure.Extensions",
+					Type:      "CustomScript",
+					Version:   "2.1",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing publisher, type and version",
+			extensions: []VMExtension{
+				{
+					Name: "monitor",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate names",
+			extensions: []VMExtension{
+				{
+					Name:      "monitor",
+					Publisher: "Microsoft.Azure.Monitor",
+					Type:      "AzureMonitorLinuxAgent",
+					Version:   "1.5",
+				},
+				{
+					Name:      "monitor",
+					Publisher: "Microsoft.Azure.Monitor",
+					Type:      "AzureMonitorLinuxAgent",
+					Version:   "1.6",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "settings not a JSON object",
+			extensions: []VMExtension{
+				{
+					Name:      "monitor",
+					Publisher: "Microsoft.Azure.Monitor",
+					Type:      "AzureMonitorLinuxAgent",
+					Version:   "1.5",
+					Settings:  `["workspaceId"]`,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "protected settings secret without key",
+			extensions: []VMExtension{
+				{
+					Name:      "monitor",
+					Publisher: "Microsoft.Azure.Monitor",
+					Type:      "AzureMonitorLinuxAgent",
+					Version:   "1.5",
+					ProtectedSettingsSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "monitor-secret"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateVMExtensions(tc.extensions, field.NewPath("vmExtensions"))
+			if tc.wantErr {
+				g.Expect(err).ToNot(HaveLen(0))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
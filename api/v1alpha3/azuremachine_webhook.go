@@ -61,6 +61,10 @@ func (m *AzureMachine) ValidateCreate() error {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateRoleAssignments(m.Spec.Identity, nil, m.Spec.RoleAssignments, field.NewPath("roleAssignments")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if errs := ValidateUserAssignedIdentity(m.Spec.Identity, m.Spec.UserAssignedIdentities, field.NewPath("userAssignedIdentities")); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
@@ -69,6 +73,14 @@ func (m *AzureMachine) ValidateCreate() error {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateDedicatedHostGroupID(m.Spec.DedicatedHostGroupID, m.Spec.SpotVMOptions, field.NewPath("dedicatedHostGroupID")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateVMExtensions(m.Spec.VMExtensions, field.NewPath("vmExtensions")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -97,6 +109,10 @@ func (m *AzureMachine) ValidateUpdate(oldRaw runtime.Object) error {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateRoleAssignments(m.Spec.Identity, old.Spec.RoleAssignments, m.Spec.RoleAssignments, field.NewPath("roleAssignments")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if errs := ValidateUserAssignedIdentity(m.Spec.Identity, m.Spec.UserAssignedIdentities, field.NewPath("userAssignedIdentities")); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
@@ -105,6 +121,14 @@ func (m *AzureMachine) ValidateUpdate(oldRaw runtime.Object) error {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateDedicatedHostGroupID(m.Spec.DedicatedHostGroupID, m.Spec.SpotVMOptions, field.NewPath("dedicatedHostGroupID")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateVMExtensions(m.Spec.VMExtensions, field.NewPath("vmExtensions")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if errs := ValidateManagedDisk(old.Spec.OSDisk.ManagedDisk, m.Spec.OSDisk.ManagedDisk, field.NewPath("osDisk").Child("managedDisk")); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
@@ -113,6 +137,58 @@ func (m *AzureMachine) ValidateUpdate(oldRaw runtime.Object) error {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := validateOSDiskUpdate(old.Spec.OSDisk, m.Spec.OSDisk, field.NewPath("osDisk")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateImageUpdate(old.Spec.Image, m.Spec.Image, field.NewPath("image")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateVMSizeUpdate(old.Spec.VMSize, m.Spec.VMSize, m.Spec.SpotVMOptions, field.NewPath("vmSize")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateDataDisksUpdate(old.Spec.DataDisks, m.Spec.DataDisks, field.NewPath("dataDisks")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateImmutable(old.Spec.Location, m.Spec.Location, field.NewPath("location")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateImmutable(old.Spec.AvailabilityZone, m.Spec.AvailabilityZone, field.NewPath("availabilityZone")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateFailureDomainUpdate(old.Spec.FailureDomain, m.Spec.FailureDomain, old.Spec.AvailabilityZone, field.NewPath("failureDomain")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateImmutable(old.Spec.SSHPublicKey, m.Spec.SSHPublicKey, field.NewPath("sshPublicKey")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateImmutable(old.Spec.AllocatePublicIP, m.Spec.AllocatePublicIP, field.NewPath("allocatePublicIP")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateImmutable(old.Spec.AcceleratedNetworking, m.Spec.AcceleratedNetworking, field.NewPath("acceleratedNetworking")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateImmutable(old.Spec.SpotVMOptions, m.Spec.SpotVMOptions, field.NewPath("spotVMOptions")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateImmutable(old.Spec.DedicatedHostGroupID, m.Spec.DedicatedHostGroupID, field.NewPath("dedicatedHostGroupID")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateImmutable(old.Spec.BootstrapData, m.Spec.BootstrapData, field.NewPath("bootstrapData")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
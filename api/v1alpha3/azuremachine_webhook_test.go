@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/gomega"
 )
 
@@ -125,10 +126,16 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 	}{
 		{
 			name:       "azuremachine with valid SSHPublicKey",
-			oldMachine: createMachineWithSSHPublicKey(t, ""),
+			oldMachine: createMachineWithSSHPublicKey(t, validSSHPublicKey),
 			machine:    createMachineWithSSHPublicKey(t, validSSHPublicKey),
 			wantErr:    false,
 		},
+		{
+			name:       "azuremachine with changed SSHPublicKey",
+			oldMachine: createMachineWithSSHPublicKey(t, validSSHPublicKey),
+			machine:    createMachineWithSSHPublicKey(t, generateSSHPublicKey(true)),
+			wantErr:    true,
+		},
 		{
 			name:       "azuremachine without SSHPublicKey",
 			oldMachine: createMachineWithSSHPublicKey(t, ""),
@@ -155,16 +162,246 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 		},
 		{
 			name:       "azuremachine with valid osDisk cache type",
-			oldMachine: createMachineWithOsDiskCacheType(t, string(compute.PossibleCachingTypesValues()[0])),
+			oldMachine: createMachineWithOsDiskCacheType(t, string(compute.PossibleCachingTypesValues()[1])),
 			machine:    createMachineWithOsDiskCacheType(t, string(compute.PossibleCachingTypesValues()[1])),
 			wantErr:    false,
 		},
+		{
+			name:       "azuremachine with changed osDisk cache type",
+			oldMachine: createMachineWithOsDiskCacheType(t, string(compute.PossibleCachingTypesValues()[0])),
+			machine:    createMachineWithOsDiskCacheType(t, string(compute.PossibleCachingTypesValues()[1])),
+			wantErr:    true,
+		},
 		{
 			name:       "azuremachine with invalid osDisk cache type",
 			oldMachine: createMachineWithOsDiskCacheType(t, string(compute.PossibleCachingTypesValues()[0])),
 			machine:    createMachineWithOsDiskCacheType(t, "invalid_cache_type"),
 			wantErr:    true,
 		},
+		{
+			name:       "azuremachine with changed image",
+			oldMachine: createMachineWithImageByID(t, "image-1"),
+			machine:    createMachineWithImageByID(t, "image-2"),
+			wantErr:    true,
+		},
+		{
+			name:       "azuremachine with changed vmSize",
+			oldMachine: createMachineWithVMSize(t, "Standard_D2s_v3", nil),
+			machine:    createMachineWithVMSize(t, "Standard_D4s_v3", nil),
+			wantErr:    false,
+		},
+		{
+			name:       "spot azuremachine with changed vmSize",
+			oldMachine: createMachineWithVMSize(t, "Standard_D2s_v3", &SpotVMOptions{}),
+			machine:    createMachineWithVMSize(t, "Standard_D4s_v3", &SpotVMOptions{}),
+			wantErr:    true,
+		},
+		{
+			name:       "azuremachine with added data disk",
+			oldMachine: createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "None"}}),
+			machine:    createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "None"}, {NameSuffix: "disk-2", DiskSizeGB: 64, Lun: to.Int32Ptr(1), CachingType: "None"}}),
+			wantErr:    false,
+		},
+		{
+			name:       "azuremachine with removed data disk",
+			oldMachine: createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "None"}, {NameSuffix: "disk-2", DiskSizeGB: 64, Lun: to.Int32Ptr(1), CachingType: "None"}}),
+			machine:    createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "None"}}),
+			wantErr:    true,
+		},
+		{
+			name:       "azuremachine with grown data disk",
+			oldMachine: createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "None"}}),
+			machine:    createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 128, Lun: to.Int32Ptr(0), CachingType: "None"}}),
+			wantErr:    false,
+		},
+		{
+			name:       "azuremachine with shrunk data disk",
+			oldMachine: createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 128, Lun: to.Int32Ptr(0), CachingType: "None"}}),
+			machine:    createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "None"}}),
+			wantErr:    true,
+		},
+		{
+			name:       "azuremachine with changed ultra data disk performance",
+			oldMachine: createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "None", ManagedDisk: &ManagedDisk{StorageAccountType: "UltraSSD_LRS"}}}),
+			machine:    createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "None", ManagedDisk: &ManagedDisk{StorageAccountType: "UltraSSD_LRS"}, DiskIOPSReadWrite: to.Int64Ptr(5000), DiskMBpsReadWrite: to.Int64Ptr(300)}}),
+			wantErr:    false,
+		},
+		{
+			name:       "azuremachine with added ultra data disk",
+			oldMachine: createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "None", ManagedDisk: &ManagedDisk{StorageAccountType: "UltraSSD_LRS"}}}),
+			machine:    createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "None", ManagedDisk: &ManagedDisk{StorageAccountType: "UltraSSD_LRS"}}, {NameSuffix: "disk-2", DiskSizeGB: 64, Lun: to.Int32Ptr(1), CachingType: "None", ManagedDisk: &ManagedDisk{StorageAccountType: "UltraSSD_LRS"}}}),
+			wantErr:    false,
+		},
+		{
+			name:       "azuremachine with first ultra data disk added",
+			oldMachine: createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "None"}}),
+			machine:    createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "None"}, {NameSuffix: "disk-2", DiskSizeGB: 64, Lun: to.Int32Ptr(1), CachingType: "None", ManagedDisk: &ManagedDisk{StorageAccountType: "UltraSSD_LRS"}}}),
+			wantErr:    true,
+		},
+		{
+			name:       "azuremachine with changed data disk caching type",
+			oldMachine: createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "None"}}),
+			machine:    createMachineWithDataDisks(t, []DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), CachingType: "ReadOnly"}}),
+			wantErr:    true,
+		},
+		{
+			name: "azuremachine with changed location",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey: validSSHPublicKey,
+					OSDisk:       validOSDisk,
+					Location:     "westus",
+				},
+			},
+			machine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey: validSSHPublicKey,
+					OSDisk:       validOSDisk,
+					Location:     "eastus",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azuremachine with changed availabilityZone",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey:     validSSHPublicKey,
+					OSDisk:           validOSDisk,
+					AvailabilityZone: AvailabilityZone{ID: to.StringPtr("1")},
+				},
+			},
+			machine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey:     validSSHPublicKey,
+					OSDisk:           validOSDisk,
+					AvailabilityZone: AvailabilityZone{ID: to.StringPtr("2")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azuremachine with changed failureDomain",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey:  validSSHPublicKey,
+					OSDisk:        validOSDisk,
+					FailureDomain: to.StringPtr("1"),
+				},
+			},
+			machine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey:  validSSHPublicKey,
+					OSDisk:        validOSDisk,
+					FailureDomain: to.StringPtr("2"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azuremachine with failureDomain set from deprecated availabilityZone",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey:     validSSHPublicKey,
+					OSDisk:           validOSDisk,
+					AvailabilityZone: AvailabilityZone{ID: to.StringPtr("1")},
+				},
+			},
+			machine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey:     validSSHPublicKey,
+					OSDisk:           validOSDisk,
+					AvailabilityZone: AvailabilityZone{ID: to.StringPtr("1")},
+					FailureDomain:    to.StringPtr("1"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "azuremachine with failureDomain set to a different zone than the deprecated availabilityZone",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey:     validSSHPublicKey,
+					OSDisk:           validOSDisk,
+					AvailabilityZone: AvailabilityZone{ID: to.StringPtr("1")},
+				},
+			},
+			machine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey:     validSSHPublicKey,
+					OSDisk:           validOSDisk,
+					AvailabilityZone: AvailabilityZone{ID: to.StringPtr("1")},
+					FailureDomain:    to.StringPtr("2"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azuremachine with changed allocatePublicIP",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey:     validSSHPublicKey,
+					OSDisk:           validOSDisk,
+					AllocatePublicIP: false,
+				},
+			},
+			machine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey:     validSSHPublicKey,
+					OSDisk:           validOSDisk,
+					AllocatePublicIP: true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azuremachine with changed acceleratedNetworking",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey:          validSSHPublicKey,
+					OSDisk:                validOSDisk,
+					AcceleratedNetworking: nil,
+				},
+			},
+			machine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SSHPublicKey:          validSSHPublicKey,
+					OSDisk:                validOSDisk,
+					AcceleratedNetworking: to.BoolPtr(true),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "azuremachine with added spotVMOptions",
+			oldMachine: createMachineWithVMSize(t, "Standard_D2s_v3", nil),
+			machine:    createMachineWithVMSize(t, "Standard_D2s_v3", &SpotVMOptions{}),
+			wantErr:    true,
+		},
+		{
+			name:       "azuremachine with changed spotVMOptions",
+			oldMachine: createMachineWithVMSize(t, "Standard_D2s_v3", &SpotVMOptions{}),
+			machine:    createMachineWithVMSize(t, "Standard_D2s_v3", &SpotVMOptions{MaxPrice: to.StringPtr("0.5")}),
+			wantErr:    true,
+		},
+		{
+			name:       "azuremachine with changed dedicatedHostGroupID",
+			oldMachine: createMachineWithDedicatedHostGroup(t, ""),
+			machine:    createMachineWithDedicatedHostGroup(t, "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"),
+			wantErr:    true,
+		},
+		{
+			name:       "azuremachine with unchanged bootstrapData",
+			oldMachine: createMachineWithBootstrapData(t, &BootstrapData{Compression: BootstrapDataCompressionGzip}),
+			machine:    createMachineWithBootstrapData(t, &BootstrapData{Compression: BootstrapDataCompressionGzip}),
+			wantErr:    false,
+		},
+		{
+			name:       "azuremachine with changed bootstrapData",
+			oldMachine: createMachineWithBootstrapData(t, nil),
+			machine:    createMachineWithBootstrapData(t, &BootstrapData{Delivery: BootstrapDataDeliveryStorageBlob}),
+			wantErr:    true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -270,3 +507,44 @@ func createMachineWithOsDiskCacheType(t *testing.T, cacheType string) *AzureMach
 	machine.Spec.OSDisk.CachingType = cacheType
 	return machine
 }
+
+func createMachineWithVMSize(t *testing.T, vmSize string, spotVMOptions *SpotVMOptions) *AzureMachine {
+	return &AzureMachine{
+		Spec: AzureMachineSpec{
+			VMSize:        vmSize,
+			SSHPublicKey:  validSSHPublicKey,
+			OSDisk:        validOSDisk,
+			SpotVMOptions: spotVMOptions,
+		},
+	}
+}
+
+func createMachineWithDedicatedHostGroup(t *testing.T, hostGroupID string) *AzureMachine {
+	return &AzureMachine{
+		Spec: AzureMachineSpec{
+			SSHPublicKey:         validSSHPublicKey,
+			OSDisk:               validOSDisk,
+			DedicatedHostGroupID: hostGroupID,
+		},
+	}
+}
+
+func createMachineWithBootstrapData(t *testing.T, bootstrapData *BootstrapData) *AzureMachine {
+	return &AzureMachine{
+		Spec: AzureMachineSpec{
+			SSHPublicKey:  validSSHPublicKey,
+			OSDisk:        validOSDisk,
+			BootstrapData: bootstrapData,
+		},
+	}
+}
+
+func createMachineWithDataDisks(t *testing.T, dataDisks []DataDisk) *AzureMachine {
+	return &AzureMachine{
+		Spec: AzureMachineSpec{
+			SSHPublicKey: validSSHPublicKey,
+			OSDisk:       validOSDisk,
+			DataDisks:    dataDisks,
+		},
+	}
+}
@@ -17,12 +17,16 @@ limitations under the License.
 package v1alpha3
 
 import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 // log is for logging in this package.
-var _ = logf.Log.WithName("azuremachinetemplate-resource")
+var machinetemplatelog = logf.Log.WithName("azuremachinetemplate-resource")
 
 // SetupWebhookWithManager sets up and registers the webhook with the manager.
 func (r *AzureMachineTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -30,3 +34,53 @@ func (r *AzureMachineTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
 		For(r).
 		Complete()
 }
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1alpha3-azuremachinetemplate,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azuremachinetemplates,versions=v1alpha3,name=validation.azuremachinetemplate.infrastructure.cluster.x-k8s.io,sideEffects=None
+
+var _ webhook.Validator = &AzureMachineTemplate{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *AzureMachineTemplate) ValidateCreate() error {
+	machinetemplatelog.Info("validate create", "name", r.Name)
+	return r.validateTemplate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *AzureMachineTemplate) ValidateUpdate(oldRaw runtime.Object) error {
+	machinetemplatelog.Info("validate update", "name", r.Name)
+	return r.validateTemplate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *AzureMachineTemplate) ValidateDelete() error {
+	machinetemplatelog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+// validateTemplate validates the settings of the template that can't be shared by the machines created from it.
+func (r *AzureMachineTemplate) validateTemplate() error {
+	var allErrs field.ErrorList
+
+	spec := r.Spec.Template.Spec
+	fldPath := field.NewPath("spec", "template", "spec")
+	if errs := ValidateSharedDataDisks(spec.DataDisks, fldPath.Child("dataDisks")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	// Role assignment names are unique in a scope, they are generated for each machine when left empty.
+	if spec.RoleAssignmentName != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("roleAssignmentName"),
+			"role assignment names must be unique to each machine, leave it empty so that one is generated for each AzureMachine"))
+	}
+	for i, ra := range spec.RoleAssignments {
+		if ra.Name != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("roleAssignments").Index(i).Child("name"),
+				"role assignment names must be unique to each machine, leave it empty so that one is generated for each AzureMachine"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("AzureMachineTemplate").GroupKind(), r.Name, allErrs)
+}
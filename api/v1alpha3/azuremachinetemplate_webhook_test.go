@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestAzureMachineTemplate_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name     string
+		template *AzureMachineTemplate
+		wantErr  bool
+	}{
+		{
+			name:     "azuremachinetemplate with new data disks",
+			template: createMachineTemplateWithDataDisks([]DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), DeletionPolicy: DiskDeletionPolicyRetain}}),
+			wantErr:  false,
+		},
+		{
+			name:     "azuremachinetemplate with existing data disk",
+			template: createMachineTemplateWithDataDisks([]DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), DiskName: "my-old-machine_disk-1"}}),
+			wantErr:  true,
+		},
+		{
+			name:     "azuremachinetemplate with generated role assignment names",
+			template: createMachineTemplateWithRoleAssignments("", []RoleAssignment{{RoleDefinitionID: "acdd72a7-3385-48ef-bd42-f606fba81ae7"}}),
+			wantErr:  false,
+		},
+		{
+			name:     "azuremachinetemplate with role assignment name",
+			template: createMachineTemplateWithRoleAssignments("", []RoleAssignment{{Name: "30a757d8-fcf0-4c8b-acf0-9253a7e093ea", RoleDefinitionID: "acdd72a7-3385-48ef-bd42-f606fba81ae7"}}),
+			wantErr:  true,
+		},
+		{
+			name:     "azuremachinetemplate with contributor role assignment name",
+			template: createMachineTemplateWithRoleAssignments("30a757d8-fcf0-4c8b-acf0-9253a7e093ea", nil),
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.template.ValidateCreate()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAzureMachineTemplate_ValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	oldTemplate := createMachineTemplateWithDataDisks(nil)
+	template := createMachineTemplateWithDataDisks([]DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, Lun: to.Int32Ptr(0), DiskName: "my-old-machine_disk-1"}})
+	g.Expect(template.ValidateUpdate(oldTemplate)).NotTo(Succeed())
+}
+
+func createMachineTemplateWithDataDisks(dataDisks []DataDisk) *AzureMachineTemplate {
+	return &AzureMachineTemplate{
+		Spec: AzureMachineTemplateSpec{
+			Template: AzureMachineTemplateResource{
+				Spec: AzureMachineSpec{
+					DataDisks: dataDisks,
+				},
+			},
+		},
+	}
+}
+
+func createMachineTemplateWithRoleAssignments(roleAssignmentName string, roleAssignments []RoleAssignment) *AzureMachineTemplate {
+	return &AzureMachineTemplate{
+		Spec: AzureMachineTemplateSpec{
+			Template: AzureMachineTemplateResource{
+				Spec: AzureMachineSpec{
+					Identity:           VMIdentitySystemAssigned,
+					RoleAssignmentName: roleAssignmentName,
+					RoleAssignments:    roleAssignments,
+				},
+			},
+		},
+	}
+}
@@ -26,6 +26,10 @@ const (
 	LoadBalancerProvisioningReason = "LoadBalancerProvisioning"
 	// LoadBalancerProvisioningFailedReason used for failure during provisioning of loadbalancer.
 	LoadBalancerProvisioningFailedReason = "LoadBalancerProvisioningFailed"
+	// NetworkInfrastructureProvisioningReason used while an operation on the cluster infrastructure is in progress.
+	NetworkInfrastructureProvisioningReason = "NetworkInfrastructureProvisioning"
+	// NetworkInfrastructureFailedReason used for failures during provisioning of cluster infrastructure.
+	NetworkInfrastructureFailedReason = "NetworkInfrastructureFailed"
 )
 
 // AzureMachine Conditions and Reasons
@@ -48,4 +52,23 @@ const (
 	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
 	// WaitingForBootstrapDataReason used when machine is waiting for bootstrap data to be ready before proceeding.
 	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
+	// BootstrapDataTooLargeReason used when the bootstrap data exceeds the size limit of the custom data of a VM.
+	BootstrapDataTooLargeReason = "BootstrapDataTooLarge"
+)
+
+// AzureMachinePool Conditions and Reasons
+const (
+	// ScaleSetRunningCondition reports on current status of the Azure scale set.
+	ScaleSetRunningCondition clusterv1.ConditionType = "ScaleSetRunning"
+	// ScaleSetProvisionFailedReason used for failures during scale set provisioning.
+	ScaleSetProvisionFailedReason = "ScaleSetProvisionFailed"
+)
+
+// Common Conditions and Reasons
+const (
+	// ServicesReconciledCondition reports whether all the Azure services of an AzureCluster or an AzureMachine are
+	// reconciled, or if some of them are skipped as requested by the SkipReconcileServicesAnnotation.
+	ServicesReconciledCondition clusterv1.ConditionType = "ServicesReconciled"
+	// ServicesSkippedReason used when the reconciliation of some services is skipped by annotation.
+	ServicesSkippedReason = "ServicesSkipped"
 )
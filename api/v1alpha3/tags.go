@@ -19,6 +19,10 @@ package v1alpha3
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // Tags defines a map of tags.
@@ -35,6 +39,71 @@ func (t Tags) HasOwned(cluster string) bool {
 	return ok && ResourceLifecycle(value) == ResourceLifecycleOwned
 }
 
+// HasShared returns true if the tags contains a tag that marks the resource as shared with the cluster, i.e. a resource
+// created outside of the cluster, possibly used by other clusters too, which the cluster added entries to.
+func (t Tags) HasShared(cluster string) bool {
+	value, ok := t[ClusterTagKey(cluster)]
+	return ok && ResourceLifecycle(value) == ResourceLifecycleShared
+}
+
+// GetEntries returns the names of the entries, e.g. the subnets, security rules or routes, the cluster added to the
+// shared resource.
+func (t Tags) GetEntries(cluster string) []string {
+	var entries []string
+	for i := 0; ; i++ {
+		value, ok := t[clusterEntriesTagKey(cluster, i)]
+		if !ok || value == "" {
+			return entries
+		}
+		entries = append(entries, strings.Split(value, ",")...)
+	}
+}
+
+// HasEntry returns true if the cluster added the named entry to the shared resource.
+func (t Tags) HasEntry(cluster, entry string) bool {
+	for _, e := range t.GetEntries(cluster) {
+		if e == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// HasEntryOfOtherCluster returns true if any cluster other than the given one added the named entry to the shared
+// resource.
+func (t Tags) HasEntryOfOtherCluster(cluster, entry string) bool {
+	for key := range t {
+		if !strings.HasPrefix(key, NameAzureProviderEntries) {
+			continue
+		}
+		// cluster names can't contain underscores, which separate the index of the continuation tags.
+		other := strings.SplitN(strings.TrimPrefix(key, NameAzureProviderEntries), "_", 2)[0]
+		if other != cluster && t.HasEntry(other, entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// EntriesTagKeys returns the keys of the tags recording the entries the cluster added to the shared resource.
+func (t Tags) EntriesTagKeys(cluster string) []string {
+	var keys []string
+	for key := range t {
+		if key == ClusterEntriesTagKey(cluster) || strings.HasPrefix(key, ClusterEntriesTagKey(cluster)+"_") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DeleteEntries removes the tags recording the entries the cluster added to the shared resource.
+func (t Tags) DeleteEntries(cluster string) {
+	for _, key := range t.EntriesTagKeys(cluster) {
+		delete(t, key)
+	}
+}
+
 // HasAzureCloudProviderOwned returns true if the tags contains a tag that marks the resource as owned by the cluster from the perspective of the in-tree cloud provider.
 func (t Tags) HasAzureCloudProviderOwned(cluster string) bool {
 	value, ok := t[ClusterAzureCloudProviderTagKey(cluster)]
@@ -46,6 +115,35 @@ func (t Tags) GetRole() string {
 	return t[NameAzureClusterAPIRole]
 }
 
+// GetMachine returns the name of the machine the tagged resource is dedicated to, if any.
+func (t Tags) GetMachine() string {
+	return t[NameAzureClusterAPIMachine]
+}
+
+// GetOwnedClusters returns the names of the clusters owning the tagged resource.
+func (t Tags) GetOwnedClusters() []string {
+	var clusters []string
+	for key, value := range t {
+		if strings.HasPrefix(key, NameAzureProviderOwned) && ResourceLifecycle(value) == ResourceLifecycleOwned {
+			clusters = append(clusters, strings.TrimPrefix(key, NameAzureProviderOwned))
+		}
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
+// GetClusters returns the names of the clusters owning or sharing the tagged resource.
+func (t Tags) GetClusters() []string {
+	var clusters []string
+	for key := range t {
+		if strings.HasPrefix(key, NameAzureProviderOwned) {
+			clusters = append(clusters, strings.TrimPrefix(key, NameAzureProviderOwned))
+		}
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
 // Difference returns the difference between this map of tags and the other map of tags.
 // Items are considered equals if key and value are equals.
 func (t Tags) Difference(other Tags) Tags {
@@ -104,6 +202,19 @@ const (
 	// dedicated to this cluster api provider implementation.
 	NameAzureClusterAPIRole = NameAzureProviderPrefix + "role"
 
+	// NameAzureClusterAPIMachine is the tag name we use to mark the resources dedicated to a single machine
+	// with the name of that machine.
+	NameAzureClusterAPIMachine = NameAzureProviderPrefix + "machine"
+
+	// NameAzureProviderEntries is the tag name prefix we use to record, on a resource shared between clusters,
+	// the comma separated names of the entries each cluster added to it.
+	// The tag key = NameAzureProviderEntries + clusterName, followed by _1, _2, etc. for the entries which don't fit
+	// in the first tag.
+	NameAzureProviderEntries = NameAzureProviderPrefix + "entries_"
+
+	// MaxTagValueLength is the maximum length of the value of an Azure tag.
+	MaxTagValueLength = 256
+
 	// APIServerRole describes the value for the apiserver role
 	APIServerRole = "apiserver"
 
@@ -113,6 +224,9 @@ const (
 	// ControlPlaneOutboundRole describes the value for the control plane outbound LB role
 	ControlPlaneOutboundRole = "controlPlaneOutbound"
 
+	// AdditionalLBRole describes the value for the role of additional, user defined load balancers
+	AdditionalLBRole = "additionalLB"
+
 	// BastionRole describes the value for the bastion role
 	BastionRole = "bastion"
 
@@ -124,6 +238,10 @@ const (
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// for annotation formatting rules.
 	VMTagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-tags-vm"
+
+	// VMExtensionsLastAppliedAnnotation is the key for the machine and machine pool object annotation which tracks the
+	// VM extensions applied last, so that the extensions removed from the spec are uninstalled.
+	VMExtensionsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-vm-extensions"
 )
 
 // ClusterTagKey generates the key for resources associated with a cluster.
@@ -136,6 +254,20 @@ func ClusterAzureCloudProviderTagKey(name string) string {
 	return fmt.Sprintf("%s%s", NameKubernetesAzureCloudProviderPrefix, name)
 }
 
+// ClusterEntriesTagKey generates the key recording the entries a cluster added to a shared resource.
+func ClusterEntriesTagKey(name string) string {
+	return fmt.Sprintf("%s%s", NameAzureProviderEntries, name)
+}
+
+// clusterEntriesTagKey generates the key of the i-th tag recording the entries a cluster added to a shared resource.
+// Entries which don't fit in the value of a single tag continue in the tags suffixed with their index.
+func clusterEntriesTagKey(name string, i int) string {
+	if i == 0 {
+		return ClusterEntriesTagKey(name)
+	}
+	return fmt.Sprintf("%s_%d", ClusterEntriesTagKey(name), i)
+}
+
 // BuildParams is used to build tags around an azure resource.
 type BuildParams struct {
 	// Lifecycle determines the resource lifecycle.
@@ -155,6 +287,14 @@ type BuildParams struct {
 	// +optional
 	Role *string
 
+	// MachineName is the name of the machine the resource is dedicated to.
+	// +optional
+	MachineName *string
+
+	// Entries are the names of the entries the cluster added to a shared resource.
+	// +optional
+	Entries []string
+
 	// Any additional tags to be added to the resource.
 	// +optional
 	Additional Tags
@@ -172,9 +312,38 @@ func Build(params BuildParams) Tags {
 		tags[NameAzureClusterAPIRole] = *params.Role
 	}
 
+	if params.MachineName != nil {
+		tags[NameAzureClusterAPIMachine] = *params.MachineName
+	}
+
 	if params.Name != nil {
 		tags["Name"] = *params.Name
 	}
 
+	if len(params.Entries) > 0 {
+		for i, value := range joinEntries(sets.NewString(params.Entries...).List()) {
+			tags[clusterEntriesTagKey(params.ClusterName, i)] = value
+		}
+	}
+
 	return tags
 }
+
+// joinEntries joins the names of entries with commas into as few tag values as possible, none of which exceeds the
+// maximum length of a tag value.
+func joinEntries(entries []string) []string {
+	values := []string{}
+	value := ""
+	for _, entry := range entries {
+		switch {
+		case value == "":
+			value = entry
+		case len(value)+len(entry)+1 > MaxTagValueLength:
+			values = append(values, value)
+			value = entry
+		default:
+			value += "," + entry
+		}
+	}
+	return append(values, value)
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package v1alpha3
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/gomega"
 )
 
@@ -88,3 +90,85 @@ func TestTags_Merge(t *testing.T) {
 		})
 	}
 }
+
+func TestTags_GetOwnedClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	tags := Build(BuildParams{
+		ClusterName: "cluster-b",
+		Lifecycle:   ResourceLifecycleOwned,
+		MachineName: to.StringPtr("machine-a"),
+		Additional: Tags{
+			ClusterTagKey("cluster-a"): string(ResourceLifecycleOwned),
+			ClusterTagKey("cluster-c"): string(ResourceLifecycleShared),
+		},
+	})
+
+	g.Expect(tags.GetOwnedClusters()).To(Equal([]string{"cluster-a", "cluster-b"}))
+	g.Expect(tags.GetClusters()).To(Equal([]string{"cluster-a", "cluster-b", "cluster-c"}))
+	g.Expect(tags.GetMachine()).To(Equal("machine-a"))
+	g.Expect(Tags{}.GetOwnedClusters()).To(BeEmpty())
+	g.Expect(Tags{}.GetClusters()).To(BeEmpty())
+}
+
+func TestTags_Entries(t *testing.T) {
+	g := NewWithT(t)
+
+	tags := Build(BuildParams{
+		ClusterName: "cluster-a",
+		Lifecycle:   ResourceLifecycleShared,
+		Entries:     []string{"subnet-b", "subnet-a", "subnet-b"},
+		Additional: Tags{
+			ClusterTagKey("cluster-b"):        string(ResourceLifecycleShared),
+			ClusterEntriesTagKey("cluster-b"): "subnet-b,subnet-c",
+		},
+	})
+
+	g.Expect(tags.HasShared("cluster-a")).To(BeTrue())
+	g.Expect(tags.HasOwned("cluster-a")).To(BeFalse())
+	g.Expect(tags.GetEntries("cluster-a")).To(Equal([]string{"subnet-a", "subnet-b"}))
+	g.Expect(tags.HasEntry("cluster-a", "subnet-a")).To(BeTrue())
+	g.Expect(tags.HasEntry("cluster-a", "subnet-c")).To(BeFalse())
+	g.Expect(tags.HasEntryOfOtherCluster("cluster-a", "subnet-a")).To(BeFalse())
+	g.Expect(tags.HasEntryOfOtherCluster("cluster-a", "subnet-b")).To(BeTrue())
+	g.Expect(tags.HasEntryOfOtherCluster("cluster-b", "subnet-c")).To(BeFalse())
+	g.Expect(Tags{}.GetEntries("cluster-a")).To(BeEmpty())
+}
+
+func TestTags_EntriesExceedingTagValueLength(t *testing.T) {
+	g := NewWithT(t)
+
+	entries := make([]string, 0, 30)
+	for i := 0; i < 30; i++ {
+		entries = append(entries, fmt.Sprintf("route-to-the-on-premises-network-%02d", i))
+	}
+	tags := Build(BuildParams{
+		ClusterName: "cluster-a",
+		Lifecycle:   ResourceLifecycleShared,
+		Entries:     entries,
+		Additional: Tags{
+			ClusterEntriesTagKey("cluster-a-1"): "route-of-another-cluster",
+		},
+	})
+
+	keys := tags.EntriesTagKeys("cluster-a")
+	g.Expect(keys).To(Equal([]string{
+		ClusterEntriesTagKey("cluster-a"),
+		ClusterEntriesTagKey("cluster-a") + "_1",
+		ClusterEntriesTagKey("cluster-a") + "_2",
+		ClusterEntriesTagKey("cluster-a") + "_3",
+		ClusterEntriesTagKey("cluster-a") + "_4",
+	}))
+	for _, key := range keys {
+		g.Expect(len(tags[key])).To(BeNumerically("<=", MaxTagValueLength))
+	}
+	g.Expect(tags.GetEntries("cluster-a")).To(Equal(entries))
+	g.Expect(tags.HasEntry("cluster-a", "route-to-the-on-premises-network-29")).To(BeTrue())
+	g.Expect(tags.HasEntryOfOtherCluster("cluster-a-1", "route-to-the-on-premises-network-29")).To(BeTrue())
+	g.Expect(tags.HasEntryOfOtherCluster("cluster-a", "route-to-the-on-premises-network-29")).To(BeFalse())
+	g.Expect(tags.HasEntryOfOtherCluster("cluster-a", "route-of-another-cluster")).To(BeTrue())
+
+	tags.DeleteEntries("cluster-a")
+	g.Expect(tags.GetEntries("cluster-a")).To(BeEmpty())
+	g.Expect(tags.GetEntries("cluster-a-1")).To(Equal([]string{"route-of-another-cluster"}))
+}
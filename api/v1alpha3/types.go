@@ -27,6 +27,17 @@ const (
 	Node string = "node"
 )
 
+const (
+	// SkipReconcileServicesAnnotation is the annotation listing, comma separated, the Azure services that must be
+	// neither reconciled nor deleted for an AzureCluster or an AzureMachine, e.g. "securitygroups,loadbalancers".
+	// It allows fixing resources by hand in Azure without the controller overwriting the fix on its next loop.
+	SkipReconcileServicesAnnotation = "azure.infrastructure.cluster.x-k8s.io/skip-reconcile-services"
+
+	// BootstrapDataDeletedAnnotation is set on an AzureMachine once the storage blob holding its bootstrap data is
+	// deleted, after its VM is provisioned.
+	BootstrapDataDeletedAnnotation = "azure.infrastructure.cluster.x-k8s.io/bootstrap-data-deleted"
+)
+
 // NetworkSpec specifies what the Azure networking resources should look like.
 type NetworkSpec struct {
 	// Vnet is the configuration for the Azure virtual network.
@@ -40,6 +51,17 @@ type NetworkSpec struct {
 	// APIServerLB is the configuration for the control-plane load balancer.
 	// +optional
 	APIServerLB LoadBalancerSpec `json:"apiServerLB,omitempty"`
+
+	// NodeOutboundLB is the configuration for the node outbound load balancer.
+	// Only the frontend IPs, idle timeout, TCP reset and allocated outbound ports settings are used, the load balancer
+	// itself is always named after the cluster.
+	// +optional
+	NodeOutboundLB *LoadBalancerSpec `json:"nodeOutboundLB,omitempty"`
+
+	// LoadBalancers is the configuration for additional load balancers owned by the cluster, e.g. an internal
+	// load balancer in front of an ingress controller. Machines and machine pools join their backend pools by name.
+	// +optional
+	LoadBalancers []LoadBalancerSpec `json:"loadBalancers,omitempty"`
 }
 
 // VnetSpec configures an Azure virtual network.
@@ -99,6 +121,165 @@ type SecurityGroup struct {
 type RouteTable struct {
 	ID   string `json:"id,omitempty"`
 	Name string `json:"name,omitempty"`
+
+	// Routes are the user-defined routes of the route table. Routes added to the route table by other means, such as
+	// the pod CIDR routes of the cloud provider route controller with kubenet, are left untouched.
+	// +optional
+	Routes []Route `json:"routes,omitempty"`
+}
+
+// RouteNextHopType defines the type of Azure hop a route sends packets to.
+type RouteNextHopType string
+
+const (
+	// RouteNextHopTypeVirtualNetworkGateway sends packets to the virtual network gateway.
+	RouteNextHopTypeVirtualNetworkGateway = RouteNextHopType("VirtualNetworkGateway")
+	// RouteNextHopTypeVnetLocal sends packets within the virtual network.
+	RouteNextHopTypeVnetLocal = RouteNextHopType("VnetLocal")
+	// RouteNextHopTypeInternet sends packets to the Internet.
+	RouteNextHopTypeInternet = RouteNextHopType("Internet")
+	// RouteNextHopTypeVirtualAppliance sends packets to a virtual appliance, such as an Azure Firewall.
+	RouteNextHopTypeVirtualAppliance = RouteNextHopType("VirtualAppliance")
+	// RouteNextHopTypeNone drops packets.
+	RouteNextHopTypeNone = RouteNextHopType("None")
+)
+
+// Route defines a user-defined route of a route table.
+type Route struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// AddressPrefix is the destination of the route, as a CIDR or a service tag.
+	// +kubebuilder:validation:MinLength=1
+	AddressPrefix string `json:"addressPrefix"`
+
+	// NextHopType is the type of Azure hop the packets are sent to.
+	// +kubebuilder:validation:Enum=VirtualNetworkGateway;VnetLocal;Internet;VirtualAppliance;None
+	NextHopType RouteNextHopType `json:"nextHopType"`
+
+	// NextHopIPAddress is the IP address packets are forwarded to. Only allowed, and required, for VirtualAppliance
+	// routes.
+	// +optional
+	NextHopIPAddress string `json:"nextHopIPAddress,omitempty"`
+}
+
+// PrivateLinkSpec configures a Private Link Service for the API server.
+type PrivateLinkSpec struct {
+	// Name is the name of the Private Link Service. Defaults to <cluster-name>-apiserver-pls.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// AllowedSubscriptions are the subscriptions, in addition to the one of the cluster, which may see the Private Link
+	// Service and whose private endpoints are automatically approved.
+	// +optional
+	AllowedSubscriptions []string `json:"allowedSubscriptions,omitempty"`
+
+	// PrivateEndpoints are the private endpoints to create in consumer subnets. An address record named after each
+	// private endpoint is added to the private DNS zone of the cluster.
+	// +optional
+	PrivateEndpoints []PrivateEndpointSpec `json:"privateEndpoints,omitempty"`
+}
+
+// PrivateEndpointSpec defines a private endpoint connecting a consumer subnet to the API server Private Link Service.
+type PrivateEndpointSpec struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// SubnetID is the ID of the consumer subnet the private endpoint gets its IP address from. The subnet must have
+	// private endpoint network policies disabled.
+	// +kubebuilder:validation:MinLength=1
+	SubnetID string `json:"subnetID"`
+
+	// PrivateIP is the IP address of the private endpoint, set once the private endpoint is created.
+	// +optional
+	PrivateIP string `json:"privateIP,omitempty"`
+}
+
+// PrivateDNSZoneSpec defines the private DNS zone resolving the private API server.
+type PrivateDNSZoneSpec struct {
+	// Name is the name of the private DNS zone. Defaults to <cluster name>.capz.io.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// ResourceGroup is the resource group of an existing private DNS zone. When set, the zone is neither created nor
+	// deleted with the cluster, and only the virtual network link and the address records of the cluster are managed.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+
+	// SubscriptionID is the subscription of an existing private DNS zone. Defaults to the subscription of the cluster.
+	// +optional
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+}
+
+// ProximityPlacementGroup defines a proximity placement group created and deleted with its owner, which keeps the
+// virtual machines placed in it physically close to each other.
+type ProximityPlacementGroup struct {
+	// Name is the name of the proximity placement group. Defaults to <owner name>-ppg.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// VMExtension defines a VM extension installed on a virtual machine or on the instances of a scale set, e.g. a
+// monitoring or a security agent.
+type VMExtension struct {
+	// Name is the name of the extension, unique among the extensions of the virtual machine or scale set.
+	Name string `json:"name"`
+
+	// Publisher is the publisher of the extension handler, e.g. Microsoft.Azure.Monitor.
+	Publisher string `json:"publisher"`
+
+	// Type is the type of the extension handler, e.g. AzureMonitorLinuxAgent.
+	Type string `json:"type"`
+
+	// Version is the major and minor version of the extension handler, e.g. 1.5. Newer minor versions are installed
+	// automatically as they are released.
+	Version string `json:"version"`
+
+	// Settings is the JSON object of the public settings of the extension.
+	// +optional
+	Settings string `json:"settings,omitempty"`
+
+	// ProtectedSettingsSecretRef references the key of a Secret, in the namespace of the owner, holding the JSON object
+	// of the protected settings of the extension. Protected settings are encrypted and never returned by Azure.
+	// +optional
+	ProtectedSettingsSecretRef *corev1.SecretKeySelector `json:"protectedSettingsSecretRef,omitempty"`
+}
+
+// BootstrapDataCompression is the compression of the bootstrap data passed to the virtual machines.
+type BootstrapDataCompression string
+
+const (
+	// BootstrapDataCompressionNone passes the bootstrap data as is.
+	BootstrapDataCompressionNone = BootstrapDataCompression("None")
+
+	// BootstrapDataCompressionGzip compresses the bootstrap data with gzip, which cloud-init decompresses on boot.
+	BootstrapDataCompressionGzip = BootstrapDataCompression("Gzip")
+)
+
+// BootstrapDataDelivery defines how the bootstrap data reaches the virtual machines.
+type BootstrapDataDelivery string
+
+const (
+	// BootstrapDataDeliveryCustomData passes the bootstrap data in the custom data of the virtual machines.
+	BootstrapDataDeliveryCustomData = BootstrapDataDelivery("CustomData")
+
+	// BootstrapDataDeliveryStorageBlob uploads the bootstrap data to a blob in the storage account of the cluster, and
+	// only passes a cloud-init include of a short-lived URL of the blob in the custom data of the virtual machine.
+	BootstrapDataDeliveryStorageBlob = BootstrapDataDelivery("StorageBlob")
+)
+
+// BootstrapData defines how the bootstrap data is passed to the virtual machines.
+type BootstrapData struct {
+	// Compression is the compression of the bootstrap data. Defaults to None.
+	// +kubebuilder:validation:Enum=None;Gzip
+	// +optional
+	Compression BootstrapDataCompression `json:"compression,omitempty"`
+
+	// Delivery defines how the bootstrap data reaches the virtual machines. Defaults to CustomData, which is limited
+	// to 64KB of bootstrap data once compressed.
+	// +kubebuilder:validation:Enum=CustomData;StorageBlob
+	// +optional
+	Delivery BootstrapDataDelivery `json:"delivery,omitempty"`
 }
 
 // SecurityGroupProtocol defines the protocol type for a security group rule.
@@ -147,6 +328,57 @@ type LoadBalancerSpec struct {
 	SKU         SKU          `json:"sku,omitempty"`
 	FrontendIPs []FrontendIP `json:"frontendIPs,omitempty"`
 	Type        LBType       `json:"type,omitempty"`
+
+	// FrontendIPsCount is the number of frontend IPs of the node outbound load balancer, a public IP is created for
+	// each of them. Outbound SNAT ports are spread across all the frontend IPs. Only used when FrontendIPs is empty.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16
+	// +optional
+	FrontendIPsCount *int32 `json:"frontendIPsCount,omitempty"`
+
+	// HealthProbe is the configuration for the health probe of the API server load balancer.
+	// Defaults to an Https probe of the /healthz endpoint.
+	// +optional
+	HealthProbe *HealthProbe `json:"healthProbe,omitempty"`
+
+	// IdleTimeoutInMinutes is the TCP idle timeout of the load balancing and outbound rules of the load balancer.
+	// Defaults to 4.
+	// +kubebuilder:validation:Minimum=4
+	// +kubebuilder:validation:Maximum=30
+	// +optional
+	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+
+	// EnableTCPReset enables sending bidirectional TCP resets on idle timeout for the load balancing and outbound
+	// rules of the load balancer.
+	// +optional
+	EnableTCPReset *bool `json:"enableTCPReset,omitempty"`
+
+	// EnableFloatingIP enables floating IP (direct server return) on the API server load balancing rule.
+	// +optional
+	EnableFloatingIP *bool `json:"enableFloatingIP,omitempty"`
+
+	// AllocatedOutboundPorts is the number of SNAT ports allocated to each backend pool member by the outbound rule
+	// of the load balancer. Must be a multiple of 8. If omitted, Azure allocates ports based on the backend pool size.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=64000
+	// +optional
+	AllocatedOutboundPorts *int32 `json:"allocatedOutboundPorts,omitempty"`
+
+	// BackendPools are the backend address pools of the load balancer.
+	// Only used by additional load balancers, see NetworkSpec.LoadBalancers.
+	// +optional
+	BackendPools []BackendPool `json:"backendPools,omitempty"`
+
+	// Probes are the health probes of the load balancer.
+	// Only used by additional load balancers, see NetworkSpec.LoadBalancers.
+	// +optional
+	Probes []LoadBalancerProbe `json:"probes,omitempty"`
+
+	// Rules are the load balancing rules of the load balancer.
+	// Only used by additional load balancers, see NetworkSpec.LoadBalancers.
+	// +optional
+	Rules []LoadBalancingRule `json:"rules,omitempty"`
 }
 
 // SKU defines an Azure load balancer SKU.
@@ -175,6 +407,135 @@ type FrontendIP struct {
 	PrivateIPAddress string `json:"privateIP,omitempty"`
 	// +optional
 	PublicIP *PublicIPSpec `json:"publicIP,omitempty"`
+	// PublicIPPrefix is a public IP prefix used by the frontend instead of a single public IP.
+	// Only supported by the node outbound load balancer.
+	// +optional
+	PublicIPPrefix *PublicIPPrefixSpec `json:"publicIPPrefix,omitempty"`
+}
+
+// HealthProbe defines the health probe of the API server load balancer.
+type HealthProbe struct {
+	// Protocol is the protocol of the probe. Defaults to Https.
+	// +kubebuilder:validation:Enum=Tcp;Https
+	// +optional
+	Protocol ProbeProtocol `json:"protocol,omitempty"`
+
+	// RequestPath is the URI used for requesting health status of Https probes. Defaults to /healthz.
+	// +optional
+	RequestPath string `json:"requestPath,omitempty"`
+
+	// IntervalInSeconds is the interval between two probes. Defaults to 15.
+	// +kubebuilder:validation:Minimum=5
+	// +optional
+	IntervalInSeconds *int32 `json:"intervalInSeconds,omitempty"`
+
+	// NumberOfProbes is the number of failed probes after which a backend is considered unhealthy. Defaults to 4.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NumberOfProbes *int32 `json:"numberOfProbes,omitempty"`
+}
+
+// BackendPool defines a load balancer backend address pool.
+type BackendPool struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// ProbeProtocol defines the protocol of a load balancer health probe.
+type ProbeProtocol string
+
+const (
+	// ProbeProtocolTCP is the value for a TCP health probe.
+	ProbeProtocolTCP = ProbeProtocol("Tcp")
+	// ProbeProtocolHTTP is the value for an HTTP health probe.
+	ProbeProtocolHTTP = ProbeProtocol("Http")
+	// ProbeProtocolHTTPS is the value for an HTTPS health probe.
+	ProbeProtocolHTTPS = ProbeProtocol("Https")
+)
+
+// LoadBalancerProbe defines a load balancer health probe.
+type LoadBalancerProbe struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Enum=Tcp;Http;Https
+	Protocol ProbeProtocol `json:"protocol"`
+
+	// Port is the port the probe is sent to.
+	Port int32 `json:"port"`
+
+	// RequestPath is the URI used for requesting health status. Required for Http and Https probes.
+	// +optional
+	RequestPath string `json:"requestPath,omitempty"`
+
+	// IntervalInSeconds is the interval between two probes. Defaults to 15.
+	// +optional
+	IntervalInSeconds *int32 `json:"intervalInSeconds,omitempty"`
+
+	// NumberOfProbes is the number of failed probes after which a backend is considered unhealthy. Defaults to 4.
+	// +optional
+	NumberOfProbes *int32 `json:"numberOfProbes,omitempty"`
+}
+
+// TransportProtocol defines the transport protocol of a load balancing rule.
+type TransportProtocol string
+
+const (
+	// TransportProtocolTCP is the value for the TCP transport protocol.
+	TransportProtocolTCP = TransportProtocol("Tcp")
+	// TransportProtocolUDP is the value for the UDP transport protocol.
+	TransportProtocolUDP = TransportProtocol("Udp")
+	// TransportProtocolAll is the value for all transport protocols.
+	TransportProtocolAll = TransportProtocol("All")
+)
+
+// LoadBalancingRule defines a load balancing rule.
+type LoadBalancingRule struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Enum=Tcp;Udp;All
+	Protocol TransportProtocol `json:"protocol"`
+
+	// FrontendIPName is the name of the frontend IP configuration of the rule.
+	// Defaults to the first frontend IP of the load balancer.
+	// +optional
+	FrontendIPName string `json:"frontendIPName,omitempty"`
+
+	// FrontendPort is the port of the frontend IP. Use 0 together with the All protocol for HA ports.
+	FrontendPort int32 `json:"frontendPort"`
+
+	// BackendPort is the port used on the backend pool members.
+	BackendPort int32 `json:"backendPort"`
+
+	// BackendPoolName is the name of the backend pool of the rule.
+	// Defaults to the first backend pool of the load balancer.
+	// +optional
+	BackendPoolName string `json:"backendPoolName,omitempty"`
+
+	// ProbeName is the name of the health probe of the rule.
+	// +optional
+	ProbeName string `json:"probeName,omitempty"`
+
+	// IdleTimeoutInMinutes is the TCP idle timeout of the rule. Defaults to 4.
+	// +optional
+	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+
+	// EnableFloatingIP enables floating IP (direct server return) on the rule.
+	// +optional
+	EnableFloatingIP *bool `json:"enableFloatingIP,omitempty"`
+}
+
+// LoadBalancerBackendPoolReference references a backend pool of one of the cluster's additional load balancers.
+type LoadBalancerBackendPoolReference struct {
+	// LoadBalancerName is the name of a load balancer listed in the AzureCluster's networkSpec.loadBalancers.
+	// +kubebuilder:validation:MinLength=1
+	LoadBalancerName string `json:"loadBalancerName"`
+
+	// BackendPoolName is the name of the backend pool to join.
+	// Defaults to the load balancer's default backend pool, named "<loadBalancerName>-backendPool".
+	// +optional
+	BackendPoolName string `json:"backendPoolName,omitempty"`
 }
 
 // PublicIPSpec defines the inputs to create an Azure public IP address.
@@ -182,6 +543,21 @@ type PublicIPSpec struct {
 	Name string `json:"name"`
 	// +optional
 	DNSName string `json:"dnsName,omitempty"`
+	// ID is the resource ID of an existing public IP to use, possibly in another resource group of the subscription.
+	// The public IP is adopted as is: it is neither created, modified nor deleted with the cluster.
+	// Name defaults to the name of the existing public IP. For the API server, DNSName defaults to the FQDN of the
+	// existing public IP, or to its IP address if it has no DNS name.
+	// +optional
+	ID string `json:"id,omitempty"`
+}
+
+// PublicIPPrefixSpec defines the inputs to create an Azure public IP prefix.
+type PublicIPPrefixSpec struct {
+	Name string `json:"name"`
+	// PrefixLength is the length of the prefix, a /28 prefix contains 16 public IPs.
+	// +kubebuilder:validation:Minimum=28
+	// +kubebuilder:validation:Maximum=31
+	PrefixLength int32 `json:"prefixLength"`
 }
 
 // VMState describes the state of an Azure virtual machine.
@@ -321,6 +697,44 @@ type UserAssignedIdentity struct {
 	ProviderID string `json:"providerID"`
 }
 
+// RoleAssignmentScope defines the scope at which a role is assigned to a system-assigned identity.
+// +kubebuilder:validation:Enum=ResourceGroup;VirtualNetwork;Subscription;Custom
+type RoleAssignmentScope string
+
+const (
+	// RoleAssignmentScopeResourceGroup assigns the role on the cluster resource group.
+	RoleAssignmentScopeResourceGroup RoleAssignmentScope = "ResourceGroup"
+	// RoleAssignmentScopeVirtualNetwork assigns the role on the cluster virtual network.
+	RoleAssignmentScopeVirtualNetwork RoleAssignmentScope = "VirtualNetwork"
+	// RoleAssignmentScopeSubscription assigns the role on the cluster subscription.
+	RoleAssignmentScopeSubscription RoleAssignmentScope = "Subscription"
+	// RoleAssignmentScopeCustom assigns the role on the resource identified by ScopeID.
+	RoleAssignmentScopeCustom RoleAssignmentScope = "Custom"
+)
+
+// RoleAssignment defines a role to assign to the system-assigned identity of a VM or VMSS.
+type RoleAssignment struct {
+	// Name is the name of the role assignment. It can be any valid GUID.
+	// If not specified, a random GUID will be generated. It can't be set in an AzureMachineTemplate.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// RoleDefinitionID is the role to assign, either as the GUID of a built-in role
+	// (https://docs.microsoft.com/en-us/azure/role-based-access-control/built-in-roles)
+	// or as the full resource ID of a role definition.
+	RoleDefinitionID string `json:"roleDefinitionID"`
+
+	// Scope is the scope of the role assignment.
+	// +kubebuilder:default=ResourceGroup
+	// +optional
+	Scope RoleAssignmentScope `json:"scope,omitempty"`
+
+	// ScopeID is the resource ID the role is assigned on. It is required when Scope is Custom
+	// and must not be set otherwise.
+	// +optional
+	ScopeID string `json:"scopeID,omitempty"`
+}
+
 // OSDisk defines the operating system disk for a VM.
 type OSDisk struct {
 	OSType           string            `json:"osType"`
@@ -343,8 +757,48 @@ type DataDisk struct {
 	Lun *int32 `json:"lun,omitempty"`
 	// +optional
 	CachingType string `json:"cachingType,omitempty"`
+	// ManagedDisk specifies the storage account type and disk encryption set of the data disk.
+	// If omitted, the storage account type defaults to the type Azure picks for the VM size.
+	// +optional
+	ManagedDisk *ManagedDisk `json:"managedDisk,omitempty"`
+	// WriteAcceleratorEnabled enables Write Accelerator on the data disk.
+	// It requires a Premium_LRS disk, a VM size that supports it, and a caching type of None or ReadOnly.
+	// +optional
+	WriteAcceleratorEnabled *bool `json:"writeAcceleratorEnabled,omitempty"`
+	// DeletionPolicy specifies whether the data disk is deleted or retained when the machine is deleted.
+	// The data disks of an AzureMachinePool are always deleted with their instance.
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy DiskDeletionPolicy `json:"deletionPolicy,omitempty"`
+	// DiskName is the name of an existing managed disk in the cluster resource group to attach, for example
+	// one retained from a previous machine. If omitted, a new empty disk named <machineName>_<nameSuffix> is created.
+	// It can only be set on an AzureMachine, as a disk can only be attached to a single machine.
+	// +optional
+	DiskName string `json:"diskName,omitempty"`
+	// DiskIOPSReadWrite is the number of IOPS allowed for the data disk. It can only be set for UltraSSD_LRS disks,
+	// whose performance can be changed after creation. If omitted, it is derived from the disk size by Azure.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DiskIOPSReadWrite *int64 `json:"diskIOPSReadWrite,omitempty"`
+	// DiskMBpsReadWrite is the bandwidth allowed for the data disk, in MB per second. It can only be set for
+	// UltraSSD_LRS disks, whose performance can be changed after creation. If omitted, it is derived from the disk
+	// size by Azure.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DiskMBpsReadWrite *int64 `json:"diskMBpsReadWrite,omitempty"`
 }
 
+// DiskDeletionPolicy defines what happens to a data disk when its machine is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain
+type DiskDeletionPolicy string
+
+const (
+	// DiskDeletionPolicyDelete deletes the data disk with the machine.
+	DiskDeletionPolicyDelete DiskDeletionPolicy = "Delete"
+	// DiskDeletionPolicyRetain keeps the data disk after the machine is deleted.
+	DiskDeletionPolicyRetain DiskDeletionPolicy = "Retain"
+)
+
 // ManagedDisk defines the managed disk options for a VM.
 type ManagedDisk struct {
 	StorageAccountType string                       `json:"storageAccountType"`
@@ -446,3 +900,35 @@ type AddressRecord struct {
 	Hostname string
 	IP       string
 }
+
+const (
+	// PutFuture is a future that was derived from a PUT request.
+	PutFuture string = "PUT"
+	// PostFuture is a future that was derived from a POST request.
+	PostFuture string = "POST"
+	// PatchFuture is a future that was derived from a PATCH request.
+	PatchFuture string = "PATCH"
+	// DeleteFuture is a future that was derived from a DELETE request.
+	DeleteFuture string = "DELETE"
+)
+
+// Future contains the data needed for an Azure long-running operation to continue across reconcile loops.
+type Future struct {
+	// Type describes the type of future, such as PUT, POST, PATCH or DELETE.
+	Type string `json:"type"`
+
+	// ServiceName is the name of the service that started the operation. It tells apart the operations of different
+	// services on a resource that keeps several of them, such as an AzureCluster.
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+
+	// ResourceGroup is the Azure resource group for the resource.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+
+	// Name is the name of the Azure resource.
+	Name string `json:"name"`
+
+	// FutureData is the base64 url encoded json Azure AutoRest Future.
+	FutureData string `json:"futureData,omitempty"`
+}
@@ -138,6 +138,21 @@ func (in *AzureClusterSpec) DeepCopyInto(out *AzureClusterSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.PrivateLink != nil {
+		in, out := &in.PrivateLink, &out.PrivateLink
+		*out = new(PrivateLinkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivateDNSZone != nil {
+		in, out := &in.PrivateDNSZone, &out.PrivateDNSZone
+		*out = new(PrivateDNSZoneSpec)
+		**out = **in
+	}
+	if in.ProximityPlacementGroup != nil {
+		in, out := &in.ProximityPlacementGroup, &out.ProximityPlacementGroup
+		*out = new(ProximityPlacementGroup)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterSpec.
@@ -167,6 +182,11 @@ func (in *AzureClusterStatus) DeepCopyInto(out *AzureClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LongRunningOperationStates != nil {
+		in, out := &in.LongRunningOperationStates, &out.LongRunningOperationStates
+		*out = make([]Future, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterStatus.
@@ -262,6 +282,11 @@ func (in *AzureMachineSpec) DeepCopyInto(out *AzureMachineSpec) {
 		*out = make([]UserAssignedIdentity, len(*in))
 		copy(*out, *in)
 	}
+	if in.RoleAssignments != nil {
+		in, out := &in.RoleAssignments, &out.RoleAssignments
+		*out = make([]RoleAssignment, len(*in))
+		copy(*out, *in)
+	}
 	in.OSDisk.DeepCopyInto(&out.OSDisk)
 	if in.DataDisks != nil {
 		in, out := &in.DataDisks, &out.DataDisks
@@ -277,6 +302,11 @@ func (in *AzureMachineSpec) DeepCopyInto(out *AzureMachineSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.LoadBalancerBackendPools != nil {
+		in, out := &in.LoadBalancerBackendPools, &out.LoadBalancerBackendPools
+		*out = make([]LoadBalancerBackendPoolReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.AcceleratedNetworking != nil {
 		in, out := &in.AcceleratedNetworking, &out.AcceleratedNetworking
 		*out = new(bool)
@@ -292,6 +322,18 @@ func (in *AzureMachineSpec) DeepCopyInto(out *AzureMachineSpec) {
 		*out = new(SecurityProfile)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VMExtensions != nil {
+		in, out := &in.VMExtensions, &out.VMExtensions
+		*out = make([]VMExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BootstrapData != nil {
+		in, out := &in.BootstrapData, &out.BootstrapData
+		*out = new(BootstrapData)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachineSpec.
@@ -334,6 +376,11 @@ func (in *AzureMachineStatus) DeepCopyInto(out *AzureMachineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LongRunningOperationState != nil {
+		in, out := &in.LongRunningOperationState, &out.LongRunningOperationState
+		*out = new(Future)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachineStatus.
@@ -466,6 +513,36 @@ func (in *AzureSharedGalleryImage) DeepCopy() *AzureSharedGalleryImage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendPool) DeepCopyInto(out *BackendPool) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendPool.
+func (in *BackendPool) DeepCopy() *BackendPool {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapData) DeepCopyInto(out *BootstrapData) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapData.
+func (in *BootstrapData) DeepCopy() *BootstrapData {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildParams) DeepCopyInto(out *BuildParams) {
 	*out = *in
@@ -479,6 +556,16 @@ func (in *BuildParams) DeepCopyInto(out *BuildParams) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.MachineName != nil {
+		in, out := &in.MachineName, &out.MachineName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Additional != nil {
 		in, out := &in.Additional, &out.Additional
 		*out = make(Tags, len(*in))
@@ -506,6 +593,26 @@ func (in *DataDisk) DeepCopyInto(out *DataDisk) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ManagedDisk != nil {
+		in, out := &in.ManagedDisk, &out.ManagedDisk
+		*out = new(ManagedDisk)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WriteAcceleratorEnabled != nil {
+		in, out := &in.WriteAcceleratorEnabled, &out.WriteAcceleratorEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DiskIOPSReadWrite != nil {
+		in, out := &in.DiskIOPSReadWrite, &out.DiskIOPSReadWrite
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DiskMBpsReadWrite != nil {
+		in, out := &in.DiskMBpsReadWrite, &out.DiskMBpsReadWrite
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataDisk.
@@ -556,6 +663,11 @@ func (in *FrontendIP) DeepCopyInto(out *FrontendIP) {
 		*out = new(PublicIPSpec)
 		**out = **in
 	}
+	if in.PublicIPPrefix != nil {
+		in, out := &in.PublicIPPrefix, &out.PublicIPPrefix
+		*out = new(PublicIPPrefixSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendIP.
@@ -568,6 +680,46 @@ func (in *FrontendIP) DeepCopy() *FrontendIP {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Future) DeepCopyInto(out *Future) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Future.
+func (in *Future) DeepCopy() *Future {
+	if in == nil {
+		return nil
+	}
+	out := new(Future)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthProbe) DeepCopyInto(out *HealthProbe) {
+	*out = *in
+	if in.IntervalInSeconds != nil {
+		in, out := &in.IntervalInSeconds, &out.IntervalInSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NumberOfProbes != nil {
+		in, out := &in.NumberOfProbes, &out.NumberOfProbes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthProbe.
+func (in *HealthProbe) DeepCopy() *HealthProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Image) DeepCopyInto(out *Image) {
 	*out = *in
@@ -658,6 +810,46 @@ func (in IngressRules) DeepCopy() IngressRules {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerBackendPoolReference) DeepCopyInto(out *LoadBalancerBackendPoolReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerBackendPoolReference.
+func (in *LoadBalancerBackendPoolReference) DeepCopy() *LoadBalancerBackendPoolReference {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerBackendPoolReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerProbe) DeepCopyInto(out *LoadBalancerProbe) {
+	*out = *in
+	if in.IntervalInSeconds != nil {
+		in, out := &in.IntervalInSeconds, &out.IntervalInSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NumberOfProbes != nil {
+		in, out := &in.NumberOfProbes, &out.NumberOfProbes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerProbe.
+func (in *LoadBalancerProbe) DeepCopy() *LoadBalancerProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 	*out = *in
@@ -668,6 +860,55 @@ func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FrontendIPsCount != nil {
+		in, out := &in.FrontendIPsCount, &out.FrontendIPsCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HealthProbe != nil {
+		in, out := &in.HealthProbe, &out.HealthProbe
+		*out = new(HealthProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdleTimeoutInMinutes != nil {
+		in, out := &in.IdleTimeoutInMinutes, &out.IdleTimeoutInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EnableTCPReset != nil {
+		in, out := &in.EnableTCPReset, &out.EnableTCPReset
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableFloatingIP != nil {
+		in, out := &in.EnableFloatingIP, &out.EnableFloatingIP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllocatedOutboundPorts != nil {
+		in, out := &in.AllocatedOutboundPorts, &out.AllocatedOutboundPorts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackendPools != nil {
+		in, out := &in.BackendPools, &out.BackendPools
+		*out = make([]BackendPool, len(*in))
+		copy(*out, *in)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]LoadBalancerProbe, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]LoadBalancingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSpec.
@@ -680,6 +921,31 @@ func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancingRule) DeepCopyInto(out *LoadBalancingRule) {
+	*out = *in
+	if in.IdleTimeoutInMinutes != nil {
+		in, out := &in.IdleTimeoutInMinutes, &out.IdleTimeoutInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EnableFloatingIP != nil {
+		in, out := &in.EnableFloatingIP, &out.EnableFloatingIP
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancingRule.
+func (in *LoadBalancingRule) DeepCopy() *LoadBalancingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedDisk) DeepCopyInto(out *ManagedDisk) {
 	*out = *in
@@ -716,6 +982,18 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 		}
 	}
 	in.APIServerLB.DeepCopyInto(&out.APIServerLB)
+	if in.NodeOutboundLB != nil {
+		in, out := &in.NodeOutboundLB, &out.NodeOutboundLB
+		*out = new(LoadBalancerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LoadBalancers != nil {
+		in, out := &in.LoadBalancers, &out.LoadBalancers
+		*out = make([]LoadBalancerSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
@@ -749,6 +1027,91 @@ func (in *OSDisk) DeepCopy() *OSDisk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicIPPrefixSpec) DeepCopyInto(out *PublicIPPrefixSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPPrefixSpec.
+func (in *PublicIPPrefixSpec) DeepCopy() *PublicIPPrefixSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicIPPrefixSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateDNSZoneSpec) DeepCopyInto(out *PrivateDNSZoneSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateDNSZoneSpec.
+func (in *PrivateDNSZoneSpec) DeepCopy() *PrivateDNSZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateDNSZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateEndpointSpec) DeepCopyInto(out *PrivateEndpointSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateEndpointSpec.
+func (in *PrivateEndpointSpec) DeepCopy() *PrivateEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLinkSpec) DeepCopyInto(out *PrivateLinkSpec) {
+	*out = *in
+	if in.AllowedSubscriptions != nil {
+		in, out := &in.AllowedSubscriptions, &out.AllowedSubscriptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateEndpoints != nil {
+		in, out := &in.PrivateEndpoints, &out.PrivateEndpoints
+		*out = make([]PrivateEndpointSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLinkSpec.
+func (in *PrivateLinkSpec) DeepCopy() *PrivateLinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProximityPlacementGroup) DeepCopyInto(out *ProximityPlacementGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProximityPlacementGroup.
+func (in *ProximityPlacementGroup) DeepCopy() *ProximityPlacementGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ProximityPlacementGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublicIPSpec) DeepCopyInto(out *PublicIPSpec) {
 	*out = *in
@@ -764,9 +1127,44 @@ func (in *PublicIPSpec) DeepCopy() *PublicIPSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleAssignment) DeepCopyInto(out *RoleAssignment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleAssignment.
+func (in *RoleAssignment) DeepCopy() *RoleAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route) DeepCopyInto(out *Route) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
+func (in *Route) DeepCopy() *Route {
+	if in == nil {
+		return nil
+	}
+	out := new(Route)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTable) DeepCopyInto(out *RouteTable) {
 	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]Route, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTable.
@@ -861,7 +1259,7 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 		copy(*out, *in)
 	}
 	in.SecurityGroup.DeepCopyInto(&out.SecurityGroup)
-	out.RouteTable = in.RouteTable
+	in.RouteTable.DeepCopyInto(&out.RouteTable)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
@@ -964,6 +1362,26 @@ func (in *VM) DeepCopy() *VM {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMExtension) DeepCopyInto(out *VMExtension) {
+	*out = *in
+	if in.ProtectedSettingsSecretRef != nil {
+		in, out := &in.ProtectedSettingsSecretRef, &out.ProtectedSettingsSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMExtension.
+func (in *VMExtension) DeepCopy() *VMExtension {
+	if in == nil {
+		return nil
+	}
+	out := new(VMExtension)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VnetSpec) DeepCopyInto(out *VnetSpec) {
 	*out = *in
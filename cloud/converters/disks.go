@@ -0,0 +1,35 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+)
+
+// GetAdditionalCapabilities returns the additional capabilities a VM or VMSS needs for its data disks, which are
+// only required for ultra disks.
+func GetAdditionalCapabilities(dataDisks []infrav1.DataDisk) *compute.AdditionalCapabilities {
+	for _, disk := range dataDisks {
+		if disk.ManagedDisk != nil && disk.ManagedDisk.StorageAccountType == string(compute.UltraSSDLRS) {
+			return &compute.AdditionalCapabilities{UltraSSDEnabled: to.BoolPtr(true)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+)
+
+func Test_FutureRoundTrip(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	pollingURL := "https://management.azure.com/subscriptions/123/providers/Microsoft.Compute/locations/westus2/operations/456"
+	resp := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Azure-Asyncoperation": []string{pollingURL}},
+		Request: &http.Request{
+			Method: http.MethodPut,
+			URL:    &url.URL{Scheme: "https", Host: "management.azure.com", Path: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm"},
+		},
+	}
+	sdkFuture, err := azureautorest.NewFutureFromResponse(resp)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	future, err := SDKToFuture(sdkFuture, infrav1.PutFuture, "my-rg", "my-vm")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(future.Type).To(gomega.Equal(infrav1.PutFuture))
+	g.Expect(future.ResourceGroup).To(gomega.Equal("my-rg"))
+	g.Expect(future.Name).To(gomega.Equal("my-vm"))
+	g.Expect(future.FutureData).NotTo(gomega.BeEmpty())
+
+	converted, err := FutureToSDK(*future)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(converted.PollingURL()).To(gomega.Equal(pollingURL))
+	g.Expect(converted.PollingMethod()).To(gomega.Equal(sdkFuture.PollingMethod()))
+}
+
+func Test_FutureToSDKInvalidData(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	_, err := FutureToSDK(infrav1.Future{Type: infrav1.DeleteFuture, FutureData: "not base64!"})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
@@ -17,11 +17,15 @@ limitations under the License.
 package azure
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"strings"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/metrics"
 	"sigs.k8s.io/cluster-api-provider-azure/version"
 )
 
@@ -49,6 +53,26 @@ const (
 	PrivateAPIServerHostname = "apiserver"
 )
 
+const (
+	// ControlPlaneNodeGroup will be used to create availability set for control plane machines.
+	ControlPlaneNodeGroup = "control-plane"
+)
+
+const (
+	// ApplicationHealthExtensionPublisher is the publisher of the Application Health extension.
+	ApplicationHealthExtensionPublisher = "Microsoft.ManagedServices"
+	// ApplicationHealthExtensionVersion is the version of the Application Health extension.
+	ApplicationHealthExtensionVersion = "1.0"
+)
+
+const (
+	// BootstrapDataContainerName is the name of the blob container holding the bootstrap data of the machines of a
+	// cluster.
+	BootstrapDataContainerName = "bootstrap"
+	// MaxCustomDataLength is the maximum length of the base64 encoded custom data of a VM.
+	MaxCustomDataLength = 87380
+)
+
 // GenerateBackendAddressPoolName generates a load balancer backend address pool name.
 func GenerateBackendAddressPoolName(lbName string) string {
 	return fmt.Sprintf("%s-%s", lbName, "backendPool")
@@ -69,6 +93,24 @@ func GenerateNodeOutboundIPName(clusterName string) string {
 	return fmt.Sprintf("pip-%s-node-outbound", clusterName)
 }
 
+// GenerateNodeOutboundFrontendIPConfigName generates the name of a frontend IP config of the node outbound LB.
+// The first frontend keeps the name used by clusters with a single node outbound IP.
+func GenerateNodeOutboundFrontendIPConfigName(lbName string, index int) string {
+	if index == 0 {
+		return GenerateFrontendIPConfigName(lbName)
+	}
+	return fmt.Sprintf("%s-%d", GenerateFrontendIPConfigName(lbName), index)
+}
+
+// GenerateNodeOutboundIPNameWithIndex generates the name of a node outbound public IP, based on the cluster name.
+// The first IP keeps the name used by clusters with a single node outbound IP.
+func GenerateNodeOutboundIPNameWithIndex(clusterName string, index int) string {
+	if index == 0 {
+		return GenerateNodeOutboundIPName(clusterName)
+	}
+	return fmt.Sprintf("%s-%d", GenerateNodeOutboundIPName(clusterName), index)
+}
+
 // GenerateNodePublicIPName generates a node public IP name, based on the machine name.
 func GenerateNodePublicIPName(machineName string) string {
 	return fmt.Sprintf("pip-%s", machineName)
@@ -89,9 +131,9 @@ func GeneratePrivateDNSZoneName(clusterName string) string {
 	return fmt.Sprintf("%s.capz.io", clusterName)
 }
 
-// GeneratePrivateFQDN generates FQDN for a private API Server.
-func GeneratePrivateFQDN(clusterName string) string {
-	return fmt.Sprintf("%s.%s", PrivateAPIServerHostname, GeneratePrivateDNSZoneName(clusterName))
+// GeneratePrivateFQDN generates FQDN for a private API Server, based on its record name in the private DNS zone.
+func GeneratePrivateFQDN(recordName, zoneName string) string {
+	return fmt.Sprintf("%s.%s", recordName, zoneName)
 }
 
 // GenerateVNetLinkName generates the name of a virtual network link name based on the vnet name.
@@ -119,11 +161,73 @@ func GenerateDataDiskName(machineName, nameSuffix string) string {
 	return fmt.Sprintf("%s_%s", machineName, nameSuffix)
 }
 
+// DataDiskName returns the name of the managed disk backing a data disk of a VM: either the existing disk
+// to attach or the generated name of the disk to create.
+func DataDiskName(machineName string, disk infrav1.DataDisk) string {
+	if disk.DiskName != "" {
+		return disk.DiskName
+	}
+	return GenerateDataDiskName(machineName, disk.NameSuffix)
+}
+
+// DiskID returns the azure resource ID for a given managed disk.
+func DiskID(subscriptionID, resourceGroup, diskName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/disks/%s", subscriptionID, resourceGroup, diskName)
+}
+
+// GenerateAvailabilitySetName generates the name of an availability set based on the cluster name and the node group.
+// The node group identifies the set of machines that belong to the availability set.
+func GenerateAvailabilitySetName(clusterName, nodeGroup string) string {
+	return fmt.Sprintf("%s_%s-as", clusterName, nodeGroup)
+}
+
+// GenerateProximityPlacementGroupName generates the name of the proximity placement group of a cluster or a machine pool.
+func GenerateProximityPlacementGroupName(ownerName string) string {
+	return fmt.Sprintf("%s-ppg", ownerName)
+}
+
+// GenerateBootstrapStorageAccountName generates the name of the storage account holding the bootstrap data of the
+// machines of a cluster. Storage account names are unique across Azure and limited to 24 lowercase letters and digits,
+// so the name is derived from a hash of the subscription, resource group and cluster name.
+func GenerateBootstrapStorageAccountName(subscriptionID, resourceGroup, clusterName string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", subscriptionID, resourceGroup, clusterName)))
+	return fmt.Sprintf("capzboot%x", sum[:8])
+}
+
+// SubscriptionID returns the azure resource ID for a given subscription.
+func SubscriptionID(subscriptionID string) string {
+	return fmt.Sprintf("/subscriptions/%s", subscriptionID)
+}
+
+// ResourceGroupID returns the azure resource ID for a given resource group.
+func ResourceGroupID(subscriptionID, resourceGroup string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", subscriptionID, resourceGroup)
+}
+
+// RoleDefinitionID returns the azure resource ID for a given role definition.
+// A role definition that is already a resource ID is returned unchanged.
+func RoleDefinitionID(subscriptionID, roleDefinition string) string {
+	if strings.HasPrefix(roleDefinition, "/") {
+		return roleDefinition
+	}
+	return fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Authorization/roleDefinitions/%s", subscriptionID, roleDefinition)
+}
+
 // VMID returns the azure resource ID for a given VM.
 func VMID(subscriptionID, resourceGroup, vmName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/virtualMachines/%s", subscriptionID, resourceGroup, vmName)
 }
 
+// AvailabilitySetID returns the azure resource ID for a given availability set.
+func AvailabilitySetID(subscriptionID, resourceGroup, asName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/availabilitySets/%s", subscriptionID, resourceGroup, asName)
+}
+
+// ProximityPlacementGroupID returns the azure resource ID for a given proximity placement group.
+func ProximityPlacementGroupID(subscriptionID, resourceGroup, ppgName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/proximityPlacementGroups/%s", subscriptionID, resourceGroup, ppgName)
+}
+
 // VNetID returns the azure resource ID for a given VNet.
 func VNetID(subscriptionID, resourceGroup, vnetName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s", subscriptionID, resourceGroup, vnetName)
@@ -139,6 +243,11 @@ func PublicIPID(subscriptionID, resourceGroup, ipName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/publicIPAddresses/%s", subscriptionID, resourceGroup, ipName)
 }
 
+// PublicIPPrefixID returns the azure resource ID for a given public IP prefix.
+func PublicIPPrefixID(subscriptionID, resourceGroup, prefixName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/publicIPPrefixes/%s", subscriptionID, resourceGroup, prefixName)
+}
+
 // RouteTableID returns the azure resource ID for a given route table.
 func RouteTableID(subscriptionID, resourceGroup, routeTableName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/routeTables/%s", subscriptionID, resourceGroup, routeTableName)
@@ -159,6 +268,11 @@ func FrontendIPConfigID(subscriptionID, resourceGroup, loadBalancerName, configN
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/loadBalancers/%s/frontendIPConfigurations/%s", subscriptionID, resourceGroup, loadBalancerName, configName)
 }
 
+// PrivateLinkServiceID returns the azure resource ID for a given private link service.
+func PrivateLinkServiceID(subscriptionID, resourceGroup, serviceName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/privateLinkServices/%s", subscriptionID, resourceGroup, serviceName)
+}
+
 // AddressPoolID returns the azure resource ID for a given backend address pool.
 func AddressPoolID(subscriptionID, resourceGroup, loadBalancerName, backendPoolName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/loadBalancers/%s/backendAddressPools/%s", subscriptionID, resourceGroup, loadBalancerName, backendPoolName)
@@ -206,3 +320,11 @@ func GetDefaultUbuntuImage(k8sVersion string) (*infrav1.Image, error) {
 func UserAgent() string {
 	return fmt.Sprintf("cluster-api-provider-azure/%s", version.Get().String())
 }
+
+// SetAutoRestClientDefaults sets the authorizer and user agent of an Azure SDK client, and instruments the requests it
+// sends with the Azure API metrics.
+func SetAutoRestClientDefaults(c *autorest.Client, auth autorest.Authorizer) {
+	c.Authorizer = auth
+	_ = c.AddToUserAgent(UserAgent()) // intentionally ignore error as it doesn't matter
+	c.Sender = autorest.CreateSender(metrics.WithAzureAPIMetrics())
+}
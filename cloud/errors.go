@@ -19,9 +19,14 @@ package azure
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/Azure/go-autorest/autorest"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 )
 
 // ErrNotOwned is returned when a resource can't be deleted because it isn't owned.
@@ -43,6 +48,37 @@ func (vde VMDeletedError) Error() string {
 	return fmt.Sprintf("VM with provider id %q has been deleted", vde.ProviderID)
 }
 
+// BootstrapDataTooLargeError is returned when the bootstrap data exceeds the size limit of the custom data of a VM.
+type BootstrapDataTooLargeError struct {
+	Length int
+}
+
+// Error returns the error string
+func (e BootstrapDataTooLargeError) Error() string {
+	return fmt.Sprintf("bootstrap data is %d characters long once base64 encoded, over the limit of %d characters of the custom data of a VM",
+		e.Length, MaxCustomDataLength)
+}
+
+// OperationNotDoneError is returned when an Azure long-running operation has not completed yet.
+type OperationNotDoneError struct {
+	Future *infrav1.Future
+}
+
+// NewOperationNotDoneError returns a new OperationNotDoneError for the given future.
+func NewOperationNotDoneError(future *infrav1.Future) OperationNotDoneError {
+	return OperationNotDoneError{Future: future}
+}
+
+// Error returns the error string
+func (onde OperationNotDoneError) Error() string {
+	return fmt.Sprintf("operation type %s on Azure resource %s/%s is not done", onde.Future.Type, onde.Future.ResourceGroup, onde.Future.Name)
+}
+
+// IsOperationNotDoneError returns true if the error is an OperationNotDoneError.
+func IsOperationNotDoneError(err error) bool {
+	return errors.As(err, &OperationNotDoneError{})
+}
+
 // ReconcileError represents an error that is not automatically recoverable
 // errorType indicates what type of action is required to recover. It can take two values
 // 1. `Transient` - Can be recovered through manual intervention, will be requeued after
@@ -81,6 +117,11 @@ func (t ReconcileError) Error() string {
 	}
 }
 
+// Unwrap returns the underlying error
+func (t ReconcileError) Unwrap() error {
+	return t.error
+}
+
 // IsTransient returns if the ReconcileError is recoverable
 func (t ReconcileError) IsTransient() bool {
 	return t.errorType == TransientErrorType
@@ -105,3 +146,123 @@ func WithTransientError(err error, requeueAfter time.Duration) ReconcileError {
 func WithTerminalError(err error) ReconcileError {
 	return ReconcileError{error: err, errorType: TerminalErrorType}
 }
+
+const (
+	// DefaultThrottlingRequeue is the requeue interval for throttled requests that do not specify a Retry-After header.
+	DefaultThrottlingRequeue = 30 * time.Second
+	// DefaultOperationPollingRequeue is the requeue interval for checking the status of a long-running operation.
+	DefaultOperationPollingRequeue = 15 * time.Second
+	// DefaultTransientRequeue is the requeue interval for errors that need a change outside of capz to recover from,
+	// such as a quota increase or a new role assignment.
+	DefaultTransientRequeue = 5 * time.Minute
+)
+
+// throttlingErrorCodes are the Azure error codes returned when the request rate exceeds the subscription limits.
+var throttlingErrorCodes = map[string]struct{}{
+	"TooManyRequests":               {},
+	"SubscriptionRequestsThrottled": {},
+}
+
+// transientErrorCodes are the Azure error codes that are not resolved by retrying right away, but can be
+// resolved without changing the spec.
+var transientErrorCodes = map[string]struct{}{
+	"QuotaExceeded":                         {},
+	"OperationNotAllowed":                   {},
+	"AuthorizationFailed":                   {},
+	"LinkedAuthorizationFailed":             {},
+	"MissingSubscriptionRegistration":       {},
+	"AllocationFailed":                      {},
+	"ZonalAllocationFailed":                 {},
+	"OverconstrainedAllocationRequest":      {},
+	"OverconstrainedZonalAllocationRequest": {},
+}
+
+// terminalErrorCodes are the Azure error codes caused by an invalid spec, which are never resolved by retrying.
+var terminalErrorCodes = map[string]struct{}{
+	"SkuNotAvailable":                      {},
+	"InvalidParameter":                     {},
+	"InvalidResourceName":                  {},
+	"ImageNotFound":                        {},
+	"PlatformImageNotFound":                {},
+	"InvalidImageReference":                {},
+	"VMMarketplaceInvalidInput":            {},
+	"MarketplacePurchaseEligibilityFailed": {},
+	"ResourcePurchaseValidationFailed":     {},
+}
+
+// ClassifyError wraps an Azure API error in a ReconcileError based on its status and error code:
+// throttled requests are transient and requeued after the Retry-After interval returned by Azure,
+// errors that need a change outside of capz are transient with a long requeue interval,
+// and errors caused by an invalid spec are terminal.
+// Errors that are already a ReconcileError and unknown errors are returned unchanged.
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var reconcileError ReconcileError
+	if errors.As(err, &reconcileError) {
+		return err
+	}
+
+	statusCode, code, resp := azureErrorDetails(err)
+	if _, ok := throttlingErrorCodes[code]; ok || statusCode == http.StatusTooManyRequests {
+		return WithTransientError(err, retryAfter(resp, DefaultThrottlingRequeue))
+	}
+	if _, ok := terminalErrorCodes[code]; ok {
+		return WithTerminalError(err)
+	}
+	if _, ok := transientErrorCodes[code]; ok {
+		return WithTransientError(err, DefaultTransientRequeue)
+	}
+	return err
+}
+
+// azureErrorDetails returns the HTTP status code, the Azure error code and the HTTP response of an Azure API error.
+func azureErrorDetails(err error) (int, string, *http.Response) {
+	var (
+		statusCode int
+		code       string
+		resp       *http.Response
+	)
+
+	derr := autorest.DetailedError{}
+	if errors.As(err, &derr) {
+		resp = derr.Response
+		if sc, ok := derr.StatusCode.(int); ok {
+			statusCode = sc
+		}
+		if derr.Original != nil {
+			err = derr.Original
+		}
+	}
+
+	var rerr *autorestazure.RequestError
+	var serr *autorestazure.ServiceError
+	switch {
+	case errors.As(err, &rerr):
+		if rerr.ServiceError != nil {
+			code = rerr.ServiceError.Code
+		}
+		if resp == nil {
+			resp = rerr.Response
+		}
+	case errors.As(err, &serr):
+		code = serr.Code
+	}
+
+	if statusCode == 0 && resp != nil {
+		statusCode = resp.StatusCode
+	}
+	return statusCode, code, resp
+}
+
+// retryAfter returns the interval of the Retry-After header of the response, or the default interval if it is not set.
+func retryAfter(resp *http.Response, defaultInterval time.Duration) time.Duration {
+	if resp == nil {
+		return defaultInterval
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultInterval
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func requestError(statusCode int, code string, header http.Header) error {
+	resp := &http.Response{StatusCode: statusCode, Header: header}
+	return autorest.NewErrorWithError(&autorestazure.RequestError{
+		DetailedError: autorest.DetailedError{Response: resp, StatusCode: statusCode},
+		ServiceError:  &autorestazure.ServiceError{Code: code},
+	}, "compute.VirtualMachinesClient", "CreateOrUpdate", resp, "Failure sending request")
+}
+
+func TestClassifyError(t *testing.T) {
+	testcases := []struct {
+		name                 string
+		err                  error
+		expectReconcileError bool
+		expectTerminal       bool
+		expectedRequeueAfter time.Duration
+	}{
+		{
+			name: "nil error",
+			err:  nil,
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("something went wrong"),
+		},
+		{
+			name: "not found error",
+			err:  autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"),
+		},
+		{
+			name:                 "throttled request with retry-after header",
+			err:                  errors.Wrap(requestError(429, "TooManyRequests", http.Header{"Retry-After": []string{"17"}}), "failed to create VM"),
+			expectReconcileError: true,
+			expectedRequeueAfter: 17 * time.Second,
+		},
+		{
+			name:                 "throttled request without retry-after header",
+			err:                  requestError(429, "SubscriptionRequestsThrottled", http.Header{}),
+			expectReconcileError: true,
+			expectedRequeueAfter: DefaultThrottlingRequeue,
+		},
+		{
+			name:                 "quota exceeded",
+			err:                  errors.Wrap(requestError(409, "QuotaExceeded", http.Header{}), "failed to create VM"),
+			expectReconcileError: true,
+			expectedRequeueAfter: DefaultTransientRequeue,
+		},
+		{
+			name:                 "sku not available",
+			err:                  errors.Wrap(requestError(409, "SkuNotAvailable", http.Header{}), "failed to create VM"),
+			expectReconcileError: true,
+			expectTerminal:       true,
+		},
+		{
+			name:                 "invalid image in async operation",
+			err:                  errors.Wrap(&autorestazure.ServiceError{Code: "PlatformImageNotFound"}, "failed to create VM"),
+			expectReconcileError: true,
+			expectTerminal:       true,
+		},
+		{
+			name:                 "already classified error",
+			err:                  errors.Wrap(WithTransientError(errors.New("not ready"), 10*time.Second), "failed to create VM"),
+			expectReconcileError: true,
+			expectedRequeueAfter: 10 * time.Second,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ClassifyError(tc.err)
+			if tc.err == nil {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err.Error()).To(ContainSubstring(tc.err.Error()))
+
+			var reconcileError ReconcileError
+			g.Expect(errors.As(err, &reconcileError)).To(Equal(tc.expectReconcileError))
+			if !tc.expectReconcileError {
+				return
+			}
+			g.Expect(reconcileError.IsTerminal()).To(Equal(tc.expectTerminal))
+			g.Expect(reconcileError.IsTransient()).To(Equal(!tc.expectTerminal))
+			g.Expect(reconcileError.RequeueAfter()).To(Equal(tc.expectedRequeueAfter))
+		})
+	}
+}
+
+func TestClassifiedErrorIsResourceNotFound(t *testing.T) {
+	g := NewWithT(t)
+	err := WithTransientError(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"), time.Second)
+	g.Expect(ResourceNotFound(err)).To(BeTrue())
+}
@@ -81,8 +81,18 @@ type ClusterDescriber interface {
 	AdditionalTags() infrav1.Tags
 }
 
+// AsyncStatusUpdater keeps the states of the long running operations started by the services of a resource, keyed
+// by the name of the Azure resource and the service, so that they can be resumed on the next reconciliation loop.
+type AsyncStatusUpdater interface {
+	GetLongRunningOperationState(name, service string) *infrav1.Future
+	SetLongRunningOperationState(*infrav1.Future)
+	DeleteLongRunningOperationState(name, service string)
+}
+
 // ClusterScoper combines the ClusterDescriber and NetworkDescriber interfaces.
 type ClusterScoper interface {
 	ClusterDescriber
 	NetworkDescriber
+	AvailabilitySetEnabled() bool
+	ProximityPlacementGroupName() string
 }
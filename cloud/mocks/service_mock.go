@@ -1029,3 +1029,31 @@ func (mr *MockClusterScoperMockRecorder) OutboundPoolName(arg0 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundPoolName", reflect.TypeOf((*MockClusterScoper)(nil).OutboundPoolName), arg0)
 }
+
+// AvailabilitySetEnabled mocks base method.
+func (m *MockClusterScoper) AvailabilitySetEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailabilitySetEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// AvailabilitySetEnabled indicates an expected call of AvailabilitySetEnabled.
+func (mr *MockClusterScoperMockRecorder) AvailabilitySetEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetEnabled", reflect.TypeOf((*MockClusterScoper)(nil).AvailabilitySetEnabled))
+}
+
+// ProximityPlacementGroupName mocks base method.
+func (m *MockClusterScoper) ProximityPlacementGroupName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProximityPlacementGroupName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ProximityPlacementGroupName indicates an expected call of ProximityPlacementGroupName.
+func (mr *MockClusterScoperMockRecorder) ProximityPlacementGroupName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProximityPlacementGroupName", reflect.TypeOf((*MockClusterScoper)(nil).ProximityPlacementGroupName))
+}
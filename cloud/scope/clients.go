@@ -73,13 +73,22 @@ func (c *AzureClients) setCredentials(subscriptionID string) error {
 	}
 
 	c.EnvironmentSettings = settings
-	c.ResourceManagerEndpoint = settings.Environment.ResourceManagerEndpoint
-	c.ResourceManagerVMDNSSuffix = settings.Environment.ResourceManagerVMDNSSuffix
+	// An endpoint and authorizer set by the caller, e.g. pointing at a fake Azure Resource Manager in tests, take
+	// precedence over the ones of the environment.
+	if c.ResourceManagerEndpoint == "" {
+		c.ResourceManagerEndpoint = settings.Environment.ResourceManagerEndpoint
+	}
+	if c.ResourceManagerVMDNSSuffix == "" {
+		c.ResourceManagerVMDNSSuffix = settings.Environment.ResourceManagerVMDNSSuffix
+	}
 	c.Values[auth.ClientID] = strings.TrimSuffix(c.Values[auth.ClientID], "\n")
 	c.Values[auth.ClientSecret] = strings.TrimSuffix(c.Values[auth.ClientSecret], "\n")
 	c.Values[auth.SubscriptionID] = strings.TrimSuffix(subscriptionID, "\n")
 	c.Values[auth.TenantID] = strings.TrimSuffix(c.Values[auth.TenantID], "\n")
 
+	if c.Authorizer != nil {
+		return nil
+	}
 	c.Authorizer, err = c.GetAuthorizer()
 	return err
 }
@@ -20,6 +20,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/Azure/go-autorest/autorest"
 	. "github.com/onsi/gomega"
 )
 
@@ -81,3 +82,18 @@ func TestGettingEnvironment(t *testing.T) {
 		})
 	}
 }
+
+func TestSetCredentialsKeepsPresetEndpoint(t *testing.T) {
+	g := NewWithT(t)
+
+	os.Setenv("AZURE_ENVIRONMENT", "")
+	c := AzureClients{
+		Authorizer:              autorest.NullAuthorizer{},
+		ResourceManagerEndpoint: "http://127.0.0.1:8080",
+	}
+	g.Expect(c.setCredentials("1234")).To(Succeed())
+	g.Expect(c.ResourceManagerEndpoint).To(Equal("http://127.0.0.1:8080"))
+	g.Expect(c.ResourceManagerVMDNSSuffix).To(Equal("cloudapp.azure.com"))
+	g.Expect(c.Authorizer).To(Equal(autorest.NullAuthorizer{}))
+	g.Expect(c.SubscriptionID()).To(Equal("1234"))
+}
@@ -22,6 +22,7 @@ import (
 	"hash/fnv"
 	"strconv"
 	"strings"
+	"sync"
 
 	"k8s.io/utils/net"
 
@@ -90,6 +91,10 @@ type ClusterScope struct {
 	AzureClients
 	Cluster      *clusterv1.Cluster
 	AzureCluster *infrav1.AzureCluster
+
+	// operationsMu guards the long running operation states of the AzureCluster, which the services reconciled
+	// concurrently read and update.
+	operationsMu sync.Mutex
 }
 
 // BaseURI returns the Azure ResourceManagerEndpoint.
@@ -114,15 +119,75 @@ func (s *ClusterScope) PublicIPSpecs() []azure.PublicIPSpec {
 			Name:    s.APIServerPublicIP().Name,
 			DNSName: s.APIServerPublicIP().DNSName,
 			IsIPv6:  false, // currently azure requires a ipv4 lb rule to enable ipv6
+			ID:      s.APIServerPublicIP().ID,
 		}
 	}
 
-	return []azure.PublicIPSpec{
-		controlPlaneOutboundIP,
-		{
-			Name: azure.GenerateNodeOutboundIPName(s.ClusterName()),
-		},
+	specs := []azure.PublicIPSpec{controlPlaneOutboundIP}
+	for _, ip := range s.NodeOutboundFrontendIPs() {
+		if ip.PublicIP == nil {
+			continue
+		}
+		specs = append(specs, azure.PublicIPSpec{
+			Name: ip.PublicIP.Name,
+			ID:   ip.PublicIP.ID,
+		})
+	}
+
+	for _, lb := range s.LoadBalancers() {
+		if lb.Type != infrav1.Public {
+			continue
+		}
+		for _, ip := range lb.FrontendIPs {
+			if ip.PublicIP == nil {
+				continue
+			}
+			specs = append(specs, azure.PublicIPSpec{
+				Name:    ip.PublicIP.Name,
+				DNSName: ip.PublicIP.DNSName,
+				ID:      ip.PublicIP.ID,
+			})
+		}
+	}
+
+	return specs
+}
+
+// PublicIPPrefixSpecs returns the public IP prefix specs.
+func (s *ClusterScope) PublicIPPrefixSpecs() []azure.PublicIPPrefixSpec {
+	var specs []azure.PublicIPPrefixSpec
+	for _, ip := range s.NodeOutboundFrontendIPs() {
+		if ip.PublicIPPrefix == nil {
+			continue
+		}
+		specs = append(specs, azure.PublicIPPrefixSpec{
+			Name:         ip.PublicIPPrefix.Name,
+			PrefixLength: ip.PublicIPPrefix.PrefixLength,
+		})
+	}
+	return specs
+}
+
+// NodeOutboundFrontendIPs returns the frontend IP configurations of the node outbound load balancer.
+func (s *ClusterScope) NodeOutboundFrontendIPs() []infrav1.FrontendIP {
+	lb := s.AzureCluster.Spec.NetworkSpec.NodeOutboundLB
+	if lb != nil && len(lb.FrontendIPs) != 0 {
+		return lb.FrontendIPs
+	}
+	count := 1
+	if lb != nil && lb.FrontendIPsCount != nil {
+		count = int(*lb.FrontendIPsCount)
+	}
+	frontendIPs := make([]infrav1.FrontendIP, 0, count)
+	for i := 0; i < count; i++ {
+		frontendIPs = append(frontendIPs, infrav1.FrontendIP{
+			Name: azure.GenerateNodeOutboundFrontendIPConfigName(s.NodeOutboundLBName(), i),
+			PublicIP: &infrav1.PublicIPSpec{
+				Name: azure.GenerateNodeOutboundIPNameWithIndex(s.ClusterName(), i),
+			},
+		})
 	}
+	return frontendIPs
 }
 
 // LBSpecs returns the load balancer specs.
@@ -130,32 +195,53 @@ func (s *ClusterScope) LBSpecs() []azure.LBSpec {
 	specs := []azure.LBSpec{
 		{
 			// Control Plane LB
-			Name:              s.APIServerLB().Name,
-			SubnetName:        s.ControlPlaneSubnet().Name,
-			FrontendIPConfigs: s.APIServerLB().FrontendIPs,
-			APIServerPort:     s.APIServerPort(),
-			Type:              s.APIServerLB().Type,
-			SKU:               infrav1.SKUStandard,
-			Role:              infrav1.APIServerRole,
-			BackendPoolName:   s.APIServerLBPoolName(s.APIServerLB().Name),
+			Name:                   s.APIServerLB().Name,
+			SubnetName:             s.ControlPlaneSubnet().Name,
+			FrontendIPConfigs:      s.APIServerLB().FrontendIPs,
+			APIServerPort:          s.APIServerPort(),
+			Type:                   s.APIServerLB().Type,
+			SKU:                    infrav1.SKUStandard,
+			Role:                   infrav1.APIServerRole,
+			BackendPoolName:        s.APIServerLBPoolName(s.APIServerLB().Name),
+			HealthProbe:            s.APIServerLB().HealthProbe,
+			IdleTimeoutInMinutes:   s.APIServerLB().IdleTimeoutInMinutes,
+			EnableTCPReset:         s.APIServerLB().EnableTCPReset,
+			EnableFloatingIP:       s.APIServerLB().EnableFloatingIP,
+			AllocatedOutboundPorts: s.APIServerLB().AllocatedOutboundPorts,
 		},
 		{
 			// Public Node outbound LB
-			Name: s.NodeOutboundLBName(),
-			FrontendIPConfigs: []infrav1.FrontendIP{
-				{
-					Name: azure.GenerateFrontendIPConfigName(s.NodeOutboundLBName()),
-					PublicIP: &infrav1.PublicIPSpec{
-						Name: azure.GenerateNodeOutboundIPName(s.ClusterName()),
-					},
-				},
-			},
-			Type:            infrav1.Public,
-			SKU:             infrav1.SKUStandard,
-			BackendPoolName: s.OutboundPoolName(s.NodeOutboundLBName()),
-			Role:            infrav1.NodeOutboundRole,
+			Name:              s.NodeOutboundLBName(),
+			FrontendIPConfigs: s.NodeOutboundFrontendIPs(),
+			Type:              infrav1.Public,
+			SKU:               infrav1.SKUStandard,
+			BackendPoolName:   s.OutboundPoolName(s.NodeOutboundLBName()),
+			Role:              infrav1.NodeOutboundRole,
 		},
 	}
+	if outboundLB := s.AzureCluster.Spec.NetworkSpec.NodeOutboundLB; outboundLB != nil {
+		specs[1].IdleTimeoutInMinutes = outboundLB.IdleTimeoutInMinutes
+		specs[1].EnableTCPReset = outboundLB.EnableTCPReset
+		specs[1].AllocatedOutboundPorts = outboundLB.AllocatedOutboundPorts
+	}
+
+	for _, lb := range s.LoadBalancers() {
+		// Additional LBs live in the node subnet, they front services running on the nodes.
+		specs = append(specs, azure.LBSpec{
+			Name:                 lb.Name,
+			SubnetName:           s.NodeSubnet().Name,
+			FrontendIPConfigs:    lb.FrontendIPs,
+			Type:                 lb.Type,
+			SKU:                  infrav1.SKUStandard,
+			Role:                 infrav1.AdditionalLBRole,
+			BackendPools:         lb.BackendPools,
+			Probes:               lb.Probes,
+			Rules:                lb.Rules,
+			IdleTimeoutInMinutes: lb.IdleTimeoutInMinutes,
+			EnableTCPReset:       lb.EnableTCPReset,
+			EnableFloatingIP:     lb.EnableFloatingIP,
+		})
+	}
 
 	if !s.IsAPIServerPrivate() {
 		return specs
@@ -185,10 +271,18 @@ func (s *ClusterScope) LBSpecs() []azure.LBSpec {
 func (s *ClusterScope) RouteTableSpecs() []azure.RouteTableSpec {
 	routetables := []azure.RouteTableSpec{}
 	if s.ControlPlaneRouteTable().Name != "" {
-		routetables = append(routetables, azure.RouteTableSpec{Name: s.ControlPlaneRouteTable().Name, Subnet: s.ControlPlaneSubnet()})
+		routetables = append(routetables, azure.RouteTableSpec{
+			Name:   s.ControlPlaneRouteTable().Name,
+			Subnet: s.ControlPlaneSubnet(),
+			Routes: s.ControlPlaneRouteTable().Routes,
+		})
 	}
 	if s.NodeRouteTable().Name != "" {
-		routetables = append(routetables, azure.RouteTableSpec{Name: s.NodeRouteTable().Name, Subnet: s.NodeSubnet()})
+		routetables = append(routetables, azure.RouteTableSpec{
+			Name:   s.NodeRouteTable().Name,
+			Subnet: s.NodeSubnet(),
+			Routes: s.NodeRouteTable().Routes,
+		})
 	}
 	return routetables
 }
@@ -243,17 +337,74 @@ func (s *ClusterScope) PrivateDNSSpec() *azure.PrivateDNSSpec {
 	var spec *azure.PrivateDNSSpec
 	if s.IsAPIServerPrivate() {
 		spec = &azure.PrivateDNSSpec{
-			ZoneName:          azure.GeneratePrivateDNSZoneName(s.ClusterName()),
+			ZoneName:          s.PrivateDNSZoneName(),
+			ZoneResourceGroup: s.ResourceGroup(),
 			VNetName:          s.Vnet().Name,
 			VNetResourceGroup: s.Vnet().ResourceGroup,
 			LinkName:          azure.GenerateVNetLinkName(s.Vnet().Name),
 			Records: []infrav1.AddressRecord{
 				{
-					Hostname: azure.PrivateAPIServerHostname,
+					Hostname: s.privateDNSRecordName(azure.PrivateAPIServerHostname),
 					IP:       s.APIServerPrivateIP(),
 				},
 			},
 		}
+		if zone := s.AzureCluster.Spec.PrivateDNSZone; zone != nil && zone.ResourceGroup != "" {
+			spec.ExistingZone = true
+			spec.ZoneResourceGroup = zone.ResourceGroup
+			if zone.SubscriptionID != s.SubscriptionID() {
+				spec.ZoneSubscriptionID = zone.SubscriptionID
+			}
+		}
+		// The private endpoints of the API server private link service are resolved by their names.
+		if privateLink := s.AzureCluster.Spec.PrivateLink; privateLink != nil {
+			for _, endpoint := range privateLink.PrivateEndpoints {
+				if endpoint.PrivateIP != "" {
+					spec.Records = append(spec.Records, infrav1.AddressRecord{
+						Hostname: s.privateDNSRecordName(endpoint.Name),
+						IP:       endpoint.PrivateIP,
+					})
+				}
+			}
+		}
+	}
+	return spec
+}
+
+// PrivateDNSZoneName returns the name of the private DNS zone resolving the private API server.
+func (s *ClusterScope) PrivateDNSZoneName() string {
+	if zone := s.AzureCluster.Spec.PrivateDNSZone; zone != nil && zone.Name != "" {
+		return zone.Name
+	}
+	return azure.GeneratePrivateDNSZoneName(s.ClusterName())
+}
+
+// privateDNSRecordName returns the name of a record of the cluster in its private DNS zone. An existing zone may be
+// shared by several clusters, so the records are qualified with the cluster name.
+func (s *ClusterScope) privateDNSRecordName(hostname string) string {
+	if zone := s.AzureCluster.Spec.PrivateDNSZone; zone != nil && zone.ResourceGroup != "" {
+		return fmt.Sprintf("%s.%s", hostname, s.ClusterName())
+	}
+	return hostname
+}
+
+// PrivateLinkSpec returns the spec of the Private Link Service publishing the internal API server load balancer.
+func (s *ClusterScope) PrivateLinkSpec() *azure.PrivateLinkSpec {
+	privateLink := s.AzureCluster.Spec.PrivateLink
+	if privateLink == nil || !s.IsAPIServerPrivate() {
+		return nil
+	}
+	spec := &azure.PrivateLinkSpec{
+		Name:                 privateLink.Name,
+		LBName:               s.APIServerLBName(),
+		FrontendIPConfigName: s.APIServerLB().FrontendIPs[0].Name,
+		SubnetName:           s.ControlPlaneSubnet().Name,
+		VNetName:             s.Vnet().Name,
+		VNetResourceGroup:    s.Vnet().ResourceGroup,
+		AllowedSubscriptions: append([]string{s.SubscriptionID()}, privateLink.AllowedSubscriptions...),
+	}
+	for i := range privateLink.PrivateEndpoints {
+		spec.PrivateEndpoints = append(spec.PrivateEndpoints, &privateLink.PrivateEndpoints[i])
 	}
 	return spec
 }
@@ -308,6 +459,11 @@ func (s *ClusterScope) APIServerLB() *infrav1.LoadBalancerSpec {
 	return &s.AzureCluster.Spec.NetworkSpec.APIServerLB
 }
 
+// LoadBalancers returns the cluster's additional load balancers.
+func (s *ClusterScope) LoadBalancers() []infrav1.LoadBalancerSpec {
+	return s.AzureCluster.Spec.NetworkSpec.LoadBalancers
+}
+
 // APIServerLBName returns the API Server LB name.
 func (s *ClusterScope) APIServerLBName() string {
 	return s.APIServerLB().Name
@@ -420,6 +576,7 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			clusterv1.ReadyCondition,
 			infrav1.NetworkInfrastructureReadyCondition,
+			infrav1.ServicesReconciledCondition,
 		}})
 }
 
@@ -448,11 +605,48 @@ func (s *ClusterScope) APIServerPort() int32 {
 // APIServerHost returns the hostname used to reach the API server.
 func (s *ClusterScope) APIServerHost() string {
 	if s.IsAPIServerPrivate() {
-		return azure.GeneratePrivateFQDN(s.ClusterName())
+		return azure.GeneratePrivateFQDN(s.privateDNSRecordName(azure.PrivateAPIServerHostname), s.PrivateDNSZoneName())
 	}
 	return s.APIServerPublicIP().DNSName
 }
 
+// AvailabilitySetEnabled informs machines that they should be part of an Availability Set.
+// Availability sets are only used when the location has no availability zones to spread machines across.
+func (s *ClusterScope) AvailabilitySetEnabled() bool {
+	return len(s.AzureCluster.Status.FailureDomains) == 0
+}
+
+// ProximityPlacementGroupName returns the name of the proximity placement group of the cluster, or an empty string
+// if its machines are not placed in one.
+func (s *ClusterScope) ProximityPlacementGroupName() string {
+	if s.AzureCluster.Spec.ProximityPlacementGroup == nil {
+		return ""
+	}
+	if s.AzureCluster.Spec.ProximityPlacementGroup.Name != "" {
+		return s.AzureCluster.Spec.ProximityPlacementGroup.Name
+	}
+	return azure.GenerateProximityPlacementGroupName(s.ClusterName())
+}
+
+// ProximityPlacementGroupSpecs returns the proximity placement group specs of the cluster.
+func (s *ClusterScope) ProximityPlacementGroupSpecs() []azure.ProximityPlacementGroupSpec {
+	if name := s.ProximityPlacementGroupName(); name != "" {
+		return []azure.ProximityPlacementGroupSpec{{Name: name}}
+	}
+	return nil
+}
+
+// StorageAccountSpecs returns the spec of the storage account holding the bootstrap data of the machines of the
+// cluster, which is only created by the machines delivering their bootstrap data through a storage blob.
+func (s *ClusterScope) StorageAccountSpecs() []azure.StorageAccountSpec {
+	return []azure.StorageAccountSpec{
+		{
+			Name:          azure.GenerateBootstrapStorageAccountName(s.SubscriptionID(), s.ResourceGroup(), s.ClusterName()),
+			ContainerName: azure.BootstrapDataContainerName,
+		},
+	}
+}
+
 // SetFailureDomain will set the spec for a for a given key
 func (s *ClusterScope) SetFailureDomain(id string, spec clusterv1.FailureDomainSpec) {
 	if s.AzureCluster.Status.FailureDomains == nil {
@@ -461,6 +655,55 @@ func (s *ClusterScope) SetFailureDomain(id string, spec clusterv1.FailureDomainS
 	s.AzureCluster.Status.FailureDomains[id] = spec
 }
 
+// GetLongRunningOperationState returns the in-progress long running operation of the service on the Azure resource
+// with the given name, or nil if there is none.
+func (s *ClusterScope) GetLongRunningOperationState(name, service string) *infrav1.Future {
+	s.operationsMu.Lock()
+	defer s.operationsMu.Unlock()
+
+	for i := range s.AzureCluster.Status.LongRunningOperationStates {
+		future := s.AzureCluster.Status.LongRunningOperationStates[i]
+		if future.Name == name && future.ServiceName == service {
+			return &future
+		}
+	}
+	return nil
+}
+
+// SetLongRunningOperationState saves the in-progress long running operation of a service, replacing any previous
+// operation of the service on the same Azure resource.
+func (s *ClusterScope) SetLongRunningOperationState(future *infrav1.Future) {
+	s.operationsMu.Lock()
+	defer s.operationsMu.Unlock()
+
+	states := s.AzureCluster.Status.LongRunningOperationStates
+	for i := range states {
+		if states[i].Name == future.Name && states[i].ServiceName == future.ServiceName {
+			states[i] = *future
+			return
+		}
+	}
+	s.AzureCluster.Status.LongRunningOperationStates = append(states, *future)
+}
+
+// DeleteLongRunningOperationState removes the long running operation of the service on the Azure resource with the
+// given name once it has completed.
+func (s *ClusterScope) DeleteLongRunningOperationState(name, service string) {
+	s.operationsMu.Lock()
+	defer s.operationsMu.Unlock()
+
+	states := []infrav1.Future{}
+	for _, future := range s.AzureCluster.Status.LongRunningOperationStates {
+		if future.Name != name || future.ServiceName != service {
+			states = append(states, future)
+		}
+	}
+	if len(states) == 0 {
+		states = nil
+	}
+	s.AzureCluster.Status.LongRunningOperationStates = states
+}
+
 // SetControlPlaneIngressRules will set the ingress rules or the control plane subnet
 func (s *ClusterScope) SetControlPlaneIngressRules() {
 	if s.ControlPlaneSubnet().SecurityGroup.IngressRules == nil {
@@ -489,6 +732,19 @@ func (s *ClusterScope) SetControlPlaneIngressRules() {
 	}
 }
 
+// SetPublicIPDNSName sets the DNS name of the API Server public IP to the FQDN or address of the existing public IP
+// it references, unless a DNS name was already specified.
+func (s *ClusterScope) SetPublicIPDNSName(ipName, dnsName string) {
+	if s.IsAPIServerPrivate() {
+		return
+	}
+	ip := s.APIServerPublicIP()
+	if ip.ID == "" || ip.Name != ipName || ip.DNSName != "" {
+		return
+	}
+	ip.DNSName = dnsName
+}
+
 // SetDNSName sets the API Server public IP DNS name.
 func (s *ClusterScope) SetDNSName() {
 	// for back compat, set the old API Server defaults if no API Server Spec has been set by new webhooks.
@@ -512,8 +768,8 @@ func (s *ClusterScope) SetDNSName() {
 		}
 		lb.DeepCopyInto(s.APIServerLB())
 	}
-	// Generate valid FQDN if not set.
-	if !s.IsAPIServerPrivate() && s.APIServerPublicIP().DNSName == "" {
+	// Generate valid FQDN if not set. The DNS name of an existing public IP is set once it has been fetched.
+	if !s.IsAPIServerPrivate() && s.APIServerPublicIP().DNSName == "" && s.APIServerPublicIP().ID == "" {
 		s.APIServerPublicIP().DNSName = s.GenerateFQDN(s.APIServerPublicIP().Name)
 	}
 }
@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+)
+
+func TestLongRunningOperationStates(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &ClusterScope{AzureCluster: &infrav1.AzureCluster{}}
+	g.Expect(s.GetLongRunningOperationState("my-lb", "loadbalancers")).To(BeNil())
+
+	put := &infrav1.Future{Type: infrav1.PutFuture, ServiceName: "loadbalancers", Name: "my-lb", FutureData: "put"}
+	s.SetLongRunningOperationState(put)
+	g.Expect(s.GetLongRunningOperationState("my-lb", "loadbalancers")).To(Equal(put))
+	// the operations of other services on a resource with the same name are kept apart.
+	g.Expect(s.GetLongRunningOperationState("my-lb", "publicips")).To(BeNil())
+
+	del := &infrav1.Future{Type: infrav1.DeleteFuture, ServiceName: "loadbalancers", Name: "my-lb", FutureData: "delete"}
+	s.SetLongRunningOperationState(del)
+	g.Expect(s.AzureCluster.Status.LongRunningOperationStates).To(HaveLen(1))
+	g.Expect(s.GetLongRunningOperationState("my-lb", "loadbalancers")).To(Equal(del))
+
+	s.DeleteLongRunningOperationState("my-lb", "loadbalancers")
+	g.Expect(s.GetLongRunningOperationState("my-lb", "loadbalancers")).To(BeNil())
+	g.Expect(s.AzureCluster.Status.LongRunningOperationStates).To(BeNil())
+}
+
+func TestLongRunningOperationStatesConcurrentAccess(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &ClusterScope{AzureCluster: &infrav1.AzureCluster{}}
+
+	// The cluster services are reconciled concurrently and each one saves its own operations.
+	var wg sync.WaitGroup
+	for _, service := range []string{"loadbalancers", "publicips"} {
+		service := service
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				name := fmt.Sprintf("resource-%d", i%10)
+				s.SetLongRunningOperationState(&infrav1.Future{Type: infrav1.PutFuture, ServiceName: service, Name: name})
+				g.Expect(s.GetLongRunningOperationState(name, service)).NotTo(BeNil())
+				if i%2 == 0 {
+					s.DeleteLongRunningOperationState(name, service)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	g.Expect(s.AzureCluster.Status.LongRunningOperationStates).To(HaveLen(10))
+}
@@ -17,9 +17,13 @@ limitations under the License.
 package scope
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"sync"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/go-logr/logr"
@@ -86,23 +90,31 @@ type MachineScope struct {
 	azure.ClusterScoper
 	Machine      *clusterv1.Machine
 	AzureMachine *infrav1.AzureMachine
+
+	// bootstrapDataURL is the URL of the blob holding the bootstrap data, when it is delivered through a storage blob.
+	bootstrapDataURL string
+	// annotationsMu guards the annotations of the AzureMachine, which the services reconciled concurrently, such as
+	// tags and vmextensions, read and update.
+	annotationsMu sync.Mutex
 }
 
 // VMSpec returns the VM spec.
 func (m *MachineScope) VMSpec() azure.VMSpec {
 	return azure.VMSpec{
-		Name:                   m.Name(),
-		Role:                   m.Role(),
-		NICNames:               m.NICNames(),
-		SSHKeyData:             m.AzureMachine.Spec.SSHPublicKey,
-		Size:                   m.AzureMachine.Spec.VMSize,
-		OSDisk:                 m.AzureMachine.Spec.OSDisk,
-		DataDisks:              m.AzureMachine.Spec.DataDisks,
-		Zone:                   m.AvailabilityZone(),
-		Identity:               m.AzureMachine.Spec.Identity,
-		UserAssignedIdentities: m.AzureMachine.Spec.UserAssignedIdentities,
-		SpotVMOptions:          m.AzureMachine.Spec.SpotVMOptions,
-		SecurityProfile:        m.AzureMachine.Spec.SecurityProfile,
+		Name:                        m.Name(),
+		Role:                        m.Role(),
+		NICNames:                    m.NICNames(),
+		SSHKeyData:                  m.AzureMachine.Spec.SSHPublicKey,
+		Size:                        m.AzureMachine.Spec.VMSize,
+		OSDisk:                      m.AzureMachine.Spec.OSDisk,
+		DataDisks:                   m.AzureMachine.Spec.DataDisks,
+		Zone:                        m.AvailabilityZone(),
+		Identity:                    m.AzureMachine.Spec.Identity,
+		UserAssignedIdentities:      m.AzureMachine.Spec.UserAssignedIdentities,
+		SpotVMOptions:               m.AzureMachine.Spec.SpotVMOptions,
+		SecurityProfile:             m.AzureMachine.Spec.SecurityProfile,
+		ProximityPlacementGroupName: m.ProximityPlacementGroupName(),
+		DedicatedHostGroupID:        m.AzureMachine.Spec.DedicatedHostGroupID,
 	}
 }
 
@@ -117,6 +129,52 @@ func (m *MachineScope) TagsSpecs() []azure.TagsSpec {
 	}
 }
 
+// VMExtensionsSpecs returns the VM extensions for the AzureMachine.
+func (m *MachineScope) VMExtensionsSpecs() []azure.VMExtensionsSpec {
+	return []azure.VMExtensionsSpec{
+		{
+			MachineName:  m.Name(),
+			ResourceType: azure.VirtualMachine,
+			Extensions:   vmExtensionSpecs(m.AzureMachine.Spec.VMExtensions),
+			Annotation:   infrav1.VMExtensionsLastAppliedAnnotation,
+		},
+	}
+}
+
+// StorageAccountSpecs returns the spec of the storage account of the cluster when the bootstrap data of the machine is
+// delivered through a storage blob.
+func (m *MachineScope) StorageAccountSpecs() []azure.StorageAccountSpec {
+	if !m.bootstrapDataInStorageBlob() {
+		return nil
+	}
+	return []azure.StorageAccountSpec{
+		{
+			Name:          azure.GenerateBootstrapStorageAccountName(m.SubscriptionID(), m.ResourceGroup(), m.ClusterName()),
+			ContainerName: azure.BootstrapDataContainerName,
+		},
+	}
+}
+
+// BootstrapDataSpecs returns the spec of the blob holding the bootstrap data of the machine when it is delivered
+// through a storage blob.
+func (m *MachineScope) BootstrapDataSpecs() []azure.BootstrapDataSpec {
+	if !m.bootstrapDataInStorageBlob() {
+		return nil
+	}
+	return []azure.BootstrapDataSpec{
+		{
+			StorageAccountName: azure.GenerateBootstrapStorageAccountName(m.SubscriptionID(), m.ResourceGroup(), m.ClusterName()),
+			ContainerName:      azure.BootstrapDataContainerName,
+			BlobName:           m.Name(),
+		},
+	}
+}
+
+// bootstrapDataInStorageBlob returns true if the bootstrap data of the machine is delivered through a storage blob.
+func (m *MachineScope) bootstrapDataInStorageBlob() bool {
+	return m.AzureMachine.Spec.BootstrapData != nil && m.AzureMachine.Spec.BootstrapData.Delivery == infrav1.BootstrapDataDeliveryStorageBlob
+}
+
 // PublicIPSpecs returns the public IP specs.
 func (m *MachineScope) PublicIPSpecs() []azure.PublicIPSpec {
 	var spec []azure.PublicIPSpec
@@ -128,6 +186,14 @@ func (m *MachineScope) PublicIPSpecs() []azure.PublicIPSpec {
 	return spec
 }
 
+// PublicIPPrefixSpecs returns the public IP prefix specs.
+func (m *MachineScope) PublicIPPrefixSpecs() []azure.PublicIPPrefixSpec {
+	return nil
+}
+
+// SetPublicIPDNSName is a no-op as machine public IPs are always created by the provider.
+func (m *MachineScope) SetPublicIPDNSName(ipName, dnsName string) {}
+
 // InboundNatSpecs returns the inbound NAT specs.
 func (m *MachineScope) InboundNatSpecs() []azure.InboundNatSpec {
 	if m.Role() == infrav1.ControlPlane {
@@ -144,17 +210,18 @@ func (m *MachineScope) InboundNatSpecs() []azure.InboundNatSpec {
 // NICSpecs returns the network interface specs.
 func (m *MachineScope) NICSpecs() []azure.NICSpec {
 	spec := azure.NICSpec{
-		Name:                    azure.GenerateNICName(m.Name()),
-		MachineName:             m.Name(),
-		VNetName:                m.Vnet().Name,
-		VNetResourceGroup:       m.Vnet().ResourceGroup,
-		SubnetName:              m.Subnet().Name,
-		VMSize:                  m.AzureMachine.Spec.VMSize,
-		AcceleratedNetworking:   m.AzureMachine.Spec.AcceleratedNetworking,
-		IPv6Enabled:             m.IsIPv6Enabled(),
-		EnableIPForwarding:      m.AzureMachine.Spec.EnableIPForwarding,
-		PublicLBName:            m.OutboundLBName(m.Role()),
-		PublicLBAddressPoolName: m.OutboundPoolName(m.OutboundLBName(m.Role())),
+		Name:                     azure.GenerateNICName(m.Name()),
+		MachineName:              m.Name(),
+		VNetName:                 m.Vnet().Name,
+		VNetResourceGroup:        m.Vnet().ResourceGroup,
+		SubnetName:               m.Subnet().Name,
+		VMSize:                   m.AzureMachine.Spec.VMSize,
+		AcceleratedNetworking:    m.AzureMachine.Spec.AcceleratedNetworking,
+		IPv6Enabled:              m.IsIPv6Enabled(),
+		EnableIPForwarding:       m.AzureMachine.Spec.EnableIPForwarding,
+		PublicLBName:             m.OutboundLBName(m.Role()),
+		PublicLBAddressPoolName:  m.OutboundPoolName(m.OutboundLBName(m.Role())),
+		AdditionalLBAddressPools: lbBackendPoolSpecs(m.AzureMachine.Spec.LoadBalancerBackendPools),
 	}
 	if m.Role() == infrav1.ControlPlane {
 		if m.IsAPIServerPrivate() {
@@ -182,6 +249,23 @@ func (m *MachineScope) NICSpecs() []azure.NICSpec {
 	return specs
 }
 
+// lbBackendPoolSpecs returns the additional load balancer backend pool specs for the given references.
+// References that omit the backend pool name join the load balancer's default backend pool.
+func lbBackendPoolSpecs(refs []infrav1.LoadBalancerBackendPoolReference) []azure.LBBackendPoolSpec {
+	var specs []azure.LBBackendPoolSpec
+	for _, ref := range refs {
+		poolName := ref.BackendPoolName
+		if poolName == "" {
+			poolName = azure.GenerateBackendAddressPoolName(ref.LoadBalancerName)
+		}
+		specs = append(specs, azure.LBBackendPoolSpec{
+			LoadBalancerName: ref.LoadBalancerName,
+			PoolName:         poolName,
+		})
+	}
+	return specs
+}
+
 // NICNames returns the NIC names
 func (m *MachineScope) NICNames() []string {
 	nicNames := make([]string, len(m.NICSpecs()))
@@ -194,12 +278,20 @@ func (m *MachineScope) NICNames() []string {
 // DiskSpecs returns the disk specs.
 func (m *MachineScope) DiskSpecs() []azure.DiskSpec {
 	spec := azure.DiskSpec{
-		Name: azure.GenerateOSDiskName(m.Name()),
+		Name:        azure.GenerateOSDiskName(m.Name()),
+		MachineName: m.Name(),
 	}
 	disks := []azure.DiskSpec{spec}
 
 	for _, dd := range m.AzureMachine.Spec.DataDisks {
-		disks = append(disks, azure.DiskSpec{Name: azure.GenerateDataDiskName(m.Name(), dd.NameSuffix)})
+		disks = append(disks, azure.DiskSpec{
+			Name:              azure.DataDiskName(m.Name(), dd),
+			DiskSizeGB:        dd.DiskSizeGB,
+			DiskIOPSReadWrite: dd.DiskIOPSReadWrite,
+			DiskMBpsReadWrite: dd.DiskMBpsReadWrite,
+			DeletionPolicy:    dd.DeletionPolicy,
+			MachineName:       m.Name(),
+		})
 	}
 	return disks
 }
@@ -218,15 +310,66 @@ func (m *MachineScope) BastionSpecs() []azure.BastionSpec {
 // RoleAssignmentSpecs returns the role assignment specs.
 func (m *MachineScope) RoleAssignmentSpecs() []azure.RoleAssignmentSpec {
 	if m.AzureMachine.Spec.Identity == infrav1.VMIdentitySystemAssigned {
+		return roleAssignmentSpecs(m.ClusterScoper, m.Name(), azure.VirtualMachine, m.AzureMachine.Spec.RoleAssignmentName, m.AzureMachine.Spec.RoleAssignments)
+	}
+	return []azure.RoleAssignmentSpec{}
+}
+
+// roleAssignmentSpecs returns the role assignment specs for the system assigned identity of a VM or VMSS.
+// When no role assignments are configured, a single spec using the legacy role assignment name is returned.
+func roleAssignmentSpecs(scoper azure.ClusterScoper, machineName, resourceType, legacyName string, roleAssignments []infrav1.RoleAssignment) []azure.RoleAssignmentSpec {
+	if len(roleAssignments) == 0 {
 		return []azure.RoleAssignmentSpec{
 			{
-				MachineName:  m.Name(),
-				Name:         m.AzureMachine.Spec.RoleAssignmentName,
-				ResourceType: azure.VirtualMachine,
+				MachineName:  machineName,
+				Name:         legacyName,
+				ResourceType: resourceType,
 			},
 		}
 	}
-	return []azure.RoleAssignmentSpec{}
+
+	specs := make([]azure.RoleAssignmentSpec, 0, len(roleAssignments))
+	for _, ra := range roleAssignments {
+		var scope string
+		switch ra.Scope {
+		case infrav1.RoleAssignmentScopeVirtualNetwork:
+			scope = azure.VNetID(scoper.SubscriptionID(), scoper.Vnet().ResourceGroup, scoper.Vnet().Name)
+		case infrav1.RoleAssignmentScopeSubscription:
+			scope = azure.SubscriptionID(scoper.SubscriptionID())
+		case infrav1.RoleAssignmentScopeCustom:
+			scope = ra.ScopeID
+		default:
+			scope = azure.ResourceGroupID(scoper.SubscriptionID(), scoper.ResourceGroup())
+		}
+		specs = append(specs, azure.RoleAssignmentSpec{
+			MachineName:      machineName,
+			Name:             ra.Name,
+			ResourceType:     resourceType,
+			RoleDefinitionID: azure.RoleDefinitionID(scoper.SubscriptionID(), ra.RoleDefinitionID),
+			Scope:            scope,
+		})
+	}
+	return specs
+}
+
+// vmExtensionSpecs returns the specs of the VM extensions of a VM or VMSS.
+func vmExtensionSpecs(extensions []infrav1.VMExtension) []azure.VMExtensionSpec {
+	specs := make([]azure.VMExtensionSpec, 0, len(extensions))
+	for _, extension := range extensions {
+		spec := azure.VMExtensionSpec{
+			Name:      extension.Name,
+			Publisher: extension.Publisher,
+			Type:      extension.Type,
+			Version:   extension.Version,
+			Settings:  extension.Settings,
+		}
+		if extension.ProtectedSettingsSecretRef != nil {
+			spec.ProtectedSettingsSecretName = extension.ProtectedSettingsSecretRef.Name
+			spec.ProtectedSettingsSecretKey = extension.ProtectedSettingsSecretRef.Key
+		}
+		specs = append(specs, spec)
+	}
+	return specs
 }
 
 // Subnet returns the machine's subnet based on its role
@@ -258,6 +401,32 @@ func (m *MachineScope) AvailabilityZone() string {
 	return ""
 }
 
+// AvailabilitySet returns the availability set for this machine if available.
+// Machines are grouped in one availability set for the control plane and one per MachineDeployment, or per
+// MachineSet for machines which are not part of a MachineDeployment. Machines in an availability zone or on
+// dedicated hosts are not part of an availability set.
+func (m *MachineScope) AvailabilitySet() (string, bool) {
+	if !m.AvailabilitySetEnabled() || m.AvailabilityZone() != "" || m.AzureMachine.Spec.DedicatedHostGroupID != "" {
+		return "", false
+	}
+
+	if m.IsControlPlane() {
+		return azure.GenerateAvailabilitySetName(m.ClusterName(), azure.ControlPlaneNodeGroup), true
+	}
+
+	// get machine deployment name from labels for machines that maybe part of a machine deployment.
+	if mdName, ok := m.Machine.Labels[clusterv1.MachineDeploymentLabelName]; ok {
+		return azure.GenerateAvailabilitySetName(m.ClusterName(), mdName), true
+	}
+
+	// if machine deployment name label is not available, use machine set name.
+	if msName, ok := m.Machine.Labels[clusterv1.MachineSetLabelName]; ok {
+		return azure.GenerateAvailabilitySetName(m.ClusterName(), msName), true
+	}
+
+	return "", false
+}
+
 // Name returns the AzureMachine name.
 func (m *MachineScope) Name() string {
 	return m.AzureMachine.Name
@@ -317,6 +486,17 @@ func (m *MachineScope) SetVMState(v infrav1.VMState) {
 	m.AzureMachine.Status.VMState = &v
 }
 
+// GetLongRunningOperationState returns the state of the AzureMachine's in-progress Azure long-running operation, if any.
+func (m *MachineScope) GetLongRunningOperationState() *infrav1.Future {
+	return m.AzureMachine.Status.LongRunningOperationState
+}
+
+// SetLongRunningOperationState saves the state of an Azure long-running operation so it can be resumed on the next reconcile.
+// A nil future clears it.
+func (m *MachineScope) SetLongRunningOperationState(future *infrav1.Future) {
+	m.AzureMachine.Status.LongRunningOperationState = future
+}
+
 // SetReady sets the AzureMachine Ready Status to true.
 func (m *MachineScope) SetReady() {
 	m.AzureMachine.Status.Ready = true
@@ -339,6 +519,9 @@ func (m *MachineScope) SetFailureReason(v capierrors.MachineStatusError) {
 
 // SetAnnotation sets a key value annotation on the AzureMachine.
 func (m *MachineScope) SetAnnotation(key, value string) {
+	m.annotationsMu.Lock()
+	defer m.annotationsMu.Unlock()
+
 	if m.AzureMachine.Annotations == nil {
 		m.AzureMachine.Annotations = map[string]string{}
 	}
@@ -348,7 +531,9 @@ func (m *MachineScope) SetAnnotation(key, value string) {
 // AnnotationJSON returns a map[string]interface from a JSON annotation.
 func (m *MachineScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
 	out := map[string]interface{}{}
+	m.annotationsMu.Lock()
 	jsonAnnotation := m.AzureMachine.GetAnnotations()[annotation]
+	m.annotationsMu.Unlock()
 	if len(jsonAnnotation) == 0 {
 		return out, nil
 	}
@@ -394,6 +579,7 @@ func (m *MachineScope) PatchObject(ctx context.Context) error {
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			clusterv1.ReadyCondition,
 			infrav1.VMRunningCondition,
+			infrav1.ServicesReconciledCondition,
 		}})
 }
 
@@ -416,22 +602,112 @@ func (m *MachineScope) AdditionalTags() infrav1.Tags {
 	return tags
 }
 
-// GetBootstrapData returns the bootstrap data from the secret in the Machine's bootstrap.dataSecretName.
+// GetBootstrapData returns the custom data of the VM: the base64 encoded bootstrap data, or a cloud-init include of the
+// URL of the blob holding it when it is delivered through a storage blob.
 func (m *MachineScope) GetBootstrapData(ctx context.Context) (string, error) {
+	if m.bootstrapDataInStorageBlob() {
+		if m.bootstrapDataURL == "" {
+			return "", errors.New("error retrieving bootstrap data: the bootstrap data blob has not been uploaded")
+		}
+		return encodeCustomData([]byte(fmt.Sprintf("#include\n%s\n", m.bootstrapDataURL)))
+	}
+
+	value, err := m.GetBootstrapDataContent(ctx)
+	if err != nil {
+		return "", err
+	}
+	return encodeCustomData(value)
+}
+
+// GetBootstrapDataContent returns the bootstrap data from the secret in the Machine's bootstrap.dataSecretName,
+// compressed as requested by the AzureMachine.
+func (m *MachineScope) GetBootstrapDataContent(ctx context.Context) ([]byte, error) {
 	if m.Machine.Spec.Bootstrap.DataSecretName == nil {
-		return "", errors.New("error retrieving bootstrap data: linked Machine's bootstrap.dataSecretName is nil")
+		return nil, errors.New("error retrieving bootstrap data: linked Machine's bootstrap.dataSecretName is nil")
 	}
 	secret := &corev1.Secret{}
 	key := types.NamespacedName{Namespace: m.Namespace(), Name: *m.Machine.Spec.Bootstrap.DataSecretName}
 	if err := m.client.Get(ctx, key, secret); err != nil {
-		return "", errors.Wrapf(err, "failed to retrieve bootstrap data secret for AzureMachine %s/%s", m.Namespace(), m.Name())
+		return nil, errors.Wrapf(err, "failed to retrieve bootstrap data secret for AzureMachine %s/%s", m.Namespace(), m.Name())
 	}
 
 	value, ok := secret.Data["value"]
 	if !ok {
-		return "", errors.New("error retrieving bootstrap data: secret value key is missing")
+		return nil, errors.New("error retrieving bootstrap data: secret value key is missing")
+	}
+	return compressBootstrapData(value, m.AzureMachine.Spec.BootstrapData)
+}
+
+// SetBootstrapDataURL sets the URL of the blob holding the bootstrap data, passed to the VM in its custom data.
+func (m *MachineScope) SetBootstrapDataURL(url string) {
+	m.bootstrapDataURL = url
+}
+
+// BootstrapDataDeleted returns true if the blob holding the bootstrap data of the machine has been deleted.
+func (m *MachineScope) BootstrapDataDeleted() bool {
+	m.annotationsMu.Lock()
+	defer m.annotationsMu.Unlock()
+
+	_, ok := m.AzureMachine.Annotations[infrav1.BootstrapDataDeletedAnnotation]
+	return ok
+}
+
+// SetBootstrapDataDeleted records that the blob holding the bootstrap data of the machine has been deleted.
+func (m *MachineScope) SetBootstrapDataDeleted() {
+	m.SetAnnotation(infrav1.BootstrapDataDeletedAnnotation, "true")
+}
+
+// compressBootstrapData compresses the bootstrap data as requested by its options.
+func compressBootstrapData(data []byte, options *infrav1.BootstrapData) ([]byte, error) {
+	if options == nil || options.Compression != infrav1.BootstrapDataCompressionGzip {
+		return data, nil
+	}
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compress bootstrap data")
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, errors.Wrap(err, "failed to compress bootstrap data")
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to compress bootstrap data")
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeCustomData base64 encodes the custom data of a VM. Custom data over the size limit of Azure is a terminal
+// error, as it can only be fixed by changing the bootstrap data or how it is delivered.
+func encodeCustomData(data []byte) (string, error) {
+	customData := base64.StdEncoding.EncodeToString(data)
+	if len(customData) > azure.MaxCustomDataLength {
+		return "", azure.WithTerminalError(azure.BootstrapDataTooLargeError{Length: len(customData)})
+	}
+	return customData, nil
+}
+
+// GetVMExtensionProtectedSettings returns the JSON object of the protected settings of a VM extension from its
+// secret, or an empty string when the extension has no protected settings.
+func (m *MachineScope) GetVMExtensionProtectedSettings(ctx context.Context, extension azure.VMExtensionSpec) (string, error) {
+	return getVMExtensionProtectedSettings(ctx, m.client, m.Namespace(), extension)
+}
+
+// getVMExtensionProtectedSettings returns the protected settings of a VM extension from the secret in namespace.
+func getVMExtensionProtectedSettings(ctx context.Context, c client.Client, namespace string, extension azure.VMExtensionSpec) (string, error) {
+	if extension.ProtectedSettingsSecretName == "" {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: namespace, Name: extension.ProtectedSettingsSecretName}
+	if err := c.Get(ctx, key, secret); err != nil {
+		return "", errors.Wrapf(err, "failed to retrieve protected settings secret %s/%s of VM extension %s", namespace, extension.ProtectedSettingsSecretName, extension.Name)
+	}
+
+	value, ok := secret.Data[extension.ProtectedSettingsSecretKey]
+	if !ok {
+		return "", errors.Errorf("error retrieving protected settings of VM extension %s: secret key %s is missing", extension.Name, extension.ProtectedSettingsSecretKey)
 	}
-	return base64.StdEncoding.EncodeToString(value), nil
+	return string(value), nil
 }
 
 // GetVMImage returns the image from the machine configuration, or a default one.
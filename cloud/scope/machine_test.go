@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+)
+
+func TestEncodeCustomData(t *testing.T) {
+	g := NewWithT(t)
+
+	// 3 bytes are base64 encoded in 4 characters
+	customData, err := encodeCustomData([]byte(strings.Repeat("a", azure.MaxCustomDataLength/4*3)))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(customData).To(HaveLen(azure.MaxCustomDataLength))
+
+	_, err = encodeCustomData([]byte(strings.Repeat("a", azure.MaxCustomDataLength/4*3+1)))
+	g.Expect(err).To(HaveOccurred())
+	var reconcileError azure.ReconcileError
+	g.Expect(errors.As(err, &reconcileError)).To(BeTrue())
+	g.Expect(reconcileError.IsTerminal()).To(BeTrue())
+	g.Expect(errors.As(err, &azure.BootstrapDataTooLargeError{})).To(BeTrue())
+}
+
+func TestCompressBootstrapData(t *testing.T) {
+	g := NewWithT(t)
+
+	data := []byte(strings.Repeat("#cloud-config\n", 1000))
+
+	uncompressed, err := compressBootstrapData(data, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(uncompressed).To(Equal(data))
+
+	uncompressed, err = compressBootstrapData(data, &infrav1.BootstrapData{Compression: infrav1.BootstrapDataCompressionNone})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(uncompressed).To(Equal(data))
+
+	compressed, err := compressBootstrapData(data, &infrav1.BootstrapData{Compression: infrav1.BootstrapDataCompressionGzip})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(len(compressed)).To(BeNumerically("<", len(data)))
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	g.Expect(err).NotTo(HaveOccurred())
+	decompressed, err := ioutil.ReadAll(r)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(decompressed).To(Equal(data))
+}
+
+func TestAnnotationJSONConcurrentAccess(t *testing.T) {
+	g := NewWithT(t)
+
+	m := &MachineScope{AzureMachine: &infrav1.AzureMachine{ObjectMeta: metav1.ObjectMeta{Name: "my-machine"}}}
+
+	// The tags and vmextensions services update their own annotation concurrently.
+	var wg sync.WaitGroup
+	for _, annotation := range []string{"tags", "extensions"} {
+		annotation := annotation
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				content, err := m.AnnotationJSON(annotation)
+				g.Expect(err).NotTo(HaveOccurred())
+				content["count"] = i
+				g.Expect(m.UpdateAnnotationJSON(annotation, content)).To(Succeed())
+			}
+		}()
+	}
+	wg.Wait()
+
+	g.Expect(m.AzureMachine.Annotations).To(HaveLen(2))
+}
+
+func TestBootstrapDataDeleted(t *testing.T) {
+	g := NewWithT(t)
+
+	m := &MachineScope{AzureMachine: &infrav1.AzureMachine{ObjectMeta: metav1.ObjectMeta{Name: "my-machine"}}}
+	g.Expect(m.BootstrapDataDeleted()).To(BeFalse())
+
+	m.SetBootstrapDataDeleted()
+	g.Expect(m.BootstrapDataDeleted()).To(BeTrue())
+	g.Expect(m.AzureMachine.Annotations).To(HaveKeyWithValue(infrav1.BootstrapDataDeletedAnnotation, "true"))
+}
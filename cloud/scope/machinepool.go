@@ -18,7 +18,7 @@ package scope
 
 import (
 	"context"
-	"encoding/base64"
+	"encoding/json"
 	"fmt"
 
 	"github.com/Azure/go-autorest/autorest/to"
@@ -103,25 +103,91 @@ func NewMachinePoolScope(params MachinePoolScopeParams) (*MachinePoolScope, erro
 // ScaleSetSpec returns the scale set spec.
 func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
 	return azure.ScaleSetSpec{
-		Name:                    m.Name(),
-		Size:                    m.AzureMachinePool.Spec.Template.VMSize,
-		Capacity:                int64(to.Int32(m.MachinePool.Spec.Replicas)),
-		SSHKeyData:              m.AzureMachinePool.Spec.Template.SSHPublicKey,
-		OSDisk:                  m.AzureMachinePool.Spec.Template.OSDisk,
-		DataDisks:               m.AzureMachinePool.Spec.Template.DataDisks,
-		SubnetName:              m.NodeSubnet().Name,
-		VNetName:                m.Vnet().Name,
-		VNetResourceGroup:       m.Vnet().ResourceGroup,
-		PublicLBName:            m.OutboundLBName(infrav1.Node),
-		PublicLBAddressPoolName: azure.GenerateOutboundBackendAddressPoolName(m.OutboundLBName(infrav1.Node)),
-		AcceleratedNetworking:   m.AzureMachinePool.Spec.Template.AcceleratedNetworking,
-		Identity:                m.AzureMachinePool.Spec.Identity,
-		UserAssignedIdentities:  m.AzureMachinePool.Spec.UserAssignedIdentities,
-		SecurityProfile:         m.AzureMachinePool.Spec.Template.SecurityProfile,
-		SpotVMOptions:           m.AzureMachinePool.Spec.Template.SpotVMOptions,
+		Name:                        m.Name(),
+		Size:                        m.AzureMachinePool.Spec.Template.VMSize,
+		Capacity:                    int64(to.Int32(m.MachinePool.Spec.Replicas)),
+		SSHKeyData:                  m.AzureMachinePool.Spec.Template.SSHPublicKey,
+		OSDisk:                      m.AzureMachinePool.Spec.Template.OSDisk,
+		DataDisks:                   m.AzureMachinePool.Spec.Template.DataDisks,
+		SubnetName:                  m.NodeSubnet().Name,
+		VNetName:                    m.Vnet().Name,
+		VNetResourceGroup:           m.Vnet().ResourceGroup,
+		PublicLBName:                m.OutboundLBName(infrav1.Node),
+		PublicLBAddressPoolName:     azure.GenerateOutboundBackendAddressPoolName(m.OutboundLBName(infrav1.Node)),
+		AdditionalLBAddressPools:    lbBackendPoolSpecs(m.AzureMachinePool.Spec.Template.LoadBalancerBackendPools),
+		AcceleratedNetworking:       m.AzureMachinePool.Spec.Template.AcceleratedNetworking,
+		Identity:                    m.AzureMachinePool.Spec.Identity,
+		UserAssignedIdentities:      m.AzureMachinePool.Spec.UserAssignedIdentities,
+		SecurityProfile:             m.AzureMachinePool.Spec.Template.SecurityProfile,
+		SpotVMOptions:               m.AzureMachinePool.Spec.Template.SpotVMOptions,
+		ProximityPlacementGroupName: m.ProximityPlacementGroupName(),
 	}
 }
 
+// ProximityPlacementGroupName returns the name of the proximity placement group of the scale set: the one of the
+// machine pool if it has one, otherwise the one of the cluster, if any.
+func (m *MachinePoolScope) ProximityPlacementGroupName() string {
+	if m.AzureMachinePool.Spec.ProximityPlacementGroup == nil {
+		return m.ClusterScoper.ProximityPlacementGroupName()
+	}
+	if m.AzureMachinePool.Spec.ProximityPlacementGroup.Name != "" {
+		return m.AzureMachinePool.Spec.ProximityPlacementGroup.Name
+	}
+	return azure.GenerateProximityPlacementGroupName(m.Name())
+}
+
+// ProximityPlacementGroupSpecs returns the spec of the proximity placement group owned by the machine pool, if any.
+// The proximity placement group of the cluster is managed with the cluster.
+func (m *MachinePoolScope) ProximityPlacementGroupSpecs() []azure.ProximityPlacementGroupSpec {
+	if m.AzureMachinePool.Spec.ProximityPlacementGroup == nil {
+		return nil
+	}
+	return []azure.ProximityPlacementGroupSpec{{Name: m.ProximityPlacementGroupName()}}
+}
+
+// VMExtensionsSpecs returns the VM extensions of the scale set, along with the Application Health extension feeding
+// the automatic repairs when the machine pool has an application health probe.
+func (m *MachinePoolScope) VMExtensionsSpecs() []azure.VMExtensionsSpec {
+	spec := azure.VMExtensionsSpec{
+		MachineName:  m.Name(),
+		ResourceType: azure.VirtualMachineScaleSet,
+		Extensions:   vmExtensionSpecs(m.AzureMachinePool.Spec.VMExtensions),
+		Annotation:   infrav1.VMExtensionsLastAppliedAnnotation,
+	}
+	if health := m.AzureMachinePool.Spec.ApplicationHealth; health != nil {
+		spec.Extensions = append(spec.Extensions, applicationHealthExtensionSpec(health))
+		spec.AutomaticRepairsGracePeriod = health.AutomaticRepairsGracePeriod
+	}
+	return []azure.VMExtensionsSpec{spec}
+}
+
+// applicationHealthExtensionSpec returns the spec of the Application Health extension probing the application.
+// See https://docs.microsoft.com/en-us/azure/virtual-machine-scale-sets/virtual-machine-scale-sets-health-extension
+func applicationHealthExtensionSpec(health *infrav1exp.ApplicationHealth) azure.VMExtensionSpec {
+	settings, _ := json.Marshal(struct {
+		Protocol    string `json:"protocol"`
+		Port        int32  `json:"port"`
+		RequestPath string `json:"requestPath,omitempty"`
+	}{
+		Protocol:    health.Protocol,
+		Port:        health.Port,
+		RequestPath: health.RequestPath,
+	})
+	return azure.VMExtensionSpec{
+		Name:      infrav1exp.ApplicationHealthExtensionName,
+		Publisher: azure.ApplicationHealthExtensionPublisher,
+		Type:      infrav1exp.ApplicationHealthExtensionName,
+		Version:   azure.ApplicationHealthExtensionVersion,
+		Settings:  string(settings),
+	}
+}
+
+// GetVMExtensionProtectedSettings returns the JSON object of the protected settings of a VM extension from its
+// secret, or an empty string when the extension has no protected settings.
+func (m *MachinePoolScope) GetVMExtensionProtectedSettings(ctx context.Context, extension azure.VMExtensionSpec) (string, error) {
+	return getVMExtensionProtectedSettings(ctx, m.client, m.AzureMachinePool.Namespace, extension)
+}
+
 // Name returns the Azure Machine Pool Name.
 func (m *MachinePoolScope) Name() string {
 	return m.AzureMachinePool.Name
@@ -217,6 +283,17 @@ func (m *MachinePoolScope) SetProvisioningState(v infrav1.VMState) {
 	}
 }
 
+// GetLongRunningOperationState returns the state of the AzureMachinePool's in-progress Azure long-running operation, if any.
+func (m *MachinePoolScope) GetLongRunningOperationState() *infrav1.Future {
+	return m.AzureMachinePool.Status.LongRunningOperationState
+}
+
+// SetLongRunningOperationState saves the state of an Azure long-running operation so it can be resumed on the next reconcile.
+// A nil future clears it.
+func (m *MachinePoolScope) SetLongRunningOperationState(future *infrav1.Future) {
+	m.AzureMachinePool.Status.LongRunningOperationState = future
+}
+
 // SetReady sets the AzureMachinePool Ready Status to true.
 func (m *MachinePoolScope) SetReady() {
 	m.AzureMachinePool.Status.Ready = true
@@ -259,6 +336,30 @@ func (m *MachinePoolScope) SetAnnotation(key, value string) {
 	m.AzureMachinePool.Annotations[key] = value
 }
 
+// AnnotationJSON returns a map[string]interface from a JSON annotation.
+func (m *MachinePoolScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	jsonAnnotation := m.AzureMachinePool.GetAnnotations()[annotation]
+	if len(jsonAnnotation) == 0 {
+		return out, nil
+	}
+	err := json.Unmarshal([]byte(jsonAnnotation), &out)
+	if err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// UpdateAnnotationJSON updates the `annotation` with `content`, marshalled into a JSON string.
+func (m *MachinePoolScope) UpdateAnnotationJSON(annotation string, content map[string]interface{}) error {
+	b, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+	m.SetAnnotation(annotation, string(b))
+	return nil
+}
+
 // PatchObject persists the machine spec and status.
 func (m *MachinePoolScope) PatchObject(ctx context.Context) error {
 	return m.patchHelper.Patch(ctx, m.AzureMachinePool)
@@ -284,7 +385,8 @@ func getAzureMachineTemplate(ctx context.Context, c client.Client, name, namespa
 	return m, nil
 }
 
-// GetBootstrapData returns the bootstrap data from the secret in the Machine's bootstrap.dataSecretName.
+// GetBootstrapData returns the bootstrap data from the secret in the Machine's bootstrap.dataSecretName, compressed as
+// requested by the AzureMachinePool and base64 encoded.
 func (m *MachinePoolScope) GetBootstrapData(ctx context.Context) (string, error) {
 	dataSecretName := m.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName
 	if dataSecretName == nil {
@@ -300,7 +402,11 @@ func (m *MachinePoolScope) GetBootstrapData(ctx context.Context) (string, error)
 	if !ok {
 		return "", errors.New("error retrieving bootstrap data: secret value key is missing")
 	}
-	return base64.StdEncoding.EncodeToString(value), nil
+	value, err := compressBootstrapData(value, m.AzureMachinePool.Spec.BootstrapData)
+	if err != nil {
+		return "", err
+	}
+	return encodeCustomData(value)
 }
 
 // GetVMImage picks an image from the machine configuration, or uses a default one.
@@ -316,13 +422,7 @@ func (m *MachinePoolScope) GetVMImage() (*infrav1.Image, error) {
 // RoleAssignmentSpecs returns the role assignment specs.
 func (m *MachinePoolScope) RoleAssignmentSpecs() []azure.RoleAssignmentSpec {
 	if m.AzureMachinePool.Spec.Identity == infrav1.VMIdentitySystemAssigned {
-		return []azure.RoleAssignmentSpec{
-			{
-				MachineName:  m.Name(),
-				Name:         m.AzureMachinePool.Spec.RoleAssignmentName,
-				ResourceType: azure.VirtualMachineScaleSet,
-			},
-		}
+		return roleAssignmentSpecs(m.ClusterScoper, m.Name(), azure.VirtualMachineScaleSet, m.AzureMachinePool.Spec.RoleAssignmentName, m.AzureMachinePool.Spec.RoleAssignments)
 	}
 	return []azure.RoleAssignmentSpec{}
 }
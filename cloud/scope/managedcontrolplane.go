@@ -237,3 +237,13 @@ func (s *ManagedControlPlaneScope) OutboundLBName(_ string) string {
 func (s *ManagedControlPlaneScope) OutboundPoolName(_ string) string {
 	return "aksOutboundBackendPool" // hard-coded in aks
 }
+
+// AvailabilitySetEnabled is always false for a managed control plane.
+func (s *ManagedControlPlaneScope) AvailabilitySetEnabled() bool {
+	return false // not applicable for a managed control plane
+}
+
+// ProximityPlacementGroupName is always empty for a managed control plane.
+func (s *ManagedControlPlaneScope) ProximityPlacementGroupName() string {
+	return "" // not applicable for a managed control plane
+}
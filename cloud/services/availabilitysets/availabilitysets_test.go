@@ -0,0 +1,269 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package availabilitysets
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/klog/klogr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/availabilitysets/mock_availabilitysets"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/resourceskus"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+func TestReconcileAvailabilitySets(t *testing.T) {
+	testcases := []struct {
+		name          string
+		skus          []compute.ResourceSku
+		expectedError string
+		expect        func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_availabilitysets.MockClientMockRecorder)
+	}{
+		{
+			name:          "create an availability set with the maximum number of fault domains",
+			skus:          newAvailabilitySetSKUs("3"),
+			expectedError: "",
+			expect: func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_availabilitysets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AvailabilitySet().Return("my-cluster_control-plane-as", true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("test-location")
+				s.ProximityPlacementGroupName().Return("")
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-cluster_control-plane-as", gomockinternal.DiffEq(compute.AvailabilitySet{
+					Sku: &compute.Sku{Name: to.StringPtr("Aligned")},
+					AvailabilitySetProperties: &compute.AvailabilitySetProperties{
+						PlatformFaultDomainCount:  to.Int32Ptr(3),
+						PlatformUpdateDomainCount: to.Int32Ptr(5),
+					},
+					Tags: map[string]*string{
+						"Name": to.StringPtr("my-cluster_control-plane-as"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("common"),
+					},
+					Location: to.StringPtr("test-location"),
+				})).Return(nil)
+			},
+		},
+		{
+			name:          "create an availability set in the proximity placement group of the cluster",
+			skus:          newAvailabilitySetSKUs("3"),
+			expectedError: "",
+			expect: func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_availabilitysets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AvailabilitySet().Return("my-cluster_control-plane-as", true)
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("test-location")
+				s.ProximityPlacementGroupName().Return("my-cluster-ppg")
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-cluster_control-plane-as", gomockinternal.DiffEq(compute.AvailabilitySet{
+					Sku: &compute.Sku{Name: to.StringPtr("Aligned")},
+					AvailabilitySetProperties: &compute.AvailabilitySetProperties{
+						PlatformFaultDomainCount:  to.Int32Ptr(3),
+						PlatformUpdateDomainCount: to.Int32Ptr(5),
+						ProximityPlacementGroup: &compute.SubResource{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-cluster-ppg"),
+						},
+					},
+					Tags: map[string]*string{
+						"Name": to.StringPtr("my-cluster_control-plane-as"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("common"),
+					},
+					Location: to.StringPtr("test-location"),
+				})).Return(nil)
+			},
+		},
+		{
+			name:          "do nothing when the machine does not need an availability set",
+			expectedError: "",
+			expect: func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_availabilitysets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AvailabilitySet().Return("", false)
+			},
+		},
+		{
+			name:          "fail when the fault domain count capability is missing",
+			skus:          newAvailabilitySetSKUs(""),
+			expectedError: "cannot find capability MaximumPlatformFaultDomainCount for availability sets sku",
+			expect: func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_availabilitysets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AvailabilitySet().Return("my-cluster_control-plane-as", true)
+			},
+		},
+		{
+			name:          "fail to create the availability set",
+			skus:          newAvailabilitySetSKUs("2"),
+			expectedError: "failed to create availability set my-cluster_my-md-as: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_availabilitysets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AvailabilitySet().Return("my-cluster_my-md-as", true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("test-location")
+				s.ProximityPlacementGroupName().Return("")
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-cluster_my-md-as", gomock.AssignableToTypeOf(compute.AvailabilitySet{})).Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_availabilitysets.NewMockAvailabilitySetScope(mockCtrl)
+			clientMock := mock_availabilitysets.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				Client:           clientMock,
+				resourceSKUCache: resourceskus.NewStaticCache(tc.skus),
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteAvailabilitySets(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_availabilitysets.MockClientMockRecorder)
+	}{
+		{
+			name:          "delete an empty availability set",
+			expectedError: "",
+			expect: func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_availabilitysets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AvailabilitySet().Return("my-cluster_my-md-as", true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cluster_my-md-as").Return(compute.AvailabilitySet{
+					AvailabilitySetProperties: &compute.AvailabilitySetProperties{},
+				}, nil)
+				m.Delete(gomockinternal.AContext(), "my-rg", "my-cluster_my-md-as").Return(nil)
+			},
+		},
+		{
+			name:          "skip deletion of an availability set with virtual machines",
+			expectedError: "",
+			expect: func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_availabilitysets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AvailabilitySet().Return("my-cluster_my-md-as", true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cluster_my-md-as").Return(compute.AvailabilitySet{
+					AvailabilitySetProperties: &compute.AvailabilitySetProperties{
+						VirtualMachines: &[]compute.SubResource{
+							{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm")},
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:          "availability set already deleted",
+			expectedError: "",
+			expect: func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_availabilitysets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AvailabilitySet().Return("my-cluster_my-md-as", true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cluster_my-md-as").Return(compute.AvailabilitySet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		},
+		{
+			name:          "availability set deletion fails",
+			expectedError: "failed to delete availability set my-cluster_my-md-as in resource group my-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_availabilitysets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AvailabilitySet().Return("my-cluster_my-md-as", true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cluster_my-md-as").Return(compute.AvailabilitySet{}, nil)
+				m.Delete(gomockinternal.AContext(), "my-rg", "my-cluster_my-md-as").Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_availabilitysets.NewMockAvailabilitySetScope(mockCtrl)
+			clientMock := mock_availabilitysets.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				Client: clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+// newAvailabilitySetSKUs returns the availability set SKUs of a location with the given maximum number of fault domains.
+func newAvailabilitySetSKUs(maxFaultDomainCount string) []compute.ResourceSku {
+	var capabilities []compute.ResourceSkuCapabilities
+	if maxFaultDomainCount != "" {
+		capabilities = append(capabilities, compute.ResourceSkuCapabilities{
+			Name:  to.StringPtr(resourceskus.MaximumPlatformFaultDomainCount),
+			Value: to.StringPtr(maxFaultDomainCount),
+		})
+	}
+	return []compute.ResourceSku{
+		{
+			Name:         to.StringPtr("Aligned"),
+			ResourceType: to.StringPtr(string(resourceskus.AvailabilitySets)),
+			Capabilities: &capabilities,
+		},
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapdata
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/storageaccounts"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	// uploadSASLifetime is how long the SAS used to upload the bootstrap data is valid.
+	uploadSASLifetime = 15 * time.Minute
+	// readSASLifetime is how long the VM can fetch its bootstrap data once the blob is uploaded.
+	readSASLifetime = time.Hour
+	// deleteSASLifetime is how long the SAS used to delete the bootstrap data is valid.
+	deleteSASLifetime = 15 * time.Minute
+)
+
+// BootstrapDataScope defines the scope interface for a bootstrap data service.
+type BootstrapDataScope interface {
+	logr.Logger
+	azure.ClusterDescriber
+	BootstrapDataSpecs() []azure.BootstrapDataSpec
+	GetBootstrapDataContent(context.Context) ([]byte, error)
+	SetBootstrapDataURL(string)
+	BootstrapDataDeleted() bool
+	SetBootstrapDataDeleted()
+	ProviderID() string
+	VMState() infrav1.VMState
+	GetLongRunningOperationState() *infrav1.Future
+}
+
+// Service provides operations on the blobs holding the bootstrap data of VMs.
+type Service struct {
+	Scope BootstrapDataScope
+	client
+	storageAccountsClient storageaccounts.Client
+}
+
+// New creates a new bootstrap data service.
+func New(scope BootstrapDataScope) *Service {
+	return &Service{
+		Scope:                 scope,
+		client:                newClient(),
+		storageAccountsClient: storageaccounts.NewClient(scope),
+	}
+}
+
+// Reconcile uploads the bootstrap data of a VM which is not created yet to its blob, and passes a short-lived read-only
+// URL of the blob to the scope. The blob holds secrets such as join tokens, so it is deleted once the VM is provisioned.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, span := tele.Tracer().Start(ctx, "bootstrapdata.Service.Reconcile")
+	defer span.End()
+
+	blobSpecs := s.Scope.BootstrapDataSpecs()
+	switch {
+	case len(blobSpecs) == 0:
+		return nil
+	case s.Scope.GetLongRunningOperationState() != nil:
+		// the VM is being created, with the URL of the blob uploaded before
+		return nil
+	case s.Scope.ProviderID() != "":
+		// the VM already exists, and fetched its bootstrap data on its first boot once it is provisioned
+		if s.Scope.VMState() != infrav1.VMStateSucceeded || s.Scope.BootstrapDataDeleted() {
+			return nil
+		}
+		for _, blobSpec := range blobSpecs {
+			if err := s.deleteBlob(ctx, blobSpec); err != nil {
+				return err
+			}
+		}
+		s.Scope.SetBootstrapDataDeleted()
+		return nil
+	}
+
+	for _, blobSpec := range blobSpecs {
+		data, err := s.Scope.GetBootstrapDataContent(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to retrieve bootstrap data")
+		}
+
+		uploadURL, err := s.blobURL(ctx, blobSpec, storage.Permissions("cw"), uploadSASLifetime)
+		if err != nil {
+			return err
+		}
+		s.Scope.V(2).Info("uploading bootstrap data", "storage account", blobSpec.StorageAccountName, "blob", blobSpec.BlobName)
+		if err := s.client.PutBlob(ctx, uploadURL, data); err != nil {
+			return errors.Wrapf(err, "failed to upload bootstrap data to blob %s of storage account %s", blobSpec.BlobName, blobSpec.StorageAccountName)
+		}
+
+		readURL, err := s.blobURL(ctx, blobSpec, storage.R, readSASLifetime)
+		if err != nil {
+			return err
+		}
+		s.Scope.SetBootstrapDataURL(readURL)
+		s.Scope.V(2).Info("successfully uploaded bootstrap data", "storage account", blobSpec.StorageAccountName, "blob", blobSpec.BlobName)
+	}
+
+	return nil
+}
+
+// Delete deletes the blobs holding the bootstrap data of a VM.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, span := tele.Tracer().Start(ctx, "bootstrapdata.Service.Delete")
+	defer span.End()
+
+	for _, blobSpec := range s.Scope.BootstrapDataSpecs() {
+		if err := s.deleteBlob(ctx, blobSpec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteBlob deletes a blob holding bootstrap data, if it still exists.
+func (s *Service) deleteBlob(ctx context.Context, blobSpec azure.BootstrapDataSpec) error {
+	deleteURL, err := s.blobURL(ctx, blobSpec, storage.D, deleteSASLifetime)
+	if err != nil && azure.ResourceNotFound(err) {
+		// the storage account is already deleted
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s.Scope.V(2).Info("deleting bootstrap data", "storage account", blobSpec.StorageAccountName, "blob", blobSpec.BlobName)
+	err = s.client.DeleteBlob(ctx, deleteURL)
+	if err != nil && azure.ResourceNotFound(err) {
+		// already deleted
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete blob %s of storage account %s", blobSpec.BlobName, blobSpec.StorageAccountName)
+	}
+	s.Scope.V(2).Info("successfully deleted bootstrap data", "storage account", blobSpec.StorageAccountName, "blob", blobSpec.BlobName)
+	return nil
+}
+
+// blobURL returns the URL of a bootstrap data blob, carrying a SAS token which grants the given permissions on the blob
+// until it expires.
+func (s *Service) blobURL(ctx context.Context, blobSpec azure.BootstrapDataSpec, permissions storage.Permissions, lifetime time.Duration) (string, error) {
+	account, err := s.storageAccountsClient.Get(ctx, s.Scope.ResourceGroup(), blobSpec.StorageAccountName)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get storage account %s", blobSpec.StorageAccountName)
+	}
+	if account.AccountProperties == nil || account.PrimaryEndpoints == nil || account.PrimaryEndpoints.Blob == nil {
+		return "", errors.Errorf("storage account %s has no blob endpoint", blobSpec.StorageAccountName)
+	}
+
+	sas, err := s.storageAccountsClient.ListServiceSAS(ctx, s.Scope.ResourceGroup(), blobSpec.StorageAccountName, storage.ServiceSasParameters{
+		CanonicalizedResource:  to.StringPtr(fmt.Sprintf("/blob/%s/%s/%s", blobSpec.StorageAccountName, blobSpec.ContainerName, blobSpec.BlobName)),
+		Resource:               storage.SignedResourceB,
+		Permissions:            permissions,
+		Protocols:              storage.HTTPS,
+		SharedAccessExpiryTime: &date.Time{Time: time.Now().UTC().Add(lifetime)},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get a SAS token for blob %s of storage account %s", blobSpec.BlobName, blobSpec.StorageAccountName)
+	}
+
+	return fmt.Sprintf("%s/%s/%s?%s", strings.TrimSuffix(to.String(account.PrimaryEndpoints.Blob), "/"), blobSpec.ContainerName, blobSpec.BlobName, sas), nil
+}
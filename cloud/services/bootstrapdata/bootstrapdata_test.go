@@ -0,0 +1,314 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapdata
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/klog/klogr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/bootstrapdata/mock_bootstrapdata"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/storageaccounts/mock_storageaccounts"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	blobSpec = azure.BootstrapDataSpec{
+		StorageAccountName: "capzboot0123456789abcdef",
+		ContainerName:      "bootstrap",
+		BlobName:           "my-vm",
+	}
+	account = storage.Account{
+		AccountProperties: &storage.AccountProperties{
+			PrimaryEndpoints: &storage.Endpoints{
+				Blob: to.StringPtr("https://capzboot0123456789abcdef.blob.core.windows.net/"),
+			},
+		},
+	}
+	blobURL = "https://capzboot0123456789abcdef.blob.core.windows.net/bootstrap/my-vm"
+)
+
+// sasParameters matches the parameters of a service SAS for the bootstrap data blob with the given permissions.
+func sasParameters(permissions storage.Permissions) gomock.Matcher {
+	return sasMatcher{permissions: permissions}
+}
+
+type sasMatcher struct {
+	permissions storage.Permissions
+}
+
+func (m sasMatcher) Matches(x interface{}) bool {
+	p, ok := x.(storage.ServiceSasParameters)
+	return ok && p.Permissions == m.permissions &&
+		to.String(p.CanonicalizedResource) == "/blob/capzboot0123456789abcdef/bootstrap/my-vm" &&
+		p.Resource == storage.SignedResourceB && p.Protocols == storage.HTTPS && p.SharedAccessExpiryTime != nil
+}
+
+func (m sasMatcher) String() string {
+	return "is a service SAS for the bootstrap data blob with permissions " + string(m.permissions)
+}
+
+func TestReconcileBootstrapData(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder)
+	}{
+		{
+			name:          "upload the bootstrap data of a new VM",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.BootstrapDataSpecs().Return([]azure.BootstrapDataSpec{blobSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.GetLongRunningOperationState().Return(nil)
+				s.ProviderID().Return("")
+				s.GetBootstrapDataContent(gomockinternal.AContext()).Return([]byte("#cloud-config"), nil)
+				a.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Times(2).Return(account, nil)
+				a.ListServiceSAS(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef", sasParameters("cw")).Return("sig=write", nil)
+				m.PutBlob(gomockinternal.AContext(), blobURL+"?sig=write", []byte("#cloud-config"))
+				a.ListServiceSAS(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef", sasParameters(storage.R)).Return("sig=read", nil)
+				s.SetBootstrapDataURL(blobURL + "?sig=read")
+			},
+		},
+		{
+			name:          "skip the upload while the VM is being created",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.BootstrapDataSpecs().Return([]azure.BootstrapDataSpec{blobSpec})
+				s.GetLongRunningOperationState().Return(&infrav1.Future{Type: infrav1.PutFuture, Name: "my-vm"})
+			},
+		},
+		{
+			name:          "keep the bootstrap data until the VM is provisioned",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.BootstrapDataSpecs().Return([]azure.BootstrapDataSpec{blobSpec})
+				s.GetLongRunningOperationState().Return(nil)
+				s.ProviderID().Return("azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.VMState().Return(infrav1.VMStateCreating)
+			},
+		},
+		{
+			name:          "delete the bootstrap data once the VM is provisioned",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.BootstrapDataSpecs().Return([]azure.BootstrapDataSpec{blobSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.GetLongRunningOperationState().Return(nil)
+				s.ProviderID().Return("azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.VMState().Return(infrav1.VMStateSucceeded)
+				s.BootstrapDataDeleted().Return(false)
+				a.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(account, nil)
+				a.ListServiceSAS(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef", sasParameters(storage.D)).Return("sig=delete", nil)
+				m.DeleteBlob(gomockinternal.AContext(), blobURL+"?sig=delete")
+				s.SetBootstrapDataDeleted()
+			},
+		},
+		{
+			name:          "bootstrap data already deleted after the VM is provisioned",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.BootstrapDataSpecs().Return([]azure.BootstrapDataSpec{blobSpec})
+				s.GetLongRunningOperationState().Return(nil)
+				s.ProviderID().Return("azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.VMState().Return(infrav1.VMStateSucceeded)
+				s.BootstrapDataDeleted().Return(true)
+			},
+		},
+		{
+			name:          "fail to delete the bootstrap data once the VM is provisioned",
+			expectedError: "failed to delete blob my-vm of storage account capzboot0123456789abcdef: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.BootstrapDataSpecs().Return([]azure.BootstrapDataSpec{blobSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.GetLongRunningOperationState().Return(nil)
+				s.ProviderID().Return("azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.VMState().Return(infrav1.VMStateSucceeded)
+				s.BootstrapDataDeleted().Return(false)
+				a.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(account, nil)
+				a.ListServiceSAS(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef", sasParameters(storage.D)).Return("sig=delete", nil)
+				m.DeleteBlob(gomockinternal.AContext(), blobURL+"?sig=delete").Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+		{
+			name:          "do nothing when the bootstrap data is passed in the custom data",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.BootstrapDataSpecs().Return(nil)
+			},
+		},
+		{
+			name:          "fail to upload the bootstrap data",
+			expectedError: "failed to upload bootstrap data to blob my-vm of storage account capzboot0123456789abcdef: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.BootstrapDataSpecs().Return([]azure.BootstrapDataSpec{blobSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.GetLongRunningOperationState().Return(nil)
+				s.ProviderID().Return("")
+				s.GetBootstrapDataContent(gomockinternal.AContext()).Return([]byte("#cloud-config"), nil)
+				a.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(account, nil)
+				a.ListServiceSAS(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef", sasParameters("cw")).Return("sig=write", nil)
+				m.PutBlob(gomockinternal.AContext(), blobURL+"?sig=write", []byte("#cloud-config")).Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+		{
+			name:          "fail when the storage account has no blob endpoint",
+			expectedError: "storage account capzboot0123456789abcdef has no blob endpoint",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.BootstrapDataSpecs().Return([]azure.BootstrapDataSpec{blobSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.GetLongRunningOperationState().Return(nil)
+				s.ProviderID().Return("")
+				s.GetBootstrapDataContent(gomockinternal.AContext()).Return([]byte("#cloud-config"), nil)
+				a.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(storage.Account{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_bootstrapdata.NewMockBootstrapDataScope(mockCtrl)
+			clientMock := mock_bootstrapdata.NewMockclient(mockCtrl)
+			accountsMock := mock_storageaccounts.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), accountsMock.EXPECT())
+
+			s := &Service{
+				Scope:                 scopeMock,
+				client:                clientMock,
+				storageAccountsClient: accountsMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteBootstrapData(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder)
+	}{
+		{
+			name:          "delete the bootstrap data",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.BootstrapDataSpecs().Return([]azure.BootstrapDataSpec{blobSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				a.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(account, nil)
+				a.ListServiceSAS(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef", sasParameters(storage.D)).Return("sig=delete", nil)
+				m.DeleteBlob(gomockinternal.AContext(), blobURL+"?sig=delete")
+			},
+		},
+		{
+			name:          "bootstrap data already deleted",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.BootstrapDataSpecs().Return([]azure.BootstrapDataSpec{blobSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				a.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(account, nil)
+				a.ListServiceSAS(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef", sasParameters(storage.D)).Return("sig=delete", nil)
+				m.DeleteBlob(gomockinternal.AContext(), blobURL+"?sig=delete").Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"))
+			},
+		},
+		{
+			name:          "storage account already deleted",
+			expectedError: "",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.BootstrapDataSpecs().Return([]azure.BootstrapDataSpec{blobSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				a.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(storage.Account{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"))
+			},
+		},
+		{
+			name:          "fail to delete the bootstrap data",
+			expectedError: "failed to delete blob my-vm of storage account capzboot0123456789abcdef: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_bootstrapdata.MockBootstrapDataScopeMockRecorder, m *mock_bootstrapdata.MockclientMockRecorder, a *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.BootstrapDataSpecs().Return([]azure.BootstrapDataSpec{blobSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				a.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(account, nil)
+				a.ListServiceSAS(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef", sasParameters(storage.D)).Return("sig=delete", nil)
+				m.DeleteBlob(gomockinternal.AContext(), blobURL+"?sig=delete").Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_bootstrapdata.NewMockBootstrapDataScope(mockCtrl)
+			clientMock := mock_bootstrapdata.NewMockclient(mockCtrl)
+			accountsMock := mock_storageaccounts.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), accountsMock.EXPECT())
+
+			s := &Service{
+				Scope:                 scopeMock,
+				client:                clientMock,
+				storageAccountsClient: accountsMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapdata
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client uploads and deletes blobs through URLs carrying a SAS token.
+type client interface {
+	PutBlob(context.Context, string, []byte) error
+	DeleteBlob(context.Context, string) error
+}
+
+// azureClient contains the HTTP client used to reach the blob service.
+type azureClient struct {
+	http *http.Client
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new blob client.
+func newClient() *azureClient {
+	return &azureClient{
+		http: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// PutBlob uploads data to the block blob at the given URL, replacing its content if it already exists.
+func (ac *azureClient) PutBlob(ctx context.Context, url string, data []byte) error {
+	ctx, span := tele.Tracer().Start(ctx, "bootstrapdata.AzureClient.PutBlob")
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	return ac.do(req, "PutBlob")
+}
+
+// DeleteBlob deletes the blob at the given URL.
+func (ac *azureClient) DeleteBlob(ctx context.Context, url string) error {
+	ctx, span := tele.Tracer().Start(ctx, "bootstrapdata.AzureClient.DeleteBlob")
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	return ac.do(req, "DeleteBlob")
+}
+
+// do sends a request to the blob service. Unsuccessful responses are returned as an autorest.DetailedError, so that
+// azure.ResourceNotFound can tell missing blobs apart.
+func (ac *azureClient) do(req *http.Request, method string) error {
+	resp, err := ac.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return autorest.NewErrorWithResponse("bootstrapdata.azureClient", method, resp, "unexpected response from the blob service")
+	}
+	return nil
+}
@@ -0,0 +1,411 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../bootstrapdata.go
+
+// Package mock_bootstrapdata is a generated GoMock package.
+package mock_bootstrapdata
+
+import (
+	context "context"
+	autorest "github.com/Azure/go-autorest/autorest"
+	logr "github.com/go-logr/logr"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	v1alpha3 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+)
+
+// MockBootstrapDataScope is a mock of BootstrapDataScope interface.
+type MockBootstrapDataScope struct {
+	ctrl     *gomock.Controller
+	recorder *MockBootstrapDataScopeMockRecorder
+}
+
+// MockBootstrapDataScopeMockRecorder is the mock recorder for MockBootstrapDataScope.
+type MockBootstrapDataScopeMockRecorder struct {
+	mock *MockBootstrapDataScope
+}
+
+// NewMockBootstrapDataScope creates a new mock instance.
+func NewMockBootstrapDataScope(ctrl *gomock.Controller) *MockBootstrapDataScope {
+	mock := &MockBootstrapDataScope{ctrl: ctrl}
+	mock.recorder = &MockBootstrapDataScopeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBootstrapDataScope) EXPECT() *MockBootstrapDataScopeMockRecorder {
+	return m.recorder
+}
+
+// Info mocks base method.
+func (m *MockBootstrapDataScope) Info(msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockBootstrapDataScopeMockRecorder) Info(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockBootstrapDataScope)(nil).Info), varargs...)
+}
+
+// Enabled mocks base method.
+func (m *MockBootstrapDataScope) Enabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockBootstrapDataScopeMockRecorder) Enabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockBootstrapDataScope)(nil).Enabled))
+}
+
+// Error mocks base method.
+func (m *MockBootstrapDataScope) Error(err error, msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{err, msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockBootstrapDataScopeMockRecorder) Error(err, msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{err, msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockBootstrapDataScope)(nil).Error), varargs...)
+}
+
+// V mocks base method.
+func (m *MockBootstrapDataScope) V(level int) logr.InfoLogger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V", level)
+	ret0, _ := ret[0].(logr.InfoLogger)
+	return ret0
+}
+
+// V indicates an expected call of V.
+func (mr *MockBootstrapDataScopeMockRecorder) V(level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V", reflect.TypeOf((*MockBootstrapDataScope)(nil).V), level)
+}
+
+// WithValues mocks base method.
+func (m *MockBootstrapDataScope) WithValues(keysAndValues ...interface{}) logr.Logger {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WithValues", varargs...)
+	ret0, _ := ret[0].(logr.Logger)
+	return ret0
+}
+
+// WithValues indicates an expected call of WithValues.
+func (mr *MockBootstrapDataScopeMockRecorder) WithValues(keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithValues", reflect.TypeOf((*MockBootstrapDataScope)(nil).WithValues), keysAndValues...)
+}
+
+// WithName mocks base method.
+func (m *MockBootstrapDataScope) WithName(name string) logr.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithName", name)
+	ret0, _ := ret[0].(logr.Logger)
+	return ret0
+}
+
+// WithName indicates an expected call of WithName.
+func (mr *MockBootstrapDataScopeMockRecorder) WithName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithName", reflect.TypeOf((*MockBootstrapDataScope)(nil).WithName), name)
+}
+
+// SubscriptionID mocks base method.
+func (m *MockBootstrapDataScope) SubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SubscriptionID indicates an expected call of SubscriptionID.
+func (mr *MockBootstrapDataScopeMockRecorder) SubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionID", reflect.TypeOf((*MockBootstrapDataScope)(nil).SubscriptionID))
+}
+
+// ClientID mocks base method.
+func (m *MockBootstrapDataScope) ClientID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientID indicates an expected call of ClientID.
+func (mr *MockBootstrapDataScopeMockRecorder) ClientID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientID", reflect.TypeOf((*MockBootstrapDataScope)(nil).ClientID))
+}
+
+// ClientSecret mocks base method.
+func (m *MockBootstrapDataScope) ClientSecret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientSecret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientSecret indicates an expected call of ClientSecret.
+func (mr *MockBootstrapDataScopeMockRecorder) ClientSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientSecret", reflect.TypeOf((*MockBootstrapDataScope)(nil).ClientSecret))
+}
+
+// CloudEnvironment mocks base method.
+func (m *MockBootstrapDataScope) CloudEnvironment() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudEnvironment")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CloudEnvironment indicates an expected call of CloudEnvironment.
+func (mr *MockBootstrapDataScopeMockRecorder) CloudEnvironment() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockBootstrapDataScope)(nil).CloudEnvironment))
+}
+
+// TenantID mocks base method.
+func (m *MockBootstrapDataScope) TenantID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TenantID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TenantID indicates an expected call of TenantID.
+func (mr *MockBootstrapDataScopeMockRecorder) TenantID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockBootstrapDataScope)(nil).TenantID))
+}
+
+// BaseURI mocks base method.
+func (m *MockBootstrapDataScope) BaseURI() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseURI")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseURI indicates an expected call of BaseURI.
+func (mr *MockBootstrapDataScopeMockRecorder) BaseURI() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockBootstrapDataScope)(nil).BaseURI))
+}
+
+// Authorizer mocks base method.
+func (m *MockBootstrapDataScope) Authorizer() autorest.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorizer")
+	ret0, _ := ret[0].(autorest.Authorizer)
+	return ret0
+}
+
+// Authorizer indicates an expected call of Authorizer.
+func (mr *MockBootstrapDataScopeMockRecorder) Authorizer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockBootstrapDataScope)(nil).Authorizer))
+}
+
+// ResourceGroup mocks base method.
+func (m *MockBootstrapDataScope) ResourceGroup() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceGroup")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ResourceGroup indicates an expected call of ResourceGroup.
+func (mr *MockBootstrapDataScopeMockRecorder) ResourceGroup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockBootstrapDataScope)(nil).ResourceGroup))
+}
+
+// ClusterName mocks base method.
+func (m *MockBootstrapDataScope) ClusterName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClusterName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClusterName indicates an expected call of ClusterName.
+func (mr *MockBootstrapDataScopeMockRecorder) ClusterName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockBootstrapDataScope)(nil).ClusterName))
+}
+
+// Location mocks base method.
+func (m *MockBootstrapDataScope) Location() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Location")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Location indicates an expected call of Location.
+func (mr *MockBootstrapDataScopeMockRecorder) Location() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockBootstrapDataScope)(nil).Location))
+}
+
+// AdditionalTags mocks base method.
+func (m *MockBootstrapDataScope) AdditionalTags() v1alpha3.Tags {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdditionalTags")
+	ret0, _ := ret[0].(v1alpha3.Tags)
+	return ret0
+}
+
+// AdditionalTags indicates an expected call of AdditionalTags.
+func (mr *MockBootstrapDataScopeMockRecorder) AdditionalTags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockBootstrapDataScope)(nil).AdditionalTags))
+}
+
+// BootstrapDataSpecs mocks base method.
+func (m *MockBootstrapDataScope) BootstrapDataSpecs() []azure.BootstrapDataSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapDataSpecs")
+	ret0, _ := ret[0].([]azure.BootstrapDataSpec)
+	return ret0
+}
+
+// BootstrapDataSpecs indicates an expected call of BootstrapDataSpecs.
+func (mr *MockBootstrapDataScopeMockRecorder) BootstrapDataSpecs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapDataSpecs", reflect.TypeOf((*MockBootstrapDataScope)(nil).BootstrapDataSpecs))
+}
+
+// GetBootstrapDataContent mocks base method.
+func (m *MockBootstrapDataScope) GetBootstrapDataContent(arg0 context.Context) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBootstrapDataContent", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBootstrapDataContent indicates an expected call of GetBootstrapDataContent.
+func (mr *MockBootstrapDataScopeMockRecorder) GetBootstrapDataContent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBootstrapDataContent", reflect.TypeOf((*MockBootstrapDataScope)(nil).GetBootstrapDataContent), arg0)
+}
+
+// SetBootstrapDataURL mocks base method.
+func (m *MockBootstrapDataScope) SetBootstrapDataURL(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBootstrapDataURL", arg0)
+}
+
+// SetBootstrapDataURL indicates an expected call of SetBootstrapDataURL.
+func (mr *MockBootstrapDataScopeMockRecorder) SetBootstrapDataURL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBootstrapDataURL", reflect.TypeOf((*MockBootstrapDataScope)(nil).SetBootstrapDataURL), arg0)
+}
+
+// BootstrapDataDeleted mocks base method.
+func (m *MockBootstrapDataScope) BootstrapDataDeleted() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapDataDeleted")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// BootstrapDataDeleted indicates an expected call of BootstrapDataDeleted.
+func (mr *MockBootstrapDataScopeMockRecorder) BootstrapDataDeleted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapDataDeleted", reflect.TypeOf((*MockBootstrapDataScope)(nil).BootstrapDataDeleted))
+}
+
+// SetBootstrapDataDeleted mocks base method.
+func (m *MockBootstrapDataScope) SetBootstrapDataDeleted() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBootstrapDataDeleted")
+}
+
+// SetBootstrapDataDeleted indicates an expected call of SetBootstrapDataDeleted.
+func (mr *MockBootstrapDataScopeMockRecorder) SetBootstrapDataDeleted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBootstrapDataDeleted", reflect.TypeOf((*MockBootstrapDataScope)(nil).SetBootstrapDataDeleted))
+}
+
+// ProviderID mocks base method.
+func (m *MockBootstrapDataScope) ProviderID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProviderID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ProviderID indicates an expected call of ProviderID.
+func (mr *MockBootstrapDataScopeMockRecorder) ProviderID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProviderID", reflect.TypeOf((*MockBootstrapDataScope)(nil).ProviderID))
+}
+
+// VMState mocks base method.
+func (m *MockBootstrapDataScope) VMState() v1alpha3.VMState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VMState")
+	ret0, _ := ret[0].(v1alpha3.VMState)
+	return ret0
+}
+
+// VMState indicates an expected call of VMState.
+func (mr *MockBootstrapDataScopeMockRecorder) VMState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VMState", reflect.TypeOf((*MockBootstrapDataScope)(nil).VMState))
+}
+
+// GetLongRunningOperationState mocks base method.
+func (m *MockBootstrapDataScope) GetLongRunningOperationState() *v1alpha3.Future {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLongRunningOperationState")
+	ret0, _ := ret[0].(*v1alpha3.Future)
+	return ret0
+}
+
+// GetLongRunningOperationState indicates an expected call of GetLongRunningOperationState.
+func (mr *MockBootstrapDataScopeMockRecorder) GetLongRunningOperationState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLongRunningOperationState", reflect.TypeOf((*MockBootstrapDataScope)(nil).GetLongRunningOperationState))
+}
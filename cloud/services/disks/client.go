@@ -27,6 +27,8 @@ import (
 
 // Client wraps go-sdk
 type client interface {
+	Get(context.Context, string, string) (compute.Disk, error)
+	Update(context.Context, string, string, compute.DiskUpdate) error
 	Delete(context.Context, string, string) error
 }
 
@@ -46,11 +48,35 @@ func newClient(auth azure.Authorizer) *azureClient {
 // newDisksClient creates a new disks client from subscription ID.
 func newDisksClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.DisksClient {
 	disksClient := compute.NewDisksClientWithBaseURI(baseURI, subscriptionID)
-	disksClient.Authorizer = authorizer
-	disksClient.AddToUserAgent(azure.UserAgent())
+	azure.SetAutoRestClientDefaults(&disksClient.Client, authorizer)
 	return disksClient
 }
 
+// Get gets information about a disk.
+func (ac *azureClient) Get(ctx context.Context, resourceGroupName, name string) (compute.Disk, error) {
+	ctx, span := tele.Tracer().Start(ctx, "disks.AzureClient.Get")
+	defer span.End()
+
+	return ac.disks.Get(ctx, resourceGroupName, name)
+}
+
+// Update updates the mutable properties of a disk.
+func (ac *azureClient) Update(ctx context.Context, resourceGroupName, name string, disk compute.DiskUpdate) error {
+	ctx, span := tele.Tracer().Start(ctx, "disks.AzureClient.Update")
+	defer span.End()
+
+	future, err := ac.disks.Update(ctx, resourceGroupName, name, disk)
+	if err != nil {
+		return err
+	}
+	err = future.WaitForCompletionRef(ctx, ac.disks.Client)
+	if err != nil {
+		return err
+	}
+	_, err = future.Result(ac.disks)
+	return err
+}
+
 // Delete removes the disk client
 func (ac *azureClient) Delete(ctx context.Context, resourceGroupName, name string) error {
 	ctx, span := tele.Tracer().Start(ctx, "disks.AzureClient.Delete")
@@ -19,10 +19,14 @@ package disks
 import (
 	"context"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -47,20 +51,89 @@ func New(scope DiskScope) *Service {
 	}
 }
 
-// Reconcile on disk is currently no-op. OS disks should only be deleted and will create with the VM automatically.
+// Reconcile tags the disks of a VM with the cluster and the machine owning them, grows the data disks whose desired
+// size is larger than their current size, and updates the performance targets of ultra data disks. Disks are created
+// and attached with the VM, and OS disks are never resized.
 func (s *Service) Reconcile(ctx context.Context) error {
-	_, span := tele.Tracer().Start(ctx, "disks.Service.Reconcile")
+	ctx, span := tele.Tracer().Start(ctx, "disks.Service.Reconcile")
 	defer span.End()
 
+	for _, diskSpec := range s.Scope.DiskSpecs() {
+		disk, err := s.client.Get(ctx, s.Scope.ResourceGroup(), diskSpec.Name)
+		if err != nil && azure.ResourceNotFound(err) {
+			// the disk will be created with the VM.
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to get disk %s in resource group %s", diskSpec.Name, s.Scope.ResourceGroup())
+		}
+		if disk.DiskProperties == nil {
+			continue
+		}
+
+		diskUpdate := getDiskUpdate(s.Scope.ClusterName(), diskSpec, disk)
+		if diskUpdate == nil {
+			continue
+		}
+
+		s.Scope.V(2).Info("updating disk", "disk", diskSpec.Name, "current size", to.Int32(disk.DiskSizeGB), "desired size", diskSpec.DiskSizeGB)
+		if err := s.client.Update(ctx, s.Scope.ResourceGroup(), diskSpec.Name, *diskUpdate); err != nil {
+			return errors.Wrapf(err, "failed to update disk %s in resource group %s", diskSpec.Name, s.Scope.ResourceGroup())
+		}
+		s.Scope.V(2).Info("successfully updated disk", "disk", diskSpec.Name)
+	}
 	return nil
 }
 
-// Delete deletes the disk associated with a VM.
+// getDiskUpdate returns the update needed to bring an existing disk to its spec, or nil if it is up to date.
+// Disks are only ever grown.
+func getDiskUpdate(clusterName string, diskSpec azure.DiskSpec, disk compute.Disk) *compute.DiskUpdate {
+	properties := compute.DiskUpdateProperties{}
+	changed := false
+	// Disks created with the VM are not tagged by Azure. Retained disks are left untagged, so that they are never
+	// collected as orphaned resources once their machine is deleted.
+	var tags map[string]*string
+	if diskSpec.DeletionPolicy != infrav1.DiskDeletionPolicyRetain {
+		existing := converters.MapToTags(disk.Tags)
+		owned := infrav1.Build(infrav1.BuildParams{
+			ClusterName: clusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			MachineName: to.StringPtr(diskSpec.MachineName),
+		})
+		if len(owned.Difference(existing)) > 0 {
+			existing.Merge(owned)
+			tags = converters.TagsToMap(existing)
+			changed = true
+		}
+	}
+	if diskSpec.DiskSizeGB > to.Int32(disk.DiskSizeGB) {
+		properties.DiskSizeGB = to.Int32Ptr(diskSpec.DiskSizeGB)
+		changed = true
+	}
+	if diskSpec.DiskIOPSReadWrite != nil && *diskSpec.DiskIOPSReadWrite != to.Int64(disk.DiskIOPSReadWrite) {
+		properties.DiskIOPSReadWrite = diskSpec.DiskIOPSReadWrite
+		changed = true
+	}
+	if diskSpec.DiskMBpsReadWrite != nil && *diskSpec.DiskMBpsReadWrite != to.Int64(disk.DiskMBpsReadWrite) {
+		properties.DiskMBpsReadWrite = diskSpec.DiskMBpsReadWrite
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return &compute.DiskUpdate{Tags: tags, DiskUpdateProperties: &properties}
+}
+
+// Delete deletes the disks associated with a VM, except for data disks that should be retained.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "disks.Service.Delete")
 	defer span.End()
 
 	for _, diskSpec := range s.Scope.DiskSpecs() {
+		if diskSpec.DeletionPolicy == infrav1.DiskDeletionPolicyRetain {
+			s.Scope.V(2).Info("retaining disk", "disk", diskSpec.Name)
+			continue
+		}
 		s.Scope.V(2).Info("deleting disk", "disk", diskSpec.Name)
 		err := s.client.Delete(ctx, s.Scope.ResourceGroup(), diskSpec.Name)
 		if err != nil && azure.ResourceNotFound(err) {
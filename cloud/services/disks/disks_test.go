@@ -21,7 +21,9 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -79,6 +81,25 @@ func TestDeleteDisk(t *testing.T) {
 				m.Delete(gomockinternal.AContext(), "my-rg", "my-disk-2").Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"))
 			},
 		},
+		{
+			name:          "retain data disk",
+			expectedError: "",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, m *mock_disks.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.DiskSpecs().Return([]azure.DiskSpec{
+					{
+						Name: "my-disk-1",
+					},
+					{
+						Name:           "my-disk-2",
+						DiskSizeGB:     128,
+						DeletionPolicy: infrav1.DiskDeletionPolicyRetain,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Delete(gomockinternal.AContext(), "my-rg", "my-disk-1")
+			},
+		},
 		{
 			name:          "error while trying to delete the disk",
 			expectedError: "failed to delete disk my-disk-1 in resource group my-rg: #: Internal Server Error: StatusCode=500",
@@ -127,6 +148,244 @@ func TestDeleteDisk(t *testing.T) {
 	}
 }
 
+func newDiskTags() map[string]*string {
+	return map[string]*string{
+		"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+		"sigs.k8s.io_cluster-api-provider-azure_machine":            to.StringPtr("my-vm"),
+	}
+}
+
+func TestReconcileDisk(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_disks.MockDiskScopeMockRecorder, m *mock_disks.MockclientMockRecorder)
+	}{
+		{
+			name:          "grow data disk",
+			expectedError: "",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, m *mock_disks.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.DiskSpecs().Return([]azure.DiskSpec{
+					{
+						Name:        "my-disk-1",
+						MachineName: "my-vm",
+					},
+					{
+						Name:        "my-disk-2",
+						MachineName: "my-vm",
+						DiskSizeGB:  256,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-disk-1").Return(compute.Disk{
+					Tags: newDiskTags(),
+					DiskProperties: &compute.DiskProperties{
+						DiskSizeGB: to.Int32Ptr(30),
+					},
+				}, nil)
+				m.Get(gomockinternal.AContext(), "my-rg", "my-disk-2").Return(compute.Disk{
+					Tags: newDiskTags(),
+					DiskProperties: &compute.DiskProperties{
+						DiskSizeGB: to.Int32Ptr(128),
+					},
+				}, nil)
+				m.Update(gomockinternal.AContext(), "my-rg", "my-disk-2", gomockinternal.DiffEq(compute.DiskUpdate{
+					DiskUpdateProperties: &compute.DiskUpdateProperties{
+						DiskSizeGB: to.Int32Ptr(256),
+					},
+				}))
+			},
+		},
+		{
+			name:          "data disk is up to date",
+			expectedError: "",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, m *mock_disks.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.DiskSpecs().Return([]azure.DiskSpec{
+					{
+						Name:        "my-disk-1",
+						MachineName: "my-vm",
+						DiskSizeGB:  128,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-disk-1").Return(compute.Disk{
+					Tags: newDiskTags(),
+					DiskProperties: &compute.DiskProperties{
+						DiskSizeGB: to.Int32Ptr(128),
+					},
+				}, nil)
+			},
+		},
+		{
+			name:          "update ultra data disk performance",
+			expectedError: "",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, m *mock_disks.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.DiskSpecs().Return([]azure.DiskSpec{
+					{
+						Name:              "my-disk-1",
+						MachineName:       "my-vm",
+						DiskSizeGB:        128,
+						DiskIOPSReadWrite: to.Int64Ptr(4000),
+						DiskMBpsReadWrite: to.Int64Ptr(200),
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-disk-1").Return(compute.Disk{
+					Tags: newDiskTags(),
+					DiskProperties: &compute.DiskProperties{
+						DiskSizeGB:        to.Int32Ptr(128),
+						DiskIOPSReadWrite: to.Int64Ptr(2000),
+						DiskMBpsReadWrite: to.Int64Ptr(200),
+					},
+				}, nil)
+				m.Update(gomockinternal.AContext(), "my-rg", "my-disk-1", gomockinternal.DiffEq(compute.DiskUpdate{
+					DiskUpdateProperties: &compute.DiskUpdateProperties{
+						DiskIOPSReadWrite: to.Int64Ptr(4000),
+					},
+				}))
+			},
+		},
+		{
+			name:          "ultra data disk performance is up to date",
+			expectedError: "",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, m *mock_disks.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.DiskSpecs().Return([]azure.DiskSpec{
+					{
+						Name:              "my-disk-1",
+						MachineName:       "my-vm",
+						DiskIOPSReadWrite: to.Int64Ptr(4000),
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-disk-1").Return(compute.Disk{
+					Tags: newDiskTags(),
+					DiskProperties: &compute.DiskProperties{
+						DiskSizeGB:        to.Int32Ptr(128),
+						DiskIOPSReadWrite: to.Int64Ptr(4000),
+					},
+				}, nil)
+			},
+		},
+		{
+			name:          "data disk does not exist yet",
+			expectedError: "",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, m *mock_disks.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.DiskSpecs().Return([]azure.DiskSpec{
+					{
+						Name:        "my-disk-1",
+						MachineName: "my-vm",
+						DiskSizeGB:  128,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-disk-1").Return(compute.Disk{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"))
+			},
+		},
+		{
+			name:          "tag disks created with the VM",
+			expectedError: "",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, m *mock_disks.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.DiskSpecs().Return([]azure.DiskSpec{
+					{
+						Name:        "my-vm_OSDisk",
+						MachineName: "my-vm",
+					},
+					{
+						Name:           "my-disk-1",
+						DiskSizeGB:     128,
+						DeletionPolicy: infrav1.DiskDeletionPolicyRetain,
+						MachineName:    "my-vm",
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm_OSDisk").Return(compute.Disk{
+					Tags: map[string]*string{"foo": to.StringPtr("bar")},
+					DiskProperties: &compute.DiskProperties{
+						DiskSizeGB: to.Int32Ptr(30),
+					},
+				}, nil)
+				m.Update(gomockinternal.AContext(), "my-rg", "my-vm_OSDisk", gomockinternal.DiffEq(compute.DiskUpdate{
+					Tags: map[string]*string{
+						"foo": to.StringPtr("bar"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_machine":            to.StringPtr("my-vm"),
+					},
+					DiskUpdateProperties: &compute.DiskUpdateProperties{},
+				}))
+				// retained disks are never tagged as owned.
+				m.Get(gomockinternal.AContext(), "my-rg", "my-disk-1").Return(compute.Disk{
+					DiskProperties: &compute.DiskProperties{
+						DiskSizeGB: to.Int32Ptr(128),
+					},
+				}, nil)
+			},
+		},
+		{
+			name:          "error while trying to update the disk",
+			expectedError: "failed to update disk my-disk-1 in resource group my-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, m *mock_disks.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.DiskSpecs().Return([]azure.DiskSpec{
+					{
+						Name:        "my-disk-1",
+						MachineName: "my-vm",
+						DiskSizeGB:  256,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-disk-1").Return(compute.Disk{
+					Tags: newDiskTags(),
+					DiskProperties: &compute.DiskProperties{
+						DiskSizeGB: to.Int32Ptr(128),
+					},
+				}, nil)
+				m.Update(gomockinternal.AContext(), "my-rg", "my-disk-1", gomock.AssignableToTypeOf(compute.DiskUpdate{})).Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_disks.NewMockDiskScope(mockCtrl)
+			clientMock := mock_disks.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestDiskSpecs(t *testing.T) {
 	testcases := []struct {
 		name                   string
@@ -138,7 +397,8 @@ func TestDiskSpecs(t *testing.T) {
 			azureMachineModifyFunc: func(m *infrav1.AzureMachine) {},
 			expectedDisks: []azure.DiskSpec{
 				{
-					Name: "my-azure-machine_OSDisk",
+					Name:        "my-azure-machine_OSDisk",
+					MachineName: "my-azure-machine",
 				},
 			},
 		}, {
@@ -150,10 +410,12 @@ func TestDiskSpecs(t *testing.T) {
 			},
 			expectedDisks: []azure.DiskSpec{
 				{
-					Name: "my-azure-machine_OSDisk",
+					Name:        "my-azure-machine_OSDisk",
+					MachineName: "my-azure-machine",
 				},
 				{
-					Name: "my-azure-machine_etcddisk",
+					Name:        "my-azure-machine_etcddisk",
+					MachineName: "my-azure-machine",
 				},
 			},
 		}, {
@@ -169,13 +431,63 @@ func TestDiskSpecs(t *testing.T) {
 			},
 			expectedDisks: []azure.DiskSpec{
 				{
-					Name: "my-azure-machine_OSDisk",
+					Name:        "my-azure-machine_OSDisk",
+					MachineName: "my-azure-machine",
+				},
+				{
+					Name:        "my-azure-machine_etcddisk",
+					MachineName: "my-azure-machine",
+				},
+				{
+					Name:        "my-azure-machine_otherdisk",
+					MachineName: "my-azure-machine",
+				},
+			},
+		}, {
+			name: "os and ultra data disk",
+			azureMachineModifyFunc: func(m *infrav1.AzureMachine) {
+				m.Spec.DataDisks = []infrav1.DataDisk{
+					{
+						NameSuffix:        "etcddisk",
+						DiskSizeGB:        128,
+						DiskIOPSReadWrite: to.Int64Ptr(4000),
+						DiskMBpsReadWrite: to.Int64Ptr(200),
+					}}
+			},
+			expectedDisks: []azure.DiskSpec{
+				{
+					Name:        "my-azure-machine_OSDisk",
+					MachineName: "my-azure-machine",
 				},
 				{
-					Name: "my-azure-machine_etcddisk",
+					Name:              "my-azure-machine_etcddisk",
+					MachineName:       "my-azure-machine",
+					DiskSizeGB:        128,
+					DiskIOPSReadWrite: to.Int64Ptr(4000),
+					DiskMBpsReadWrite: to.Int64Ptr(200),
+				},
+			},
+		}, {
+			name: "os and retained data disk attached by name",
+			azureMachineModifyFunc: func(m *infrav1.AzureMachine) {
+				m.Spec.DataDisks = []infrav1.DataDisk{
+					{
+						NameSuffix:     "etcddisk",
+						DiskSizeGB:     128,
+						DeletionPolicy: infrav1.DiskDeletionPolicyRetain,
+						DiskName:       "my-retained-disk",
+					}}
+			},
+			expectedDisks: []azure.DiskSpec{
+				{
+					Name:        "my-azure-machine_OSDisk",
+					MachineName: "my-azure-machine",
 				},
 				{
-					Name: "my-azure-machine_otherdisk",
+					Name:           "my-retained-disk",
+					MachineName:    "my-azure-machine",
+					DiskSizeGB:     128,
+					DeletionPolicy: infrav1.DiskDeletionPolicyRetain,
 				},
 			},
 		}}
@@ -22,6 +22,7 @@ package mock_disks
 
 import (
 	context "context"
+	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -49,6 +50,35 @@ func (m *Mockclient) EXPECT() *MockclientMockRecorder {
 	return m.recorder
 }
 
+// Get mocks base method.
+func (m *Mockclient) Get(arg0 context.Context, arg1, arg2 string) (compute.Disk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(compute.Disk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockclientMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockclient)(nil).Get), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *Mockclient) Update(arg0 context.Context, arg1, arg2 string, arg3 compute.DiskUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockclientMockRecorder) Update(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*Mockclient)(nil).Update), arg0, arg1, arg2, arg3)
+}
+
 // Delete mocks base method.
 func (m *Mockclient) Delete(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
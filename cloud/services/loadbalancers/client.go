@@ -22,15 +22,18 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
 	"github.com/Azure/go-autorest/autorest"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
 // Client wraps go-sdk
 type Client interface {
 	Get(context.Context, string, string) (network.LoadBalancer, error)
-	CreateOrUpdate(context.Context, string, string, network.LoadBalancer) error
-	Delete(context.Context, string, string) error
+	CreateOrUpdateAsync(context.Context, string, string, network.LoadBalancer) (*infrav1.Future, error)
+	DeleteAsync(context.Context, string, string) (*infrav1.Future, error)
+	IsDone(context.Context, *infrav1.Future) (bool, error)
 }
 
 // AzureClient contains the Azure go-sdk Client
@@ -49,8 +52,7 @@ func NewClient(auth azure.Authorizer) *AzureClient {
 // newLoadbalancersClient creates a new load balancer client from subscription ID.
 func newLoadBalancersClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.LoadBalancersClient {
 	loadBalancersClient := network.NewLoadBalancersClientWithBaseURI(baseURI, subscriptionID)
-	loadBalancersClient.Authorizer = authorizer
-	loadBalancersClient.AddToUserAgent(azure.UserAgent())
+	azure.SetAutoRestClientDefaults(&loadBalancersClient.Client, authorizer)
 	return loadBalancersClient
 }
 
@@ -62,36 +64,41 @@ func (ac *AzureClient) Get(ctx context.Context, resourceGroupName, lbName string
 	return ac.loadbalancers.Get(ctx, resourceGroupName, lbName, "")
 }
 
-// CreateOrUpdate creates or updates a load balancer.
-func (ac *AzureClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, lbName string, lb network.LoadBalancer) error {
-	ctx, span := tele.Tracer().Start(ctx, "loadbalancers.AzureClient.CreateOrUpdate")
+// CreateOrUpdateAsync starts the operation to create or update a load balancer and returns its future without
+// waiting for it to complete.
+func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, resourceGroupName string, lbName string, lb network.LoadBalancer) (*infrav1.Future, error) {
+	ctx, span := tele.Tracer().Start(ctx, "loadbalancers.AzureClient.CreateOrUpdateAsync")
 	defer span.End()
 
 	future, err := ac.loadbalancers.CreateOrUpdate(ctx, resourceGroupName, lbName, lb)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	err = future.WaitForCompletionRef(ctx, ac.loadbalancers.Client)
-	if err != nil {
-		return err
-	}
-	_, err = future.Result(ac.loadbalancers)
-	return err
+	return converters.SDKToFuture(future.Future, infrav1.PutFuture, resourceGroupName, lbName)
 }
 
-// Delete deletes the specified load balancer.
-func (ac *AzureClient) Delete(ctx context.Context, resourceGroupName, lbName string) error {
-	ctx, span := tele.Tracer().Start(ctx, "loadbalancers.AzureClient.Delete")
+// DeleteAsync starts the operation to delete a load balancer and returns its future without waiting for it to
+// complete.
+func (ac *AzureClient) DeleteAsync(ctx context.Context, resourceGroupName, lbName string) (*infrav1.Future, error) {
+	ctx, span := tele.Tracer().Start(ctx, "loadbalancers.AzureClient.DeleteAsync")
 	defer span.End()
 
 	future, err := ac.loadbalancers.Delete(ctx, resourceGroupName, lbName)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	err = future.WaitForCompletionRef(ctx, ac.loadbalancers.Client)
+	return converters.SDKToFuture(future.Future, infrav1.DeleteFuture, resourceGroupName, lbName)
+}
+
+// IsDone polls a long-running operation once and returns true when it has completed.
+// It returns the error of the operation if it has failed.
+func (ac *AzureClient) IsDone(ctx context.Context, future *infrav1.Future) (bool, error) {
+	ctx, span := tele.Tracer().Start(ctx, "loadbalancers.AzureClient.IsDone")
+	defer span.End()
+
+	sdkFuture, err := converters.FutureToSDK(*future)
 	if err != nil {
-		return err
+		return false, err
 	}
-	_, err = future.Result(ac.loadbalancers)
-	return err
+	return sdkFuture.DoneWithContext(ctx, ac.loadbalancers)
 }
@@ -18,6 +18,7 @@ package loadbalancers
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -31,11 +32,23 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+const (
+	// defaultIdleTimeoutInMinutes is the TCP idle timeout of the rules of a load balancer, unless overridden in the spec.
+	defaultIdleTimeoutInMinutes = 4
+	// httpsProbeName is the name of the Https health probe of the API server load balancer.
+	httpsProbeName = "HTTPSProbe"
+	// tcpProbeName is the name of the Tcp health probe of the API server load balancer.
+	tcpProbeName = "TCPProbe"
+	// serviceName is the name of the service in the long running operation states of the cluster.
+	serviceName = "loadbalancers"
+)
+
 // LBScope defines the scope interface for a load balancer service.
 type LBScope interface {
 	logr.Logger
 	azure.ClusterDescriber
 	azure.NetworkDescriber
+	azure.AsyncStatusUpdater
 	LBSpecs() []azure.LBSpec
 }
 
@@ -55,126 +68,216 @@ func New(scope LBScope) *Service {
 	}
 }
 
-// Reconcile gets/creates/updates a load balancer.
+// Reconcile gets/creates/updates a load balancer. The operations are resumed on the next reconcile instead of
+// blocking the worker until they complete, and it returns an OperationNotDoneError while any is in progress.
 func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "loadbalancers.Service.Reconcile")
 	defer span.End()
 
+	var inProgress error
 	for _, lbSpec := range s.Scope.LBSpecs() {
-		s.Scope.V(2).Info("creating load balancer", "load balancer", lbSpec.Name)
+		if future := s.Scope.GetLongRunningOperationState(lbSpec.Name, serviceName); future != nil {
+			if err := s.checkOperation(ctx, future); err != nil {
+				if azure.IsOperationNotDoneError(err) {
+					inProgress = err
+					continue
+				}
+				return err
+			}
+		}
 
-		frontendIPConfigs, frontendIDs, err := s.getFrontendIPConfigs(lbSpec)
+		lb, err := s.getLoadBalancer(lbSpec)
 		if err != nil {
 			return err
 		}
 
-		lb := network.LoadBalancer{
-			Sku:      &network.LoadBalancerSku{Name: converters.SKUtoSDK(lbSpec.SKU)},
-			Location: to.StringPtr(s.Scope.Location()),
-			Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
-				ClusterName: s.Scope.ClusterName(),
-				Lifecycle:   infrav1.ResourceLifecycleOwned,
-				Role:        to.StringPtr(lbSpec.Role),
-				Additional:  s.Scope.AdditionalTags(),
-			})),
-			LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
-				FrontendIPConfigurations: &frontendIPConfigs,
-				BackendAddressPools: &[]network.BackendAddressPool{
-					{
-						Name: to.StringPtr(lbSpec.BackendPoolName),
-					},
-				},
-				OutboundRules: &[]network.OutboundRule{
-					{
-						Name: to.StringPtr("OutboundNATAllProtocols"),
-						OutboundRulePropertiesFormat: &network.OutboundRulePropertiesFormat{
-							Protocol:                 network.LoadBalancerOutboundRuleProtocolAll,
-							IdleTimeoutInMinutes:     to.Int32Ptr(4),
-							FrontendIPConfigurations: &frontendIDs,
-							BackendAddressPool: &network.SubResource{
-								ID: to.StringPtr(azure.AddressPoolID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), lbSpec.Name, lbSpec.BackendPoolName)),
-							},
-						},
-					},
-				},
-			},
+		existingLB, err := s.Client.Get(ctx, s.Scope.ResourceGroup(), lbSpec.Name)
+		switch {
+		case err != nil && !azure.ResourceNotFound(err):
+			return errors.Wrapf(err, "failed to get load balancer %s in %s", lbSpec.Name, s.Scope.ResourceGroup())
+		case err == nil:
+			// load balancer already exists
+			if isLoadBalancerUpToDate(existingLB, lb) {
+				s.Scope.V(2).Info("load balancer exists and is up to date, skipping update", "load balancer", lbSpec.Name)
+				continue
+			}
+			s.Scope.V(2).Info("updating load balancer", "load balancer", lbSpec.Name)
+			// We append the existing LB etag to the header to ensure we only apply the updates if the LB has not been modified.
+			lb.Etag = existingLB.Etag
+			// Inbound NAT rules are reconciled separately by the inboundnatrules service, keep them.
+			if existingLB.LoadBalancerPropertiesFormat != nil {
+				lb.LoadBalancerPropertiesFormat.InboundNatRules = existingLB.LoadBalancerPropertiesFormat.InboundNatRules
+			}
+		default:
+			s.Scope.V(2).Info("creating load balancer", "load balancer", lbSpec.Name)
 		}
 
-		if lbSpec.Role == infrav1.APIServerRole {
-			probeName := "HTTPSProbe"
-			lb.LoadBalancerPropertiesFormat.Probes = &[]network.Probe{
+		future, err := s.Client.CreateOrUpdateAsync(ctx, s.Scope.ResourceGroup(), lbSpec.Name, lb)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create load balancer \"%s\"", lbSpec.Name)
+		}
+		future.ServiceName = serviceName
+		s.Scope.SetLongRunningOperationState(future)
+		if err := s.checkOperation(ctx, future); err != nil {
+			if azure.IsOperationNotDoneError(err) {
+				inProgress = err
+				continue
+			}
+			return err
+		}
+
+		s.Scope.V(2).Info("successfully created or updated load balancer", "load balancer", lbSpec.Name)
+	}
+	return inProgress
+}
+
+// getLoadBalancer returns the desired state of the load balancer described by lbSpec.
+func (s *Service) getLoadBalancer(lbSpec azure.LBSpec) (network.LoadBalancer, error) {
+	frontendIPConfigs, frontendIDs, err := s.getFrontendIPConfigs(lbSpec)
+	if err != nil {
+		return network.LoadBalancer{}, err
+	}
+
+	idleTimeout := to.Int32Ptr(defaultIdleTimeoutInMinutes)
+	if lbSpec.IdleTimeoutInMinutes != nil {
+		idleTimeout = lbSpec.IdleTimeoutInMinutes
+	}
+
+	lb := network.LoadBalancer{
+		Sku:      &network.LoadBalancerSku{Name: converters.SKUtoSDK(lbSpec.SKU)},
+		Location: to.StringPtr(s.Scope.Location()),
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.Scope.ClusterName(),
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Role:        to.StringPtr(lbSpec.Role),
+			Additional:  s.Scope.AdditionalTags(),
+		})),
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &frontendIPConfigs,
+			BackendAddressPools: &[]network.BackendAddressPool{
 				{
-					Name: to.StringPtr(probeName),
-					ProbePropertiesFormat: &network.ProbePropertiesFormat{
-						Protocol:          network.ProbeProtocolHTTPS,
-						RequestPath:       to.StringPtr("/healthz"),
-						Port:              to.Int32Ptr(lbSpec.APIServerPort),
-						IntervalInSeconds: to.Int32Ptr(15),
-						NumberOfProbes:    to.Int32Ptr(4),
-					},
+					Name: to.StringPtr(lbSpec.BackendPoolName),
 				},
-			}
-			// We disable outbound SNAT explicitly in the HTTPS LB rule and enable TCP and UDP outbound NAT with an outbound rule.
-			// For more information on Standard LB outbound connections see https://docs.microsoft.com/en-us/azure/load-balancer/load-balancer-outbound-connections.
-			var frontendIPConfig network.SubResource
-			if len(frontendIDs) != 0 {
-				frontendIPConfig = frontendIDs[0]
-			}
-			lb.LoadBalancerPropertiesFormat.LoadBalancingRules = &[]network.LoadBalancingRule{
+			},
+			OutboundRules: &[]network.OutboundRule{
 				{
-					Name: to.StringPtr("LBRuleHTTPS"),
-					LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
-						DisableOutboundSnat:     to.BoolPtr(true),
-						Protocol:                network.TransportProtocolTCP,
-						FrontendPort:            to.Int32Ptr(lbSpec.APIServerPort),
-						BackendPort:             to.Int32Ptr(lbSpec.APIServerPort),
-						IdleTimeoutInMinutes:    to.Int32Ptr(4),
-						EnableFloatingIP:        to.BoolPtr(false),
-						LoadDistribution:        network.LoadDistributionDefault,
-						FrontendIPConfiguration: &frontendIPConfig,
+					Name: to.StringPtr("OutboundNATAllProtocols"),
+					OutboundRulePropertiesFormat: &network.OutboundRulePropertiesFormat{
+						Protocol:                 network.LoadBalancerOutboundRuleProtocolAll,
+						IdleTimeoutInMinutes:     idleTimeout,
+						EnableTCPReset:           lbSpec.EnableTCPReset,
+						AllocatedOutboundPorts:   lbSpec.AllocatedOutboundPorts,
+						FrontendIPConfigurations: &frontendIDs,
 						BackendAddressPool: &network.SubResource{
 							ID: to.StringPtr(azure.AddressPoolID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), lbSpec.Name, lbSpec.BackendPoolName)),
 						},
-						Probe: &network.SubResource{
-							ID: to.StringPtr(azure.ProbeID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), lbSpec.Name, probeName)),
-						},
 					},
 				},
-			}
-			if lbSpec.Type == infrav1.Internal {
-				lb.LoadBalancerPropertiesFormat.OutboundRules = nil
-			}
-		}
-
-		err = s.Client.CreateOrUpdate(ctx, s.Scope.ResourceGroup(), lbSpec.Name, lb)
+			},
+		},
+	}
 
-		if err != nil {
-			return errors.Wrapf(err, "failed to create load balancer \"%s\"", lbSpec.Name)
+	if lbSpec.Role == infrav1.APIServerRole {
+		probe := getHealthProbe(lbSpec)
+		lb.LoadBalancerPropertiesFormat.Probes = &[]network.Probe{probe}
+		// We disable outbound SNAT explicitly in the HTTPS LB rule and enable TCP and UDP outbound NAT with an outbound rule.
+		// For more information on Standard LB outbound connections see https://docs.microsoft.com/en-us/azure/load-balancer/load-balancer-outbound-connections.
+		var frontendIPConfig network.SubResource
+		if len(frontendIDs) != 0 {
+			frontendIPConfig = frontendIDs[0]
+		}
+		enableFloatingIP := to.BoolPtr(false)
+		if lbSpec.EnableFloatingIP != nil {
+			enableFloatingIP = lbSpec.EnableFloatingIP
+		}
+		lb.LoadBalancerPropertiesFormat.LoadBalancingRules = &[]network.LoadBalancingRule{
+			{
+				Name: to.StringPtr("LBRuleHTTPS"),
+				LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+					DisableOutboundSnat:     to.BoolPtr(true),
+					Protocol:                network.TransportProtocolTCP,
+					FrontendPort:            to.Int32Ptr(lbSpec.APIServerPort),
+					BackendPort:             to.Int32Ptr(lbSpec.APIServerPort),
+					IdleTimeoutInMinutes:    idleTimeout,
+					EnableFloatingIP:        enableFloatingIP,
+					EnableTCPReset:          lbSpec.EnableTCPReset,
+					LoadDistribution:        network.LoadDistributionDefault,
+					FrontendIPConfiguration: &frontendIPConfig,
+					BackendAddressPool: &network.SubResource{
+						ID: to.StringPtr(azure.AddressPoolID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), lbSpec.Name, lbSpec.BackendPoolName)),
+					},
+					Probe: &network.SubResource{
+						ID: to.StringPtr(azure.ProbeID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), lbSpec.Name, to.String(probe.Name))),
+					},
+				},
+			},
+		}
+		if lbSpec.Type == infrav1.Internal {
+			lb.LoadBalancerPropertiesFormat.OutboundRules = nil
 		}
+	}
 
-		s.Scope.V(2).Info("successfully created load balancer", "load balancer", lbSpec.Name)
+	if lbSpec.Role == infrav1.AdditionalLBRole {
+		// Outbound connectivity of the backend pool members is provided by the outbound LBs.
+		lb.LoadBalancerPropertiesFormat.OutboundRules = nil
+		lb.LoadBalancerPropertiesFormat.BackendAddressPools = getBackendAddressPools(lbSpec)
+		lb.LoadBalancerPropertiesFormat.Probes = getProbes(lbSpec)
+		lb.LoadBalancerPropertiesFormat.LoadBalancingRules = s.getLoadBalancingRules(lbSpec)
 	}
-	return nil
+
+	return lb, nil
 }
 
-// Delete deletes the public load balancer with the provided name.
+// Delete deletes the public load balancer with the provided name. Like Reconcile, it returns an
+// OperationNotDoneError while any deletion is in progress.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "loadbalancers.Service.Delete")
 	defer span.End()
 
+	var inProgress error
 	for _, lbSpec := range s.Scope.LBSpecs() {
-		s.Scope.V(2).Info("deleting load balancer", "load balancer", lbSpec.Name)
-		err := s.Client.Delete(ctx, s.Scope.ResourceGroup(), lbSpec.Name)
-		if err != nil && azure.ResourceNotFound(err) {
-			// already deleted
-			continue
+		future := s.Scope.GetLongRunningOperationState(lbSpec.Name, serviceName)
+		if future == nil || future.Type != infrav1.DeleteFuture {
+			s.Scope.V(2).Info("deleting load balancer", "load balancer", lbSpec.Name)
+			var err error
+			future, err = s.Client.DeleteAsync(ctx, s.Scope.ResourceGroup(), lbSpec.Name)
+			if err != nil && azure.ResourceNotFound(err) {
+				// already deleted
+				s.Scope.DeleteLongRunningOperationState(lbSpec.Name, serviceName)
+				continue
+			}
+			if err != nil {
+				return errors.Wrapf(err, "failed to delete load balancer %s in resource group %s", lbSpec.Name, s.Scope.ResourceGroup())
+			}
+			future.ServiceName = serviceName
+			s.Scope.SetLongRunningOperationState(future)
 		}
-		if err != nil {
-			return errors.Wrapf(err, "failed to delete load balancer %s in resource group %s", lbSpec.Name, s.Scope.ResourceGroup())
+
+		if err := s.checkOperation(ctx, future); err != nil {
+			if azure.IsOperationNotDoneError(err) {
+				inProgress = err
+				continue
+			}
+			return err
 		}
 
 		s.Scope.V(2).Info("deleted public load balancer", "load balancer", lbSpec.Name)
 	}
+	return inProgress
+}
+
+// checkOperation polls the in-progress long-running operation of a load balancer. It returns an OperationNotDoneError
+// while the operation is running, and clears the operation state once it has completed or failed.
+func (s *Service) checkOperation(ctx context.Context, future *infrav1.Future) error {
+	done, err := s.Client.IsDone(ctx, future)
+	if err != nil {
+		s.Scope.DeleteLongRunningOperationState(future.Name, serviceName)
+		return errors.Wrapf(err, "failed operation type %s on load balancer %s in resource group %s", future.Type, future.Name, future.ResourceGroup)
+	}
+	if !done {
+		return azure.NewOperationNotDoneError(future)
+	}
+	s.Scope.DeleteLongRunningOperationState(future.Name, serviceName)
 	return nil
 }
 
@@ -191,10 +294,24 @@ func (s *Service) getFrontendIPConfigs(lbSpec azure.LBSpec) ([]network.FrontendI
 				},
 				PrivateIPAddress: to.StringPtr(ipConfig.PrivateIPAddress),
 			}
+			if ipConfig.PrivateIPAddress == "" {
+				properties.PrivateIPAllocationMethod = network.Dynamic
+				properties.PrivateIPAddress = nil
+			}
+		} else if ipConfig.PublicIPPrefix != nil {
+			properties = network.FrontendIPConfigurationPropertiesFormat{
+				PublicIPPrefix: &network.SubResource{
+					ID: to.StringPtr(azure.PublicIPPrefixID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), ipConfig.PublicIPPrefix.Name)),
+				},
+			}
 		} else {
+			publicIPID := ipConfig.PublicIP.ID
+			if publicIPID == "" {
+				publicIPID = azure.PublicIPID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), ipConfig.PublicIP.Name)
+			}
 			properties = network.FrontendIPConfigurationPropertiesFormat{
 				PublicIPAddress: &network.PublicIPAddress{
-					ID: to.StringPtr(azure.PublicIPID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), ipConfig.PublicIP.Name)),
+					ID: to.StringPtr(publicIPID),
 				},
 			}
 		}
@@ -208,3 +325,360 @@ func (s *Service) getFrontendIPConfigs(lbSpec azure.LBSpec) ([]network.FrontendI
 	}
 	return frontendIPConfigurations, frontendIDs, nil
 }
+
+// getHealthProbe returns the health probe of the API server load balancer.
+func getHealthProbe(lbSpec azure.LBSpec) network.Probe {
+	properties := &network.ProbePropertiesFormat{
+		Protocol:          network.ProbeProtocolHTTPS,
+		RequestPath:       to.StringPtr("/healthz"),
+		Port:              to.Int32Ptr(lbSpec.APIServerPort),
+		IntervalInSeconds: to.Int32Ptr(15),
+		NumberOfProbes:    to.Int32Ptr(4),
+	}
+	name := httpsProbeName
+	if probe := lbSpec.HealthProbe; probe != nil {
+		if probe.Protocol == infrav1.ProbeProtocolTCP {
+			name = tcpProbeName
+			properties.Protocol = network.ProbeProtocolTCP
+			properties.RequestPath = nil
+		} else if probe.RequestPath != "" {
+			properties.RequestPath = to.StringPtr(probe.RequestPath)
+		}
+		if probe.IntervalInSeconds != nil {
+			properties.IntervalInSeconds = probe.IntervalInSeconds
+		}
+		if probe.NumberOfProbes != nil {
+			properties.NumberOfProbes = probe.NumberOfProbes
+		}
+	}
+	return network.Probe{
+		Name:                  to.StringPtr(name),
+		ProbePropertiesFormat: properties,
+	}
+}
+
+func getBackendAddressPools(lbSpec azure.LBSpec) *[]network.BackendAddressPool {
+	pools := make([]network.BackendAddressPool, 0, len(lbSpec.BackendPools))
+	for _, pool := range lbSpec.BackendPools {
+		pools = append(pools, network.BackendAddressPool{
+			Name: to.StringPtr(pool.Name),
+		})
+	}
+	return &pools
+}
+
+func getProbes(lbSpec azure.LBSpec) *[]network.Probe {
+	probes := make([]network.Probe, 0, len(lbSpec.Probes))
+	for _, probe := range lbSpec.Probes {
+		properties := &network.ProbePropertiesFormat{
+			Protocol:          network.ProbeProtocol(probe.Protocol),
+			Port:              to.Int32Ptr(probe.Port),
+			IntervalInSeconds: to.Int32Ptr(15),
+			NumberOfProbes:    to.Int32Ptr(4),
+		}
+		if probe.RequestPath != "" {
+			properties.RequestPath = to.StringPtr(probe.RequestPath)
+		}
+		if probe.IntervalInSeconds != nil {
+			properties.IntervalInSeconds = probe.IntervalInSeconds
+		}
+		if probe.NumberOfProbes != nil {
+			properties.NumberOfProbes = probe.NumberOfProbes
+		}
+		probes = append(probes, network.Probe{
+			Name:                  to.StringPtr(probe.Name),
+			ProbePropertiesFormat: properties,
+		})
+	}
+	return &probes
+}
+
+func (s *Service) getLoadBalancingRules(lbSpec azure.LBSpec) *[]network.LoadBalancingRule {
+	rules := make([]network.LoadBalancingRule, 0, len(lbSpec.Rules))
+	for _, rule := range lbSpec.Rules {
+		frontendIPName := rule.FrontendIPName
+		if frontendIPName == "" && len(lbSpec.FrontendIPConfigs) != 0 {
+			frontendIPName = lbSpec.FrontendIPConfigs[0].Name
+		}
+		backendPoolName := rule.BackendPoolName
+		if backendPoolName == "" && len(lbSpec.BackendPools) != 0 {
+			backendPoolName = lbSpec.BackendPools[0].Name
+		}
+		properties := &network.LoadBalancingRulePropertiesFormat{
+			Protocol:             network.TransportProtocol(rule.Protocol),
+			FrontendPort:         to.Int32Ptr(rule.FrontendPort),
+			BackendPort:          to.Int32Ptr(rule.BackendPort),
+			IdleTimeoutInMinutes: to.Int32Ptr(defaultIdleTimeoutInMinutes),
+			EnableFloatingIP:     to.BoolPtr(false),
+			EnableTCPReset:       lbSpec.EnableTCPReset,
+			LoadDistribution:     network.LoadDistributionDefault,
+			FrontendIPConfiguration: &network.SubResource{
+				ID: to.StringPtr(azure.FrontendIPConfigID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), lbSpec.Name, frontendIPName)),
+			},
+			BackendAddressPool: &network.SubResource{
+				ID: to.StringPtr(azure.AddressPoolID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), lbSpec.Name, backendPoolName)),
+			},
+		}
+		if lbSpec.Type == infrav1.Public {
+			// Outbound SNAT is provided by the node outbound LB.
+			properties.DisableOutboundSnat = to.BoolPtr(true)
+		}
+		// Settings of the rule take precedence over the ones of the load balancer.
+		if lbSpec.IdleTimeoutInMinutes != nil {
+			properties.IdleTimeoutInMinutes = lbSpec.IdleTimeoutInMinutes
+		}
+		if rule.IdleTimeoutInMinutes != nil {
+			properties.IdleTimeoutInMinutes = rule.IdleTimeoutInMinutes
+		}
+		if lbSpec.EnableFloatingIP != nil {
+			properties.EnableFloatingIP = lbSpec.EnableFloatingIP
+		}
+		if rule.EnableFloatingIP != nil {
+			properties.EnableFloatingIP = rule.EnableFloatingIP
+		}
+		if rule.ProbeName != "" {
+			properties.Probe = &network.SubResource{
+				ID: to.StringPtr(azure.ProbeID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), lbSpec.Name, rule.ProbeName)),
+			}
+		}
+		rules = append(rules, network.LoadBalancingRule{
+			Name:                              to.StringPtr(rule.Name),
+			LoadBalancingRulePropertiesFormat: properties,
+		})
+	}
+	return &rules
+}
+
+// isLoadBalancerUpToDate returns true if the settings managed by the service are the same on the existing and the
+// desired load balancer, i.e. when no update is required.
+func isLoadBalancerUpToDate(existing, desired network.LoadBalancer) bool {
+	if existing.LoadBalancerPropertiesFormat == nil {
+		return false
+	}
+	have, want := existing.LoadBalancerPropertiesFormat, desired.LoadBalancerPropertiesFormat
+
+	if len(frontendIPConfigs(have)) != len(frontendIPConfigs(want)) {
+		return false
+	}
+	for _, w := range frontendIPConfigs(want) {
+		h, ok := findFrontendIPConfig(frontendIPConfigs(have), to.String(w.Name))
+		if !ok || !frontendIPConfigUpToDate(h, w) {
+			return false
+		}
+	}
+
+	if len(backendAddressPools(have)) != len(backendAddressPools(want)) {
+		return false
+	}
+	for _, w := range backendAddressPools(want) {
+		if _, ok := findBackendAddressPool(backendAddressPools(have), to.String(w.Name)); !ok {
+			return false
+		}
+	}
+
+	if len(probes(have)) != len(probes(want)) {
+		return false
+	}
+	for _, w := range probes(want) {
+		h, ok := findProbe(probes(have), to.String(w.Name))
+		if !ok || !probeUpToDate(h, w) {
+			return false
+		}
+	}
+
+	if len(loadBalancingRules(have)) != len(loadBalancingRules(want)) {
+		return false
+	}
+	for _, w := range loadBalancingRules(want) {
+		h, ok := findLoadBalancingRule(loadBalancingRules(have), to.String(w.Name))
+		if !ok || !loadBalancingRuleUpToDate(h, w) {
+			return false
+		}
+	}
+
+	if len(outboundRules(have)) != len(outboundRules(want)) {
+		return false
+	}
+	for _, w := range outboundRules(want) {
+		h, ok := findOutboundRule(outboundRules(have), to.String(w.Name))
+		if !ok || !outboundRuleUpToDate(h, w) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func frontendIPConfigUpToDate(have, want network.FrontendIPConfiguration) bool {
+	if have.FrontendIPConfigurationPropertiesFormat == nil || want.FrontendIPConfigurationPropertiesFormat == nil {
+		return have.FrontendIPConfigurationPropertiesFormat == want.FrontendIPConfigurationPropertiesFormat
+	}
+	h, w := have.FrontendIPConfigurationPropertiesFormat, want.FrontendIPConfigurationPropertiesFormat
+	if w.PublicIPAddress != nil && (h.PublicIPAddress == nil || !strings.EqualFold(to.String(h.PublicIPAddress.ID), to.String(w.PublicIPAddress.ID))) {
+		return false
+	}
+	if w.PublicIPPrefix != nil && !subResourceUpToDate(h.PublicIPPrefix, w.PublicIPPrefix) {
+		return false
+	}
+	if w.Subnet != nil && (h.Subnet == nil || !strings.EqualFold(to.String(h.Subnet.ID), to.String(w.Subnet.ID))) {
+		return false
+	}
+	// Azure assigns an address to dynamic frontends, only compare static ones.
+	if w.PrivateIPAddress != nil && to.String(h.PrivateIPAddress) != to.String(w.PrivateIPAddress) {
+		return false
+	}
+	return true
+}
+
+func probeUpToDate(have, want network.Probe) bool {
+	if have.ProbePropertiesFormat == nil || want.ProbePropertiesFormat == nil {
+		return have.ProbePropertiesFormat == want.ProbePropertiesFormat
+	}
+	h, w := have.ProbePropertiesFormat, want.ProbePropertiesFormat
+	return strings.EqualFold(string(h.Protocol), string(w.Protocol)) &&
+		to.Int32(h.Port) == to.Int32(w.Port) &&
+		to.String(h.RequestPath) == to.String(w.RequestPath) &&
+		to.Int32(h.IntervalInSeconds) == to.Int32(w.IntervalInSeconds) &&
+		to.Int32(h.NumberOfProbes) == to.Int32(w.NumberOfProbes)
+}
+
+func loadBalancingRuleUpToDate(have, want network.LoadBalancingRule) bool {
+	if have.LoadBalancingRulePropertiesFormat == nil || want.LoadBalancingRulePropertiesFormat == nil {
+		return have.LoadBalancingRulePropertiesFormat == want.LoadBalancingRulePropertiesFormat
+	}
+	h, w := have.LoadBalancingRulePropertiesFormat, want.LoadBalancingRulePropertiesFormat
+	return strings.EqualFold(string(h.Protocol), string(w.Protocol)) &&
+		to.Int32(h.FrontendPort) == to.Int32(w.FrontendPort) &&
+		to.Int32(h.BackendPort) == to.Int32(w.BackendPort) &&
+		to.Int32(h.IdleTimeoutInMinutes) == to.Int32(w.IdleTimeoutInMinutes) &&
+		to.Bool(h.EnableFloatingIP) == to.Bool(w.EnableFloatingIP) &&
+		to.Bool(h.EnableTCPReset) == to.Bool(w.EnableTCPReset) &&
+		to.Bool(h.DisableOutboundSnat) == to.Bool(w.DisableOutboundSnat) &&
+		subResourceUpToDate(h.FrontendIPConfiguration, w.FrontendIPConfiguration) &&
+		subResourceUpToDate(h.BackendAddressPool, w.BackendAddressPool) &&
+		subResourceUpToDate(h.Probe, w.Probe)
+}
+
+func outboundRuleUpToDate(have, want network.OutboundRule) bool {
+	if have.OutboundRulePropertiesFormat == nil || want.OutboundRulePropertiesFormat == nil {
+		return have.OutboundRulePropertiesFormat == want.OutboundRulePropertiesFormat
+	}
+	h, w := have.OutboundRulePropertiesFormat, want.OutboundRulePropertiesFormat
+	// Azure picks the number of allocated ports when it is not set, only compare it when it is.
+	if w.AllocatedOutboundPorts != nil && to.Int32(h.AllocatedOutboundPorts) != to.Int32(w.AllocatedOutboundPorts) {
+		return false
+	}
+	if !subResourcesUpToDate(h.FrontendIPConfigurations, w.FrontendIPConfigurations) {
+		return false
+	}
+	return strings.EqualFold(string(h.Protocol), string(w.Protocol)) &&
+		to.Int32(h.IdleTimeoutInMinutes) == to.Int32(w.IdleTimeoutInMinutes) &&
+		to.Bool(h.EnableTCPReset) == to.Bool(w.EnableTCPReset) &&
+		subResourceUpToDate(h.BackendAddressPool, w.BackendAddressPool)
+}
+
+func subResourceUpToDate(have, want *network.SubResource) bool {
+	if have == nil || want == nil {
+		return have == want
+	}
+	return strings.EqualFold(to.String(have.ID), to.String(want.ID))
+}
+
+func subResourcesUpToDate(have, want *[]network.SubResource) bool {
+	var h, w []network.SubResource
+	if have != nil {
+		h = *have
+	}
+	if want != nil {
+		w = *want
+	}
+	if len(h) != len(w) {
+		return false
+	}
+	for i := range w {
+		if !subResourceUpToDate(&h[i], &w[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func frontendIPConfigs(lb *network.LoadBalancerPropertiesFormat) []network.FrontendIPConfiguration {
+	if lb.FrontendIPConfigurations == nil {
+		return nil
+	}
+	return *lb.FrontendIPConfigurations
+}
+
+func backendAddressPools(lb *network.LoadBalancerPropertiesFormat) []network.BackendAddressPool {
+	if lb.BackendAddressPools == nil {
+		return nil
+	}
+	return *lb.BackendAddressPools
+}
+
+func probes(lb *network.LoadBalancerPropertiesFormat) []network.Probe {
+	if lb.Probes == nil {
+		return nil
+	}
+	return *lb.Probes
+}
+
+func loadBalancingRules(lb *network.LoadBalancerPropertiesFormat) []network.LoadBalancingRule {
+	if lb.LoadBalancingRules == nil {
+		return nil
+	}
+	return *lb.LoadBalancingRules
+}
+
+func outboundRules(lb *network.LoadBalancerPropertiesFormat) []network.OutboundRule {
+	if lb.OutboundRules == nil {
+		return nil
+	}
+	return *lb.OutboundRules
+}
+
+func findFrontendIPConfig(configs []network.FrontendIPConfiguration, name string) (network.FrontendIPConfiguration, bool) {
+	for _, c := range configs {
+		if strings.EqualFold(to.String(c.Name), name) {
+			return c, true
+		}
+	}
+	return network.FrontendIPConfiguration{}, false
+}
+
+func findBackendAddressPool(pools []network.BackendAddressPool, name string) (network.BackendAddressPool, bool) {
+	for _, p := range pools {
+		if strings.EqualFold(to.String(p.Name), name) {
+			return p, true
+		}
+	}
+	return network.BackendAddressPool{}, false
+}
+
+func findProbe(probes []network.Probe, name string) (network.Probe, bool) {
+	for _, p := range probes {
+		if strings.EqualFold(to.String(p.Name), name) {
+			return p, true
+		}
+	}
+	return network.Probe{}, false
+}
+
+func findLoadBalancingRule(rules []network.LoadBalancingRule, name string) (network.LoadBalancingRule, bool) {
+	for _, r := range rules {
+		if strings.EqualFold(to.String(r.Name), name) {
+			return r, true
+		}
+	}
+	return network.LoadBalancingRule{}, false
+}
+
+func findOutboundRule(rules []network.OutboundRule, name string) (network.OutboundRule, bool) {
+	for _, r := range rules {
+		if strings.EqualFold(to.String(r.Name), name) {
+			return r, true
+		}
+	}
+	return network.OutboundRule{}, false
+}
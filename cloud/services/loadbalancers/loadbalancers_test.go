@@ -57,7 +57,8 @@ func TestReconcileLoadBalancer(t *testing.T) {
 				s.Location().AnyTimes().Return("testlocation")
 				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-publiclb", gomock.AssignableToTypeOf(network.LoadBalancer{})).Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publiclb").Return(network.LoadBalancer{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-publiclb", gomock.AssignableToTypeOf(network.LoadBalancer{})).Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
 			},
 		},
 		{
@@ -91,7 +92,8 @@ func TestReconcileLoadBalancer(t *testing.T) {
 				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
 				gomock.InOrder(
-					m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-publiclb", gomockinternal.DiffEq(network.LoadBalancer{
+					m.Get(gomockinternal.AContext(), "my-rg", "my-publiclb").Return(network.LoadBalancer{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found")),
+					m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-publiclb", gomockinternal.DiffEq(network.LoadBalancer{
 						Tags: map[string]*string{
 							"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
 							"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr(infrav1.APIServerRole),
@@ -163,7 +165,229 @@ func TestReconcileLoadBalancer(t *testing.T) {
 								},
 							},
 						},
-					})).Return(nil))
+					})).Return(lbFuture(infrav1.PutFuture, "my-publiclb"), nil))
+			},
+		},
+		{
+			name:          "fail to get an existing LB",
+			expectedError: "failed to get load balancer my-publiclb in my-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, m *mock_loadbalancers.MockClientMockRecorder, mVnet *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.LBSpecs().Return([]azure.LBSpec{
+					{
+						Name:            "my-publiclb",
+						Role:            infrav1.APIServerRole,
+						Type:            infrav1.Public,
+						SKU:             infrav1.SKUStandard,
+						SubnetName:      "my-cp-subnet",
+						BackendPoolName: "my-publiclb-backendPool",
+						FrontendIPConfigs: []infrav1.FrontendIP{
+							{
+								Name: "my-publiclb-frontEnd",
+								PublicIP: &infrav1.PublicIPSpec{
+									Name:    "my-publicip",
+									DNSName: "my-cluster.12345.mydomain.com",
+								},
+							},
+						},
+						APIServerPort: 6443,
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("testlocation")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publiclb").Return(network.LoadBalancer{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+		{
+			name:          "existing apiserver LB is up to date",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, m *mock_loadbalancers.MockClientMockRecorder, mVnet *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.LBSpecs().Return([]azure.LBSpec{
+					{
+						Name:            "my-publiclb",
+						Role:            infrav1.APIServerRole,
+						Type:            infrav1.Public,
+						SKU:             infrav1.SKUStandard,
+						SubnetName:      "my-cp-subnet",
+						BackendPoolName: "my-publiclb-backendPool",
+						FrontendIPConfigs: []infrav1.FrontendIP{
+							{
+								Name: "my-publiclb-frontEnd",
+								PublicIP: &infrav1.PublicIPSpec{
+									Name:    "my-publicip",
+									DNSName: "my-cluster.12345.mydomain.com",
+								},
+							},
+						},
+						APIServerPort: 6443,
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("testlocation")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				existing := newPublicAPIServerLB()
+				existing.Etag = to.StringPtr("my-etag")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publiclb").Return(existing, nil)
+			},
+		},
+		{
+			name:          "update apiserver LB with modified probe and rule settings",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, m *mock_loadbalancers.MockClientMockRecorder, mVnet *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.LBSpecs().Return([]azure.LBSpec{
+					{
+						Name:            "my-publiclb",
+						Role:            infrav1.APIServerRole,
+						Type:            infrav1.Public,
+						SKU:             infrav1.SKUStandard,
+						SubnetName:      "my-cp-subnet",
+						BackendPoolName: "my-publiclb-backendPool",
+						FrontendIPConfigs: []infrav1.FrontendIP{
+							{
+								Name: "my-publiclb-frontEnd",
+								PublicIP: &infrav1.PublicIPSpec{
+									Name:    "my-publicip",
+									DNSName: "my-cluster.12345.mydomain.com",
+								},
+							},
+						},
+						APIServerPort: 6443,
+						HealthProbe: &infrav1.HealthProbe{
+							Protocol:          infrav1.ProbeProtocolHTTPS,
+							RequestPath:       "/readyz",
+							IntervalInSeconds: to.Int32Ptr(5),
+							NumberOfProbes:    to.Int32Ptr(2),
+						},
+						IdleTimeoutInMinutes:   to.Int32Ptr(10),
+						EnableTCPReset:         to.BoolPtr(true),
+						AllocatedOutboundPorts: to.Int32Ptr(1024),
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("testlocation")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				existing := newPublicAPIServerLB()
+				existing.Etag = to.StringPtr("my-etag")
+				existing.InboundNatRules = &[]network.InboundNatRule{{Name: to.StringPtr("my-machine-nat")}}
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publiclb").Return(existing, nil)
+
+				updated := newPublicAPIServerLB()
+				updated.Etag = to.StringPtr("my-etag")
+				updated.InboundNatRules = &[]network.InboundNatRule{{Name: to.StringPtr("my-machine-nat")}}
+				probe := (*updated.Probes)[0].ProbePropertiesFormat
+				probe.RequestPath = to.StringPtr("/readyz")
+				probe.IntervalInSeconds = to.Int32Ptr(5)
+				probe.NumberOfProbes = to.Int32Ptr(2)
+				rule := (*updated.LoadBalancingRules)[0].LoadBalancingRulePropertiesFormat
+				rule.IdleTimeoutInMinutes = to.Int32Ptr(10)
+				rule.EnableTCPReset = to.BoolPtr(true)
+				outboundRule := (*updated.OutboundRules)[0].OutboundRulePropertiesFormat
+				outboundRule.IdleTimeoutInMinutes = to.Int32Ptr(10)
+				outboundRule.EnableTCPReset = to.BoolPtr(true)
+				outboundRule.AllocatedOutboundPorts = to.Int32Ptr(1024)
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-publiclb", gomockinternal.DiffEq(updated)).Return(lbFuture(infrav1.PutFuture, "my-publiclb"), nil)
+			},
+		},
+		{
+			name:          "create apiserver LB with a tcp health probe",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, m *mock_loadbalancers.MockClientMockRecorder, mVnet *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.LBSpecs().Return([]azure.LBSpec{
+					{
+						Name:            "my-publiclb",
+						Role:            infrav1.APIServerRole,
+						Type:            infrav1.Public,
+						SKU:             infrav1.SKUStandard,
+						SubnetName:      "my-cp-subnet",
+						BackendPoolName: "my-publiclb-backendPool",
+						FrontendIPConfigs: []infrav1.FrontendIP{
+							{
+								Name: "my-publiclb-frontEnd",
+								PublicIP: &infrav1.PublicIPSpec{
+									Name:    "my-publicip",
+									DNSName: "my-cluster.12345.mydomain.com",
+								},
+							},
+						},
+						APIServerPort: 6443,
+						HealthProbe: &infrav1.HealthProbe{
+							Protocol: infrav1.ProbeProtocolTCP,
+						},
+						EnableFloatingIP: to.BoolPtr(true),
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("testlocation")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publiclb").Return(network.LoadBalancer{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+
+				expected := newPublicAPIServerLB()
+				(*expected.Probes)[0] = network.Probe{
+					Name: to.StringPtr("TCPProbe"),
+					ProbePropertiesFormat: &network.ProbePropertiesFormat{
+						Protocol:          network.ProbeProtocolTCP,
+						Port:              to.Int32Ptr(6443),
+						IntervalInSeconds: to.Int32Ptr(15),
+						NumberOfProbes:    to.Int32Ptr(4),
+					},
+				}
+				rule := (*expected.LoadBalancingRules)[0].LoadBalancingRulePropertiesFormat
+				rule.EnableFloatingIP = to.BoolPtr(true)
+				rule.Probe = &network.SubResource{
+					ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/probes/TCPProbe"),
+				}
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-publiclb", gomockinternal.DiffEq(expected)).Return(lbFuture(infrav1.PutFuture, "my-publiclb"), nil)
+			},
+		},
+		{
+			name:          "create apiserver LB with an existing public IP",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, m *mock_loadbalancers.MockClientMockRecorder, mVnet *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.LBSpecs().Return([]azure.LBSpec{
+					{
+						Name:            "my-publiclb",
+						Role:            infrav1.APIServerRole,
+						Type:            infrav1.Public,
+						SKU:             infrav1.SKUStandard,
+						SubnetName:      "my-cp-subnet",
+						BackendPoolName: "my-publiclb-backendPool",
+						FrontendIPConfigs: []infrav1.FrontendIP{
+							{
+								Name: "my-publiclb-frontEnd",
+								PublicIP: &infrav1.PublicIPSpec{
+									Name: "my-reserved-ip",
+									ID:   "/subscriptions/123/resourceGroups/my-ip-rg/providers/Microsoft.Network/publicIPAddresses/my-reserved-ip",
+								},
+							},
+						},
+						APIServerPort: 6443,
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("testlocation")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publiclb").Return(network.LoadBalancer{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+
+				expected := newPublicAPIServerLB()
+				(*expected.FrontendIPConfigurations)[0].PublicIPAddress = &network.PublicIPAddress{
+					ID: to.StringPtr("/subscriptions/123/resourceGroups/my-ip-rg/providers/Microsoft.Network/publicIPAddresses/my-reserved-ip"),
+				}
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-publiclb", gomockinternal.DiffEq(expected)).Return(lbFuture(infrav1.PutFuture, "my-publiclb"), nil)
 			},
 		},
 		{
@@ -198,7 +422,8 @@ func TestReconcileLoadBalancer(t *testing.T) {
 				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
 				gomock.InOrder(
-					m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-private-lb", gomockinternal.DiffEq(network.LoadBalancer{
+					m.Get(gomockinternal.AContext(), "my-rg", "my-private-lb").Return(network.LoadBalancer{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found")),
+					m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-private-lb", gomockinternal.DiffEq(network.LoadBalancer{
 						Tags: map[string]*string{
 							"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
 							"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr(infrav1.APIServerRole),
@@ -259,7 +484,7 @@ func TestReconcileLoadBalancer(t *testing.T) {
 								},
 							},
 						},
-					})).Return(nil))
+					})).Return(lbFuture(infrav1.PutFuture, "my-private-lb"), nil))
 			},
 		},
 		{
@@ -290,7 +515,8 @@ func TestReconcileLoadBalancer(t *testing.T) {
 				s.ClusterName().AnyTimes().Return("cluster-name")
 				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
 				gomock.InOrder(
-					m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "cluster-name", gomockinternal.DiffEq(network.LoadBalancer{
+					m.Get(gomockinternal.AContext(), "my-rg", "cluster-name").Return(network.LoadBalancer{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found")),
+					m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "cluster-name", gomockinternal.DiffEq(network.LoadBalancer{
 						Tags: map[string]*string{
 							"sigs.k8s.io_cluster-api-provider-azure_cluster_cluster-name": to.StringPtr("owned"),
 							"sigs.k8s.io_cluster-api-provider-azure_role":                 to.StringPtr(infrav1.NodeOutboundRole),
@@ -327,7 +553,220 @@ func TestReconcileLoadBalancer(t *testing.T) {
 								},
 							},
 						},
-					})).Return(nil))
+					})).Return(lbFuture(infrav1.PutFuture, "cluster-name"), nil))
+			},
+		},
+		{
+			name:          "create node outbound LB with multiple frontend IPs and a public IP prefix",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, m *mock_loadbalancers.MockClientMockRecorder, mVnet *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.LBSpecs().Return([]azure.LBSpec{
+					{
+						Name:            "cluster-name",
+						Role:            infrav1.NodeOutboundRole,
+						Type:            infrav1.Public,
+						SKU:             infrav1.SKUStandard,
+						BackendPoolName: "cluster-name-outboundBackendPool",
+						FrontendIPConfigs: []infrav1.FrontendIP{
+							{
+								Name: "cluster-name-frontEnd",
+								PublicIP: &infrav1.PublicIPSpec{
+									Name: "outbound-publicip",
+								},
+							},
+							{
+								Name: "cluster-name-frontEnd-1",
+								PublicIP: &infrav1.PublicIPSpec{
+									Name: "outbound-publicip-1",
+								},
+							},
+							{
+								Name: "cluster-name-prefix",
+								PublicIPPrefix: &infrav1.PublicIPPrefixSpec{
+									Name:         "outbound-publicipprefix",
+									PrefixLength: 30,
+								},
+							},
+						},
+						AllocatedOutboundPorts: to.Int32Ptr(8000),
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("testlocation")
+				s.ClusterName().AnyTimes().Return("cluster-name")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				gomock.InOrder(
+					m.Get(gomockinternal.AContext(), "my-rg", "cluster-name").Return(network.LoadBalancer{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found")),
+					m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "cluster-name", gomockinternal.DiffEq(network.LoadBalancer{
+						Tags: map[string]*string{
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_cluster-name": to.StringPtr("owned"),
+							"sigs.k8s.io_cluster-api-provider-azure_role":                 to.StringPtr(infrav1.NodeOutboundRole),
+						},
+						Sku:      &network.LoadBalancerSku{Name: network.LoadBalancerSkuNameStandard},
+						Location: to.StringPtr("testlocation"),
+						LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+							FrontendIPConfigurations: &[]network.FrontendIPConfiguration{
+								{
+									Name: to.StringPtr("cluster-name-frontEnd"),
+									FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+										PublicIPAddress: &network.PublicIPAddress{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/outbound-publicip")},
+									},
+								},
+								{
+									Name: to.StringPtr("cluster-name-frontEnd-1"),
+									FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+										PublicIPAddress: &network.PublicIPAddress{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/outbound-publicip-1")},
+									},
+								},
+								{
+									Name: to.StringPtr("cluster-name-prefix"),
+									FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+										PublicIPPrefix: &network.SubResource{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/outbound-publicipprefix")},
+									},
+								},
+							},
+							BackendAddressPools: &[]network.BackendAddressPool{
+								{
+									Name: to.StringPtr("cluster-name-outboundBackendPool"),
+								},
+							},
+							OutboundRules: &[]network.OutboundRule{
+								{
+									Name: to.StringPtr("OutboundNATAllProtocols"),
+									OutboundRulePropertiesFormat: &network.OutboundRulePropertiesFormat{
+										FrontendIPConfigurations: &[]network.SubResource{
+											{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/cluster-name/frontendIPConfigurations/cluster-name-frontEnd")},
+											{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/cluster-name/frontendIPConfigurations/cluster-name-frontEnd-1")},
+											{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/cluster-name/frontendIPConfigurations/cluster-name-prefix")},
+										},
+										BackendAddressPool: &network.SubResource{
+											ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/cluster-name/backendAddressPools/cluster-name-outboundBackendPool"),
+										},
+										Protocol:               network.LoadBalancerOutboundRuleProtocolAll,
+										IdleTimeoutInMinutes:   to.Int32Ptr(4),
+										AllocatedOutboundPorts: to.Int32Ptr(8000),
+									},
+								},
+							},
+						},
+					})).Return(lbFuture(infrav1.PutFuture, "cluster-name"), nil))
+			},
+		},
+		{
+			name:          "create additional internal LB",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, m *mock_loadbalancers.MockClientMockRecorder, mVnet *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.LBSpecs().Return([]azure.LBSpec{
+					{
+						Name:       "ingress-lb",
+						Role:       infrav1.AdditionalLBRole,
+						Type:       infrav1.Internal,
+						SKU:        infrav1.SKUStandard,
+						SubnetName: "my-node-subnet",
+						FrontendIPConfigs: []infrav1.FrontendIP{
+							{
+								Name: "ingress-lb-frontEnd",
+							},
+						},
+						BackendPools: []infrav1.BackendPool{
+							{
+								Name: "ingress-lb-backendPool",
+							},
+						},
+						Probes: []infrav1.LoadBalancerProbe{
+							{
+								Name:        "http-probe",
+								Protocol:    infrav1.ProbeProtocolHTTP,
+								Port:        30080,
+								RequestPath: "/healthz",
+							},
+						},
+						Rules: []infrav1.LoadBalancingRule{
+							{
+								Name:             "http",
+								Protocol:         infrav1.TransportProtocolTCP,
+								FrontendPort:     80,
+								BackendPort:      30080,
+								ProbeName:        "http-probe",
+								EnableFloatingIP: to.BoolPtr(true),
+							},
+						},
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
+					ResourceGroup: "my-rg",
+					Name:          "my-vnet",
+				})
+				s.Location().AnyTimes().Return("testlocation")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				gomock.InOrder(
+					m.Get(gomockinternal.AContext(), "my-rg", "ingress-lb").Return(network.LoadBalancer{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found")),
+					m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "ingress-lb", gomockinternal.DiffEq(network.LoadBalancer{
+						Tags: map[string]*string{
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+							"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr(infrav1.AdditionalLBRole),
+						},
+						Sku:      &network.LoadBalancerSku{Name: network.LoadBalancerSkuNameStandard},
+						Location: to.StringPtr("testlocation"),
+						LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+							FrontendIPConfigurations: &[]network.FrontendIPConfiguration{
+								{
+									Name: to.StringPtr("ingress-lb-frontEnd"),
+									FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+										PrivateIPAllocationMethod: network.Dynamic,
+										Subnet: &network.Subnet{
+											ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-node-subnet"),
+										},
+									},
+								},
+							},
+							BackendAddressPools: &[]network.BackendAddressPool{
+								{
+									Name: to.StringPtr("ingress-lb-backendPool"),
+								},
+							},
+							LoadBalancingRules: &[]network.LoadBalancingRule{
+								{
+									Name: to.StringPtr("http"),
+									LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+										Protocol:             network.TransportProtocolTCP,
+										FrontendPort:         to.Int32Ptr(80),
+										BackendPort:          to.Int32Ptr(30080),
+										IdleTimeoutInMinutes: to.Int32Ptr(4),
+										EnableFloatingIP:     to.BoolPtr(true),
+										LoadDistribution:     network.LoadDistributionDefault,
+										FrontendIPConfiguration: &network.SubResource{
+											ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/ingress-lb/frontendIPConfigurations/ingress-lb-frontEnd"),
+										},
+										BackendAddressPool: &network.SubResource{
+											ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/ingress-lb/backendAddressPools/ingress-lb-backendPool"),
+										},
+										Probe: &network.SubResource{
+											ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/ingress-lb/probes/http-probe"),
+										},
+									},
+								},
+							},
+							Probes: &[]network.Probe{
+								{
+									Name: to.StringPtr("http-probe"),
+									ProbePropertiesFormat: &network.ProbePropertiesFormat{
+										Protocol:          network.ProbeProtocolHTTP,
+										Port:              to.Int32Ptr(30080),
+										RequestPath:       to.StringPtr("/healthz"),
+										IntervalInSeconds: to.Int32Ptr(15),
+										NumberOfProbes:    to.Int32Ptr(4),
+									},
+								},
+							},
+						},
+					})).Return(lbFuture(infrav1.PutFuture, "ingress-lb"), nil))
 			},
 		},
 		{
@@ -365,9 +804,56 @@ func TestReconcileLoadBalancer(t *testing.T) {
 				s.ClusterName().AnyTimes().Return("cluster-name")
 				s.IsIPv6Enabled().AnyTimes().Return(false)
 				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-lb", gomock.AssignableToTypeOf(network.LoadBalancer{}))
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-lb-2", gomock.AssignableToTypeOf(network.LoadBalancer{}))
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-lb-3", gomock.AssignableToTypeOf(network.LoadBalancer{}))
+				m.Get(gomockinternal.AContext(), "my-rg", "my-lb").Return(network.LoadBalancer{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-lb", gomock.AssignableToTypeOf(network.LoadBalancer{})).Return(lbFuture(infrav1.PutFuture, "my-lb"), nil)
+				m.Get(gomockinternal.AContext(), "my-rg", "my-lb-2").Return(network.LoadBalancer{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-lb-2", gomock.AssignableToTypeOf(network.LoadBalancer{})).Return(lbFuture(infrav1.PutFuture, "my-lb-2"), nil)
+				m.Get(gomockinternal.AContext(), "my-rg", "my-lb-3").Return(network.LoadBalancer{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-lb-3", gomock.AssignableToTypeOf(network.LoadBalancer{})).Return(lbFuture(infrav1.PutFuture, "my-lb-3"), nil)
+			},
+		},
+		{
+			name:          "reconciles the other LBs while the creation of an LB is in progress",
+			expectedError: "operation type PUT on Azure resource my-rg/my-lb is not done",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, m *mock_loadbalancers.MockClientMockRecorder, mVnet *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.LBSpecs().Return([]azure.LBSpec{
+					{
+						Name:          "my-lb",
+						APIServerPort: 6443,
+						Role:          infrav1.APIServerRole,
+						Type:          infrav1.Public,
+					},
+					{
+						Name: "my-lb-2",
+						Role: infrav1.NodeOutboundRole,
+						Type: infrav1.Public,
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("testlocation")
+				s.ClusterName().AnyTimes().Return("cluster-name")
+				s.IsIPv6Enabled().AnyTimes().Return(false)
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.GetLongRunningOperationState("my-lb", serviceName).Return(lbFuture(infrav1.PutFuture, "my-lb"))
+				m.IsDone(gomockinternal.AContext(), lbFuture(infrav1.PutFuture, "my-lb")).Return(false, nil)
+				m.Get(gomockinternal.AContext(), "my-rg", "my-lb-2").Return(network.LoadBalancer{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-lb-2", gomock.AssignableToTypeOf(network.LoadBalancer{})).Return(lbFuture(infrav1.PutFuture, "my-lb-2"), nil)
+			},
+		},
+		{
+			name:          "fails when the in-progress creation of an LB failed",
+			expectedError: "failed operation type PUT on load balancer my-lb in resource group my-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, m *mock_loadbalancers.MockClientMockRecorder, mVnet *mock_virtualnetworks.MockClientMockRecorder) {
+				s.LBSpecs().Return([]azure.LBSpec{
+					{
+						Name: "my-lb",
+					},
+				})
+				s.GetLongRunningOperationState("my-lb", serviceName).Return(lbFuture(infrav1.PutFuture, "my-lb"))
+				m.IsDone(gomockinternal.AContext(), lbFuture(infrav1.PutFuture, "my-lb")).Return(false, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+				s.DeleteLongRunningOperationState("my-lb", serviceName)
 			},
 		},
 	}
@@ -386,6 +872,12 @@ func TestReconcileLoadBalancer(t *testing.T) {
 			vnetMock := mock_virtualnetworks.NewMockClient(mockCtrl)
 
 			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), vnetMock.EXPECT())
+			// no long-running operation is in progress unless the test case says otherwise, and any started
+			// operation completes on its first poll.
+			scopeMock.EXPECT().GetLongRunningOperationState(gomock.Any(), serviceName).AnyTimes().Return(nil)
+			scopeMock.EXPECT().SetLongRunningOperationState(gomock.Any()).AnyTimes()
+			scopeMock.EXPECT().DeleteLongRunningOperationState(gomock.Any(), serviceName).AnyTimes()
+			clientMock.EXPECT().IsDone(gomockinternal.AContext(), gomock.Any()).AnyTimes().Return(true, nil)
 
 			s := &Service{
 				Scope:                 scopeMock,
@@ -423,8 +915,8 @@ func TestDeleteLoadBalancer(t *testing.T) {
 					},
 				})
 				s.ResourceGroup().AnyTimes().Return("my-rg")
-				m.Delete(gomockinternal.AContext(), "my-rg", "my-internallb")
-				m.Delete(gomockinternal.AContext(), "my-rg", "my-publiclb")
+				m.DeleteAsync(gomockinternal.AContext(), "my-rg", "my-internallb").Return(lbFuture(infrav1.DeleteFuture, "my-internallb"), nil)
+				m.DeleteAsync(gomockinternal.AContext(), "my-rg", "my-publiclb").Return(lbFuture(infrav1.DeleteFuture, "my-publiclb"), nil)
 			},
 		},
 		{
@@ -438,8 +930,8 @@ func TestDeleteLoadBalancer(t *testing.T) {
 					},
 				})
 				s.ResourceGroup().AnyTimes().Return("my-rg")
-				m.Delete(gomockinternal.AContext(), "my-rg", "my-publiclb").
-					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.DeleteAsync(gomockinternal.AContext(), "my-rg", "my-publiclb").
+					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
 			},
 		},
 		{
@@ -453,8 +945,39 @@ func TestDeleteLoadBalancer(t *testing.T) {
 					},
 				})
 				s.ResourceGroup().AnyTimes().Return("my-rg")
-				m.Delete(gomockinternal.AContext(), "my-rg", "my-publiclb").
-					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+				m.DeleteAsync(gomockinternal.AContext(), "my-rg", "my-publiclb").
+					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+		{
+			name:          "waits for the in-progress deletion of a load balancer",
+			expectedError: "operation type DELETE on Azure resource my-rg/my-publiclb is not done",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, m *mock_loadbalancers.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.LBSpecs().Return([]azure.LBSpec{
+					{
+						Name: "my-publiclb",
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.GetLongRunningOperationState("my-publiclb", serviceName).Return(lbFuture(infrav1.DeleteFuture, "my-publiclb"))
+				m.IsDone(gomockinternal.AContext(), lbFuture(infrav1.DeleteFuture, "my-publiclb")).Return(false, nil)
+			},
+		},
+		{
+			name:          "deletes a load balancer with an in-progress update",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, m *mock_loadbalancers.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.LBSpecs().Return([]azure.LBSpec{
+					{
+						Name: "my-publiclb",
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.GetLongRunningOperationState("my-publiclb", serviceName).Return(lbFuture(infrav1.PutFuture, "my-publiclb"))
+				m.DeleteAsync(gomockinternal.AContext(), "my-rg", "my-publiclb").Return(lbFuture(infrav1.DeleteFuture, "my-publiclb"), nil)
+				s.SetLongRunningOperationState(lbFuture(infrav1.DeleteFuture, "my-publiclb"))
 			},
 		},
 	}
@@ -472,6 +995,12 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			publicLBMock := mock_loadbalancers.NewMockClient(mockCtrl)
 
 			tc.expect(scopeMock.EXPECT(), publicLBMock.EXPECT())
+			// no long-running operation is in progress unless the test case says otherwise, and any started
+			// operation completes on its first poll.
+			scopeMock.EXPECT().GetLongRunningOperationState(gomock.Any(), serviceName).AnyTimes().Return(nil)
+			scopeMock.EXPECT().SetLongRunningOperationState(gomock.Any()).AnyTimes()
+			scopeMock.EXPECT().DeleteLongRunningOperationState(gomock.Any(), serviceName).AnyTimes()
+			publicLBMock.EXPECT().IsDone(gomockinternal.AContext(), gomock.Any()).AnyTimes().Return(true, nil)
 
 			s := &Service{
 				Scope:  scopeMock,
@@ -488,3 +1017,85 @@ func TestDeleteLoadBalancer(t *testing.T) {
 		})
 	}
 }
+
+// lbFuture returns the future of a long-running operation of the given type on a load balancer.
+func lbFuture(futureType, name string) *infrav1.Future {
+	return &infrav1.Future{Type: futureType, ServiceName: serviceName, ResourceGroup: "my-rg", Name: name, FutureData: "ZmFrZWZ1dHVyZQ=="}
+}
+
+// newPublicAPIServerLB returns the default public API server load balancer "my-publiclb".
+func newPublicAPIServerLB() network.LoadBalancer {
+	return network.LoadBalancer{
+		Tags: map[string]*string{
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+			"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr(infrav1.APIServerRole),
+		},
+		Sku:      &network.LoadBalancerSku{Name: network.LoadBalancerSkuNameStandard},
+		Location: to.StringPtr("testlocation"),
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &[]network.FrontendIPConfiguration{
+				{
+					Name: to.StringPtr("my-publiclb-frontEnd"),
+					FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+						PublicIPAddress: &network.PublicIPAddress{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-publicip")},
+					},
+				},
+			},
+			BackendAddressPools: &[]network.BackendAddressPool{
+				{
+					Name: to.StringPtr("my-publiclb-backendPool"),
+				},
+			},
+			LoadBalancingRules: &[]network.LoadBalancingRule{
+				{
+					Name: to.StringPtr("LBRuleHTTPS"),
+					LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+						DisableOutboundSnat:  to.BoolPtr(true),
+						Protocol:             network.TransportProtocolTCP,
+						FrontendPort:         to.Int32Ptr(6443),
+						BackendPort:          to.Int32Ptr(6443),
+						IdleTimeoutInMinutes: to.Int32Ptr(4),
+						EnableFloatingIP:     to.BoolPtr(false),
+						LoadDistribution:     network.LoadDistributionDefault,
+						FrontendIPConfiguration: &network.SubResource{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd"),
+						},
+						BackendAddressPool: &network.SubResource{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/backendAddressPools/my-publiclb-backendPool"),
+						},
+						Probe: &network.SubResource{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/probes/HTTPSProbe"),
+						},
+					},
+				},
+			},
+			Probes: &[]network.Probe{
+				{
+					Name: to.StringPtr("HTTPSProbe"),
+					ProbePropertiesFormat: &network.ProbePropertiesFormat{
+						Protocol:          network.ProbeProtocolHTTPS,
+						Port:              to.Int32Ptr(6443),
+						RequestPath:       to.StringPtr("/healthz"),
+						IntervalInSeconds: to.Int32Ptr(15),
+						NumberOfProbes:    to.Int32Ptr(4),
+					},
+				},
+			},
+			OutboundRules: &[]network.OutboundRule{
+				{
+					Name: to.StringPtr("OutboundNATAllProtocols"),
+					OutboundRulePropertiesFormat: &network.OutboundRulePropertiesFormat{
+						FrontendIPConfigurations: &[]network.SubResource{
+							{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd")},
+						},
+						BackendAddressPool: &network.SubResource{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/backendAddressPools/my-publiclb-backendPool"),
+						},
+						Protocol:             network.LoadBalancerOutboundRuleProtocolAll,
+						IdleTimeoutInMinutes: to.Int32Ptr(4),
+					},
+				},
+			},
+		},
+	}
+}
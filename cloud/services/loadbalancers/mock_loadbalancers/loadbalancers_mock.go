@@ -468,6 +468,44 @@ func (mr *MockLBScopeMockRecorder) OutboundPoolName(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundPoolName", reflect.TypeOf((*MockLBScope)(nil).OutboundPoolName), arg0)
 }
 
+// GetLongRunningOperationState mocks base method.
+func (m *MockLBScope) GetLongRunningOperationState(name, service string) *v1alpha3.Future {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLongRunningOperationState", name, service)
+	ret0, _ := ret[0].(*v1alpha3.Future)
+	return ret0
+}
+
+// GetLongRunningOperationState indicates an expected call of GetLongRunningOperationState.
+func (mr *MockLBScopeMockRecorder) GetLongRunningOperationState(name, service interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLongRunningOperationState", reflect.TypeOf((*MockLBScope)(nil).GetLongRunningOperationState), name, service)
+}
+
+// SetLongRunningOperationState mocks base method.
+func (m *MockLBScope) SetLongRunningOperationState(arg0 *v1alpha3.Future) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLongRunningOperationState", arg0)
+}
+
+// SetLongRunningOperationState indicates an expected call of SetLongRunningOperationState.
+func (mr *MockLBScopeMockRecorder) SetLongRunningOperationState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockLBScope)(nil).SetLongRunningOperationState), arg0)
+}
+
+// DeleteLongRunningOperationState mocks base method.
+func (m *MockLBScope) DeleteLongRunningOperationState(name, service string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteLongRunningOperationState", name, service)
+}
+
+// DeleteLongRunningOperationState indicates an expected call of DeleteLongRunningOperationState.
+func (mr *MockLBScopeMockRecorder) DeleteLongRunningOperationState(name, service interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockLBScope)(nil).DeleteLongRunningOperationState), name, service)
+}
+
 // LBSpecs mocks base method.
 func (m *MockLBScope) LBSpecs() []azure.LBSpec {
 	m.ctrl.T.Helper()
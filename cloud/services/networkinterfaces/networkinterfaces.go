@@ -24,7 +24,9 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -102,6 +104,12 @@ func (s *Service) Reconcile(ctx context.Context) error {
 						ID: to.StringPtr(azure.AddressPoolID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), nicSpec.InternalLBName, nicSpec.InternalLBAddressPoolName)),
 					})
 			}
+			for _, pool := range nicSpec.AdditionalLBAddressPools {
+				backendAddressPools = append(backendAddressPools,
+					network.BackendAddressPool{
+						ID: to.StringPtr(azure.AddressPoolID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), pool.LoadBalancerName, pool.PoolName)),
+					})
+			}
 			nicConfig.LoadBalancerBackendAddressPools = &backendAddressPools
 
 			if nicSpec.PublicIPName != "" {
@@ -146,6 +154,12 @@ func (s *Service) Reconcile(ctx context.Context) error {
 				nicSpec.Name,
 				network.Interface{
 					Location: to.StringPtr(s.Scope.Location()),
+					Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+						ClusterName: s.Scope.ClusterName(),
+						Lifecycle:   infrav1.ResourceLifecycleOwned,
+						Name:        to.StringPtr(nicSpec.Name),
+						MachineName: to.StringPtr(nicSpec.MachineName),
+					})),
 					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
 						EnableAcceleratedNetworking: nicSpec.AcceleratedNetworking,
 						IPConfigurations:            &ipConfigurations,
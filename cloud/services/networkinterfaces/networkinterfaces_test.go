@@ -68,6 +68,7 @@ func TestReconcileNetworkInterface(t *testing.T) {
 				s.SubscriptionID().AnyTimes().Return("123")
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.Location().AnyTimes().Return("fake-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				gomock.InOrder(
 					m.Get(gomockinternal.AContext(), "my-rg", "nic-1"),
 					m.Get(gomockinternal.AContext(), "my-rg", "nic-2"))
@@ -92,6 +93,7 @@ func TestReconcileNetworkInterface(t *testing.T) {
 				s.SubscriptionID().AnyTimes().Return("123")
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.Location().AnyTimes().Return("fake-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				gomock.InOrder(
 					m.Get(gomockinternal.AContext(), "my-rg", "my-net-interface").
 						Return(network.Interface{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found")),
@@ -121,11 +123,17 @@ func TestReconcileNetworkInterface(t *testing.T) {
 				s.SubscriptionID().AnyTimes().Return("123")
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.Location().AnyTimes().Return("fake-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				m.Get(gomockinternal.AContext(), "my-rg", "my-net-interface").
 					Return(network.Interface{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
 				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-net-interface", gomockinternal.DiffEq(network.Interface{
 					Location: to.StringPtr("fake-location"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("my-net-interface"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_machine":            to.StringPtr("azure-test1"),
+					},
 					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
 						EnableAcceleratedNetworking: to.BoolPtr(true),
 						EnableIPForwarding:          to.BoolPtr(false),
@@ -164,12 +172,18 @@ func TestReconcileNetworkInterface(t *testing.T) {
 				s.SubscriptionID().AnyTimes().Return("123")
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.Location().AnyTimes().Return("fake-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.V(gomock.AssignableToTypeOf(3)).AnyTimes().Return(klogr.New())
 				gomock.InOrder(
 					m.Get(gomockinternal.AContext(), "my-rg", "my-net-interface").
 						Return(network.Interface{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found")),
 					m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-net-interface", gomockinternal.DiffEq(network.Interface{
 						Location: to.StringPtr("fake-location"),
+						Tags: map[string]*string{
+							"Name": to.StringPtr("my-net-interface"),
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+							"sigs.k8s.io_cluster-api-provider-azure_machine":            to.StringPtr("azure-test1"),
+						},
 						InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
 							EnableAcceleratedNetworking: to.BoolPtr(true),
 							EnableIPForwarding:          to.BoolPtr(false),
@@ -210,11 +224,17 @@ func TestReconcileNetworkInterface(t *testing.T) {
 				s.SubscriptionID().AnyTimes().Return("123")
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.Location().AnyTimes().Return("fake-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.V(gomock.AssignableToTypeOf(3)).AnyTimes().Return(klogr.New())
 				m.Get(gomockinternal.AContext(), "my-rg", "my-net-interface").
 					Return(network.Interface{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
 				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-net-interface", gomockinternal.DiffEq(network.Interface{
 					Location: to.StringPtr("fake-location"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("my-net-interface"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_machine":            to.StringPtr("azure-test1"),
+					},
 					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
 						EnableAcceleratedNetworking: to.BoolPtr(true),
 						EnableIPForwarding:          to.BoolPtr(false),
@@ -235,6 +255,63 @@ func TestReconcileNetworkInterface(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name:          "node network interface joining additional load balancers successfully created",
+			expectedError: "",
+			expect: func(s *mock_networkinterfaces.MockNICScopeMockRecorder, m *mock_networkinterfaces.MockClientMockRecorder) {
+				s.NICSpecs().Return([]azure.NICSpec{
+					{
+						Name:                    "my-net-interface",
+						MachineName:             "azure-test1",
+						SubnetName:              "my-subnet",
+						VNetName:                "my-vnet",
+						VNetResourceGroup:       "my-rg",
+						PublicLBName:            "my-public-lb",
+						PublicLBAddressPoolName: "cluster-name-outboundBackendPool",
+						AdditionalLBAddressPools: []azure.LBBackendPoolSpec{
+							{
+								LoadBalancerName: "ingress-lb",
+								PoolName:         "ingress-lb-backendPool",
+							},
+						},
+						VMSize:                "Standard_D2v2",
+						AcceleratedNetworking: nil,
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("fake-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.V(gomock.AssignableToTypeOf(3)).AnyTimes().Return(klogr.New())
+				gomock.InOrder(
+					m.Get(gomockinternal.AContext(), "my-rg", "my-net-interface").
+						Return(network.Interface{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found")),
+					m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-net-interface", gomockinternal.DiffEq(network.Interface{
+						Location: to.StringPtr("fake-location"),
+						Tags: map[string]*string{
+							"Name": to.StringPtr("my-net-interface"),
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+							"sigs.k8s.io_cluster-api-provider-azure_machine":            to.StringPtr("azure-test1"),
+						},
+						InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+							EnableAcceleratedNetworking: to.BoolPtr(true),
+							EnableIPForwarding:          to.BoolPtr(false),
+							IPConfigurations: &[]network.InterfaceIPConfiguration{
+								{
+									Name: to.StringPtr("pipConfig"),
+									InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+										LoadBalancerBackendAddressPools: &[]network.BackendAddressPool{
+											{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-public-lb/backendAddressPools/cluster-name-outboundBackendPool")},
+											{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/ingress-lb/backendAddressPools/ingress-lb-backendPool")}},
+										PrivateIPAllocationMethod: network.Dynamic,
+										Subnet:                    &network.Subnet{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+									},
+								},
+							},
+						},
+					})))
+			},
+		},
 		{
 			name:          "network interface with Public IP successfully created",
 			expectedError: "",
@@ -254,6 +331,7 @@ func TestReconcileNetworkInterface(t *testing.T) {
 				s.SubscriptionID().AnyTimes().Return("123")
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.Location().AnyTimes().Return("fake-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.V(gomock.AssignableToTypeOf(3)).AnyTimes().Return(klogr.New())
 				m.Get(gomockinternal.AContext(), "my-rg", "my-public-net-interface").
 					Return(network.Interface{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
@@ -279,11 +357,17 @@ func TestReconcileNetworkInterface(t *testing.T) {
 				s.SubscriptionID().AnyTimes().Return("123")
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.Location().AnyTimes().Return("fake-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				m.Get(gomockinternal.AContext(), "my-rg", "my-net-interface").
 					Return(network.Interface{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
 				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-net-interface", gomockinternal.DiffEq(network.Interface{
 					Location: to.StringPtr("fake-location"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("my-net-interface"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_machine":            to.StringPtr("azure-test1"),
+					},
 					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
 						EnableAcceleratedNetworking: to.BoolPtr(true),
 						EnableIPForwarding:          to.BoolPtr(false),
@@ -322,11 +406,17 @@ func TestReconcileNetworkInterface(t *testing.T) {
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.Location().AnyTimes().Return("fake-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				m.Get(gomockinternal.AContext(), "my-rg", "my-net-interface").
 					Return(network.Interface{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
 				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-net-interface", gomockinternal.DiffEq(network.Interface{
 					Location: to.StringPtr("fake-location"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("my-net-interface"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_machine":            to.StringPtr("azure-test1"),
+					},
 					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
 						EnableAcceleratedNetworking: to.BoolPtr(false),
 						EnableIPForwarding:          to.BoolPtr(false),
@@ -365,12 +455,18 @@ func TestReconcileNetworkInterface(t *testing.T) {
 				s.SubscriptionID().AnyTimes().Return("123")
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.Location().AnyTimes().Return("fake-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				gomock.InOrder(
 					m.Get(gomockinternal.AContext(), "my-rg", "my-net-interface").
 						Return(network.Interface{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found")),
 					m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-net-interface", gomockinternal.DiffEq(network.Interface{
 						Location: to.StringPtr("fake-location"),
+						Tags: map[string]*string{
+							"Name": to.StringPtr("my-net-interface"),
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+							"sigs.k8s.io_cluster-api-provider-azure_machine":            to.StringPtr("azure-test1"),
+						},
 						InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
 							EnableAcceleratedNetworking: to.BoolPtr(true),
 							EnableIPForwarding:          to.BoolPtr(true),
@@ -0,0 +1,85 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client wraps go-sdk
+type client interface {
+	ListByResourceGroup(context.Context, string) ([]resources.GenericResourceExpanded, error)
+	DeleteByID(context.Context, string, string) error
+}
+
+// azureClient contains the Azure go-sdk Client
+type azureClient struct {
+	resources resources.Client
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new resources client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	c := newResourcesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return &azureClient{
+		resources: c,
+	}
+}
+
+// newResourcesClient creates a new generic resources client from subscription ID.
+func newResourcesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) resources.Client {
+	resourcesClient := resources.NewClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&resourcesClient.Client, authorizer)
+	return resourcesClient
+}
+
+// ListByResourceGroup lists all the resources of a resource group, along with their tags and creation time.
+func (ac *azureClient) ListByResourceGroup(ctx context.Context, resourceGroupName string) ([]resources.GenericResourceExpanded, error) {
+	ctx, span := tele.Tracer().Start(ctx, "orphans.AzureClient.ListByResourceGroup")
+	defer span.End()
+
+	iter, err := ac.resources.ListByResourceGroupComplete(ctx, resourceGroupName, "", "createdTime", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []resources.GenericResourceExpanded
+	for iter.NotDone() {
+		list = append(list, iter.Value())
+		if err := iter.NextWithContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// DeleteByID starts the deletion of a resource by ID without waiting for it to complete. Resources which are still
+// being deleted are found again, and deleted again, on the next pass of the collector.
+func (ac *azureClient) DeleteByID(ctx context.Context, resourceID string, apiVersion string) error {
+	ctx, span := tele.Tracer().Start(ctx, "orphans.AzureClient.DeleteByID")
+	defer span.End()
+
+	_, err := ac.resources.DeleteByID(ctx, resourceID, apiVersion)
+	return err
+}
@@ -0,0 +1,294 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../orphans.go
+
+// Package mock_orphans is a generated GoMock package.
+package mock_orphans
+
+import (
+	autorest "github.com/Azure/go-autorest/autorest"
+	logr "github.com/go-logr/logr"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockOrphanScope is a mock of OrphanScope interface.
+type MockOrphanScope struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrphanScopeMockRecorder
+}
+
+// MockOrphanScopeMockRecorder is the mock recorder for MockOrphanScope.
+type MockOrphanScopeMockRecorder struct {
+	mock *MockOrphanScope
+}
+
+// NewMockOrphanScope creates a new mock instance.
+func NewMockOrphanScope(ctrl *gomock.Controller) *MockOrphanScope {
+	mock := &MockOrphanScope{ctrl: ctrl}
+	mock.recorder = &MockOrphanScopeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrphanScope) EXPECT() *MockOrphanScopeMockRecorder {
+	return m.recorder
+}
+
+// Info mocks base method.
+func (m *MockOrphanScope) Info(msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockOrphanScopeMockRecorder) Info(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockOrphanScope)(nil).Info), varargs...)
+}
+
+// Enabled mocks base method.
+func (m *MockOrphanScope) Enabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockOrphanScopeMockRecorder) Enabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockOrphanScope)(nil).Enabled))
+}
+
+// Error mocks base method.
+func (m *MockOrphanScope) Error(err error, msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{err, msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockOrphanScopeMockRecorder) Error(err, msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{err, msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockOrphanScope)(nil).Error), varargs...)
+}
+
+// V mocks base method.
+func (m *MockOrphanScope) V(level int) logr.InfoLogger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V", level)
+	ret0, _ := ret[0].(logr.InfoLogger)
+	return ret0
+}
+
+// V indicates an expected call of V.
+func (mr *MockOrphanScopeMockRecorder) V(level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V", reflect.TypeOf((*MockOrphanScope)(nil).V), level)
+}
+
+// WithValues mocks base method.
+func (m *MockOrphanScope) WithValues(keysAndValues ...interface{}) logr.Logger {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WithValues", varargs...)
+	ret0, _ := ret[0].(logr.Logger)
+	return ret0
+}
+
+// WithValues indicates an expected call of WithValues.
+func (mr *MockOrphanScopeMockRecorder) WithValues(keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithValues", reflect.TypeOf((*MockOrphanScope)(nil).WithValues), keysAndValues...)
+}
+
+// WithName mocks base method.
+func (m *MockOrphanScope) WithName(name string) logr.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithName", name)
+	ret0, _ := ret[0].(logr.Logger)
+	return ret0
+}
+
+// WithName indicates an expected call of WithName.
+func (mr *MockOrphanScopeMockRecorder) WithName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithName", reflect.TypeOf((*MockOrphanScope)(nil).WithName), name)
+}
+
+// SubscriptionID mocks base method.
+func (m *MockOrphanScope) SubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SubscriptionID indicates an expected call of SubscriptionID.
+func (mr *MockOrphanScopeMockRecorder) SubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionID", reflect.TypeOf((*MockOrphanScope)(nil).SubscriptionID))
+}
+
+// ClientID mocks base method.
+func (m *MockOrphanScope) ClientID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientID indicates an expected call of ClientID.
+func (mr *MockOrphanScopeMockRecorder) ClientID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientID", reflect.TypeOf((*MockOrphanScope)(nil).ClientID))
+}
+
+// ClientSecret mocks base method.
+func (m *MockOrphanScope) ClientSecret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientSecret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientSecret indicates an expected call of ClientSecret.
+func (mr *MockOrphanScopeMockRecorder) ClientSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientSecret", reflect.TypeOf((*MockOrphanScope)(nil).ClientSecret))
+}
+
+// CloudEnvironment mocks base method.
+func (m *MockOrphanScope) CloudEnvironment() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudEnvironment")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CloudEnvironment indicates an expected call of CloudEnvironment.
+func (mr *MockOrphanScopeMockRecorder) CloudEnvironment() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockOrphanScope)(nil).CloudEnvironment))
+}
+
+// TenantID mocks base method.
+func (m *MockOrphanScope) TenantID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TenantID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TenantID indicates an expected call of TenantID.
+func (mr *MockOrphanScopeMockRecorder) TenantID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockOrphanScope)(nil).TenantID))
+}
+
+// BaseURI mocks base method.
+func (m *MockOrphanScope) BaseURI() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseURI")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseURI indicates an expected call of BaseURI.
+func (mr *MockOrphanScopeMockRecorder) BaseURI() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockOrphanScope)(nil).BaseURI))
+}
+
+// Authorizer mocks base method.
+func (m *MockOrphanScope) Authorizer() autorest.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorizer")
+	ret0, _ := ret[0].(autorest.Authorizer)
+	return ret0
+}
+
+// Authorizer indicates an expected call of Authorizer.
+func (mr *MockOrphanScopeMockRecorder) Authorizer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockOrphanScope)(nil).Authorizer))
+}
+
+// MockOwners is a mock of Owners interface.
+type MockOwners struct {
+	ctrl     *gomock.Controller
+	recorder *MockOwnersMockRecorder
+}
+
+// MockOwnersMockRecorder is the mock recorder for MockOwners.
+type MockOwnersMockRecorder struct {
+	mock *MockOwners
+}
+
+// NewMockOwners creates a new mock instance.
+func NewMockOwners(ctrl *gomock.Controller) *MockOwners {
+	mock := &MockOwners{ctrl: ctrl}
+	mock.recorder = &MockOwnersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOwners) EXPECT() *MockOwnersMockRecorder {
+	return m.recorder
+}
+
+// ClusterExists mocks base method.
+func (m *MockOwners) ClusterExists(name string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClusterExists", name)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ClusterExists indicates an expected call of ClusterExists.
+func (mr *MockOwnersMockRecorder) ClusterExists(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterExists", reflect.TypeOf((*MockOwners)(nil).ClusterExists), name)
+}
+
+// MachineExists mocks base method.
+func (m *MockOwners) MachineExists(cluster, name string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MachineExists", cluster, name)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// MachineExists indicates an expected call of MachineExists.
+func (mr *MockOwnersMockRecorder) MachineExists(cluster, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MachineExists", reflect.TypeOf((*MockOwners)(nil).MachineExists), cluster, name)
+}
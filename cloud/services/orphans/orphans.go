@@ -0,0 +1,217 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// deletableTypes are the resource types orphaned resources can be deleted for, along with the API version used to
+// delete them and their deletion order: resources are deleted before the resources they reference. Orphaned
+// resources of other types are only reported.
+var deletableTypes = map[string]struct {
+	apiVersion string
+	order      int
+}{
+	"microsoft.compute/virtualmachines":       {apiVersion: "2020-06-30", order: 0},
+	"microsoft.network/networkinterfaces":     {apiVersion: "2019-06-01", order: 1},
+	"microsoft.compute/disks":                 {apiVersion: "2020-06-30", order: 1},
+	"microsoft.compute/availabilitysets":      {apiVersion: "2020-06-30", order: 1},
+	"microsoft.network/loadbalancers":         {apiVersion: "2019-06-01", order: 2},
+	"microsoft.network/publicipaddresses":     {apiVersion: "2019-06-01", order: 3},
+	"microsoft.network/networksecuritygroups": {apiVersion: "2019-06-01", order: 3},
+	"microsoft.network/routetables":           {apiVersion: "2019-06-01", order: 3},
+	"microsoft.network/virtualnetworks":       {apiVersion: "2019-06-01", order: 4},
+}
+
+// OrphanScope defines the scope interface for an orphaned resources service.
+type OrphanScope interface {
+	logr.Logger
+	azure.Authorizer
+}
+
+// Owners tells whether the owners of Azure resources still exist.
+type Owners interface {
+	// ClusterExists returns true if a cluster with the given name exists.
+	ClusterExists(name string) bool
+	// MachineExists returns true if a machine with the given name exists in the cluster.
+	MachineExists(cluster, name string) bool
+}
+
+// Orphan is an Azure resource owned by a cluster or a machine that no longer exists.
+type Orphan struct {
+	ID   string
+	Name string
+	Type string
+	// ClusterName is the name of the cluster owning the resource.
+	ClusterName string
+	// OtherClusterNames are the names of the other clusters owning the resource, if any.
+	OtherClusterNames []string
+	// MachineName is the name of the machine the resource is dedicated to, if the cluster still exists.
+	MachineName string
+}
+
+// Owner describes the missing owner of the resource.
+func (o Orphan) Owner() string {
+	if o.MachineName != "" {
+		return fmt.Sprintf("machine %s of cluster %s", o.MachineName, o.ClusterName)
+	}
+	if len(o.OtherClusterNames) > 0 {
+		return fmt.Sprintf("clusters %s", strings.Join(append([]string{o.ClusterName}, o.OtherClusterNames...), ", "))
+	}
+	return fmt.Sprintf("cluster %s", o.ClusterName)
+}
+
+// OwnedBy returns true if one of the owners of the resource exists among the given owners, e.g. when a machine was
+// recreated with the same name since the resource was found.
+func (o Orphan) OwnedBy(owners Owners) bool {
+	for _, cluster := range append([]string{o.ClusterName}, o.OtherClusterNames...) {
+		if owners.ClusterExists(cluster) && (o.MachineName == "" || owners.MachineExists(cluster, o.MachineName)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Deletable returns true if the collector knows how to delete the resource.
+func (o Orphan) Deletable() bool {
+	_, ok := deletableTypes[strings.ToLower(o.Type)]
+	return ok
+}
+
+// Service provides operations on orphaned azure resources
+type Service struct {
+	Scope OrphanScope
+	client
+}
+
+// New creates a new service.
+func New(scope OrphanScope) *Service {
+	return &Service{
+		Scope:  scope,
+		client: newClient(scope),
+	}
+}
+
+// Find lists the resources of a resource group tagged as owned by clusters, or dedicated to a machine, which no
+// longer exist. A resource owned by several clusters is only orphaned once none of them exists. Resources are returned
+// in the order they can be deleted in.
+//
+// The owners are listed once the resources are, so that the owner of a resource created during the pass is listed
+// too. Resources created after createdBefore, or whose creation time is unknown, are skipped: their owner may still be
+// creating them.
+func (s *Service) Find(ctx context.Context, resourceGroup string, listOwners func(context.Context) (Owners, error), createdBefore time.Time) ([]Orphan, error) {
+	ctx, span := tele.Tracer().Start(ctx, "orphans.Service.Find")
+	defer span.End()
+
+	resources, err := s.client.ListByResourceGroup(ctx, resourceGroup)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list resources in resource group %s", resourceGroup)
+	}
+
+	owners, err := listOwners(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []Orphan
+	for _, resource := range resources {
+		if resource.CreatedTime == nil || !resource.CreatedTime.Before(createdBefore) {
+			continue
+		}
+		tags := converters.MapToTags(resource.Tags)
+		clusters := tags.GetOwnedClusters()
+		if len(clusters) == 0 {
+			continue
+		}
+		orphan := Orphan{
+			ID:                to.String(resource.ID),
+			Name:              to.String(resource.Name),
+			Type:              to.String(resource.Type),
+			ClusterName:       clusters[0],
+			OtherClusterNames: otherClusters(clusters, 0),
+		}
+		for i, cluster := range clusters {
+			// A resource dedicated to a machine of an existing cluster is orphaned once the machine no longer exists.
+			if owners.ClusterExists(cluster) {
+				orphan.ClusterName = cluster
+				orphan.OtherClusterNames = otherClusters(clusters, i)
+				orphan.MachineName = tags.GetMachine()
+				break
+			}
+		}
+		if orphan.OwnedBy(owners) {
+			continue
+		}
+		orphans = append(orphans, orphan)
+	}
+
+	sort.SliceStable(orphans, func(i, j int) bool {
+		return deletionOrder(orphans[i]) < deletionOrder(orphans[j])
+	})
+	return orphans, nil
+}
+
+// Delete starts the deletion of an orphaned resource.
+func (s *Service) Delete(ctx context.Context, orphan Orphan) error {
+	ctx, span := tele.Tracer().Start(ctx, "orphans.Service.Delete")
+	defer span.End()
+
+	deletable, ok := deletableTypes[strings.ToLower(orphan.Type)]
+	if !ok {
+		return errors.Errorf("deleting resources of type %s is not supported", orphan.Type)
+	}
+
+	s.Scope.V(2).Info("deleting orphaned resource", "resource", orphan.ID, "owner", orphan.Owner())
+	err := s.client.DeleteByID(ctx, orphan.ID, deletable.apiVersion)
+	if err != nil && azure.ResourceNotFound(err) {
+		// already deleted
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete orphaned resource %s", orphan.ID)
+	}
+	return nil
+}
+
+// otherClusters returns the clusters other than the i-th one.
+func otherClusters(clusters []string, i int) []string {
+	if len(clusters) < 2 {
+		return nil
+	}
+	return append(append([]string{}, clusters[:i]...), clusters[i+1:]...)
+}
+
+// deletionOrder returns the position of a resource in the deletion order, unsupported types coming last.
+func deletionOrder(orphan Orphan) int {
+	if deletable, ok := deletableTypes[strings.ToLower(orphan.Type)]; ok {
+		return deletable.order
+	}
+	return len(deletableTypes)
+}
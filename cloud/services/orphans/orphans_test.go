@@ -0,0 +1,340 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/klogr"
+
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/orphans/mock_orphans"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+type fakeOwners struct {
+	clusters sets.String
+	machines sets.String
+}
+
+func (o fakeOwners) ClusterExists(name string) bool {
+	return o.clusters.Has(name)
+}
+
+func (o fakeOwners) MachineExists(cluster, name string) bool {
+	return o.machines.Has(cluster + "/" + name)
+}
+
+// collectedBefore is the time before which resources must have been created to be collected.
+var collectedBefore = time.Date(2020, time.December, 1, 12, 0, 0, 0, time.UTC)
+
+func resource(name, resourceType string, tags map[string]string) resources.GenericResourceExpanded {
+	res := resources.GenericResourceExpanded{
+		ID:          to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/" + resourceType + "/" + name),
+		Name:        to.StringPtr(name),
+		Type:        to.StringPtr(resourceType),
+		Tags:        map[string]*string{},
+		CreatedTime: &date.Time{Time: collectedBefore.Add(-time.Hour)},
+	}
+	for k, v := range tags {
+		res.Tags[k] = to.StringPtr(v)
+	}
+	return res
+}
+
+func withCreatedTime(res resources.GenericResourceExpanded, created *date.Time) resources.GenericResourceExpanded {
+	res.CreatedTime = created
+	return res
+}
+
+func TestFindOrphans(t *testing.T) {
+	testcases := []struct {
+		name          string
+		resources     []resources.GenericResourceExpanded
+		listErr       error
+		ownersErr     error
+		expected      []Orphan
+		expectedError string
+	}{
+		{
+			name: "resources with existing owners",
+			resources: []resources.GenericResourceExpanded{
+				resource("my-vm", "Microsoft.Compute/virtualMachines", map[string]string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+					"sigs.k8s.io_cluster-api-provider-azure_machine":            "my-vm",
+				}),
+				resource("my-vnet", "Microsoft.Network/virtualNetworks", map[string]string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+				}),
+				resource("unrelated", "Microsoft.Storage/storageAccounts", nil),
+			},
+		},
+		{
+			name: "resources of deleted clusters and machines",
+			resources: []resources.GenericResourceExpanded{
+				resource("old-vnet", "Microsoft.Network/virtualNetworks", map[string]string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_old-cluster": "owned",
+				}),
+				resource("old-vm-nic", "Microsoft.Network/networkInterfaces", map[string]string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+					"sigs.k8s.io_cluster-api-provider-azure_machine":            "old-vm",
+				}),
+				resource("old-vm", "Microsoft.Compute/virtualMachines", map[string]string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+					"sigs.k8s.io_cluster-api-provider-azure_machine":            "old-vm",
+				}),
+				resource("old-storage", "Microsoft.Storage/storageAccounts", map[string]string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_old-cluster": "owned",
+				}),
+			},
+			expected: []Orphan{
+				{
+					ID:          "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/old-vm",
+					Name:        "old-vm",
+					Type:        "Microsoft.Compute/virtualMachines",
+					ClusterName: "my-cluster",
+					MachineName: "old-vm",
+				},
+				{
+					ID:          "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkInterfaces/old-vm-nic",
+					Name:        "old-vm-nic",
+					Type:        "Microsoft.Network/networkInterfaces",
+					ClusterName: "my-cluster",
+					MachineName: "old-vm",
+				},
+				{
+					ID:          "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/old-vnet",
+					Name:        "old-vnet",
+					Type:        "Microsoft.Network/virtualNetworks",
+					ClusterName: "old-cluster",
+				},
+				{
+					ID:          "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/old-storage",
+					Name:        "old-storage",
+					Type:        "Microsoft.Storage/storageAccounts",
+					ClusterName: "old-cluster",
+				},
+			},
+		},
+		{
+			name: "resources owned by several clusters",
+			resources: []resources.GenericResourceExpanded{
+				resource("common-nsg", "Microsoft.Network/networkSecurityGroups", map[string]string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster":  "owned",
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_old-cluster": "owned",
+				}),
+				resource("old-nsg", "Microsoft.Network/networkSecurityGroups", map[string]string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_old-cluster":   "owned",
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": "owned",
+				}),
+			},
+			expected: []Orphan{
+				{
+					ID:                "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/old-nsg",
+					Name:              "old-nsg",
+					Type:              "Microsoft.Network/networkSecurityGroups",
+					ClusterName:       "old-cluster",
+					OtherClusterNames: []string{"other-cluster"},
+				},
+			},
+		},
+		{
+			name: "shared resources are never orphaned",
+			resources: []resources.GenericResourceExpanded{
+				resource("shared-vnet", "Microsoft.Network/virtualNetworks", map[string]string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_old-cluster": "shared",
+				}),
+			},
+		},
+		{
+			name: "recently created resources are skipped",
+			resources: []resources.GenericResourceExpanded{
+				withCreatedTime(resource("new-vm-nic", "Microsoft.Network/networkInterfaces", map[string]string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+					"sigs.k8s.io_cluster-api-provider-azure_machine":            "new-vm",
+				}), &date.Time{Time: collectedBefore.Add(time.Second)}),
+				withCreatedTime(resource("unknown-vm-nic", "Microsoft.Network/networkInterfaces", map[string]string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+					"sigs.k8s.io_cluster-api-provider-azure_machine":            "unknown-vm",
+				}), nil),
+			},
+		},
+		{
+			name:          "owners list fails",
+			resources:     []resources.GenericResourceExpanded{},
+			ownersErr:     errors.New("failed to list AzureMachines"),
+			expectedError: "failed to list AzureMachines",
+		},
+		{
+			name:          "list fails",
+			listErr:       autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"),
+			expectedError: "failed to list resources in resource group my-rg: #: Internal Server Error: StatusCode=500",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_orphans.NewMockOrphanScope(mockCtrl)
+			clientMock := mock_orphans.NewMockclient(mockCtrl)
+			clientMock.EXPECT().ListByResourceGroup(gomockinternal.AContext(), "my-rg").Return(tc.resources, tc.listErr)
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			listOwners := func(context.Context) (Owners, error) {
+				return fakeOwners{
+					clusters: sets.NewString("my-cluster"),
+					machines: sets.NewString("my-cluster/my-vm"),
+				}, tc.ownersErr
+			}
+			orphans, err := s.Find(context.TODO(), "my-rg", listOwners, collectedBefore)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(orphans).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestDeleteOrphan(t *testing.T) {
+	testcases := []struct {
+		name          string
+		orphan        Orphan
+		expect        func(s *mock_orphans.MockOrphanScopeMockRecorder, m *mock_orphans.MockclientMockRecorder)
+		expectedError string
+	}{
+		{
+			name: "delete a network interface",
+			orphan: Orphan{
+				ID:          "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkInterfaces/old-vm-nic",
+				Type:        "Microsoft.Network/networkInterfaces",
+				ClusterName: "my-cluster",
+				MachineName: "old-vm",
+			},
+			expect: func(s *mock_orphans.MockOrphanScopeMockRecorder, m *mock_orphans.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.DeleteByID(gomockinternal.AContext(), "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkInterfaces/old-vm-nic", "2019-06-01")
+			},
+		},
+		{
+			name: "resource already deleted",
+			orphan: Orphan{
+				ID:          "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/disks/old-vm_OSDisk",
+				Type:        "Microsoft.Compute/disks",
+				ClusterName: "old-cluster",
+			},
+			expect: func(s *mock_orphans.MockOrphanScopeMockRecorder, m *mock_orphans.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.DeleteByID(gomockinternal.AContext(), "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/disks/old-vm_OSDisk", "2020-06-30").
+					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		},
+		{
+			name: "delete fails",
+			orphan: Orphan{
+				ID:          "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/old-ip",
+				Type:        "Microsoft.Network/publicIPAddresses",
+				ClusterName: "old-cluster",
+			},
+			expect: func(s *mock_orphans.MockOrphanScopeMockRecorder, m *mock_orphans.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.DeleteByID(gomockinternal.AContext(), "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/old-ip", "2019-06-01").
+					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 400}, "In use"))
+			},
+			expectedError: "failed to delete orphaned resource /subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/old-ip: #: In use: StatusCode=400",
+		},
+		{
+			name: "unsupported resource type",
+			orphan: Orphan{
+				ID:          "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/old-storage",
+				Type:        "Microsoft.Storage/storageAccounts",
+				ClusterName: "old-cluster",
+			},
+			expect:        func(s *mock_orphans.MockOrphanScopeMockRecorder, m *mock_orphans.MockclientMockRecorder) {},
+			expectedError: "deleting resources of type Microsoft.Storage/storageAccounts is not supported",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_orphans.NewMockOrphanScope(mockCtrl)
+			clientMock := mock_orphans.NewMockclient(mockCtrl)
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Delete(context.TODO(), tc.orphan)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestOrphanOwner(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(Orphan{ClusterName: "my-cluster", MachineName: "my-vm"}.Owner()).To(Equal("machine my-vm of cluster my-cluster"))
+	g.Expect(Orphan{ClusterName: "my-cluster"}.Owner()).To(Equal("cluster my-cluster"))
+	g.Expect(Orphan{ClusterName: "my-cluster", OtherClusterNames: []string{"other-cluster"}}.Owner()).To(Equal("clusters my-cluster, other-cluster"))
+	g.Expect(Orphan{Type: "Microsoft.Network/networkInterfaces"}.Deletable()).To(BeTrue())
+	g.Expect(Orphan{Type: "Microsoft.Storage/storageAccounts"}.Deletable()).To(BeFalse())
+}
+
+func TestOrphanOwnedBy(t *testing.T) {
+	g := NewWithT(t)
+
+	owners := fakeOwners{
+		clusters: sets.NewString("my-cluster"),
+		machines: sets.NewString("my-cluster/my-vm"),
+	}
+	g.Expect(Orphan{ClusterName: "my-cluster"}.OwnedBy(owners)).To(BeTrue())
+	g.Expect(Orphan{ClusterName: "my-cluster", MachineName: "my-vm"}.OwnedBy(owners)).To(BeTrue())
+	g.Expect(Orphan{ClusterName: "my-cluster", MachineName: "old-vm"}.OwnedBy(owners)).To(BeFalse())
+	g.Expect(Orphan{ClusterName: "old-cluster"}.OwnedBy(owners)).To(BeFalse())
+	g.Expect(Orphan{ClusterName: "old-cluster", OtherClusterNames: []string{"my-cluster"}}.OwnedBy(owners)).To(BeTrue())
+}
@@ -29,6 +29,7 @@ import (
 type client interface {
 	CreateOrUpdateZone(context.Context, string, string, privatedns.PrivateZone) error
 	DeleteZone(context.Context, string, string) error
+	GetLink(context.Context, string, string, string) (privatedns.VirtualNetworkLink, error)
 	CreateOrUpdateLink(context.Context, string, string, string, privatedns.VirtualNetworkLink) error
 	DeleteLink(context.Context, string, string, string) error
 	CreateOrUpdateRecordSet(context.Context, string, string, privatedns.RecordType, string, privatedns.RecordSet) error
@@ -46,33 +47,35 @@ var _ client = (*azureClient)(nil)
 
 // newClient creates a new VM client from subscription ID.
 func newClient(auth azure.Authorizer) *azureClient {
-	c := newPrivateZonesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
-	v := newVirtualNetworkLinksClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
-	r := newRecordSetsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return newClientForSubscription(auth, auth.SubscriptionID())
+}
+
+// newClientForSubscription creates a new client for the private DNS zones of the given subscription.
+func newClientForSubscription(auth azure.Authorizer, subscriptionID string) *azureClient {
+	c := newPrivateZonesClient(subscriptionID, auth.BaseURI(), auth.Authorizer())
+	v := newVirtualNetworkLinksClient(subscriptionID, auth.BaseURI(), auth.Authorizer())
+	r := newRecordSetsClient(subscriptionID, auth.BaseURI(), auth.Authorizer())
 	return &azureClient{c, v, r}
 }
 
 // newPrivateZonesClient creates a new private zones client from subscription ID.
 func newPrivateZonesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) privatedns.PrivateZonesClient {
 	zonesClient := privatedns.NewPrivateZonesClientWithBaseURI(baseURI, subscriptionID)
-	zonesClient.Authorizer = authorizer
-	zonesClient.AddToUserAgent(azure.UserAgent())
+	azure.SetAutoRestClientDefaults(&zonesClient.Client, authorizer)
 	return zonesClient
 }
 
 // newVirtualNetworkLinksClient creates a new virtual networks link client from subscription ID.
 func newVirtualNetworkLinksClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) privatedns.VirtualNetworkLinksClient {
 	linksClient := privatedns.NewVirtualNetworkLinksClientWithBaseURI(baseURI, subscriptionID)
-	linksClient.Authorizer = authorizer
-	linksClient.AddToUserAgent(azure.UserAgent())
+	azure.SetAutoRestClientDefaults(&linksClient.Client, authorizer)
 	return linksClient
 }
 
 // newRecordSetsClient creates a new record sets client from subscription ID.
 func newRecordSetsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) privatedns.RecordSetsClient {
 	recordsClient := privatedns.NewRecordSetsClientWithBaseURI(baseURI, subscriptionID)
-	recordsClient.Authorizer = authorizer
-	recordsClient.AddToUserAgent(azure.UserAgent())
+	azure.SetAutoRestClientDefaults(&recordsClient.Client, authorizer)
 	return recordsClient
 }
 
@@ -110,6 +113,14 @@ func (ac *azureClient) DeleteZone(ctx context.Context, resourceGroupName, name s
 	return err
 }
 
+// GetLink gets a virtual network link to the specified Private DNS zone.
+func (ac *azureClient) GetLink(ctx context.Context, resourceGroupName, privateZoneName, name string) (privatedns.VirtualNetworkLink, error) {
+	ctx, span := tele.Tracer().Start(ctx, "privatedns.AzureClient.GetLink")
+	defer span.End()
+
+	return ac.vnetlinks.Get(ctx, resourceGroupName, privateZoneName, name)
+}
+
 // CreateOrUpdateLink creates or updates a virtual network link to the specified Private DNS zone.
 func (ac *azureClient) CreateOrUpdateLink(ctx context.Context, resourceGroupName, privateZoneName, name string, link privatedns.VirtualNetworkLink) error {
 	ctx, span := tele.Tracer().Start(ctx, "privatedns.AzureClient.CreateOrUpdateLink")
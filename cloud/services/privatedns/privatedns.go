@@ -20,6 +20,7 @@ import (
 	"context"
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/privatedns/mgmt/privatedns"
 	"github.com/Azure/go-autorest/autorest/to"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 
@@ -57,17 +58,37 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	zoneSpec := s.Scope.PrivateDNSSpec()
 	if zoneSpec != nil {
-		// Create the private DNS zone.
-		s.Scope.V(2).Info("creating private DNS zone", "private dns zone", zoneSpec.ZoneName)
-		err := s.client.CreateOrUpdateZone(ctx, s.Scope.ResourceGroup(), zoneSpec.ZoneName, privatedns.PrivateZone{Location: to.StringPtr(azure.Global)})
-		if err != nil {
-			return errors.Wrapf(err, "failed to create private DNS zone %s", zoneSpec.ZoneName)
+		zoneClient := s.zoneClient(zoneSpec)
+
+		// Create the private DNS zone, unless an existing one is used.
+		if !zoneSpec.ExistingZone {
+			s.Scope.V(2).Info("creating private DNS zone", "private dns zone", zoneSpec.ZoneName)
+			err := zoneClient.CreateOrUpdateZone(ctx, zoneSpec.ZoneResourceGroup, zoneSpec.ZoneName, privatedns.PrivateZone{Location: to.StringPtr(azure.Global)})
+			if err != nil {
+				return errors.Wrapf(err, "failed to create private DNS zone %s", zoneSpec.ZoneName)
+			}
+			s.Scope.V(2).Info("successfully created private DNS zone", "private dns zone", zoneSpec.ZoneName)
 		}
-		s.Scope.V(2).Info("successfully created private DNS zone", "private dns zone", zoneSpec.ZoneName)
 
-		// Link the virtual network.
+		// Link the virtual network, and tag the link with the cluster. Other clusters sharing the virtual network and an
+		// existing zone use the same link, so their tags are kept.
 		s.Scope.V(2).Info("creating a virtual network link", "virtual network", zoneSpec.VNetName, "private dns zone", zoneSpec.ZoneName)
+		lifecycle := infrav1.ResourceLifecycleOwned
+		tags := infrav1.Tags{}
+		if zoneSpec.ExistingZone {
+			lifecycle = infrav1.ResourceLifecycleShared
+			existing, err := zoneClient.GetLink(ctx, zoneSpec.ZoneResourceGroup, zoneSpec.ZoneName, zoneSpec.LinkName)
+			if err != nil && !azure.ResourceNotFound(err) {
+				return errors.Wrapf(err, "failed to get virtual network link %s", zoneSpec.LinkName)
+			}
+			tags = converters.MapToTags(existing.Tags)
+		}
+		tags.Merge(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.Scope.ClusterName(),
+			Lifecycle:   lifecycle,
+		}))
 		link := privatedns.VirtualNetworkLink{
+			Tags: converters.TagsToMap(tags),
 			VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
 				VirtualNetwork: &privatedns.SubResource{
 					ID: to.StringPtr(azure.VNetID(s.Scope.SubscriptionID(), zoneSpec.VNetResourceGroup, zoneSpec.VNetName)),
@@ -76,7 +97,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			},
 			Location: to.StringPtr(azure.Global),
 		}
-		err = s.client.CreateOrUpdateLink(ctx, s.Scope.ResourceGroup(), zoneSpec.ZoneName, zoneSpec.LinkName, link)
+		err := zoneClient.CreateOrUpdateLink(ctx, zoneSpec.ZoneResourceGroup, zoneSpec.ZoneName, zoneSpec.LinkName, link)
 		if err != nil {
 			return errors.Wrapf(err, "failed to create virtual network link %s", zoneSpec.LinkName)
 		}
@@ -100,7 +121,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 					Ipv6Address: &record.IP,
 				}}
 			}
-			err := s.client.CreateOrUpdateRecordSet(ctx, s.Scope.ResourceGroup(), zoneSpec.ZoneName, recordType, record.Hostname, set)
+			err := zoneClient.CreateOrUpdateRecordSet(ctx, zoneSpec.ZoneResourceGroup, zoneSpec.ZoneName, recordType, record.Hostname, set)
 			if err != nil {
 				return errors.Wrapf(err, "failed to create record %s in private DNS zone %s", record.Hostname, zoneSpec.ZoneName)
 			}
@@ -110,31 +131,81 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return nil
 }
 
-// Delete deletes the private zone.
+// Delete deletes the private zone, or the records of the cluster from an existing zone.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "privatedns.Service.Delete")
 	defer span.End()
 
 	zoneSpec := s.Scope.PrivateDNSSpec()
 	if zoneSpec != nil {
-		// Remove the virtual network link.
-		s.Scope.V(2).Info("removing virtual network link", "virtual network", zoneSpec.VNetName, "private dns zone", zoneSpec.ZoneName)
-		err := s.client.DeleteLink(ctx, s.Scope.ResourceGroup(), zoneSpec.ZoneName, zoneSpec.LinkName)
-		if err != nil && !azure.ResourceNotFound(err) {
-			return errors.Wrapf(err, "failed to delete virtual network link %s with zone %s in resource group %s", zoneSpec.VNetName, zoneSpec.ZoneName, s.Scope.ResourceGroup())
+		zoneClient := s.zoneClient(zoneSpec)
+
+		if err := s.deleteLink(ctx, zoneClient, zoneSpec); err != nil {
+			return err
+		}
+
+		// An existing zone is kept, only the records of the cluster are deleted.
+		if zoneSpec.ExistingZone {
+			for _, record := range zoneSpec.Records {
+				s.Scope.V(2).Info("deleting record set", "private dns zone", zoneSpec.ZoneName, "record", record.Hostname)
+				err := zoneClient.DeleteRecordSet(ctx, zoneSpec.ZoneResourceGroup, zoneSpec.ZoneName, converters.GetRecordType(record.IP), record.Hostname)
+				if err != nil && !azure.ResourceNotFound(err) {
+					return errors.Wrapf(err, "failed to delete record %s in private DNS zone %s", record.Hostname, zoneSpec.ZoneName)
+				}
+			}
+			return nil
 		}
 
 		// Delete the private DNS zone, which also deletes all records.
 		s.Scope.V(2).Info("deleting private dns zone", "private dns zone", zoneSpec.ZoneName)
-		err = s.client.DeleteZone(ctx, s.Scope.ResourceGroup(), zoneSpec.ZoneName)
+		err := zoneClient.DeleteZone(ctx, zoneSpec.ZoneResourceGroup, zoneSpec.ZoneName)
 		if err != nil && azure.ResourceNotFound(err) {
 			// already deleted
 			return nil
 		}
 		if err != nil && !azure.ResourceNotFound(err) {
-			return errors.Wrapf(err, "failed to delete private dns zone %s in resource group %s", zoneSpec.ZoneName, s.Scope.ResourceGroup())
+			return errors.Wrapf(err, "failed to delete private dns zone %s in resource group %s", zoneSpec.ZoneName, zoneSpec.ZoneResourceGroup)
 		}
 		s.Scope.V(2).Info("successfully deleted private dns zone", "private dns zone", zoneSpec.ZoneName)
 	}
 	return nil
 }
+
+// deleteLink removes the virtual network link of the cluster. The link to an existing zone may be shared with other
+// clusters using the same virtual network, in which case only the tag of the cluster is removed from it.
+func (s *Service) deleteLink(ctx context.Context, zoneClient client, zoneSpec *azure.PrivateDNSSpec) error {
+	if zoneSpec.ExistingZone {
+		link, err := zoneClient.GetLink(ctx, zoneSpec.ZoneResourceGroup, zoneSpec.ZoneName, zoneSpec.LinkName)
+		if azure.ResourceNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to get virtual network link %s", zoneSpec.LinkName)
+		}
+		tags := converters.MapToTags(link.Tags)
+		delete(tags, infrav1.ClusterTagKey(s.Scope.ClusterName()))
+		if others := tags.GetClusters(); len(others) > 0 {
+			s.Scope.V(2).Info("keeping virtual network link used by other clusters", "virtual network", zoneSpec.VNetName, "private dns zone", zoneSpec.ZoneName, "clusters", others)
+			link.Tags = converters.TagsToMap(tags)
+			if err := zoneClient.CreateOrUpdateLink(ctx, zoneSpec.ZoneResourceGroup, zoneSpec.ZoneName, zoneSpec.LinkName, link); err != nil {
+				return errors.Wrapf(err, "failed to remove the cluster tag from virtual network link %s", zoneSpec.LinkName)
+			}
+			return nil
+		}
+	}
+
+	s.Scope.V(2).Info("removing virtual network link", "virtual network", zoneSpec.VNetName, "private dns zone", zoneSpec.ZoneName)
+	err := zoneClient.DeleteLink(ctx, zoneSpec.ZoneResourceGroup, zoneSpec.ZoneName, zoneSpec.LinkName)
+	if err != nil && !azure.ResourceNotFound(err) {
+		return errors.Wrapf(err, "failed to delete virtual network link %s with zone %s in resource group %s", zoneSpec.VNetName, zoneSpec.ZoneName, zoneSpec.ZoneResourceGroup)
+	}
+	return nil
+}
+
+// zoneClient returns the client for the private DNS zone, which may be in another subscription than the cluster.
+func (s *Service) zoneClient(zoneSpec *azure.PrivateDNSSpec) client {
+	if zoneSpec.ZoneSubscriptionID == "" {
+		return s.client
+	}
+	return newClientForSubscription(s.Scope, zoneSpec.ZoneSubscriptionID)
+}
@@ -55,6 +55,7 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
 					ZoneName:          "my-dns-zone",
+					ZoneResourceGroup: "my-rg",
 					VNetName:          "my-vnet",
 					VNetResourceGroup: "vnet-rg",
 					LinkName:          "my-link",
@@ -67,8 +68,12 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				})
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.SubscriptionID().Return("123")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				m.CreateOrUpdateZone(gomockinternal.AContext(), "my-rg", "my-dns-zone", privatedns.PrivateZone{Location: to.StringPtr(azure.Global)})
 				m.CreateOrUpdateLink(gomockinternal.AContext(), "my-rg", "my-dns-zone", "my-link", privatedns.VirtualNetworkLink{
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
 					VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
 						VirtualNetwork: &privatedns.SubResource{
 							ID: to.StringPtr("/subscriptions/123/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"),
@@ -96,6 +101,7 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
 					ZoneName:          "my-dns-zone",
+					ZoneResourceGroup: "my-rg",
 					VNetName:          "my-vnet",
 					VNetResourceGroup: "vnet-rg",
 					LinkName:          "my-link",
@@ -108,8 +114,12 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				})
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.SubscriptionID().Return("123")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				m.CreateOrUpdateZone(gomockinternal.AContext(), "my-rg", "my-dns-zone", privatedns.PrivateZone{Location: to.StringPtr(azure.Global)})
 				m.CreateOrUpdateLink(gomockinternal.AContext(), "my-rg", "my-dns-zone", "my-link", privatedns.VirtualNetworkLink{
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
 					VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
 						VirtualNetwork: &privatedns.SubResource{
 							ID: to.StringPtr("/subscriptions/123/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"),
@@ -130,6 +140,76 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				})
 			},
 		},
+		{
+			name:          "use an existing private dns zone",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, m *mock_privatedns.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
+					ZoneName:          "k8s.example.com",
+					ZoneResourceGroup: "dns-rg",
+					ExistingZone:      true,
+					VNetName:          "my-vnet",
+					VNetResourceGroup: "vnet-rg",
+					LinkName:          "my-link",
+					Records: []infrav1.AddressRecord{
+						{
+							Hostname: "apiserver.my-cluster",
+							IP:       "10.0.0.8",
+						},
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.SubscriptionID().Return("123")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.GetLink(gomockinternal.AContext(), "dns-rg", "k8s.example.com", "my-link").Return(privatedns.VirtualNetworkLink{
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": to.StringPtr("shared"),
+					},
+				}, nil)
+				m.CreateOrUpdateLink(gomockinternal.AContext(), "dns-rg", "k8s.example.com", "my-link", privatedns.VirtualNetworkLink{
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster":    to.StringPtr("shared"),
+					},
+					VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+						VirtualNetwork: &privatedns.SubResource{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"),
+						},
+						RegistrationEnabled: to.BoolPtr(false),
+					},
+					Location: to.StringPtr(azure.Global),
+				})
+				m.CreateOrUpdateRecordSet(gomockinternal.AContext(), "dns-rg", "k8s.example.com", privatedns.A, "apiserver.my-cluster", privatedns.RecordSet{
+					RecordSetProperties: &privatedns.RecordSetProperties{
+						TTL: to.Int64Ptr(300),
+						ARecords: &[]privatedns.ARecord{
+							{
+								Ipv4Address: to.StringPtr("10.0.0.8"),
+							},
+						},
+					},
+				})
+			},
+		},
+		{
+			name:          "fail to get the link to an existing private dns zone",
+			expectedError: "failed to get virtual network link my-link: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, m *mock_privatedns.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
+					ZoneName:          "k8s.example.com",
+					ZoneResourceGroup: "dns-rg",
+					ExistingZone:      true,
+					VNetName:          "my-vnet",
+					VNetResourceGroup: "vnet-rg",
+					LinkName:          "my-link",
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.GetLink(gomockinternal.AContext(), "dns-rg", "k8s.example.com", "my-link").
+					Return(privatedns.VirtualNetworkLink{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
 		{
 			name:          "link creation fails",
 			expectedError: "failed to create virtual network link my-link: #: Internal Server Error: StatusCode=500",
@@ -137,6 +217,7 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
 					ZoneName:          "my-dns-zone",
+					ZoneResourceGroup: "my-rg",
 					VNetName:          "my-vnet",
 					VNetResourceGroup: "vnet-rg",
 					LinkName:          "my-link",
@@ -149,8 +230,12 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				})
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.SubscriptionID().Return("123")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				m.CreateOrUpdateZone(gomockinternal.AContext(), "my-rg", "my-dns-zone", privatedns.PrivateZone{Location: to.StringPtr(azure.Global)})
 				m.CreateOrUpdateLink(gomockinternal.AContext(), "my-rg", "my-dns-zone", "my-link", privatedns.VirtualNetworkLink{
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
 					VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
 						VirtualNetwork: &privatedns.SubResource{
 							ID: to.StringPtr("/subscriptions/123/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"),
@@ -213,6 +298,7 @@ func TestDeletePrivateDNS(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
 					ZoneName:          "my-dns-zone",
+					ZoneResourceGroup: "my-rg",
 					VNetName:          "my-vnet",
 					VNetResourceGroup: "vnet-rg",
 					LinkName:          "my-link",
@@ -228,6 +314,110 @@ func TestDeletePrivateDNS(t *testing.T) {
 				m.DeleteZone(gomockinternal.AContext(), "my-rg", "my-dns-zone")
 			},
 		},
+		{
+			name:          "delete the records from an existing dns zone",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, m *mock_privatedns.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
+					ZoneName:          "k8s.example.com",
+					ZoneResourceGroup: "dns-rg",
+					ExistingZone:      true,
+					VNetName:          "my-vnet",
+					VNetResourceGroup: "vnet-rg",
+					LinkName:          "my-link",
+					Records: []infrav1.AddressRecord{
+						{
+							Hostname: "apiserver.my-cluster",
+							IP:       "10.0.0.8",
+						},
+						{
+							Hostname: "my-endpoint.my-cluster",
+							IP:       "2603:1030:805:2::b",
+						},
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.GetLink(gomockinternal.AContext(), "dns-rg", "k8s.example.com", "my-link").Return(privatedns.VirtualNetworkLink{
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("shared"),
+					},
+				}, nil)
+				m.DeleteLink(gomockinternal.AContext(), "dns-rg", "k8s.example.com", "my-link")
+				m.DeleteRecordSet(gomockinternal.AContext(), "dns-rg", "k8s.example.com", privatedns.A, "apiserver.my-cluster")
+				m.DeleteRecordSet(gomockinternal.AContext(), "dns-rg", "k8s.example.com", privatedns.AAAA, "my-endpoint.my-cluster").
+					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		},
+		{
+			name:          "keep the link to an existing dns zone used by another cluster",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, m *mock_privatedns.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
+					ZoneName:          "k8s.example.com",
+					ZoneResourceGroup: "dns-rg",
+					ExistingZone:      true,
+					VNetName:          "my-vnet",
+					VNetResourceGroup: "vnet-rg",
+					LinkName:          "my-link",
+					Records: []infrav1.AddressRecord{
+						{
+							Hostname: "apiserver.my-cluster",
+							IP:       "10.0.0.8",
+						},
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				link := privatedns.VirtualNetworkLink{
+					VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+						VirtualNetwork: &privatedns.SubResource{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"),
+						},
+						RegistrationEnabled: to.BoolPtr(false),
+					},
+					Location: to.StringPtr(azure.Global),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster":    to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": to.StringPtr("shared"),
+					},
+				}
+				m.GetLink(gomockinternal.AContext(), "dns-rg", "k8s.example.com", "my-link").Return(link, nil)
+				untagged := link
+				untagged.Tags = map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": to.StringPtr("shared"),
+				}
+				m.CreateOrUpdateLink(gomockinternal.AContext(), "dns-rg", "k8s.example.com", "my-link", untagged)
+				m.DeleteRecordSet(gomockinternal.AContext(), "dns-rg", "k8s.example.com", privatedns.A, "apiserver.my-cluster")
+			},
+		},
+		{
+			name:          "link to an existing dns zone already deleted",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, m *mock_privatedns.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
+					ZoneName:          "k8s.example.com",
+					ZoneResourceGroup: "dns-rg",
+					ExistingZone:      true,
+					VNetName:          "my-vnet",
+					VNetResourceGroup: "vnet-rg",
+					LinkName:          "my-link",
+					Records: []infrav1.AddressRecord{
+						{
+							Hostname: "apiserver.my-cluster",
+							IP:       "10.0.0.8",
+						},
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.GetLink(gomockinternal.AContext(), "dns-rg", "k8s.example.com", "my-link").
+					Return(privatedns.VirtualNetworkLink{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.DeleteRecordSet(gomockinternal.AContext(), "dns-rg", "k8s.example.com", privatedns.A, "apiserver.my-cluster")
+			},
+		},
 		{
 			name:          "link already deleted",
 			expectedError: "",
@@ -235,6 +425,7 @@ func TestDeletePrivateDNS(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
 					ZoneName:          "my-dns-zone",
+					ZoneResourceGroup: "my-rg",
 					VNetName:          "my-vnet",
 					VNetResourceGroup: "vnet-rg",
 					LinkName:          "my-link",
@@ -258,6 +449,7 @@ func TestDeletePrivateDNS(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
 					ZoneName:          "my-dns-zone",
+					ZoneResourceGroup: "my-rg",
 					VNetName:          "my-vnet",
 					VNetResourceGroup: "vnet-rg",
 					LinkName:          "my-link",
@@ -282,6 +474,7 @@ func TestDeletePrivateDNS(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
 					ZoneName:          "my-dns-zone",
+					ZoneResourceGroup: "my-rg",
 					VNetName:          "my-vnet",
 					VNetResourceGroup: "vnet-rg",
 					LinkName:          "my-link",
@@ -304,6 +497,7 @@ func TestDeletePrivateDNS(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
 					ZoneName:          "my-dns-zone",
+					ZoneResourceGroup: "my-rg",
 					VNetName:          "my-vnet",
 					VNetResourceGroup: "vnet-rg",
 					LinkName:          "my-link",
@@ -0,0 +1,150 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinks
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/go-autorest/autorest"
+
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client wraps go-sdk
+type client interface {
+	GetPrivateLinkService(context.Context, string, string) (network.PrivateLinkService, error)
+	CreateOrUpdatePrivateLinkService(context.Context, string, string, network.PrivateLinkService) error
+	DeletePrivateLinkService(context.Context, string, string) error
+	GetPrivateEndpoint(context.Context, string, string) (network.PrivateEndpoint, error)
+	CreateOrUpdatePrivateEndpoint(context.Context, string, string, network.PrivateEndpoint) error
+	DeletePrivateEndpoint(context.Context, string, string) error
+}
+
+// azureClient contains the Azure go-sdk Client
+type azureClient struct {
+	privatelinkservices network.PrivateLinkServicesClient
+	privateendpoints    network.PrivateEndpointsClient
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new private link client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	s := newPrivateLinkServicesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	e := newPrivateEndpointsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return &azureClient{s, e}
+}
+
+// newPrivateLinkServicesClient creates a new private link services client from subscription ID.
+func newPrivateLinkServicesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.PrivateLinkServicesClient {
+	servicesClient := network.NewPrivateLinkServicesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&servicesClient.Client, authorizer)
+	return servicesClient
+}
+
+// newPrivateEndpointsClient creates a new private endpoints client from subscription ID.
+func newPrivateEndpointsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.PrivateEndpointsClient {
+	endpointsClient := network.NewPrivateEndpointsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&endpointsClient.Client, authorizer)
+	return endpointsClient
+}
+
+// GetPrivateLinkService gets the specified private link service.
+func (ac *azureClient) GetPrivateLinkService(ctx context.Context, resourceGroupName, serviceName string) (network.PrivateLinkService, error) {
+	ctx, span := tele.Tracer().Start(ctx, "privatelinks.AzureClient.GetPrivateLinkService")
+	defer span.End()
+
+	return ac.privatelinkservices.Get(ctx, resourceGroupName, serviceName, "")
+}
+
+// CreateOrUpdatePrivateLinkService creates or updates a private link service.
+func (ac *azureClient) CreateOrUpdatePrivateLinkService(ctx context.Context, resourceGroupName, serviceName string, service network.PrivateLinkService) error {
+	ctx, span := tele.Tracer().Start(ctx, "privatelinks.AzureClient.CreateOrUpdatePrivateLinkService")
+	defer span.End()
+
+	future, err := ac.privatelinkservices.CreateOrUpdate(ctx, resourceGroupName, serviceName, service)
+	if err != nil {
+		return err
+	}
+	err = future.WaitForCompletionRef(ctx, ac.privatelinkservices.Client)
+	if err != nil {
+		return err
+	}
+	_, err = future.Result(ac.privatelinkservices)
+	return err
+}
+
+// DeletePrivateLinkService deletes a private link service.
+func (ac *azureClient) DeletePrivateLinkService(ctx context.Context, resourceGroupName, serviceName string) error {
+	ctx, span := tele.Tracer().Start(ctx, "privatelinks.AzureClient.DeletePrivateLinkService")
+	defer span.End()
+
+	future, err := ac.privatelinkservices.Delete(ctx, resourceGroupName, serviceName)
+	if err != nil {
+		return err
+	}
+	err = future.WaitForCompletionRef(ctx, ac.privatelinkservices.Client)
+	if err != nil {
+		return err
+	}
+	_, err = future.Result(ac.privatelinkservices)
+	return err
+}
+
+// GetPrivateEndpoint gets the specified private endpoint, along with its network interfaces.
+func (ac *azureClient) GetPrivateEndpoint(ctx context.Context, resourceGroupName, endpointName string) (network.PrivateEndpoint, error) {
+	ctx, span := tele.Tracer().Start(ctx, "privatelinks.AzureClient.GetPrivateEndpoint")
+	defer span.End()
+
+	return ac.privateendpoints.Get(ctx, resourceGroupName, endpointName, "")
+}
+
+// CreateOrUpdatePrivateEndpoint creates or updates a private endpoint.
+func (ac *azureClient) CreateOrUpdatePrivateEndpoint(ctx context.Context, resourceGroupName, endpointName string, endpoint network.PrivateEndpoint) error {
+	ctx, span := tele.Tracer().Start(ctx, "privatelinks.AzureClient.CreateOrUpdatePrivateEndpoint")
+	defer span.End()
+
+	future, err := ac.privateendpoints.CreateOrUpdate(ctx, resourceGroupName, endpointName, endpoint)
+	if err != nil {
+		return err
+	}
+	err = future.WaitForCompletionRef(ctx, ac.privateendpoints.Client)
+	if err != nil {
+		return err
+	}
+	_, err = future.Result(ac.privateendpoints)
+	return err
+}
+
+// DeletePrivateEndpoint deletes a private endpoint.
+func (ac *azureClient) DeletePrivateEndpoint(ctx context.Context, resourceGroupName, endpointName string) error {
+	ctx, span := tele.Tracer().Start(ctx, "privatelinks.AzureClient.DeletePrivateEndpoint")
+	defer span.End()
+
+	future, err := ac.privateendpoints.Delete(ctx, resourceGroupName, endpointName)
+	if err != nil {
+		return err
+	}
+	err = future.WaitForCompletionRef(ctx, ac.privateendpoints.Client)
+	if err != nil {
+		return err
+	}
+	_, err = future.Result(ac.privateendpoints)
+	return err
+}
@@ -0,0 +1,341 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/networkinterfaces"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// networkPoliciesDisabled is the value of the subnet network policies allowing private link services and private
+// endpoints in the subnet.
+const networkPoliciesDisabled = "Disabled"
+
+// Scope defines the scope interface for a private link service.
+type Scope interface {
+	logr.Logger
+	azure.ClusterDescriber
+	PrivateLinkSpec() *azure.PrivateLinkSpec
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope Scope
+	client
+	interfacesClient networkinterfaces.Client
+	subnetsClient    subnets.Client
+}
+
+// New creates a new private link service.
+func New(scope Scope) *Service {
+	return &Service{
+		Scope:            scope,
+		client:           newClient(scope),
+		interfacesClient: networkinterfaces.NewClient(scope),
+		subnetsClient:    subnets.NewClient(scope),
+	}
+}
+
+// Reconcile publishes the internal API server load balancer as a private link service, and creates the private
+// endpoints connecting consumer subnets to it.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, span := tele.Tracer().Start(ctx, "privatelinks.Service.Reconcile")
+	defer span.End()
+
+	spec := s.Scope.PrivateLinkSpec()
+	if spec == nil {
+		return nil
+	}
+
+	// The NAT IP address of the private link service is allocated from the control plane subnet.
+	if err := s.disableNetworkPolicies(ctx, spec); err != nil {
+		return err
+	}
+
+	serviceID := azure.PrivateLinkServiceID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), spec.Name)
+	service := network.PrivateLinkService{
+		Location: to.StringPtr(s.Scope.Location()),
+		Tags:     s.tags(),
+		PrivateLinkServiceProperties: &network.PrivateLinkServiceProperties{
+			LoadBalancerFrontendIPConfigurations: &[]network.FrontendIPConfiguration{
+				{
+					ID: to.StringPtr(azure.FrontendIPConfigID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), spec.LBName, spec.FrontendIPConfigName)),
+				},
+			},
+			IPConfigurations: &[]network.PrivateLinkServiceIPConfiguration{
+				{
+					Name: to.StringPtr(spec.Name + "-nat"),
+					PrivateLinkServiceIPConfigurationProperties: &network.PrivateLinkServiceIPConfigurationProperties{
+						PrivateIPAllocationMethod: network.Dynamic,
+						Subnet: &network.Subnet{
+							ID: to.StringPtr(azure.SubnetID(s.Scope.SubscriptionID(), spec.VNetResourceGroup, spec.VNetName, spec.SubnetName)),
+						},
+						Primary: to.BoolPtr(true),
+					},
+				},
+			},
+			Visibility:   &network.PrivateLinkServicePropertiesVisibility{Subscriptions: &spec.AllowedSubscriptions},
+			AutoApproval: &network.PrivateLinkServicePropertiesAutoApproval{Subscriptions: &spec.AllowedSubscriptions},
+		},
+	}
+	existingService, err := s.client.GetPrivateLinkService(ctx, s.Scope.ResourceGroup(), spec.Name)
+	switch {
+	case err != nil && !azure.ResourceNotFound(err):
+		return errors.Wrapf(err, "failed to get private link service %s", spec.Name)
+	case err == nil && isPrivateLinkServiceUpToDate(existingService, service):
+		s.Scope.V(2).Info("private link service exists and is up to date, skipping update", "private link service", spec.Name)
+	default:
+		s.Scope.V(2).Info("creating private link service", "private link service", spec.Name)
+		if err := s.client.CreateOrUpdatePrivateLinkService(ctx, s.Scope.ResourceGroup(), spec.Name, service); err != nil {
+			return errors.Wrapf(err, "failed to create private link service %s", spec.Name)
+		}
+		s.Scope.V(2).Info("successfully created private link service", "private link service", spec.Name)
+	}
+
+	for _, endpoint := range spec.PrivateEndpoints {
+		desired := network.PrivateEndpoint{
+			Location: to.StringPtr(s.Scope.Location()),
+			Tags:     s.tags(),
+			PrivateEndpointProperties: &network.PrivateEndpointProperties{
+				Subnet: &network.Subnet{ID: to.StringPtr(endpoint.SubnetID)},
+				PrivateLinkServiceConnections: &[]network.PrivateLinkServiceConnection{
+					{
+						Name: to.StringPtr(endpoint.Name),
+						PrivateLinkServiceConnectionProperties: &network.PrivateLinkServiceConnectionProperties{
+							PrivateLinkServiceID: to.StringPtr(serviceID),
+						},
+					},
+				},
+			},
+		}
+		existing, err := s.client.GetPrivateEndpoint(ctx, s.Scope.ResourceGroup(), endpoint.Name)
+		switch {
+		case err != nil && !azure.ResourceNotFound(err):
+			return errors.Wrapf(err, "failed to get private endpoint %s", endpoint.Name)
+		case err == nil && isPrivateEndpointUpToDate(existing, desired):
+			s.Scope.V(2).Info("private endpoint exists and is up to date, skipping update", "private endpoint", endpoint.Name)
+		default:
+			s.Scope.V(2).Info("creating private endpoint", "private endpoint", endpoint.Name)
+			if err := s.client.CreateOrUpdatePrivateEndpoint(ctx, s.Scope.ResourceGroup(), endpoint.Name, desired); err != nil {
+				return errors.Wrapf(err, "failed to create private endpoint %s", endpoint.Name)
+			}
+			// The network interface of the private endpoint is only known once it is created.
+			existing, err = s.client.GetPrivateEndpoint(ctx, s.Scope.ResourceGroup(), endpoint.Name)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get private endpoint %s", endpoint.Name)
+			}
+			s.Scope.V(2).Info("successfully created private endpoint", "private endpoint", endpoint.Name)
+		}
+
+		// The address of the private endpoint is recorded so that it can be resolved in the private DNS zone.
+		ip, err := s.privateIP(ctx, endpoint.Name, existing)
+		if err != nil {
+			return err
+		}
+		endpoint.PrivateIP = ip
+	}
+	return nil
+}
+
+// Delete deletes the private endpoints and the private link service. The private link of an AzureCluster can't be
+// modified, so the spec lists all the private endpoints created for it.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, span := tele.Tracer().Start(ctx, "privatelinks.Service.Delete")
+	defer span.End()
+
+	spec := s.Scope.PrivateLinkSpec()
+	if spec == nil {
+		return nil
+	}
+
+	for _, endpoint := range spec.PrivateEndpoints {
+		s.Scope.V(2).Info("deleting private endpoint", "private endpoint", endpoint.Name)
+		err := s.client.DeletePrivateEndpoint(ctx, s.Scope.ResourceGroup(), endpoint.Name)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to delete private endpoint %s in resource group %s", endpoint.Name, s.Scope.ResourceGroup())
+		}
+	}
+
+	s.Scope.V(2).Info("deleting private link service", "private link service", spec.Name)
+	err := s.client.DeletePrivateLinkService(ctx, s.Scope.ResourceGroup(), spec.Name)
+	if err != nil && azure.ResourceNotFound(err) {
+		// already deleted
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete private link service %s in resource group %s", spec.Name, s.Scope.ResourceGroup())
+	}
+	s.Scope.V(2).Info("successfully deleted private link service", "private link service", spec.Name)
+	return nil
+}
+
+// disableNetworkPolicies disables the private link service network policies of the subnet the NAT IP address of
+// the private link service is allocated from, which is required by Azure.
+func (s *Service) disableNetworkPolicies(ctx context.Context, spec *azure.PrivateLinkSpec) error {
+	subnet, err := s.subnetsClient.Get(ctx, spec.VNetResourceGroup, spec.VNetName, spec.SubnetName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get subnet %s in vnet %s", spec.SubnetName, spec.VNetName)
+	}
+	if subnet.SubnetPropertiesFormat == nil || strings.EqualFold(to.String(subnet.PrivateLinkServiceNetworkPolicies), networkPoliciesDisabled) {
+		return nil
+	}
+
+	s.Scope.V(2).Info("disabling private link service network policies", "subnet", spec.SubnetName)
+	subnet.PrivateLinkServiceNetworkPolicies = to.StringPtr(networkPoliciesDisabled)
+	if err := s.subnetsClient.CreateOrUpdate(ctx, spec.VNetResourceGroup, spec.VNetName, spec.SubnetName, subnet); err != nil {
+		return errors.Wrapf(err, "failed to disable private link service network policies of subnet %s", spec.SubnetName)
+	}
+	return nil
+}
+
+// privateIP returns the IP address of a private endpoint, read from the network interface Azure creates for it.
+func (s *Service) privateIP(ctx context.Context, endpointName string, endpoint network.PrivateEndpoint) (string, error) {
+	if endpoint.PrivateEndpointProperties == nil || endpoint.NetworkInterfaces == nil || len(*endpoint.NetworkInterfaces) == 0 {
+		return "", errors.Errorf("private endpoint %s has no network interface", endpointName)
+	}
+
+	nicID := to.String((*endpoint.NetworkInterfaces)[0].ID)
+	resource, err := autorestazure.ParseResourceID(nicID)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid network interface ID %s", nicID)
+	}
+	nic, err := s.interfacesClient.Get(ctx, resource.ResourceGroup, resource.ResourceName)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get network interface %s of private endpoint %s", resource.ResourceName, endpointName)
+	}
+	if nic.InterfacePropertiesFormat != nil && nic.IPConfigurations != nil {
+		for _, ipConfig := range *nic.IPConfigurations {
+			if ipConfig.InterfaceIPConfigurationPropertiesFormat != nil && ipConfig.PrivateIPAddress != nil {
+				return *ipConfig.PrivateIPAddress, nil
+			}
+		}
+	}
+	return "", errors.Errorf("network interface %s of private endpoint %s has no private IP address", resource.ResourceName, endpointName)
+}
+
+// isPrivateLinkServiceUpToDate returns true if the settings managed by the service are the same on the existing and
+// the desired private link service, i.e. when no update is required.
+func isPrivateLinkServiceUpToDate(existing, desired network.PrivateLinkService) bool {
+	if existing.PrivateLinkServiceProperties == nil || existing.ProvisioningState == network.Failed {
+		return false
+	}
+	have, want := existing.PrivateLinkServiceProperties, desired.PrivateLinkServiceProperties
+
+	var haveFrontends, wantFrontends []string
+	if have.LoadBalancerFrontendIPConfigurations != nil {
+		for _, frontend := range *have.LoadBalancerFrontendIPConfigurations {
+			haveFrontends = append(haveFrontends, to.String(frontend.ID))
+		}
+	}
+	for _, frontend := range *want.LoadBalancerFrontendIPConfigurations {
+		wantFrontends = append(wantFrontends, to.String(frontend.ID))
+	}
+	if !sameIDs(haveFrontends, wantFrontends) {
+		return false
+	}
+
+	var haveSubnets, wantSubnets []string
+	if have.IPConfigurations != nil {
+		for _, ipConfig := range *have.IPConfigurations {
+			if ipConfig.PrivateLinkServiceIPConfigurationProperties != nil && ipConfig.Subnet != nil {
+				haveSubnets = append(haveSubnets, to.String(ipConfig.Subnet.ID))
+			}
+		}
+	}
+	for _, ipConfig := range *want.IPConfigurations {
+		wantSubnets = append(wantSubnets, to.String(ipConfig.Subnet.ID))
+	}
+	if !sameIDs(haveSubnets, wantSubnets) {
+		return false
+	}
+
+	var haveVisibility, haveAutoApproval []string
+	if have.Visibility != nil && have.Visibility.Subscriptions != nil {
+		haveVisibility = *have.Visibility.Subscriptions
+	}
+	if have.AutoApproval != nil && have.AutoApproval.Subscriptions != nil {
+		haveAutoApproval = *have.AutoApproval.Subscriptions
+	}
+	return sameIDs(haveVisibility, *want.Visibility.Subscriptions) && sameIDs(haveAutoApproval, *want.AutoApproval.Subscriptions)
+}
+
+// isPrivateEndpointUpToDate returns true if the existing private endpoint is in the desired subnet and connected to
+// the desired private link service, i.e. when no update is required.
+func isPrivateEndpointUpToDate(existing, desired network.PrivateEndpoint) bool {
+	if existing.PrivateEndpointProperties == nil || existing.ProvisioningState == network.Failed {
+		return false
+	}
+	have, want := existing.PrivateEndpointProperties, desired.PrivateEndpointProperties
+	if have.Subnet == nil || !strings.EqualFold(to.String(have.Subnet.ID), to.String(want.Subnet.ID)) {
+		return false
+	}
+
+	var haveServices, wantServices []string
+	if have.PrivateLinkServiceConnections != nil {
+		for _, connection := range *have.PrivateLinkServiceConnections {
+			if connection.PrivateLinkServiceConnectionProperties != nil {
+				haveServices = append(haveServices, to.String(connection.PrivateLinkServiceID))
+			}
+		}
+	}
+	for _, connection := range *want.PrivateLinkServiceConnections {
+		wantServices = append(wantServices, to.String(connection.PrivateLinkServiceID))
+	}
+	return sameIDs(haveServices, wantServices)
+}
+
+// sameIDs returns true if both lists hold the same Azure resource or subscription IDs, regardless of their order
+// and case.
+func sameIDs(have, want []string) bool {
+	if len(have) != len(want) {
+		return false
+	}
+	ids := make(map[string]int, len(want))
+	for _, id := range want {
+		ids[strings.ToLower(id)]++
+	}
+	for _, id := range have {
+		id = strings.ToLower(id)
+		if ids[id] == 0 {
+			return false
+		}
+		ids[id]--
+	}
+	return true
+}
+
+// tags returns the tags of the resources owned by the cluster.
+func (s *Service) tags() map[string]*string {
+	return converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.Scope.ClusterName(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Additional:  s.Scope.AdditionalTags(),
+	}))
+}
@@ -0,0 +1,523 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinks
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/klog/klogr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/networkinterfaces/mock_networkinterfaces"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/privatelinks/mock_privatelinks"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/subnets/mock_subnets"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+const nicID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkInterfaces/my-endpoint-nic"
+
+func newPrivateLinkSpec() *azure.PrivateLinkSpec {
+	return &azure.PrivateLinkSpec{
+		Name:                 "my-cluster-apiserver-pls",
+		LBName:               "my-cluster-internal-lb",
+		FrontendIPConfigName: "my-cluster-internal-lb-frontEnd",
+		SubnetName:           "my-cp-subnet",
+		VNetName:             "my-vnet",
+		VNetResourceGroup:    "my-vnet-rg",
+		AllowedSubscriptions: []string{"123", "456"},
+		PrivateEndpoints: []*infrav1.PrivateEndpointSpec{
+			{
+				Name:     "my-endpoint",
+				SubnetID: "/subscriptions/456/resourceGroups/consumer-rg/providers/Microsoft.Network/virtualNetworks/consumer-vnet/subnets/consumer-subnet",
+			},
+		},
+	}
+}
+
+// newExistingPrivateLinkService returns the private link service matching newPrivateLinkSpec, as returned by Azure.
+func newExistingPrivateLinkService() network.PrivateLinkService {
+	return network.PrivateLinkService{
+		Name: to.StringPtr("my-cluster-apiserver-pls"),
+		PrivateLinkServiceProperties: &network.PrivateLinkServiceProperties{
+			ProvisioningState: network.Succeeded,
+			LoadBalancerFrontendIPConfigurations: &[]network.FrontendIPConfiguration{
+				{
+					ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster-internal-lb/frontendIPConfigurations/my-cluster-internal-lb-frontEnd"),
+				},
+			},
+			IPConfigurations: &[]network.PrivateLinkServiceIPConfiguration{
+				{
+					Name: to.StringPtr("my-cluster-apiserver-pls-nat"),
+					PrivateLinkServiceIPConfigurationProperties: &network.PrivateLinkServiceIPConfigurationProperties{
+						PrivateIPAddress: to.StringPtr("10.0.0.5"),
+						Subnet: &network.Subnet{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/MY-VNET-RG/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-cp-subnet"),
+						},
+					},
+				},
+			},
+			Visibility:   &network.PrivateLinkServicePropertiesVisibility{Subscriptions: &[]string{"456", "123"}},
+			AutoApproval: &network.PrivateLinkServicePropertiesAutoApproval{Subscriptions: &[]string{"456", "123"}},
+		},
+	}
+}
+
+// newExistingPrivateEndpoint returns the private endpoint matching newPrivateLinkSpec, as returned by Azure.
+func newExistingPrivateEndpoint() network.PrivateEndpoint {
+	return network.PrivateEndpoint{
+		Name: to.StringPtr("my-endpoint"),
+		PrivateEndpointProperties: &network.PrivateEndpointProperties{
+			ProvisioningState: network.Succeeded,
+			Subnet: &network.Subnet{
+				ID: to.StringPtr("/subscriptions/456/resourceGroups/consumer-rg/providers/Microsoft.Network/virtualNetworks/consumer-vnet/subnets/consumer-subnet"),
+			},
+			NetworkInterfaces: &[]network.Interface{{ID: to.StringPtr(nicID)}},
+			PrivateLinkServiceConnections: &[]network.PrivateLinkServiceConnection{
+				{
+					Name: to.StringPtr("my-endpoint"),
+					PrivateLinkServiceConnectionProperties: &network.PrivateLinkServiceConnectionProperties{
+						PrivateLinkServiceID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateLinkServices/my-cluster-apiserver-pls"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func newEndpointInterface() network.Interface {
+	return network.Interface{
+		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+			IPConfigurations: &[]network.InterfaceIPConfiguration{
+				{
+					InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+						PrivateIPAddress: to.StringPtr("10.1.0.4"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestReconcilePrivateLink(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder, nic *mock_networkinterfaces.MockClientMockRecorder)
+	}{
+		{
+			name:          "no private link",
+			expectedError: "",
+			expect: func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder, nic *mock_networkinterfaces.MockClientMockRecorder) {
+				s.PrivateLinkSpec().Return(nil)
+			},
+		},
+		{
+			name:          "create private link service and private endpoint successfully",
+			expectedError: "",
+			expect: func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder, nic *mock_networkinterfaces.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateLinkSpec().Return(newPrivateLinkSpec())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.Location().AnyTimes().Return("westus")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				sn.Get(gomockinternal.AContext(), "my-vnet-rg", "my-vnet", "my-cp-subnet").Return(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{AddressPrefix: to.StringPtr("10.0.0.0/16")},
+				}, nil)
+				sn.CreateOrUpdate(gomockinternal.AContext(), "my-vnet-rg", "my-vnet", "my-cp-subnet", network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						AddressPrefix:                     to.StringPtr("10.0.0.0/16"),
+						PrivateLinkServiceNetworkPolicies: to.StringPtr("Disabled"),
+					},
+				})
+				m.GetPrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls").
+					Return(network.PrivateLinkService{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdatePrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls", gomockinternal.DiffEq(network.PrivateLinkService{
+					Location: to.StringPtr("westus"),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
+					PrivateLinkServiceProperties: &network.PrivateLinkServiceProperties{
+						LoadBalancerFrontendIPConfigurations: &[]network.FrontendIPConfiguration{
+							{
+								ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster-internal-lb/frontendIPConfigurations/my-cluster-internal-lb-frontEnd"),
+							},
+						},
+						IPConfigurations: &[]network.PrivateLinkServiceIPConfiguration{
+							{
+								Name: to.StringPtr("my-cluster-apiserver-pls-nat"),
+								PrivateLinkServiceIPConfigurationProperties: &network.PrivateLinkServiceIPConfigurationProperties{
+									PrivateIPAllocationMethod: network.Dynamic,
+									Subnet: &network.Subnet{
+										ID: to.StringPtr("/subscriptions/123/resourceGroups/my-vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-cp-subnet"),
+									},
+									Primary: to.BoolPtr(true),
+								},
+							},
+						},
+						Visibility:   &network.PrivateLinkServicePropertiesVisibility{Subscriptions: &[]string{"123", "456"}},
+						AutoApproval: &network.PrivateLinkServicePropertiesAutoApproval{Subscriptions: &[]string{"123", "456"}},
+					},
+				}))
+				m.GetPrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint").
+					Return(network.PrivateEndpoint{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdatePrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint", gomockinternal.DiffEq(network.PrivateEndpoint{
+					Location: to.StringPtr("westus"),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
+					PrivateEndpointProperties: &network.PrivateEndpointProperties{
+						Subnet: &network.Subnet{
+							ID: to.StringPtr("/subscriptions/456/resourceGroups/consumer-rg/providers/Microsoft.Network/virtualNetworks/consumer-vnet/subnets/consumer-subnet"),
+						},
+						PrivateLinkServiceConnections: &[]network.PrivateLinkServiceConnection{
+							{
+								Name: to.StringPtr("my-endpoint"),
+								PrivateLinkServiceConnectionProperties: &network.PrivateLinkServiceConnectionProperties{
+									PrivateLinkServiceID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateLinkServices/my-cluster-apiserver-pls"),
+								},
+							},
+						},
+					},
+				}))
+				m.GetPrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint").Return(network.PrivateEndpoint{
+					PrivateEndpointProperties: &network.PrivateEndpointProperties{
+						NetworkInterfaces: &[]network.Interface{{ID: to.StringPtr(nicID)}},
+					},
+				}, nil)
+				nic.Get(gomockinternal.AContext(), "my-rg", "my-endpoint-nic").Return(network.Interface{
+					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+						IPConfigurations: &[]network.InterfaceIPConfiguration{
+							{
+								InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+									PrivateIPAddress: to.StringPtr("10.1.0.4"),
+								},
+							},
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:          "skip disabling network policies already disabled",
+			expectedError: "",
+			expect: func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder, nic *mock_networkinterfaces.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				spec := newPrivateLinkSpec()
+				spec.PrivateEndpoints = nil
+				s.PrivateLinkSpec().Return(spec)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.Location().AnyTimes().Return("westus")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				sn.Get(gomockinternal.AContext(), "my-vnet-rg", "my-vnet", "my-cp-subnet").Return(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						AddressPrefix:                     to.StringPtr("10.0.0.0/16"),
+						PrivateLinkServiceNetworkPolicies: to.StringPtr("Disabled"),
+					},
+				}, nil)
+				m.GetPrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls").
+					Return(network.PrivateLinkService{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdatePrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls", gomock.AssignableToTypeOf(network.PrivateLinkService{}))
+			},
+		},
+		{
+			name:          "skip updating private link service and private endpoint that are up to date",
+			expectedError: "",
+			expect: func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder, nic *mock_networkinterfaces.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateLinkSpec().Return(newPrivateLinkSpec())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.Location().AnyTimes().Return("westus")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				sn.Get(gomockinternal.AContext(), "my-vnet-rg", "my-vnet", "my-cp-subnet").Return(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{PrivateLinkServiceNetworkPolicies: to.StringPtr("Disabled")},
+				}, nil)
+				m.GetPrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls").Return(newExistingPrivateLinkService(), nil)
+				m.GetPrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint").Return(newExistingPrivateEndpoint(), nil)
+				nic.Get(gomockinternal.AContext(), "my-rg", "my-endpoint-nic").Return(newEndpointInterface(), nil)
+			},
+		},
+		{
+			name:          "update private link service and private endpoint that have drifted",
+			expectedError: "",
+			expect: func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder, nic *mock_networkinterfaces.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateLinkSpec().Return(newPrivateLinkSpec())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.Location().AnyTimes().Return("westus")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				sn.Get(gomockinternal.AContext(), "my-vnet-rg", "my-vnet", "my-cp-subnet").Return(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{PrivateLinkServiceNetworkPolicies: to.StringPtr("Disabled")},
+				}, nil)
+				service := newExistingPrivateLinkService()
+				service.AutoApproval.Subscriptions = &[]string{"123"}
+				m.GetPrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls").Return(service, nil)
+				m.CreateOrUpdatePrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls", gomock.AssignableToTypeOf(network.PrivateLinkService{}))
+				endpoint := newExistingPrivateEndpoint()
+				endpoint.ProvisioningState = network.Failed
+				m.GetPrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint").Return(endpoint, nil)
+				m.CreateOrUpdatePrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint", gomock.AssignableToTypeOf(network.PrivateEndpoint{}))
+				m.GetPrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint").Return(newExistingPrivateEndpoint(), nil)
+				nic.Get(gomockinternal.AContext(), "my-rg", "my-endpoint-nic").Return(newEndpointInterface(), nil)
+			},
+		},
+		{
+			name:          "private link service get fails",
+			expectedError: "failed to get private link service my-cluster-apiserver-pls: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder, nic *mock_networkinterfaces.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateLinkSpec().Return(newPrivateLinkSpec())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.Location().AnyTimes().Return("westus")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				sn.Get(gomockinternal.AContext(), "my-vnet-rg", "my-vnet", "my-cp-subnet").Return(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{PrivateLinkServiceNetworkPolicies: to.StringPtr("Disabled")},
+				}, nil)
+				m.GetPrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls").
+					Return(network.PrivateLinkService{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+		{
+			name:          "private link service creation fails",
+			expectedError: "failed to create private link service my-cluster-apiserver-pls: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder, nic *mock_networkinterfaces.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateLinkSpec().Return(newPrivateLinkSpec())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.Location().AnyTimes().Return("westus")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				sn.Get(gomockinternal.AContext(), "my-vnet-rg", "my-vnet", "my-cp-subnet").Return(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{PrivateLinkServiceNetworkPolicies: to.StringPtr("Disabled")},
+				}, nil)
+				m.GetPrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls").
+					Return(network.PrivateLinkService{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdatePrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls", gomock.AssignableToTypeOf(network.PrivateLinkService{})).
+					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+		{
+			name:          "private endpoint without network interface fails",
+			expectedError: "private endpoint my-endpoint has no network interface",
+			expect: func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder, nic *mock_networkinterfaces.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateLinkSpec().Return(newPrivateLinkSpec())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.Location().AnyTimes().Return("westus")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				sn.Get(gomockinternal.AContext(), "my-vnet-rg", "my-vnet", "my-cp-subnet").Return(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{PrivateLinkServiceNetworkPolicies: to.StringPtr("Disabled")},
+				}, nil)
+				m.GetPrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls").
+					Return(network.PrivateLinkService{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdatePrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls", gomock.AssignableToTypeOf(network.PrivateLinkService{}))
+				m.GetPrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint").
+					Return(network.PrivateEndpoint{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdatePrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint", gomock.AssignableToTypeOf(network.PrivateEndpoint{}))
+				m.GetPrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint").Return(network.PrivateEndpoint{
+					PrivateEndpointProperties: &network.PrivateEndpointProperties{},
+				}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatelinks.NewMockScope(mockCtrl)
+			clientMock := mock_privatelinks.NewMockclient(mockCtrl)
+			subnetsMock := mock_subnets.NewMockClient(mockCtrl)
+			interfacesMock := mock_networkinterfaces.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), subnetsMock.EXPECT(), interfacesMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				client:           clientMock,
+				subnetsClient:    subnetsMock,
+				interfacesClient: interfacesMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestReconcilePrivateLinkRecordsEndpointIP(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_privatelinks.NewMockScope(mockCtrl)
+	clientMock := mock_privatelinks.NewMockclient(mockCtrl)
+	subnetsMock := mock_subnets.NewMockClient(mockCtrl)
+	interfacesMock := mock_networkinterfaces.NewMockClient(mockCtrl)
+
+	spec := newPrivateLinkSpec()
+	s := scopeMock.EXPECT()
+	s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+	s.PrivateLinkSpec().Return(spec)
+	s.ResourceGroup().AnyTimes().Return("my-rg")
+	s.SubscriptionID().AnyTimes().Return("123")
+	s.Location().AnyTimes().Return("westus")
+	s.ClusterName().AnyTimes().Return("my-cluster")
+	s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+	subnetsMock.EXPECT().Get(gomockinternal.AContext(), "my-vnet-rg", "my-vnet", "my-cp-subnet").Return(network.Subnet{
+		SubnetPropertiesFormat: &network.SubnetPropertiesFormat{PrivateLinkServiceNetworkPolicies: to.StringPtr("Disabled")},
+	}, nil)
+	clientMock.EXPECT().GetPrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls").
+		Return(network.PrivateLinkService{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+	clientMock.EXPECT().CreateOrUpdatePrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls", gomock.AssignableToTypeOf(network.PrivateLinkService{}))
+	clientMock.EXPECT().GetPrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint").
+		Return(network.PrivateEndpoint{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+	clientMock.EXPECT().CreateOrUpdatePrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint", gomock.AssignableToTypeOf(network.PrivateEndpoint{}))
+	clientMock.EXPECT().GetPrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint").Return(network.PrivateEndpoint{
+		PrivateEndpointProperties: &network.PrivateEndpointProperties{
+			NetworkInterfaces: &[]network.Interface{{ID: to.StringPtr(nicID)}},
+		},
+	}, nil)
+	interfacesMock.EXPECT().Get(gomockinternal.AContext(), "my-rg", "my-endpoint-nic").Return(network.Interface{
+		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+			IPConfigurations: &[]network.InterfaceIPConfiguration{
+				{
+					InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+						PrivateIPAddress: to.StringPtr("10.1.0.4"),
+					},
+				},
+			},
+		},
+	}, nil)
+
+	svc := &Service{
+		Scope:            scopeMock,
+		client:           clientMock,
+		subnetsClient:    subnetsMock,
+		interfacesClient: interfacesMock,
+	}
+
+	g.Expect(svc.Reconcile(context.TODO())).To(Succeed())
+	g.Expect(spec.PrivateEndpoints[0].PrivateIP).To(Equal("10.1.0.4"))
+}
+
+func TestDeletePrivateLink(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder)
+	}{
+		{
+			name:          "no private link",
+			expectedError: "",
+			expect: func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder) {
+				s.PrivateLinkSpec().Return(nil)
+			},
+		},
+		{
+			name:          "delete private endpoints and private link service successfully",
+			expectedError: "",
+			expect: func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateLinkSpec().Return(newPrivateLinkSpec())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				gomock.InOrder(
+					m.DeletePrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint"),
+					m.DeletePrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls"),
+				)
+			},
+		},
+		{
+			name:          "private endpoint and private link service already deleted",
+			expectedError: "",
+			expect: func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateLinkSpec().Return(newPrivateLinkSpec())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.DeletePrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint").
+					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.DeletePrivateLinkService(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-pls").
+					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		},
+		{
+			name:          "private endpoint deletion fails",
+			expectedError: "failed to delete private endpoint my-endpoint in resource group my-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_privatelinks.MockScopeMockRecorder, m *mock_privatelinks.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PrivateLinkSpec().Return(newPrivateLinkSpec())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.DeletePrivateEndpoint(gomockinternal.AContext(), "my-rg", "my-endpoint").
+					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatelinks.NewMockScope(mockCtrl)
+			clientMock := mock_privatelinks.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
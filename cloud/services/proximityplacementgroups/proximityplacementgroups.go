@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proximityplacementgroups
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ProximityPlacementGroupScope defines the scope interface for a proximity placement groups service.
+type ProximityPlacementGroupScope interface {
+	logr.Logger
+	azure.ClusterDescriber
+	ProximityPlacementGroupSpecs() []azure.ProximityPlacementGroupSpec
+}
+
+// Service provides operations on proximity placement groups.
+type Service struct {
+	Scope ProximityPlacementGroupScope
+	Client
+}
+
+// New creates a new proximity placement groups service.
+func New(scope ProximityPlacementGroupScope) *Service {
+	return &Service{
+		Scope:  scope,
+		Client: NewClient(scope),
+	}
+}
+
+// Reconcile creates or updates the proximity placement groups.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, span := tele.Tracer().Start(ctx, "proximityplacementgroups.Service.Reconcile")
+	defer span.End()
+
+	for _, ppgSpec := range s.Scope.ProximityPlacementGroupSpecs() {
+		s.Scope.V(2).Info("creating proximity placement group", "proximity placement group", ppgSpec.Name)
+
+		ppg := compute.ProximityPlacementGroup{
+			ProximityPlacementGroupProperties: &compute.ProximityPlacementGroupProperties{
+				ProximityPlacementGroupType: compute.Standard,
+			},
+			Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+				ClusterName: s.Scope.ClusterName(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Name:        to.StringPtr(ppgSpec.Name),
+				Role:        to.StringPtr(infrav1.CommonRole),
+				Additional:  s.Scope.AdditionalTags(),
+			})),
+			Location: to.StringPtr(s.Scope.Location()),
+		}
+		if err := s.Client.CreateOrUpdate(ctx, s.Scope.ResourceGroup(), ppgSpec.Name, ppg); err != nil {
+			return errors.Wrapf(err, "failed to create proximity placement group %s", ppgSpec.Name)
+		}
+
+		s.Scope.V(2).Info("successfully created proximity placement group", "proximity placement group", ppgSpec.Name)
+	}
+
+	return nil
+}
+
+// Delete deletes the proximity placement groups once no virtual machine, scale set or availability set is left in them.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, span := tele.Tracer().Start(ctx, "proximityplacementgroups.Service.Delete")
+	defer span.End()
+
+	for _, ppgSpec := range s.Scope.ProximityPlacementGroupSpecs() {
+		ppg, err := s.Client.Get(ctx, s.Scope.ResourceGroup(), ppgSpec.Name)
+		if err != nil && azure.ResourceNotFound(err) {
+			// already deleted
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to get proximity placement group %s in resource group %s", ppgSpec.Name, s.Scope.ResourceGroup())
+		}
+
+		if inUse(ppg) {
+			s.Scope.V(2).Info("skipping deletion of proximity placement group in use", "proximity placement group", ppgSpec.Name)
+			continue
+		}
+
+		s.Scope.V(2).Info("deleting proximity placement group", "proximity placement group", ppgSpec.Name)
+		err = s.Client.Delete(ctx, s.Scope.ResourceGroup(), ppgSpec.Name)
+		if err != nil && azure.ResourceNotFound(err) {
+			// already deleted
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete proximity placement group %s in resource group %s", ppgSpec.Name, s.Scope.ResourceGroup())
+		}
+
+		s.Scope.V(2).Info("successfully deleted proximity placement group", "proximity placement group", ppgSpec.Name)
+	}
+
+	return nil
+}
+
+// inUse returns true if virtual machines, scale sets or availability sets are still placed in the proximity placement group.
+func inUse(ppg compute.ProximityPlacementGroup) bool {
+	if ppg.ProximityPlacementGroupProperties == nil {
+		return false
+	}
+	return (ppg.VirtualMachines != nil && len(*ppg.VirtualMachines) > 0) ||
+		(ppg.VirtualMachineScaleSets != nil && len(*ppg.VirtualMachineScaleSets) > 0) ||
+		(ppg.AvailabilitySets != nil && len(*ppg.AvailabilitySets) > 0)
+}
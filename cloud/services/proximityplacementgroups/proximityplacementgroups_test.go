@@ -0,0 +1,203 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proximityplacementgroups
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/klog/klogr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/proximityplacementgroups/mock_proximityplacementgroups"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+func TestReconcileProximityPlacementGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_proximityplacementgroups.MockClientMockRecorder)
+	}{
+		{
+			name:          "create a proximity placement group",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_proximityplacementgroups.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ProximityPlacementGroupSpecs().Return([]azure.ProximityPlacementGroupSpec{{Name: "my-cluster-ppg"}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("test-location")
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-cluster-ppg", gomockinternal.DiffEq(compute.ProximityPlacementGroup{
+					ProximityPlacementGroupProperties: &compute.ProximityPlacementGroupProperties{
+						ProximityPlacementGroupType: compute.Standard,
+					},
+					Tags: map[string]*string{
+						"Name": to.StringPtr("my-cluster-ppg"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("common"),
+					},
+					Location: to.StringPtr("test-location"),
+				})).Return(nil)
+			},
+		},
+		{
+			name:          "do nothing without proximity placement group",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_proximityplacementgroups.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ProximityPlacementGroupSpecs().Return(nil)
+			},
+		},
+		{
+			name:          "fail to create the proximity placement group",
+			expectedError: "failed to create proximity placement group my-pool-ppg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_proximityplacementgroups.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ProximityPlacementGroupSpecs().Return([]azure.ProximityPlacementGroupSpec{{Name: "my-pool-ppg"}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("test-location")
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-pool-ppg", gomock.AssignableToTypeOf(compute.ProximityPlacementGroup{})).Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_proximityplacementgroups.NewMockProximityPlacementGroupScope(mockCtrl)
+			clientMock := mock_proximityplacementgroups.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				Client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteProximityPlacementGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_proximityplacementgroups.MockClientMockRecorder)
+	}{
+		{
+			name:          "delete an empty proximity placement group",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_proximityplacementgroups.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ProximityPlacementGroupSpecs().Return([]azure.ProximityPlacementGroupSpec{{Name: "my-cluster-ppg"}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cluster-ppg").Return(compute.ProximityPlacementGroup{
+					ProximityPlacementGroupProperties: &compute.ProximityPlacementGroupProperties{},
+				}, nil)
+				m.Delete(gomockinternal.AContext(), "my-rg", "my-cluster-ppg").Return(nil)
+			},
+		},
+		{
+			name:          "skip deletion of a proximity placement group with a scale set",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_proximityplacementgroups.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ProximityPlacementGroupSpecs().Return([]azure.ProximityPlacementGroupSpec{{Name: "my-cluster-ppg"}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cluster-ppg").Return(compute.ProximityPlacementGroup{
+					ProximityPlacementGroupProperties: &compute.ProximityPlacementGroupProperties{
+						VirtualMachineScaleSets: &[]compute.SubResourceWithColocationStatus{
+							{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/my-vmss")},
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:          "proximity placement group already deleted",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_proximityplacementgroups.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ProximityPlacementGroupSpecs().Return([]azure.ProximityPlacementGroupSpec{{Name: "my-cluster-ppg"}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cluster-ppg").Return(compute.ProximityPlacementGroup{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		},
+		{
+			name:          "proximity placement group deletion fails",
+			expectedError: "failed to delete proximity placement group my-cluster-ppg in resource group my-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_proximityplacementgroups.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ProximityPlacementGroupSpecs().Return([]azure.ProximityPlacementGroupSpec{{Name: "my-cluster-ppg"}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cluster-ppg").Return(compute.ProximityPlacementGroup{}, nil)
+				m.Delete(gomockinternal.AContext(), "my-rg", "my-cluster-ppg").Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_proximityplacementgroups.NewMockProximityPlacementGroupScope(mockCtrl)
+			clientMock := mock_proximityplacementgroups.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				Client: clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -31,11 +31,15 @@ type Client interface {
 	Get(context.Context, string, string) (network.PublicIPAddress, error)
 	CreateOrUpdate(context.Context, string, string, network.PublicIPAddress) error
 	Delete(context.Context, string, string) error
+	GetPrefix(context.Context, string, string) (network.PublicIPPrefix, error)
+	CreateOrUpdatePrefix(context.Context, string, string, network.PublicIPPrefix) error
+	DeletePrefix(context.Context, string, string) error
 }
 
 // AzureClient contains the Azure go-sdk Client
 type AzureClient struct {
-	publicips network.PublicIPAddressesClient
+	publicips        network.PublicIPAddressesClient
+	publicipprefixes network.PublicIPPrefixesClient
 }
 
 var _ Client = &AzureClient{}
@@ -43,17 +47,24 @@ var _ Client = &AzureClient{}
 // NewClient creates a new public IP client from subscription ID.
 func NewClient(auth azure.Authorizer) *AzureClient {
 	c := newPublicIPAddressesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
-	return &AzureClient{c}
+	p := newPublicIPPrefixesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return &AzureClient{c, p}
 }
 
 // newPublicIPAddressesClient creates a new public IP client from subscription ID.
 func newPublicIPAddressesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.PublicIPAddressesClient {
 	publicIPsClient := network.NewPublicIPAddressesClientWithBaseURI(baseURI, subscriptionID)
-	publicIPsClient.Authorizer = authorizer
-	publicIPsClient.AddToUserAgent(azure.UserAgent())
+	azure.SetAutoRestClientDefaults(&publicIPsClient.Client, authorizer)
 	return publicIPsClient
 }
 
+// newPublicIPPrefixesClient creates a new public IP prefix client from subscription ID.
+func newPublicIPPrefixesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.PublicIPPrefixesClient {
+	publicIPPrefixesClient := network.NewPublicIPPrefixesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&publicIPPrefixesClient.Client, authorizer)
+	return publicIPPrefixesClient
+}
+
 // Get gets the specified public IP address in a specified resource group.
 func (ac *AzureClient) Get(ctx context.Context, resourceGroupName, ipName string) (network.PublicIPAddress, error) {
 	ctx, span := tele.Tracer().Start(ctx, "publicips.AzureClient.Get")
@@ -95,3 +106,45 @@ func (ac *AzureClient) Delete(ctx context.Context, resourceGroupName, ipName str
 	_, err = future.Result(ac.publicips)
 	return err
 }
+
+// GetPrefix gets the specified public IP prefix in a specified resource group.
+func (ac *AzureClient) GetPrefix(ctx context.Context, resourceGroupName, prefixName string) (network.PublicIPPrefix, error) {
+	ctx, span := tele.Tracer().Start(ctx, "publicips.AzureClient.GetPrefix")
+	defer span.End()
+
+	return ac.publicipprefixes.Get(ctx, resourceGroupName, prefixName, "")
+}
+
+// CreateOrUpdatePrefix creates or updates a public IP prefix.
+func (ac *AzureClient) CreateOrUpdatePrefix(ctx context.Context, resourceGroupName string, prefixName string, prefix network.PublicIPPrefix) error {
+	ctx, span := tele.Tracer().Start(ctx, "publicips.AzureClient.CreateOrUpdatePrefix")
+	defer span.End()
+
+	future, err := ac.publicipprefixes.CreateOrUpdate(ctx, resourceGroupName, prefixName, prefix)
+	if err != nil {
+		return err
+	}
+	err = future.WaitForCompletionRef(ctx, ac.publicipprefixes.Client)
+	if err != nil {
+		return err
+	}
+	_, err = future.Result(ac.publicipprefixes)
+	return err
+}
+
+// DeletePrefix deletes the specified public IP prefix.
+func (ac *AzureClient) DeletePrefix(ctx context.Context, resourceGroupName, prefixName string) error {
+	ctx, span := tele.Tracer().Start(ctx, "publicips.AzureClient.DeletePrefix")
+	defer span.End()
+
+	future, err := ac.publicipprefixes.Delete(ctx, resourceGroupName, prefixName)
+	if err != nil {
+		return err
+	}
+	err = future.WaitForCompletionRef(ctx, ac.publicipprefixes.Client)
+	if err != nil {
+		return err
+	}
+	_, err = future.Result(ac.publicipprefixes)
+	return err
+}
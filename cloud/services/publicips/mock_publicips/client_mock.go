@@ -92,3 +92,46 @@ func (mr *MockClientMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockClient)(nil).Delete), arg0, arg1, arg2)
 }
+
+// GetPrefix mocks base method.
+func (m *MockClient) GetPrefix(arg0 context.Context, arg1, arg2 string) (network.PublicIPPrefix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrefix", arg0, arg1, arg2)
+	ret0, _ := ret[0].(network.PublicIPPrefix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrefix indicates an expected call of GetPrefix.
+func (mr *MockClientMockRecorder) GetPrefix(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrefix", reflect.TypeOf((*MockClient)(nil).GetPrefix), arg0, arg1, arg2)
+}
+
+// CreateOrUpdatePrefix mocks base method.
+func (m *MockClient) CreateOrUpdatePrefix(arg0 context.Context, arg1, arg2 string, arg3 network.PublicIPPrefix) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrefix", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdatePrefix indicates an expected call of CreateOrUpdatePrefix.
+func (mr *MockClientMockRecorder) CreateOrUpdatePrefix(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrefix", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrefix), arg0, arg1, arg2, arg3)
+}
+
+// DeletePrefix mocks base method.
+func (m *MockClient) DeletePrefix(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrefix", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrefix indicates an expected call of DeletePrefix.
+func (mr *MockClientMockRecorder) DeletePrefix(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrefix", reflect.TypeOf((*MockClient)(nil).DeletePrefix), arg0, arg1, arg2)
+}
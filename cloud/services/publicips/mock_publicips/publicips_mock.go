@@ -313,3 +313,29 @@ func (mr *MockPublicIPScopeMockRecorder) PublicIPSpecs() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicIPSpecs", reflect.TypeOf((*MockPublicIPScope)(nil).PublicIPSpecs))
 }
+
+// PublicIPPrefixSpecs mocks base method.
+func (m *MockPublicIPScope) PublicIPPrefixSpecs() []azure.PublicIPPrefixSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicIPPrefixSpecs")
+	ret0, _ := ret[0].([]azure.PublicIPPrefixSpec)
+	return ret0
+}
+
+// PublicIPPrefixSpecs indicates an expected call of PublicIPPrefixSpecs.
+func (mr *MockPublicIPScopeMockRecorder) PublicIPPrefixSpecs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicIPPrefixSpecs", reflect.TypeOf((*MockPublicIPScope)(nil).PublicIPPrefixSpecs))
+}
+
+// SetPublicIPDNSName mocks base method.
+func (m *MockPublicIPScope) SetPublicIPDNSName(ipName, dnsName string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPublicIPDNSName", ipName, dnsName)
+}
+
+// SetPublicIPDNSName indicates an expected call of SetPublicIPDNSName.
+func (mr *MockPublicIPScopeMockRecorder) SetPublicIPDNSName(ipName, dnsName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPublicIPDNSName", reflect.TypeOf((*MockPublicIPScope)(nil).SetPublicIPDNSName), ipName, dnsName)
+}
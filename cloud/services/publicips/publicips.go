@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -36,6 +37,8 @@ type PublicIPScope interface {
 	logr.Logger
 	azure.ClusterDescriber
 	PublicIPSpecs() []azure.PublicIPSpec
+	PublicIPPrefixSpecs() []azure.PublicIPPrefixSpec
+	SetPublicIPDNSName(ipName, dnsName string)
 }
 
 // Service provides operations on Azure resources.
@@ -58,6 +61,13 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	defer span.End()
 
 	for _, ip := range s.Scope.PublicIPSpecs() {
+		if ip.ID != "" {
+			if err := s.reconcileExisting(ctx, ip); err != nil {
+				return err
+			}
+			continue
+		}
+
 		s.Scope.V(2).Info("creating public IP", "public ip", ip.Name)
 
 		// only set DNS properties if there is a DNS name specified
@@ -104,6 +114,37 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		s.Scope.V(2).Info("successfully created public IP", "public ip", ip.Name)
 	}
 
+	for _, prefix := range s.Scope.PublicIPPrefixSpecs() {
+		s.Scope.V(2).Info("creating public IP prefix", "public ip prefix", prefix.Name)
+
+		err := s.Client.CreateOrUpdatePrefix(
+			ctx,
+			s.Scope.ResourceGroup(),
+			prefix.Name,
+			network.PublicIPPrefix{
+				Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+					ClusterName: s.Scope.ClusterName(),
+					Lifecycle:   infrav1.ResourceLifecycleOwned,
+					Name:        to.StringPtr(prefix.Name),
+					Additional:  s.Scope.AdditionalTags(),
+				})),
+				Sku:      &network.PublicIPPrefixSku{Name: network.PublicIPPrefixSkuNameStandard},
+				Name:     to.StringPtr(prefix.Name),
+				Location: to.StringPtr(s.Scope.Location()),
+				PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+					PublicIPAddressVersion: network.IPv4,
+					PrefixLength:           to.Int32Ptr(prefix.PrefixLength),
+				},
+			},
+		)
+
+		if err != nil {
+			return errors.Wrap(err, "cannot create public IP prefix")
+		}
+
+		s.Scope.V(2).Info("successfully created public IP prefix", "public ip prefix", prefix.Name)
+	}
+
 	return nil
 }
 
@@ -113,6 +154,11 @@ func (s *Service) Delete(ctx context.Context) error {
 	defer span.End()
 
 	for _, ip := range s.Scope.PublicIPSpecs() {
+		if ip.ID != "" {
+			s.Scope.V(2).Info("Skipping IP deletion for existing public IP", "public ip", ip.ID)
+			continue
+		}
+
 		managed, err := s.isIPManaged(ctx, ip.Name)
 		if err != nil && !azure.ResourceNotFound(err) {
 			return errors.Wrap(err, "could not get public IP management state")
@@ -135,6 +181,58 @@ func (s *Service) Delete(ctx context.Context) error {
 
 		s.Scope.V(2).Info("deleted public IP", "public ip", ip.Name)
 	}
+
+	for _, prefix := range s.Scope.PublicIPPrefixSpecs() {
+		managed, err := s.isPrefixManaged(ctx, prefix.Name)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrap(err, "could not get public IP prefix management state")
+		}
+
+		if !managed {
+			s.Scope.V(2).Info("Skipping prefix deletion for unmanaged public IP prefix", "public ip prefix", prefix.Name)
+			continue
+		}
+
+		s.Scope.V(2).Info("deleting public IP prefix", "public ip prefix", prefix.Name)
+		err = s.Client.DeletePrefix(ctx, s.Scope.ResourceGroup(), prefix.Name)
+		if err != nil && azure.ResourceNotFound(err) {
+			// already deleted
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete public IP prefix %s in resource group %s", prefix.Name, s.Scope.ResourceGroup())
+		}
+
+		s.Scope.V(2).Info("deleted public IP prefix", "public ip prefix", prefix.Name)
+	}
+	return nil
+}
+
+// reconcileExisting verifies that the existing public IP referenced by the spec can be used and reports its FQDN,
+// or its address if it has no DNS name, to the scope. The public IP itself is left untouched.
+func (s *Service) reconcileExisting(ctx context.Context, ip azure.PublicIPSpec) error {
+	resource, err := autorestazure.ParseResourceID(ip.ID)
+	if err != nil {
+		return errors.Wrapf(err, "invalid public IP ID %s", ip.ID)
+	}
+	if !strings.EqualFold(resource.SubscriptionID, s.Scope.SubscriptionID()) {
+		return errors.Errorf("public IP %s must be in subscription %s", ip.ID, s.Scope.SubscriptionID())
+	}
+
+	existing, err := s.Client.Get(ctx, resource.ResourceGroup, resource.ResourceName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get existing public IP %s in resource group %s", resource.ResourceName, resource.ResourceGroup)
+	}
+	s.Scope.V(2).Info("using existing public IP", "public ip", ip.ID)
+
+	if existing.PublicIPAddressPropertiesFormat == nil {
+		return nil
+	}
+	if existing.DNSSettings != nil && to.String(existing.DNSSettings.Fqdn) != "" {
+		s.Scope.SetPublicIPDNSName(ip.Name, to.String(existing.DNSSettings.Fqdn))
+	} else if to.String(existing.IPAddress) != "" {
+		s.Scope.SetPublicIPDNSName(ip.Name, to.String(existing.IPAddress))
+	}
 	return nil
 }
 
@@ -148,3 +246,14 @@ func (s *Service) isIPManaged(ctx context.Context, ipName string) (bool, error)
 	tags := converters.MapToTags(ip.Tags)
 	return tags.HasOwned(s.Scope.ClusterName()), nil
 }
+
+// isPrefixManaged returns true if the prefix has an owned tag with the cluster name as value,
+// meaning that the prefix's lifecycle is managed.
+func (s *Service) isPrefixManaged(ctx context.Context, prefixName string) (bool, error) {
+	prefix, err := s.Client.GetPrefix(ctx, s.Scope.ResourceGroup(), prefixName)
+	if err != nil {
+		return false, err
+	}
+	tags := converters.MapToTags(prefix.Tags)
+	return tags.HasOwned(s.Scope.ClusterName()), nil
+}
@@ -55,6 +55,7 @@ func TestReconcilePublicIP(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PublicIPPrefixSpecs().Return(nil)
 				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
 					{
 						Name:    "my-publicip",
@@ -145,6 +146,122 @@ func TestReconcilePublicIP(t *testing.T) {
 				)
 			},
 		},
+		{
+			name:          "can create public IP prefixes",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PublicIPSpecs().Return(nil)
+				s.PublicIPPrefixSpecs().Return([]azure.PublicIPPrefixSpec{
+					{
+						Name:         "my-publicipprefix",
+						PrefixLength: 30,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				m.CreateOrUpdatePrefix(gomockinternal.AContext(), "my-rg", "my-publicipprefix", gomockinternal.DiffEq(network.PublicIPPrefix{
+					Name:     to.StringPtr("my-publicipprefix"),
+					Sku:      &network.PublicIPPrefixSku{Name: network.PublicIPPrefixSkuNameStandard},
+					Location: to.StringPtr("testlocation"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("my-publicipprefix"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
+					PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+						PublicIPAddressVersion: network.IPv4,
+						PrefixLength:           to.Int32Ptr(30),
+					},
+				})).Times(1)
+			},
+		},
+		{
+			name:          "fail to create a public IP prefix",
+			expectedError: "cannot create public IP prefix: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PublicIPSpecs().Return(nil)
+				s.PublicIPPrefixSpecs().Return([]azure.PublicIPPrefixSpec{
+					{
+						Name:         "my-publicipprefix",
+						PrefixLength: 30,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				m.CreateOrUpdatePrefix(gomockinternal.AContext(), "my-rg", "my-publicipprefix", gomock.AssignableToTypeOf(network.PublicIPPrefix{})).Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+		{
+			name:          "use existing public IP in another resource group",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PublicIPPrefixSpecs().Return(nil)
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name: "my-reserved-ip",
+						ID:   "/subscriptions/123/resourceGroups/my-ip-rg/providers/Microsoft.Network/publicIPAddresses/my-reserved-ip",
+					},
+					{
+						Name: "my-reserved-ip-2",
+						ID:   "/subscriptions/123/resourceGroups/my-ip-rg/providers/Microsoft.Network/publicIPAddresses/my-reserved-ip-2",
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				m.Get(gomockinternal.AContext(), "my-ip-rg", "my-reserved-ip").Return(network.PublicIPAddress{
+					Name: to.StringPtr("my-reserved-ip"),
+					PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+						IPAddress: to.StringPtr("52.1.2.3"),
+						DNSSettings: &network.PublicIPAddressDNSSettings{
+							DomainNameLabel: to.StringPtr("my-api"),
+							Fqdn:            to.StringPtr("my-api.eastus.cloudapp.azure.com"),
+						},
+					},
+				}, nil)
+				s.SetPublicIPDNSName("my-reserved-ip", "my-api.eastus.cloudapp.azure.com")
+				m.Get(gomockinternal.AContext(), "my-ip-rg", "my-reserved-ip-2").Return(network.PublicIPAddress{
+					Name: to.StringPtr("my-reserved-ip-2"),
+					PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+						IPAddress: to.StringPtr("52.1.2.4"),
+					},
+				}, nil)
+				s.SetPublicIPDNSName("my-reserved-ip-2", "52.1.2.4")
+			},
+		},
+		{
+			name:          "fail to get existing public IP",
+			expectedError: "failed to get existing public IP my-reserved-ip in resource group my-ip-rg: #: Not found: StatusCode=404",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name: "my-reserved-ip",
+						ID:   "/subscriptions/123/resourceGroups/my-ip-rg/providers/Microsoft.Network/publicIPAddresses/my-reserved-ip",
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				m.Get(gomockinternal.AContext(), "my-ip-rg", "my-reserved-ip").Return(network.PublicIPAddress{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		},
+		{
+			name:          "existing public IP in another subscription",
+			expectedError: "public IP /subscriptions/456/resourceGroups/my-ip-rg/providers/Microsoft.Network/publicIPAddresses/my-reserved-ip must be in subscription 123",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name: "my-reserved-ip",
+						ID:   "/subscriptions/456/resourceGroups/my-ip-rg/providers/Microsoft.Network/publicIPAddresses/my-reserved-ip",
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+			},
+		},
 		{
 			name:          "fail to create a public IP",
 			expectedError: "cannot create public IP: #: Internal Server Error: StatusCode=500",
@@ -205,6 +322,7 @@ func TestDeletePublicIP(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PublicIPPrefixSpecs().Return(nil)
 				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
 					{
 						Name: "my-publicip",
@@ -238,6 +356,7 @@ func TestDeletePublicIP(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PublicIPPrefixSpecs().Return(nil)
 				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
 					{
 						Name: "my-publicip",
@@ -282,11 +401,49 @@ func TestDeletePublicIP(t *testing.T) {
 					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
 			},
 		},
+		{
+			name:          "successfully delete an existing public IP prefix",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PublicIPSpecs().Return(nil)
+				s.PublicIPPrefixSpecs().Return([]azure.PublicIPPrefixSpec{
+					{
+						Name:         "my-publicipprefix",
+						PrefixLength: 30,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.GetPrefix(gomockinternal.AContext(), "my-rg", "my-publicipprefix").Return(network.PublicIPPrefix{
+					Name: to.StringPtr("my-publicipprefix"),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
+				}, nil)
+				m.DeletePrefix(gomockinternal.AContext(), "my-rg", "my-publicipprefix")
+			},
+		},
+		{
+			name:          "skip existing public ip deletion",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PublicIPPrefixSpecs().Return(nil)
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name: "my-reserved-ip",
+						ID:   "/subscriptions/123/resourceGroups/my-ip-rg/providers/Microsoft.Network/publicIPAddresses/my-reserved-ip",
+					},
+				})
+			},
+		},
 		{
 			name:          "skip unmanaged public ip deletion",
 			expectedError: "",
 			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.PublicIPPrefixSpecs().Return(nil)
 				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
 					{
 						Name: "my-publicip",
@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
 	"github.com/pkg/errors"
@@ -29,30 +31,51 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-// Cache loads resource SKUs at the beginning of reconcile to expose
-// features available on compute resources. It exposes convenience
-// functionality for trawling Azure SKU capabilities. It may be adapted
-// to periodically refresh data in the background.
+// Cache loads resource SKUs to expose features available on compute
+// resources. It exposes convenience functionality for trawling Azure SKU
+// capabilities. A cache is safe for concurrent use: it is shared by all
+// the reconciles of a subscription and location, see Caches.
 type Cache struct {
-	client Client
-
 	// location is the Azure location for which this cache stores sku info.
-	// we do lookup once per reconcile for the given cluster/location.
 	location string
 
+	// ttl is how long the cached sku information is used before being refreshed.
+	// A zero ttl means the sku information is loaded once and never refreshed.
+	ttl time.Duration
+
+	// now returns the current time, it is overridden in tests.
+	now func() time.Time
+
+	// mu protects client, data and lastRefresh.
+	mu sync.RWMutex
+
+	// client is used to load the sku information from Azure.
+	client Client
+
 	// data is the cached sku information from Azure.
-	// synchronization required if data is cached across reconcile calls, (i.e., refreshed in background as Runnable via mgr.Add(...))
 	data []compute.ResourceSku
+
+	// lastRefresh is the time data was last loaded from Azure.
+	lastRefresh time.Time
+
+	// refreshMu ensures a single refresh is in flight at any time.
+	refreshMu sync.Mutex
 }
 
 // NewCacheFunc allows for mocking out the underlying client
 type NewCacheFunc func(azure.Authorizer, string) *Cache
 
-// NewCache instantiates a cache and initializes its contents.
+// NewCache instantiates a cache which loads its contents once, on first use.
 func NewCache(auth azure.Authorizer, location string) *Cache {
+	return newCache(NewClient(auth), location, 0)
+}
+
+func newCache(client Client, location string, ttl time.Duration) *Cache {
 	return &Cache{
-		client:   NewClient(auth),
+		client:   client,
 		location: location,
+		ttl:      ttl,
+		now:      time.Now,
 	}
 }
 
@@ -70,18 +93,71 @@ func NewStaticCache(data []compute.ResourceSku) *Cache {
 	}
 }
 
-func (c *Cache) refresh(ctx context.Context, location string) error {
+// setClient replaces the client used to refresh the cache.
+func (c *Cache) setClient(client Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.client = client
+}
+
+// isFresh returns true if the cached data can be used without being refreshed. It must be called with mu held.
+func (c *Cache) isFresh() bool {
+	if c.data == nil {
+		return false
+	}
+	return c.ttl == 0 || c.now().Sub(c.lastRefresh) < c.ttl
+}
+
+// getData returns the cached sku information, refreshing it first if it is missing or expired.
+// The returned slice is never modified by the cache and may be read without holding any lock.
+func (c *Cache) getData(ctx context.Context) ([]compute.ResourceSku, error) {
+	c.mu.RLock()
+	data, fresh := c.data, c.isFresh()
+	c.mu.RUnlock()
+	if fresh {
+		cacheHits.WithLabelValues(c.location).Inc()
+		return data, nil
+	}
+
+	cacheMisses.WithLabelValues(c.location).Inc()
+	return c.refresh(ctx)
+}
+
+// refresh loads the sku information from Azure. Concurrent callers wait for the refresh in flight instead of
+// starting their own, and then use its result.
+func (c *Cache) refresh(ctx context.Context) ([]compute.ResourceSku, error) {
 	ctx, span := tele.Tracer().Start(ctx, "resourceskus.Cache.refresh")
 	defer span.End()
 
-	data, err := c.client.List(ctx, fmt.Sprintf("location eq '%s'", location))
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	// another caller may have refreshed the cache while we were waiting for the lock.
+	c.mu.RLock()
+	client, data, fresh := c.client, c.data, c.isFresh()
+	c.mu.RUnlock()
+	if fresh {
+		return data, nil
+	}
+
+	data, err := client.List(ctx, fmt.Sprintf("location eq '%s'", c.location))
 	if err != nil {
-		return errors.Wrap(err, "failed to refresh resource sku cache")
+		cacheRefreshes.WithLabelValues(c.location, refreshResultError).Inc()
+		return nil, errors.Wrap(err, "failed to refresh resource sku cache")
+	}
+	cacheRefreshes.WithLabelValues(c.location, refreshResultSuccess).Inc()
+
+	// an empty list is still a successful refresh, make sure it is not mistaken for missing data.
+	if data == nil {
+		data = []compute.ResourceSku{}
 	}
 
+	c.mu.Lock()
 	c.data = data
+	c.lastRefresh = c.now()
+	c.mu.Unlock()
 
-	return nil
+	return data, nil
 }
 
 // Get returns a resource SKU with the provided name and category. It
@@ -93,13 +169,12 @@ func (c *Cache) Get(ctx context.Context, name string, kind ResourceType) (SKU, e
 	ctx, span := tele.Tracer().Start(ctx, "resourceskus.Cache.Get")
 	defer span.End()
 
-	if c.data == nil {
-		if err := c.refresh(ctx, c.location); err != nil {
-			return SKU{}, err
-		}
+	data, err := c.getData(ctx)
+	if err != nil {
+		return SKU{}, err
 	}
 
-	for _, sku := range c.data {
+	for _, sku := range data {
 		if sku.Name != nil && *sku.Name == name {
 			return SKU(sku), nil
 		}
@@ -112,14 +187,13 @@ func (c *Cache) Map(ctx context.Context, mapFn func(sku SKU)) error {
 	ctx, span := tele.Tracer().Start(ctx, "resourceskus.Cache.Map")
 	defer span.End()
 
-	if c.data == nil {
-		if err := c.refresh(ctx, c.location); err != nil {
-			return err
-		}
+	data, err := c.getData(ctx)
+	if err != nil {
+		return err
 	}
 
-	for i := range c.data {
-		val := SKU(c.data[i])
+	for i := range data {
+		val := SKU(data[i])
 		mapFn(val)
 	}
 
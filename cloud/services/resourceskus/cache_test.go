@@ -18,12 +18,16 @@ package resourceskus
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 )
 
 func TestCacheGet(t *testing.T) {
@@ -391,3 +395,113 @@ func TestCacheGetZonesWithVMSize(t *testing.T) {
 		})
 	}
 }
+
+// fakeClient is a resource SKU client which counts list calls and optionally blocks them until released.
+type fakeClient struct {
+	calls   int32
+	skus    []compute.ResourceSku
+	err     error
+	release chan struct{}
+}
+
+func (f *fakeClient) List(context.Context, string) ([]compute.ResourceSku, error) {
+	atomic.AddInt32(&f.calls, 1)
+	if f.release != nil {
+		<-f.release
+	}
+	return f.skus, f.err
+}
+
+func TestCacheRefresh(t *testing.T) {
+	skus := []compute.ResourceSku{
+		{
+			Name:         to.StringPtr("foo"),
+			ResourceType: to.StringPtr("bar"),
+		},
+	}
+
+	t.Run("should only list skus once per ttl", func(t *testing.T) {
+		client := &fakeClient{skus: skus}
+		cache := newCache(client, "test-location", time.Minute)
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			if _, err := cache.Get(context.Background(), "foo", "bar"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if client.calls != 1 {
+			t.Fatalf("expected 1 list call before the ttl expired, but got %d", client.calls)
+		}
+
+		now = now.Add(time.Minute)
+		if _, err := cache.Get(context.Background(), "foo", "bar"); err != nil {
+			t.Fatal(err)
+		}
+		if client.calls != 2 {
+			t.Fatalf("expected 2 list calls after the ttl expired, but got %d", client.calls)
+		}
+	})
+
+	t.Run("should not list skus again with a zero ttl", func(t *testing.T) {
+		client := &fakeClient{skus: skus}
+		cache := newCache(client, "test-location", 0)
+
+		for i := 0; i < 3; i++ {
+			if _, err := cache.Get(context.Background(), "foo", "bar"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if client.calls != 1 {
+			t.Fatalf("expected 1 list call, but got %d", client.calls)
+		}
+	})
+
+	t.Run("should keep a single refresh in flight", func(t *testing.T) {
+		client := &fakeClient{skus: skus, release: make(chan struct{})}
+		cache := newCache(client, "test-location", time.Minute)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := cache.Get(context.Background(), "foo", "bar")
+				errs <- err
+			}()
+		}
+		close(client.release)
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if client.calls != 1 {
+			t.Fatalf("expected 1 list call for concurrent lookups, but got %d", client.calls)
+		}
+	})
+
+	t.Run("should retry a failed refresh", func(t *testing.T) {
+		client := &fakeClient{err: errors.New("throttled")}
+		cache := newCache(client, "test-location", time.Minute)
+
+		_, err := cache.Get(context.Background(), "foo", "bar")
+		if err == nil || err.Error() != "failed to refresh resource sku cache: throttled" {
+			t.Fatalf("expected refresh error, but got %v", err)
+		}
+
+		client.err = nil
+		client.skus = skus
+		if _, err := cache.Get(context.Background(), "foo", "bar"); err != nil {
+			t.Fatal(err)
+		}
+		if client.calls != 2 {
+			t.Fatalf("expected 2 list calls, but got %d", client.calls)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+)
+
+// DefaultCacheTTL is the default duration resource SKUs are cached for.
+const DefaultCacheTTL = time.Hour
+
+// cacheKey identifies the cache of a subscription and location.
+type cacheKey struct {
+	subscriptionID string
+	location       string
+}
+
+// Caches holds one resource SKU cache per subscription and location. It is meant to be created once per manager
+// and shared by the reconcilers, so that resource SKUs are listed once per TTL instead of on every reconcile.
+// Caches is safe for concurrent use.
+type Caches struct {
+	ttl       time.Duration
+	newClient func(azure.Authorizer) Client
+
+	mu     sync.Mutex
+	caches map[cacheKey]*Cache
+}
+
+// NewCaches creates an empty set of caches whose resource SKUs are refreshed after the given TTL.
+func NewCaches(ttl time.Duration) *Caches {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &Caches{
+		ttl: ttl,
+		newClient: func(auth azure.Authorizer) Client {
+			return NewClient(auth)
+		},
+		caches: make(map[cacheKey]*Cache),
+	}
+}
+
+// Get returns the cache of the subscription of the authorizer and the given location, creating it if needed.
+// The cache is refreshed with the credentials of the latest caller. A nil Caches returns a new cache which
+// is not shared.
+func (c *Caches) Get(auth azure.Authorizer, location string) *Cache {
+	if c == nil {
+		return NewCache(auth, location)
+	}
+
+	key := cacheKey{
+		subscriptionID: strings.ToLower(auth.SubscriptionID()),
+		location:       strings.ToLower(location),
+	}
+	client := c.newClient(auth)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cache, ok := c.caches[key]
+	if !ok {
+		cache = newCache(client, location, c.ttl)
+		c.caches[key] = cache
+		return cache
+	}
+	cache.setClient(client)
+	return cache
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/gomega"
+
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+)
+
+// fakeAuthorizer is an azure.Authorizer of a subscription.
+type fakeAuthorizer struct {
+	subscriptionID string
+}
+
+var _ azure.Authorizer = fakeAuthorizer{}
+
+func (f fakeAuthorizer) SubscriptionID() string          { return f.subscriptionID }
+func (f fakeAuthorizer) ClientID() string                { return "" }
+func (f fakeAuthorizer) ClientSecret() string            { return "" }
+func (f fakeAuthorizer) CloudEnvironment() string        { return "AzurePublicCloud" }
+func (f fakeAuthorizer) TenantID() string                { return "" }
+func (f fakeAuthorizer) BaseURI() string                 { return "https://management.azure.com/" }
+func (f fakeAuthorizer) Authorizer() autorest.Authorizer { return autorest.NullAuthorizer{} }
+
+func TestCachesGet(t *testing.T) {
+	g := NewWithT(t)
+
+	caches := NewCaches(time.Minute)
+	cache := caches.Get(fakeAuthorizer{subscriptionID: "123"}, "eastus")
+	g.Expect(cache.ttl).To(Equal(time.Minute))
+
+	g.Expect(caches.Get(fakeAuthorizer{subscriptionID: "123"}, "EastUS")).To(BeIdenticalTo(cache))
+	g.Expect(caches.Get(fakeAuthorizer{subscriptionID: "123"}, "westus")).NotTo(BeIdenticalTo(cache))
+	g.Expect(caches.Get(fakeAuthorizer{subscriptionID: "456"}, "eastus")).NotTo(BeIdenticalTo(cache))
+}
+
+func TestCachesDefaultTTL(t *testing.T) {
+	g := NewWithT(t)
+
+	caches := NewCaches(0)
+	g.Expect(caches.Get(fakeAuthorizer{subscriptionID: "123"}, "eastus").ttl).To(Equal(DefaultCacheTTL))
+}
+
+func TestNilCachesGet(t *testing.T) {
+	g := NewWithT(t)
+
+	var caches *Caches
+	cache := caches.Get(fakeAuthorizer{subscriptionID: "123"}, "eastus")
+	g.Expect(cache).NotTo(BeNil())
+	g.Expect(caches.Get(fakeAuthorizer{subscriptionID: "123"}, "eastus")).NotTo(BeIdenticalTo(cache))
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsSubsystem = "capz_resource_sku_cache"
+
+	refreshResultSuccess = "success"
+	refreshResultError   = "error"
+)
+
+var (
+	// cacheHits counts the lookups served from cached sku information.
+	cacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "hits_total",
+			Help:      "Total number of resource SKU cache lookups served from cached data.",
+		},
+		[]string{"location"},
+	)
+
+	// cacheMisses counts the lookups that required the sku information to be loaded or refreshed.
+	cacheMisses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "misses_total",
+			Help:      "Total number of resource SKU cache lookups which found missing or expired data.",
+		},
+		[]string{"location"},
+	)
+
+	// cacheRefreshes counts the calls to the Azure ResourceSkus API, by result.
+	cacheRefreshes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "refreshes_total",
+			Help:      "Total number of resource SKU cache refreshes from the Azure API, by result.",
+		},
+		[]string{"location", "result"},
+	)
+)
+
+func init() {
+	// register the metrics with the controller runtime registry so they are exposed on the controller metrics endpoint.
+	metrics.Registry.MustRegister(cacheHits, cacheMisses, cacheRefreshes)
+}
@@ -35,6 +35,8 @@ const (
 	VirtualMachines ResourceType = "virtualMachines"
 	// Disks is a convenience constant to filter resource SKUs to only include disks.
 	Disks ResourceType = "disks"
+	// AvailabilitySets is a convenience constant to filter resource SKUs to only include availability sets.
+	AvailabilitySets ResourceType = "availabilitySets"
 )
 
 // Supported models an enum of possible boolean values for resource support in the Azure API.
@@ -62,6 +64,10 @@ const (
 	MinimumMemory = 2
 	// EncryptionAtHost identifies the capability for encryption at host.
 	EncryptionAtHost = "EncryptionAtHostSupported"
+	// MaximumPlatformFaultDomainCount identifies the maximum number of fault domains of an availability set.
+	MaximumPlatformFaultDomainCount = "MaximumPlatformFaultDomainCount"
+	// UltraSSDAvailable identifies the capability for ultra disk support.
+	UltraSSDAvailable = "UltraSSDAvailable"
 )
 
 // HasCapability return true for a capability which can be either
@@ -81,6 +87,53 @@ func (s SKU) HasCapability(name string) bool {
 	return false
 }
 
+// HasZonalCapability returns true for a capability which is only supported
+// in some zones of a location, as listed in the zone details of the SKU.
+// Examples include "UltraSSDAvailable".
+func (s SKU) HasZonalCapability(name, location, zone string) bool {
+	if s.LocationInfo == nil {
+		return false
+	}
+	for _, locationInfo := range *s.LocationInfo {
+		if locationInfo.Location == nil || !strings.EqualFold(*locationInfo.Location, location) || locationInfo.ZoneDetails == nil {
+			continue
+		}
+		for _, zoneDetails := range *locationInfo.ZoneDetails {
+			if zoneDetails.Name == nil || !contains(*zoneDetails.Name, zone) || zoneDetails.Capabilities == nil {
+				continue
+			}
+			for _, capability := range *zoneDetails.Capabilities {
+				if capability.Name != nil && *capability.Name == name &&
+					capability.Value != nil && strings.EqualFold(*capability.Value, string(CapabilitySupported)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCapability gets and returns the value of a capability, if it exists.
+func (s SKU) GetCapability(name string) (string, bool) {
+	if s.Capabilities != nil {
+		for _, capability := range *s.Capabilities {
+			if capability.Name != nil && *capability.Name == name && capability.Value != nil {
+				return *capability.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
 // HasCapabilityWithCapacity returns true when the provided resource
 // exposes a numeric capability and the maximum value exposed by that
 // capability exceeds the value requested by the user. Examples include
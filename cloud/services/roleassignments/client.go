@@ -29,6 +29,7 @@ import (
 // client wraps go-sdk
 type client interface {
 	Create(context.Context, string, string, authorization.RoleAssignmentCreateParameters) (authorization.RoleAssignment, error)
+	Delete(context.Context, string, string) (authorization.RoleAssignment, error)
 }
 
 // azureClient contains the Azure go-sdk Client
@@ -47,8 +48,7 @@ func newClient(auth azure.Authorizer) *azureClient {
 // newRoleAssignmentClient creates a role assignments client from subscription ID.
 func newRoleAssignmentClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) authorization.RoleAssignmentsClient {
 	roleClient := authorization.NewRoleAssignmentsClientWithBaseURI(baseURI, subscriptionID)
-	roleClient.Authorizer = authorizer
-	roleClient.AddToUserAgent(azure.UserAgent())
+	azure.SetAutoRestClientDefaults(&roleClient.Client, authorizer)
 	return roleClient
 }
 
@@ -67,3 +67,14 @@ func (ac *azureClient) Create(ctx context.Context, scope string, roleAssignmentN
 
 	return ac.roleassignments.Create(ctx, scope, roleAssignmentName, parameters)
 }
+
+// Delete deletes a role assignment.
+// Parameters:
+// scope - the scope of the role assignment to delete.
+// roleAssignmentName - the name of the role assignment to delete.
+func (ac *azureClient) Delete(ctx context.Context, scope string, roleAssignmentName string) (authorization.RoleAssignment, error) {
+	ctx, span := tele.Tracer().Start(ctx, "roleassignments.AzureClient.Delete")
+	defer span.End()
+
+	return ac.roleassignments.Delete(ctx, scope, roleAssignmentName)
+}
@@ -58,86 +58,120 @@ func New(scope RoleAssignmentScope) *Service {
 	}
 }
 
-// Reconcile creates a role assignment.
+// Reconcile creates the role assignments for the system assigned identity.
 func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "roleassignments.Service.Reconcile")
 	defer span.End()
 
+	// principal IDs are looked up once per VM or VMSS, which may have several role assignments.
+	principalIDs := make(map[string]*string)
 	for _, roleSpec := range s.Scope.RoleAssignmentSpecs() {
-
+		var kind string
 		switch roleSpec.ResourceType {
 		case azure.VirtualMachine:
-			return s.reconcileVM(ctx, roleSpec)
+			kind = "VM"
 		case azure.VirtualMachineScaleSet:
-			return s.reconcileVMSS(ctx, roleSpec)
+			kind = "VMSS"
 		default:
 			return errors.Errorf("unexpected resource type %q. Expected one of [%s, %s]", roleSpec.ResourceType,
 				azure.VirtualMachine, azure.VirtualMachineScaleSet)
 		}
 
+		key := roleSpec.ResourceType + "/" + roleSpec.MachineName
+		principalID, ok := principalIDs[key]
+		if !ok {
+			var err error
+			if roleSpec.ResourceType == azure.VirtualMachine {
+				principalID, err = s.getVMPrincipalID(ctx, roleSpec)
+			} else {
+				principalID, err = s.getVMSSPrincipalID(ctx, roleSpec)
+			}
+			if err != nil {
+				return err
+			}
+			principalIDs[key] = principalID
+		}
+
+		if err := s.assignRole(ctx, roleSpec, principalID); err != nil {
+			return errors.Wrapf(err, "cannot assign role to %s system assigned identity", kind)
+		}
+
+		s.Scope.V(2).Info("successfully created role assignment for generated identity", "role assignment", roleSpec.Name, "resource type", roleSpec.ResourceType, "name", roleSpec.MachineName)
 	}
 	return nil
 }
 
-func (s *Service) reconcileVM(ctx context.Context, roleSpec azure.RoleAssignmentSpec) error {
-	ctx, span := tele.Tracer().Start(ctx, "roleassignments.Service.reconcileVM")
+func (s *Service) getVMPrincipalID(ctx context.Context, roleSpec azure.RoleAssignmentSpec) (*string, error) {
+	ctx, span := tele.Tracer().Start(ctx, "roleassignments.Service.getVMPrincipalID")
 	defer span.End()
 
 	resultVM, err := s.virtualMachinesClient.Get(ctx, s.Scope.ResourceGroup(), roleSpec.MachineName)
 	if err != nil {
-		return errors.Wrapf(err, "cannot get VM to assign role to system assigned identity")
+		return nil, errors.Wrapf(err, "cannot get VM to assign role to system assigned identity")
 	}
-
-	err = s.assignRole(ctx, roleSpec.Name, resultVM.Identity.PrincipalID)
-	if err != nil {
-		return errors.Wrapf(err, "cannot assign role to VM system assigned identity")
+	if resultVM.Identity == nil {
+		return nil, errors.Errorf("VM %s does not have a system assigned identity", roleSpec.MachineName)
 	}
-
-	s.Scope.V(2).Info("successfully created role assignment for generated Identity for VM", "virtual machine", roleSpec.MachineName)
-
-	return nil
+	return resultVM.Identity.PrincipalID, nil
 }
 
-func (s *Service) reconcileVMSS(ctx context.Context, roleSpec azure.RoleAssignmentSpec) error {
-	ctx, span := tele.Tracer().Start(ctx, "roleassignments.Service.reconcileVMSS")
+func (s *Service) getVMSSPrincipalID(ctx context.Context, roleSpec azure.RoleAssignmentSpec) (*string, error) {
+	ctx, span := tele.Tracer().Start(ctx, "roleassignments.Service.getVMSSPrincipalID")
 	defer span.End()
 
 	resultVMSS, err := s.virtualMachineScaleSetClient.Get(ctx, s.Scope.ResourceGroup(), roleSpec.MachineName)
 	if err != nil {
-		return errors.Wrapf(err, "cannot get VMSS to assign role to system assigned identity")
+		return nil, errors.Wrapf(err, "cannot get VMSS to assign role to system assigned identity")
 	}
-
-	err = s.assignRole(ctx, roleSpec.Name, resultVMSS.Identity.PrincipalID)
-	if err != nil {
-		return errors.Wrapf(err, "cannot assign role to VMSS system assigned identity")
+	if resultVMSS.Identity == nil {
+		return nil, errors.Errorf("VMSS %s does not have a system assigned identity", roleSpec.MachineName)
 	}
-
-	s.Scope.V(2).Info("successfully created role assignment for generated Identity for VMSS", "virtual machine scale set", roleSpec.MachineName)
-
-	return nil
+	return resultVMSS.Identity.PrincipalID, nil
 }
 
-func (s *Service) assignRole(ctx context.Context, roleAssignmentName string, principalID *string) error {
+func (s *Service) assignRole(ctx context.Context, roleSpec azure.RoleAssignmentSpec, principalID *string) error {
 	ctx, span := tele.Tracer().Start(ctx, "roleassignments.Service.assignRole")
 	defer span.End()
 
-	scope := fmt.Sprintf("/subscriptions/%s/", s.Scope.SubscriptionID())
-	// Azure built-in roles https://docs.microsoft.com/en-us/azure/role-based-access-control/built-in-roles
-	contributorRoleDefinitionID := fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Authorization/roleDefinitions/%s", s.Scope.SubscriptionID(), azureBuiltInContributorID)
 	params := authorization.RoleAssignmentCreateParameters{
 		Properties: &authorization.RoleAssignmentProperties{
-			RoleDefinitionID: to.StringPtr(contributorRoleDefinitionID),
+			RoleDefinitionID: to.StringPtr(s.roleDefinitionID(roleSpec)),
 			PrincipalID:      principalID,
 		},
 	}
-	_, err := s.client.Create(ctx, scope, roleAssignmentName, params)
+	_, err := s.client.Create(ctx, s.scope(roleSpec), roleSpec.Name, params)
 	return err
 }
 
-// Delete is a no-op as the role assignments get deleted as part of VM deletion.
+// Delete deletes the role assignments created for the system assigned identity.
 func (s *Service) Delete(ctx context.Context) error {
-	_, span := tele.Tracer().Start(ctx, "roleassignments.Service.Delete")
+	ctx, span := tele.Tracer().Start(ctx, "roleassignments.Service.Delete")
 	defer span.End()
 
+	for _, roleSpec := range s.Scope.RoleAssignmentSpecs() {
+		s.Scope.V(2).Info("deleting role assignment", "role assignment", roleSpec.Name)
+		_, err := s.client.Delete(ctx, s.scope(roleSpec), roleSpec.Name)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to delete role assignment %s", roleSpec.Name)
+		}
+		s.Scope.V(2).Info("successfully deleted role assignment", "role assignment", roleSpec.Name)
+	}
 	return nil
 }
+
+// scope returns the scope of a role assignment, defaulting to the subscription.
+func (s *Service) scope(roleSpec azure.RoleAssignmentSpec) string {
+	if roleSpec.Scope != "" {
+		return roleSpec.Scope
+	}
+	return fmt.Sprintf("/subscriptions/%s/", s.Scope.SubscriptionID())
+}
+
+// roleDefinitionID returns the role definition of a role assignment, defaulting to the built-in Contributor role.
+func (s *Service) roleDefinitionID(roleSpec azure.RoleAssignmentSpec) string {
+	if roleSpec.RoleDefinitionID != "" {
+		return roleSpec.RoleDefinitionID
+	}
+	// Azure built-in roles https://docs.microsoft.com/en-us/azure/role-based-access-control/built-in-roles
+	return azure.RoleDefinitionID(s.Scope.SubscriptionID(), azureBuiltInContributorID)
+}
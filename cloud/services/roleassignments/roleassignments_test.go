@@ -69,6 +69,50 @@ func TestReconcileRoleAssignmentsVM(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name:          "create multiple role assignments with custom roles and scopes",
+			expectedError: "",
+			expect: func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder, m *mock_roleassignments.MockclientMockRecorder, v *mock_virtualmachines.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.SubscriptionID().AnyTimes().Return("12345")
+				s.ResourceGroup().Return("my-rg")
+				s.RoleAssignmentSpecs().Return([]azure.RoleAssignmentSpec{
+					{
+						MachineName:      "test-vm",
+						Name:             "role-1",
+						ResourceType:     azure.VirtualMachine,
+						RoleDefinitionID: "/subscriptions/12345/providers/Microsoft.Authorization/roleDefinitions/4d97b98b-1d4f-4787-a291-c67834d212e7",
+						Scope:            "/subscriptions/12345/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+					},
+					{
+						MachineName:      "test-vm",
+						Name:             "role-2",
+						ResourceType:     azure.VirtualMachine,
+						RoleDefinitionID: "/subscriptions/12345/providers/Microsoft.Authorization/roleDefinitions/acdd72a7-3385-48ef-bd42-f606fba81ae7",
+						Scope:            "/subscriptions/12345/resourceGroups/my-rg",
+					},
+				})
+				v.Get(gomockinternal.AContext(), "my-rg", "test-vm").Return(compute.VirtualMachine{
+					Identity: &compute.VirtualMachineIdentity{
+						PrincipalID: to.StringPtr("000"),
+					},
+				}, nil)
+				gomock.InOrder(
+					m.Create(gomockinternal.AContext(), "/subscriptions/12345/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet", "role-1", gomockinternal.DiffEq(authorization.RoleAssignmentCreateParameters{
+						Properties: &authorization.RoleAssignmentProperties{
+							RoleDefinitionID: to.StringPtr("/subscriptions/12345/providers/Microsoft.Authorization/roleDefinitions/4d97b98b-1d4f-4787-a291-c67834d212e7"),
+							PrincipalID:      to.StringPtr("000"),
+						},
+					})),
+					m.Create(gomockinternal.AContext(), "/subscriptions/12345/resourceGroups/my-rg", "role-2", gomockinternal.DiffEq(authorization.RoleAssignmentCreateParameters{
+						Properties: &authorization.RoleAssignmentProperties{
+							RoleDefinitionID: to.StringPtr("/subscriptions/12345/providers/Microsoft.Authorization/roleDefinitions/acdd72a7-3385-48ef-bd42-f606fba81ae7"),
+							PrincipalID:      to.StringPtr("000"),
+						},
+					})),
+				)
+			},
+		},
 		{
 			name:          "error getting VM",
 			expectedError: "cannot get VM to assign role to system assigned identity: #: Internal Server Error: StatusCode=500",
@@ -237,3 +281,98 @@ func TestReconcileRoleAssignmentsVMSS(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteRoleAssignments(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder, m *mock_roleassignments.MockclientMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "delete role assignments",
+			expectedError: "",
+			expect: func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder, m *mock_roleassignments.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.SubscriptionID().AnyTimes().Return("12345")
+				s.RoleAssignmentSpecs().Return([]azure.RoleAssignmentSpec{
+					{
+						MachineName:  "test-vm",
+						Name:         "role-1",
+						ResourceType: azure.VirtualMachine,
+					},
+					{
+						MachineName:  "test-vm",
+						Name:         "role-2",
+						ResourceType: azure.VirtualMachine,
+						Scope:        "/subscriptions/12345/resourceGroups/my-rg",
+					},
+				})
+				m.Delete(gomockinternal.AContext(), "/subscriptions/12345/", "role-1")
+				m.Delete(gomockinternal.AContext(), "/subscriptions/12345/resourceGroups/my-rg", "role-2")
+			},
+		},
+		{
+			name:          "role assignment already deleted",
+			expectedError: "",
+			expect: func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder, m *mock_roleassignments.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.SubscriptionID().AnyTimes().Return("12345")
+				s.RoleAssignmentSpecs().Return([]azure.RoleAssignmentSpec{
+					{
+						MachineName:  "test-vm",
+						Name:         "role-1",
+						ResourceType: azure.VirtualMachine,
+						Scope:        "/subscriptions/12345/resourceGroups/my-rg",
+					},
+				})
+				m.Delete(gomockinternal.AContext(), "/subscriptions/12345/resourceGroups/my-rg", "role-1").
+					Return(authorization.RoleAssignment{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"))
+			},
+		},
+		{
+			name:          "error deleting role assignment",
+			expectedError: "failed to delete role assignment role-1: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_roleassignments.MockRoleAssignmentScopeMockRecorder, m *mock_roleassignments.MockclientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.SubscriptionID().AnyTimes().Return("12345")
+				s.RoleAssignmentSpecs().Return([]azure.RoleAssignmentSpec{
+					{
+						MachineName:  "test-vm",
+						Name:         "role-1",
+						ResourceType: azure.VirtualMachine,
+						Scope:        "/subscriptions/12345/resourceGroups/my-rg",
+					},
+				})
+				m.Delete(gomockinternal.AContext(), "/subscriptions/12345/resourceGroups/my-rg", "role-1").
+					Return(authorization.RoleAssignment{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_roleassignments.NewMockRoleAssignmentScope(mockCtrl)
+			clientMock := mock_roleassignments.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
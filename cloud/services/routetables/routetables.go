@@ -18,14 +18,18 @@ package routetables
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/subnets"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -41,13 +45,15 @@ type RouteTableScope interface {
 type Service struct {
 	Scope RouteTableScope
 	client
+	subnetsClient subnets.Client
 }
 
 // New creates a new service.
 func New(scope *scope.ClusterScope) *Service {
 	return &Service{
-		Scope:  scope,
-		client: newClient(scope),
+		Scope:         scope,
+		client:        newClient(scope),
+		subnetsClient: subnets.NewClient(scope),
 	}
 }
 
@@ -56,40 +62,62 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "routetables.Service.Reconcile")
 	defer span.End()
 
-	if !s.Scope.Vnet().IsManaged(s.Scope.ClusterName()) {
+	// Route tables are only reconciled along with a custom vnet when it is shared with the cluster.
+	vnetManaged := s.Scope.Vnet().IsManaged(s.Scope.ClusterName())
+	if !vnetManaged && !s.Scope.Vnet().Tags.HasShared(s.Scope.ClusterName()) {
 		s.Scope.V(4).Info("Skipping route tables reconcile in custom vnet mode")
 		return nil
 	}
 
 	for _, routeTableSpec := range s.Scope.RouteTableSpecs() {
 		existingRouteTable, err := s.Get(ctx, s.Scope.ResourceGroup(), routeTableSpec.Name)
-		if !azure.ResourceNotFound(err) {
-			if err != nil {
-				return errors.Wrapf(err, "failed to get route table %s in %s", routeTableSpec.Name, s.Scope.ResourceGroup())
-			}
-
+		switch {
+		case err != nil && !azure.ResourceNotFound(err):
+			return errors.Wrapf(err, "failed to get route table %s in %s", routeTableSpec.Name, s.Scope.ResourceGroup())
+		case err == nil:
 			// route table already exists
-			// currently don't support specifying your own routes via spec
 			routeTableSpec.Subnet.RouteTable.Name = to.String(existingRouteTable.Name)
 			routeTableSpec.Subnet.RouteTable.ID = to.String(existingRouteTable.ID)
-
-			continue
+			if err := s.update(ctx, routeTableSpec, existingRouteTable, vnetManaged); err != nil {
+				return err
+			}
+		default:
+			s.Scope.V(2).Info("creating Route Table", "route table", routeTableSpec.Name)
+			routes := make([]network.Route, 0, len(routeTableSpec.Routes))
+			entries := make([]string, 0, len(routeTableSpec.Routes))
+			for _, route := range routeTableSpec.Routes {
+				routes = append(routes, converters.RouteToSDK(route))
+				entries = append(entries, route.Name)
+			}
+			err = s.client.CreateOrUpdate(
+				ctx,
+				s.Scope.ResourceGroup(),
+				routeTableSpec.Name,
+				network.RouteTable{
+					Location: to.StringPtr(s.Scope.Location()),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+						Routes: &routes,
+					},
+					Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+						ClusterName: s.Scope.ClusterName(),
+						Lifecycle:   infrav1.ResourceLifecycleOwned,
+						Name:        to.StringPtr(routeTableSpec.Name),
+						Entries:     entries,
+					})),
+				},
+			)
+			if err != nil {
+				return errors.Wrapf(err, "failed to create route table %s in resource group %s", routeTableSpec.Name, s.Scope.ResourceGroup())
+			}
+			s.Scope.V(2).Info("successfully created route table", "route table", routeTableSpec.Name)
 		}
 
-		s.Scope.V(2).Info("creating Route Table", "route table", routeTableSpec.Name)
-		err = s.client.CreateOrUpdate(
-			ctx,
-			s.Scope.ResourceGroup(),
-			routeTableSpec.Name,
-			network.RouteTable{
-				Location:                   to.StringPtr(s.Scope.Location()),
-				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{},
-			},
-		)
-		if err != nil {
-			return errors.Wrapf(err, "failed to create route table %s in resource group %s", routeTableSpec.Name, s.Scope.ResourceGroup())
+		// The subnets of a custom vnet may already exist without a route table.
+		if !vnetManaged {
+			if err := s.associate(ctx, routeTableSpec); err != nil {
+				return err
+			}
 		}
-		s.Scope.V(2).Info("successfully created route table", "route table", routeTableSpec.Name)
 	}
 	return nil
 }
@@ -99,13 +127,38 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "routetables.Service.Delete")
 	defer span.End()
 
-	if !s.Scope.Vnet().IsManaged(s.Scope.ClusterName()) {
-		s.Scope.V(4).Info("Skipping route table deletion in custom vnet mode")
-		return nil
-	}
+	vnetManaged := s.Scope.Vnet().IsManaged(s.Scope.ClusterName())
 	for _, routeTableSpec := range s.Scope.RouteTableSpecs() {
+		existingRouteTable, err := s.Get(ctx, s.Scope.ResourceGroup(), routeTableSpec.Name)
+		if err != nil && azure.ResourceNotFound(err) {
+			// already deleted
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to get route table %s in %s", routeTableSpec.Name, s.Scope.ResourceGroup())
+		}
+
+		tags := converters.MapToTags(existingRouteTable.Tags)
+		if tags.HasShared(s.Scope.ClusterName()) {
+			if err := s.unshare(ctx, existingRouteTable); err != nil {
+				return err
+			}
+			continue
+		}
+		if !vnetManaged && !tags.HasOwned(s.Scope.ClusterName()) {
+			s.Scope.V(4).Info("Skipping route table deletion in custom vnet mode", "route table", routeTableSpec.Name)
+			continue
+		}
+
+		// A route table can't be deleted while a subnet of the custom vnet still uses it.
+		if !vnetManaged {
+			if err := s.dissociate(ctx, routeTableSpec, existingRouteTable); err != nil {
+				return err
+			}
+		}
+
 		s.Scope.V(2).Info("deleting route table", "route table", routeTableSpec.Name)
-		err := s.client.Delete(ctx, s.Scope.ResourceGroup(), routeTableSpec.Name)
+		err = s.client.Delete(ctx, s.Scope.ResourceGroup(), routeTableSpec.Name)
 		if err != nil && azure.ResourceNotFound(err) {
 			// already deleted
 			continue
@@ -118,3 +171,190 @@ func (s *Service) Delete(ctx context.Context) error {
 	}
 	return nil
 }
+
+// update adds the user-defined routes missing from an existing route table and removes the ones dropped from the
+// spec, leaving the routes added by other means, such as the cloud provider route controller, untouched. The routes
+// the cluster manages are recorded in the tags of the route table.
+func (s *Service) update(ctx context.Context, spec azure.RouteTableSpec, rt network.RouteTable, vnetManaged bool) error {
+	tags := converters.MapToTags(rt.Tags)
+	lifecycle := infrav1.ResourceLifecycleOwned
+	// A route table provided along with a custom vnet may be shared with other clusters.
+	if !vnetManaged && !tags.HasOwned(s.Scope.ClusterName()) {
+		lifecycle = infrav1.ResourceLifecycleShared
+	}
+
+	routes, entries, changed := mergeRoutes(*routes(rt), spec.Routes, tags, s.Scope.ClusterName())
+	newTags := infrav1.Tags{}
+	newTags.Merge(tags)
+	newTags.DeleteEntries(s.Scope.ClusterName())
+	newTags.Merge(infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.Scope.ClusterName(),
+		Lifecycle:   lifecycle,
+		Entries:     entries,
+	}))
+	if !changed && newTags.Equals(tags) {
+		s.Scope.V(2).Info("route table exists and its routes are up to date, skipping update", "route table", spec.Name)
+		return nil
+	}
+
+	s.Scope.V(2).Info("updating route table", "route table", spec.Name)
+	err := s.client.CreateOrUpdate(ctx, s.Scope.ResourceGroup(), spec.Name, network.RouteTable{
+		Location:                   rt.Location,
+		RouteTablePropertiesFormat: withRoutes(rt, routes),
+		Etag:                       rt.Etag,
+		Tags:                       converters.TagsToMap(newTags),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update route table %s in resource group %s", spec.Name, s.Scope.ResourceGroup())
+	}
+	s.Scope.V(2).Info("successfully updated route table", "route table", spec.Name)
+	return nil
+}
+
+// mergeRoutes merges the user-defined routes of a cluster into the existing routes of a route table. It returns the
+// resulting routes, the names of the routes managed by the cluster, and whether the routes changed. A route is only
+// managed by the cluster if the cluster added or updated it, or if another cluster manages it as well, so routes
+// added by other means are never removed.
+func mergeRoutes(existing []network.Route, desired []infrav1.Route, tags infrav1.Tags, cluster string) ([]network.Route, []string, bool) {
+	wanted := make(map[string]network.Route, len(desired))
+	for _, route := range desired {
+		wanted[route.Name] = converters.RouteToSDK(route)
+	}
+
+	result := make([]network.Route, 0, len(existing)+len(desired))
+	entries := make([]string, 0, len(desired))
+	changed := false
+	for _, route := range existing {
+		name := to.String(route.Name)
+		want, ok := wanted[name]
+		switch {
+		case ok:
+			delete(wanted, name)
+			if !routeEquals(route, want) {
+				route = want
+				changed = true
+				entries = append(entries, name)
+			} else if tags.HasEntry(cluster, name) || tags.HasEntryOfOtherCluster(cluster, name) {
+				entries = append(entries, name)
+			}
+		case tags.HasEntry(cluster, name) && !tags.HasEntryOfOtherCluster(cluster, name):
+			// the route was removed from the spec
+			changed = true
+			continue
+		}
+		result = append(result, route)
+	}
+	for _, route := range desired {
+		if want, ok := wanted[route.Name]; ok {
+			result = append(result, want)
+			entries = append(entries, route.Name)
+			changed = true
+		}
+	}
+	return result, entries, changed
+}
+
+// routeEquals returns true if two routes send the same packets to the same hop.
+func routeEquals(a, b network.Route) bool {
+	if a.RoutePropertiesFormat == nil || b.RoutePropertiesFormat == nil {
+		return a.RoutePropertiesFormat == b.RoutePropertiesFormat
+	}
+	return strings.EqualFold(to.String(a.AddressPrefix), to.String(b.AddressPrefix)) &&
+		strings.EqualFold(string(a.NextHopType), string(b.NextHopType)) &&
+		to.String(a.NextHopIPAddress) == to.String(b.NextHopIPAddress)
+}
+
+// associate associates the route table with its subnet in a custom vnet if the subnet has no route table yet.
+func (s *Service) associate(ctx context.Context, spec azure.RouteTableSpec) error {
+	vnet := s.Scope.Vnet()
+	subnet, err := s.subnetsClient.Get(ctx, vnet.ResourceGroup, vnet.Name, spec.Subnet.Name)
+	if azure.ResourceNotFound(err) {
+		// the subnet is created along with its route table association
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get subnet %s in vnet %s", spec.Subnet.Name, vnet.Name)
+	}
+	if subnet.SubnetPropertiesFormat == nil || subnet.RouteTable != nil {
+		return nil
+	}
+
+	s.Scope.V(2).Info("associating route table with subnet", "route table", spec.Name, "subnet", spec.Subnet.Name)
+	subnet.RouteTable = &network.RouteTable{
+		ID: to.StringPtr(azure.RouteTableID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), spec.Name)),
+	}
+	if err := s.subnetsClient.CreateOrUpdate(ctx, vnet.ResourceGroup, vnet.Name, spec.Subnet.Name, subnet); err != nil {
+		return errors.Wrapf(err, "failed to associate route table %s with subnet %s", spec.Name, spec.Subnet.Name)
+	}
+	return nil
+}
+
+// dissociate removes the route table from its subnet in a custom vnet if the subnet uses it.
+func (s *Service) dissociate(ctx context.Context, spec azure.RouteTableSpec, rt network.RouteTable) error {
+	vnet := s.Scope.Vnet()
+	subnet, err := s.subnetsClient.Get(ctx, vnet.ResourceGroup, vnet.Name, spec.Subnet.Name)
+	if azure.ResourceNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get subnet %s in vnet %s", spec.Subnet.Name, vnet.Name)
+	}
+	if subnet.SubnetPropertiesFormat == nil || subnet.RouteTable == nil ||
+		!strings.EqualFold(to.String(subnet.RouteTable.ID), to.String(rt.ID)) {
+		return nil
+	}
+
+	s.Scope.V(2).Info("dissociating route table from subnet", "route table", spec.Name, "subnet", spec.Subnet.Name)
+	subnet.RouteTable = nil
+	if err := s.subnetsClient.CreateOrUpdate(ctx, vnet.ResourceGroup, vnet.Name, spec.Subnet.Name, subnet); err != nil {
+		return errors.Wrapf(err, "failed to dissociate route table %s from subnet %s", spec.Name, spec.Subnet.Name)
+	}
+	return nil
+}
+
+// unshare removes the routes the cluster added to a shared route table, except the ones other clusters still use,
+// along with the tags of the cluster.
+func (s *Service) unshare(ctx context.Context, rt network.RouteTable) error {
+	tags := converters.MapToTags(rt.Tags)
+	remaining := make([]network.Route, 0)
+	for _, route := range *routes(rt) {
+		name := to.String(route.Name)
+		if tags.HasEntry(s.Scope.ClusterName(), name) && !tags.HasEntryOfOtherCluster(s.Scope.ClusterName(), name) {
+			continue
+		}
+		remaining = append(remaining, route)
+	}
+	delete(tags, infrav1.ClusterTagKey(s.Scope.ClusterName()))
+	tags.DeleteEntries(s.Scope.ClusterName())
+
+	s.Scope.V(2).Info("removing routes from shared route table", "route table", to.String(rt.Name))
+	err := s.client.CreateOrUpdate(ctx, s.Scope.ResourceGroup(), to.String(rt.Name), network.RouteTable{
+		Location:                   rt.Location,
+		RouteTablePropertiesFormat: withRoutes(rt, remaining),
+		Etag:                       rt.Etag,
+		Tags:                       converters.TagsToMap(tags),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove routes from shared route table %s in resource group %s", to.String(rt.Name), s.Scope.ResourceGroup())
+	}
+	return nil
+}
+
+// withRoutes returns a copy of the properties of a route table with the given routes, so that a PUT keeps the other
+// properties, such as whether BGP route propagation is disabled.
+func withRoutes(rt network.RouteTable, routes []network.Route) *network.RouteTablePropertiesFormat {
+	properties := network.RouteTablePropertiesFormat{}
+	if rt.RouteTablePropertiesFormat != nil {
+		properties = *rt.RouteTablePropertiesFormat
+	}
+	properties.Routes = &routes
+	return &properties
+}
+
+// routes returns the routes of a route table.
+func routes(rt network.RouteTable) *[]network.Route {
+	if rt.RouteTablePropertiesFormat == nil || rt.Routes == nil {
+		return &[]network.Route{}
+	}
+	return rt.Routes
+}
@@ -33,6 +33,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/routetables/mock_routetables"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/subnets/mock_subnets"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 )
 
@@ -45,7 +46,7 @@ func TestReconcileRouteTables(t *testing.T) {
 		name          string
 		tags          infrav1.Tags
 		expectedError string
-		expect        func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder)
+		expect        func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder)
 	}{
 		{
 			name: "route tables in custom vnet mode",
@@ -55,13 +56,60 @@ func TestReconcileRouteTables(t *testing.T) {
 				"sigs.k8s.io_cluster-api-provider-azure_role":                 "common",
 			},
 			expectedError: "",
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder) {
-				s.Vnet().Return(&infrav1.VnetSpec{
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
 					ID:   "1234",
 					Name: "my-vnet",
 				})
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				s.ClusterName()
+				s.ClusterName().AnyTimes().Return("test-cluster")
+			},
+		},
+		{
+			name:          "route table tagged as shared in shared custom vnet",
+			expectedError: "",
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
+					ID:   "1234",
+					Name: "my-vnet",
+					Tags: infrav1.Tags{"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": "shared"},
+				})
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				s.RouteTableSpecs().Return([]azure.RouteTableSpec{{
+					Name: "my-cp-routetable",
+					Subnet: &infrav1.SubnetSpec{
+						Name: "control-plane-subnet",
+						Role: infrav1.SubnetControlPlane,
+					},
+				}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cp-routetable").Return(network.RouteTable{
+					ID:       to.StringPtr("1"),
+					Name:     to.StringPtr("my-cp-routetable"),
+					Location: to.StringPtr("westus"),
+					Etag:     to.StringPtr("test-etag"),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+						Routes: &[]network.Route{{Name: to.StringPtr("custom-route")}},
+					},
+					Tags: map[string]*string{"Name": to.StringPtr("custom-rt")},
+				}, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-cp-routetable", gomockinternal.DiffEq(network.RouteTable{
+					Location: to.StringPtr("westus"),
+					Etag:     to.StringPtr("test-etag"),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+						Routes: &[]network.Route{{Name: to.StringPtr("custom-route")}},
+					},
+					Tags: map[string]*string{
+						"Name": to.StringPtr("custom-rt"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("shared"),
+					},
+				}))
+				sn.Get(gomockinternal.AContext(), "", "my-vnet", "control-plane-subnet").Return(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						RouteTable: &network.RouteTable{ID: to.StringPtr("1")},
+					},
+				}, nil)
 			},
 		},
 		{
@@ -72,12 +120,12 @@ func TestReconcileRouteTables(t *testing.T) {
 				"sigs.k8s.io_cluster-api-provider-azure_role":                 "common",
 			},
 			expectedError: "",
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder) {
-				s.Vnet().Return(&infrav1.VnetSpec{
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
 					Name: "my-vnet",
 				})
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				s.ClusterName()
+				s.ClusterName().AnyTimes().Return("test-cluster")
 				s.RouteTableSpecs().Return([]azure.RouteTableSpec{
 					{
 						Name: "my-cp-routetable",
@@ -113,12 +161,12 @@ func TestReconcileRouteTables(t *testing.T) {
 				"sigs.k8s.io_cluster-api-provider-azure_role":                 "common",
 			},
 			expectedError: "",
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder) {
-				s.Vnet().Return(&infrav1.VnetSpec{
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
 					Name: "my-vnet",
 				})
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				s.ClusterName()
+				s.ClusterName().AnyTimes().Return("test-cluster")
 				s.RouteTableSpecs().AnyTimes().Return([]azure.RouteTableSpec{
 					{
 						Name: "my-cp-routetable",
@@ -141,6 +189,9 @@ func TestReconcileRouteTables(t *testing.T) {
 				m.Get(gomockinternal.AContext(), "my-rg", "my-cp-routetable").Return(network.RouteTable{
 					Name: to.StringPtr("my-cp-routetable"),
 					ID:   to.StringPtr("1"),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+					},
 				}, nil)
 				s.NodeSubnet().AnyTimes().Return(&infrav1.SubnetSpec{Name: "node-subnet", Role: infrav1.SubnetNode})
 				s.NodeRouteTable().AnyTimes().Return(&infrav1.RouteTable{Name: "my-node-routetable"})
@@ -148,7 +199,149 @@ func TestReconcileRouteTables(t *testing.T) {
 				m.Get(gomockinternal.AContext(), "my-rg", "my-node-routetable").Return(network.RouteTable{
 					Name: to.StringPtr("my-node-routetable"),
 					ID:   to.StringPtr("2"),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+					},
+				}, nil)
+			},
+		},
+		{
+			name:          "user-defined routes merged without removing other routes or properties",
+			expectedError: "",
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
+					Name: "my-vnet",
+				})
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				s.RouteTableSpecs().Return([]azure.RouteTableSpec{{
+					Name: "my-node-routetable",
+					Subnet: &infrav1.SubnetSpec{
+						Name: "node-subnet",
+						Role: infrav1.SubnetNode,
+					},
+					Routes: []infrav1.Route{
+						{Name: "egress", AddressPrefix: "0.0.0.0/0", NextHopType: infrav1.RouteNextHopTypeVirtualAppliance, NextHopIPAddress: "10.1.0.4"},
+						{Name: "storage", AddressPrefix: "Storage", NextHopType: infrav1.RouteNextHopTypeInternet},
+					},
+				}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-node-routetable").Return(network.RouteTable{
+					ID:       to.StringPtr("1"),
+					Name:     to.StringPtr("my-node-routetable"),
+					Location: to.StringPtr("westus"),
+					Etag:     to.StringPtr("test-etag"),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+						DisableBgpRoutePropagation: to.BoolPtr(true),
+						Routes: &[]network.Route{
+							{
+								Name: to.StringPtr("kubenet-node-0"),
+								RoutePropertiesFormat: &network.RoutePropertiesFormat{
+									AddressPrefix:    to.StringPtr("10.244.0.0/24"),
+									NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+									NextHopIPAddress: to.StringPtr("10.1.0.5"),
+								},
+							},
+							{
+								Name: to.StringPtr("egress"),
+								RoutePropertiesFormat: &network.RoutePropertiesFormat{
+									AddressPrefix:    to.StringPtr("0.0.0.0/0"),
+									NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+									NextHopIPAddress: to.StringPtr("10.1.0.1"),
+								},
+							},
+							{
+								Name: to.StringPtr("old-egress"),
+								RoutePropertiesFormat: &network.RoutePropertiesFormat{
+									AddressPrefix: to.StringPtr("10.0.0.0/8"),
+									NextHopType:   network.RouteNextHopTypeNone,
+								},
+							},
+						},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster":   to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_test-cluster":   to.StringPtr("egress"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_test-cluster_1": to.StringPtr("old-egress"),
+					},
 				}, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-node-routetable", gomockinternal.DiffEq(network.RouteTable{
+					Location: to.StringPtr("westus"),
+					Etag:     to.StringPtr("test-etag"),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+						DisableBgpRoutePropagation: to.BoolPtr(true),
+						Routes: &[]network.Route{
+							{
+								Name: to.StringPtr("kubenet-node-0"),
+								RoutePropertiesFormat: &network.RoutePropertiesFormat{
+									AddressPrefix:    to.StringPtr("10.244.0.0/24"),
+									NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+									NextHopIPAddress: to.StringPtr("10.1.0.5"),
+								},
+							},
+							{
+								Name: to.StringPtr("egress"),
+								RoutePropertiesFormat: &network.RoutePropertiesFormat{
+									AddressPrefix:    to.StringPtr("0.0.0.0/0"),
+									NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+									NextHopIPAddress: to.StringPtr("10.1.0.4"),
+								},
+							},
+							{
+								Name: to.StringPtr("storage"),
+								RoutePropertiesFormat: &network.RoutePropertiesFormat{
+									AddressPrefix: to.StringPtr("Storage"),
+									NextHopType:   network.RouteNextHopTypeInternet,
+								},
+							},
+						},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_test-cluster": to.StringPtr("egress,storage"),
+					},
+				}))
+			},
+		},
+		{
+			name:          "route table associated with subnet of custom vnet",
+			expectedError: "",
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
+					ID:            "1234",
+					Name:          "my-vnet",
+					ResourceGroup: "vnet-rg",
+					Tags:          infrav1.Tags{"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": "shared"},
+				})
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.RouteTableSpecs().Return([]azure.RouteTableSpec{{
+					Name: "my-node-routetable",
+					Subnet: &infrav1.SubnetSpec{
+						Name: "node-subnet",
+						Role: infrav1.SubnetNode,
+					},
+				}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().Return("westus")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-node-routetable").Return(network.RouteTable{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-node-routetable", gomock.AssignableToTypeOf(network.RouteTable{}))
+				sn.Get(gomockinternal.AContext(), "vnet-rg", "my-vnet", "node-subnet").Return(network.Subnet{
+					Name: to.StringPtr("node-subnet"),
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						AddressPrefix: to.StringPtr("10.0.0.0/24"),
+					},
+				}, nil)
+				sn.CreateOrUpdate(gomockinternal.AContext(), "vnet-rg", "my-vnet", "node-subnet", gomockinternal.DiffEq(network.Subnet{
+					Name: to.StringPtr("node-subnet"),
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						AddressPrefix: to.StringPtr("10.0.0.0/24"),
+						RouteTable: &network.RouteTable{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/routeTables/my-node-routetable"),
+						},
+					},
+				}))
 			},
 		},
 		{
@@ -159,12 +352,12 @@ func TestReconcileRouteTables(t *testing.T) {
 				"sigs.k8s.io_cluster-api-provider-azure_role":                 "common",
 			},
 			expectedError: "failed to get route table my-cp-routetable in my-rg: #: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder) {
-				s.Vnet().Return(&infrav1.VnetSpec{
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
 					Name: "my-vnet",
 				})
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				s.ClusterName()
+				s.ClusterName().AnyTimes().Return("test-cluster")
 				s.RouteTableSpecs().Return([]azure.RouteTableSpec{{
 					Name: "my-cp-routetable",
 					Subnet: &infrav1.SubnetSpec{
@@ -188,12 +381,12 @@ func TestReconcileRouteTables(t *testing.T) {
 				"sigs.k8s.io_cluster-api-provider-azure_role":                 "common",
 			},
 			expectedError: "failed to create route table my-cp-routetable in resource group my-rg: #: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder) {
-				s.Vnet().Return(&infrav1.VnetSpec{
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
 					Name: "my-vnet",
 				})
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				s.ClusterName()
+				s.ClusterName().AnyTimes().Return("test-cluster")
 				s.RouteTableSpecs().Return([]azure.RouteTableSpec{{
 					Name: "my-cp-routetable",
 					Subnet: &infrav1.SubnetSpec{
@@ -220,12 +413,14 @@ func TestReconcileRouteTables(t *testing.T) {
 			defer mockCtrl.Finish()
 			scopeMock := mock_routetables.NewMockRouteTableScope(mockCtrl)
 			clientMock := mock_routetables.NewMockclient(mockCtrl)
+			subnetsMock := mock_subnets.NewMockClient(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), subnetsMock.EXPECT())
 
 			s := &Service{
-				Scope:  scopeMock,
-				client: clientMock,
+				Scope:         scopeMock,
+				client:        clientMock,
+				subnetsClient: subnetsMock,
 			}
 
 			err := s.Reconcile(context.TODO())
@@ -244,7 +439,7 @@ func TestDeleteRouteTable(t *testing.T) {
 		name          string
 		tags          infrav1.Tags
 		expectedError string
-		expect        func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder)
+		expect        func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder)
 	}{
 		{
 			name: "route tables in custom vnet mode",
@@ -254,13 +449,119 @@ func TestDeleteRouteTable(t *testing.T) {
 				"sigs.k8s.io_cluster-api-provider-azure_role":                 "common",
 			},
 			expectedError: "",
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder) {
-				s.Vnet().Return(&infrav1.VnetSpec{
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
 					ID:   "1234",
 					Name: "my-vnet",
 				})
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				s.ClusterName()
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				s.RouteTableSpecs().Return([]azure.RouteTableSpec{{
+					Name: "my-cp-routetable",
+					Subnet: &infrav1.SubnetSpec{
+						Name: "control-plane-subnet",
+						Role: infrav1.SubnetControlPlane,
+					},
+				}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cp-routetable").Return(network.RouteTable{Name: to.StringPtr("my-cp-routetable")}, nil)
+			},
+		},
+		{
+			name:          "routes of the cluster removed from shared route table without removing its other properties",
+			expectedError: "",
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
+					ID:   "1234",
+					Name: "my-vnet",
+				})
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				s.RouteTableSpecs().Return([]azure.RouteTableSpec{{
+					Name: "my-cp-routetable",
+					Subnet: &infrav1.SubnetSpec{
+						Name: "control-plane-subnet",
+						Role: infrav1.SubnetControlPlane,
+					},
+				}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cp-routetable").Return(network.RouteTable{
+					Name:     to.StringPtr("my-cp-routetable"),
+					Location: to.StringPtr("westus"),
+					Etag:     to.StringPtr("test-etag"),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+						DisableBgpRoutePropagation: to.BoolPtr(true),
+						Routes: &[]network.Route{
+							{Name: to.StringPtr("my-route")},
+							{Name: to.StringPtr("common-route")},
+							{Name: to.StringPtr("custom-route")},
+						},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster":   to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_test-cluster":   to.StringPtr("common-route"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_test-cluster_1": to.StringPtr("my-route"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster":  to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_other-cluster":  to.StringPtr("common-route"),
+					},
+				}, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-cp-routetable", gomockinternal.DiffEq(network.RouteTable{
+					Location: to.StringPtr("westus"),
+					Etag:     to.StringPtr("test-etag"),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+						DisableBgpRoutePropagation: to.BoolPtr(true),
+						Routes: &[]network.Route{
+							{Name: to.StringPtr("common-route")},
+							{Name: to.StringPtr("custom-route")},
+						},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_other-cluster": to.StringPtr("common-route"),
+					},
+				}))
+			},
+		},
+		{
+			name:          "route table dissociated from subnet of custom vnet before deletion",
+			expectedError: "",
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
+					ID:            "1234",
+					Name:          "my-vnet",
+					ResourceGroup: "vnet-rg",
+				})
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				s.RouteTableSpecs().Return([]azure.RouteTableSpec{{
+					Name: "my-node-routetable",
+					Subnet: &infrav1.SubnetSpec{
+						Name: "node-subnet",
+						Role: infrav1.SubnetNode,
+					},
+				}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-node-routetable").Return(network.RouteTable{
+					ID:   to.StringPtr("my-node-routetable-id"),
+					Name: to.StringPtr("my-node-routetable"),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+					},
+				}, nil)
+				sn.Get(gomockinternal.AContext(), "vnet-rg", "my-vnet", "node-subnet").Return(network.Subnet{
+					Name: to.StringPtr("node-subnet"),
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						AddressPrefix: to.StringPtr("10.0.0.0/24"),
+						RouteTable:    &network.RouteTable{ID: to.StringPtr("my-node-routetable-id")},
+					},
+				}, nil)
+				sn.CreateOrUpdate(gomockinternal.AContext(), "vnet-rg", "my-vnet", "node-subnet", gomockinternal.DiffEq(network.Subnet{
+					Name: to.StringPtr("node-subnet"),
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						AddressPrefix: to.StringPtr("10.0.0.0/24"),
+					},
+				}))
+				m.Delete(gomockinternal.AContext(), "my-rg", "my-node-routetable")
 			},
 		},
 		{
@@ -271,12 +572,12 @@ func TestDeleteRouteTable(t *testing.T) {
 				"sigs.k8s.io_cluster-api-provider-azure_role":                 "common",
 			},
 			expectedError: "",
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder) {
-				s.Vnet().Return(&infrav1.VnetSpec{
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
 					Name: "my-vnet",
 				})
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				s.ClusterName()
+				s.ClusterName().AnyTimes().Return("test-cluster")
 				s.RouteTableSpecs().Return([]azure.RouteTableSpec{
 					{
 						Name: "my-cp-routetable",
@@ -294,10 +595,12 @@ func TestDeleteRouteTable(t *testing.T) {
 					},
 				})
 				s.ControlPlaneRouteTable().AnyTimes().Return(&infrav1.RouteTable{Name: "my-cp-routetable"})
-				s.ResourceGroup().Return("my-rg")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cp-routetable").Return(network.RouteTable{Name: to.StringPtr("my-cp-routetable")}, nil)
 				m.Delete(gomockinternal.AContext(), "my-rg", "my-cp-routetable")
 				s.NodeRouteTable().AnyTimes().Return(&infrav1.RouteTable{Name: "my-node-routetable"})
-				s.ResourceGroup().Return("my-rg")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-node-routetable").Return(network.RouteTable{Name: to.StringPtr("my-node-routetable")}, nil)
 				m.Delete(gomockinternal.AContext(), "my-rg", "my-node-routetable")
 			},
 		},
@@ -309,12 +612,12 @@ func TestDeleteRouteTable(t *testing.T) {
 				"sigs.k8s.io_cluster-api-provider-azure_role":                 "common",
 			},
 			expectedError: "",
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder) {
-				s.Vnet().Return(&infrav1.VnetSpec{
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
 					Name: "my-vnet",
 				})
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				s.ClusterName()
+				s.ClusterName().AnyTimes().Return("test-cluster")
 				s.RouteTableSpecs().Return([]azure.RouteTableSpec{
 					{
 						Name: "my-cp-routetable",
@@ -332,10 +635,12 @@ func TestDeleteRouteTable(t *testing.T) {
 					},
 				})
 				s.ControlPlaneRouteTable().AnyTimes().Return(&infrav1.RouteTable{Name: "my-cp-routetable"})
-				s.ResourceGroup().Return("my-rg")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cp-routetable").Return(network.RouteTable{Name: to.StringPtr("my-cp-routetable")}, nil)
 				m.Delete(gomockinternal.AContext(), "my-rg", "my-cp-routetable").Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"))
 				s.NodeRouteTable().AnyTimes().Return(&infrav1.RouteTable{Name: "my-node-routetable"})
-				s.ResourceGroup().Return("my-rg")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-node-routetable").Return(network.RouteTable{Name: to.StringPtr("my-node-routetable")}, nil)
 				m.Delete(gomockinternal.AContext(), "my-rg", "my-node-routetable").Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"))
 			},
 		},
@@ -347,12 +652,12 @@ func TestDeleteRouteTable(t *testing.T) {
 				"sigs.k8s.io_cluster-api-provider-azure_role":                 "common",
 			},
 			expectedError: "failed to delete route table my-cp-routetable in resource group my-rg: #: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder) {
-				s.Vnet().Return(&infrav1.VnetSpec{
+			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, m *mock_routetables.MockclientMockRecorder, sn *mock_subnets.MockClientMockRecorder) {
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{
 					Name: "my-vnet",
 				})
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				s.ClusterName()
+				s.ClusterName().AnyTimes().Return("test-cluster")
 				s.RouteTableSpecs().Return([]azure.RouteTableSpec{{
 					Name: "my-cp-routetable",
 					Subnet: &infrav1.SubnetSpec{
@@ -362,6 +667,7 @@ func TestDeleteRouteTable(t *testing.T) {
 				}})
 				s.ControlPlaneRouteTable().AnyTimes().Return(&infrav1.RouteTable{Name: "my-cp-routetable"})
 				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cp-routetable").Return(network.RouteTable{Name: to.StringPtr("my-cp-routetable")}, nil)
 				m.Delete(gomockinternal.AContext(), "my-rg", "my-cp-routetable").Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
 				s.NodeRouteTable().Times(0)
 			},
@@ -377,12 +683,14 @@ func TestDeleteRouteTable(t *testing.T) {
 			defer mockCtrl.Finish()
 			scopeMock := mock_routetables.NewMockRouteTableScope(mockCtrl)
 			clientMock := mock_routetables.NewMockclient(mockCtrl)
+			subnetsMock := mock_subnets.NewMockClient(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), subnetsMock.EXPECT())
 
 			s := &Service{
-				Scope:  scopeMock,
-				client: clientMock,
+				Scope:         scopeMock,
+				client:        clientMock,
+				subnetsClient: subnetsMock,
 			}
 
 			err := s.Delete(context.TODO())
@@ -24,7 +24,9 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-11-01/network"
 	"github.com/Azure/go-autorest/autorest"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -33,10 +35,11 @@ type Client interface {
 	List(context.Context, string) ([]compute.VirtualMachineScaleSet, error)
 	ListInstances(context.Context, string, string) ([]compute.VirtualMachineScaleSetVM, error)
 	Get(context.Context, string, string) (compute.VirtualMachineScaleSet, error)
-	CreateOrUpdate(context.Context, string, string, compute.VirtualMachineScaleSet) error
-	Update(context.Context, string, string, compute.VirtualMachineScaleSetUpdate) error
-	UpdateInstances(context.Context, string, string, []string) error
-	Delete(context.Context, string, string) error
+	CreateOrUpdateAsync(context.Context, string, string, compute.VirtualMachineScaleSet) (*infrav1.Future, error)
+	UpdateAsync(context.Context, string, string, compute.VirtualMachineScaleSetUpdate) (*infrav1.Future, error)
+	UpdateInstancesAsync(context.Context, string, string, []string) (*infrav1.Future, error)
+	DeleteAsync(context.Context, string, string) (*infrav1.Future, error)
+	IsDone(context.Context, *infrav1.Future) (bool, error)
 	GetPublicIPAddress(context.Context, string, string) (network.PublicIPAddress, error)
 }
 
@@ -61,24 +64,21 @@ func NewClient(auth azure.Authorizer) *AzureClient {
 // newVirtualMachineScaleSetVMsClient creates a new vmss VM client from subscription ID.
 func newVirtualMachineScaleSetVMsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachineScaleSetVMsClient {
 	c := compute.NewVirtualMachineScaleSetVMsClientWithBaseURI(baseURI, subscriptionID)
-	c.Authorizer = authorizer
-	_ = c.AddToUserAgent(azure.UserAgent()) // intentionally ignore error as it doesn't matter
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
 	return c
 }
 
 // newVirtualMachineScaleSetsClient creates a new vmss client from subscription ID.
 func newVirtualMachineScaleSetsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachineScaleSetsClient {
 	c := compute.NewVirtualMachineScaleSetsClientWithBaseURI(baseURI, subscriptionID)
-	c.Authorizer = authorizer
-	_ = c.AddToUserAgent(azure.UserAgent()) // intentionally ignore error as it doesn't matter
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
 	return c
 }
 
 // newPublicIPsClient creates a new publicIPs client from subscription ID.
 func newPublicIPsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.PublicIPAddressesClient {
 	c := network.NewPublicIPAddressesClientWithBaseURI(baseURI, subscriptionID)
-	c.Authorizer = authorizer
-	_ = c.AddToUserAgent(azure.UserAgent()) // intentionally ignore error as it doesn't matter
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
 	return c
 }
 
@@ -128,44 +128,35 @@ func (ac *AzureClient) Get(ctx context.Context, resourceGroupName, vmssName stri
 	return ac.scalesets.Get(ctx, resourceGroupName, vmssName)
 }
 
-// CreateOrUpdate the operation to create or update a virtual machine scale set.
-func (ac *AzureClient) CreateOrUpdate(ctx context.Context, resourceGroupName, vmssName string, vmss compute.VirtualMachineScaleSet) error {
-	ctx, span := tele.Tracer().Start(ctx, "scalesets.AzureClient.CreateOrUpdate")
+// CreateOrUpdateAsync starts the operation to create or update a virtual machine scale set and returns its future
+// without waiting for it to complete.
+func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, resourceGroupName, vmssName string, vmss compute.VirtualMachineScaleSet) (*infrav1.Future, error) {
+	ctx, span := tele.Tracer().Start(ctx, "scalesets.AzureClient.CreateOrUpdateAsync")
 	defer span.End()
 
 	future, err := ac.scalesets.CreateOrUpdate(ctx, resourceGroupName, vmssName, vmss)
 	if err != nil {
-		return err
-	}
-	err = future.WaitForCompletionRef(ctx, ac.scalesets.Client)
-	if err != nil {
-		return err
+		return nil, err
 	}
-	_, err = future.Result(ac.scalesets)
-	return err
+	return converters.SDKToFuture(future.Future, infrav1.PutFuture, resourceGroupName, vmssName)
 }
 
-// Update update a VM scale set.
-// Parameters: resourceGroupName - the name of the resource group. VMScaleSetName - the name of the VM scale set to create or update. parameters - the scale set object.
-func (ac *AzureClient) Update(ctx context.Context, resourceGroupName, vmssName string, parameters compute.VirtualMachineScaleSetUpdate) error {
-	ctx, span := tele.Tracer().Start(ctx, "scalesets.AzureClient.Update")
+// UpdateAsync starts the operation to update a VM scale set and returns its future without waiting for it to complete.
+func (ac *AzureClient) UpdateAsync(ctx context.Context, resourceGroupName, vmssName string, parameters compute.VirtualMachineScaleSetUpdate) (*infrav1.Future, error) {
+	ctx, span := tele.Tracer().Start(ctx, "scalesets.AzureClient.UpdateAsync")
 	defer span.End()
 
 	future, err := ac.scalesets.Update(ctx, resourceGroupName, vmssName, parameters)
 	if err != nil {
-		return err
-	}
-	err = future.WaitForCompletionRef(ctx, ac.scalesets.Client)
-	if err != nil {
-		return err
+		return nil, err
 	}
-	_, err = future.Result(ac.scalesets)
-	return err
+	return converters.SDKToFuture(future.Future, infrav1.PatchFuture, resourceGroupName, vmssName)
 }
 
-// UpdateInstances update instances of a VM scale set.
-func (ac *AzureClient) UpdateInstances(ctx context.Context, resourceGroupName, vmssName string, instanceIDs []string) error {
-	ctx, span := tele.Tracer().Start(ctx, "scalesets.AzureClient.UpdateInstances")
+// UpdateInstancesAsync starts the operation to update instances of a VM scale set to the latest model and returns
+// its future without waiting for it to complete.
+func (ac *AzureClient) UpdateInstancesAsync(ctx context.Context, resourceGroupName, vmssName string, instanceIDs []string) (*infrav1.Future, error) {
+	ctx, span := tele.Tracer().Start(ctx, "scalesets.AzureClient.UpdateInstancesAsync")
 	defer span.End()
 
 	params := compute.VirtualMachineScaleSetVMInstanceRequiredIDs{
@@ -173,31 +164,35 @@ func (ac *AzureClient) UpdateInstances(ctx context.Context, resourceGroupName, v
 	}
 	future, err := ac.scalesets.UpdateInstances(ctx, resourceGroupName, vmssName, params)
 	if err != nil {
-		return err
-	}
-	err = future.WaitForCompletionRef(ctx, ac.scalesets.Client)
-	if err != nil {
-		return err
+		return nil, err
 	}
-	_, err = future.Result(ac.scalesets)
-	return err
+	return converters.SDKToFuture(future.Future, infrav1.PostFuture, resourceGroupName, vmssName)
 }
 
-// Delete the operation to delete a virtual machine scale set.
-func (ac *AzureClient) Delete(ctx context.Context, resourceGroupName, vmssName string) error {
-	ctx, span := tele.Tracer().Start(ctx, "scalesets.AzureClient.Delete")
+// DeleteAsync starts the operation to delete a virtual machine scale set and returns its future
+// without waiting for it to complete.
+func (ac *AzureClient) DeleteAsync(ctx context.Context, resourceGroupName, vmssName string) (*infrav1.Future, error) {
+	ctx, span := tele.Tracer().Start(ctx, "scalesets.AzureClient.DeleteAsync")
 	defer span.End()
 
 	future, err := ac.scalesets.Delete(ctx, resourceGroupName, vmssName)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	err = future.WaitForCompletionRef(ctx, ac.scalesets.Client)
+	return converters.SDKToFuture(future.Future, infrav1.DeleteFuture, resourceGroupName, vmssName)
+}
+
+// IsDone polls a long-running operation once and returns true when it has completed.
+// It returns the error of the operation if it has failed.
+func (ac *AzureClient) IsDone(ctx context.Context, future *infrav1.Future) (bool, error) {
+	ctx, span := tele.Tracer().Start(ctx, "scalesets.AzureClient.IsDone")
+	defer span.End()
+
+	sdkFuture, err := converters.FutureToSDK(*future)
 	if err != nil {
-		return err
+		return false, err
 	}
-	_, err = future.Result(ac.scalesets)
-	return err
+	return sdkFuture.DoneWithContext(ctx, ac.scalesets)
 }
 
 // GetPublicIPAddress gets the public IP address for the given public IP name.
@@ -47,6 +47,8 @@ type ScaleSetScope interface {
 	NeedsK8sVersionUpdate() bool
 	SaveK8sVersion()
 	SetProvisioningState(infrav1.VMState)
+	GetLongRunningOperationState() *infrav1.Future
+	SetLongRunningOperationState(*infrav1.Future)
 }
 
 // Service provides operations on azure resources
@@ -86,6 +88,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	defer span.End()
 
 	vmssSpec := s.Scope.ScaleSetSpec()
+	if future := s.Scope.GetLongRunningOperationState(); future != nil {
+		if err := s.checkOperation(ctx, future); err != nil {
+			return err
+		}
+	}
 
 	sku, err := s.resourceSKUCache.Get(ctx, vmssSpec.Size, resourceskus.VirtualMachines)
 	if err != nil {
@@ -126,6 +133,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return err
 	}
 
+	additionalCapabilities, err := getAdditionalCapabilities(vmssSpec, sku)
+	if err != nil {
+		return err
+	}
+
 	priority, evictionPolicy, billingProfile, err := converters.GetSpotVMOptions(vmssSpec.SpotVMOptions)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get Spot VM options")
@@ -141,6 +153,13 @@ func (s *Service) Reconcile(ctx context.Context) error {
 				})
 		}
 	}
+	// Join the backend pools of the cluster's additional load balancers
+	for _, pool := range vmssSpec.AdditionalLBAddressPools {
+		backendAddressPools = append(backendAddressPools,
+			compute.SubResource{
+				ID: to.StringPtr(azure.AddressPoolID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), pool.LoadBalancerName, pool.PoolName)),
+			})
+	}
 
 	sshKey, err := base64.StdEncoding.DecodeString(vmssSpec.SSHKeyData)
 	if err != nil {
@@ -169,6 +188,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			UpgradePolicy: &compute.UpgradePolicy{
 				Mode: compute.UpgradeModeManual,
 			},
+			AdditionalCapabilities: additionalCapabilities,
 			VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
 				OsProfile: &compute.VirtualMachineScaleSetOSProfile{
 					ComputerNamePrefix: to.StringPtr(vmssSpec.Name),
@@ -242,6 +262,12 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 	}
 
+	if vmssSpec.ProximityPlacementGroupName != "" {
+		vmss.ProximityPlacementGroup = &compute.SubResource{
+			ID: to.StringPtr(azure.ProximityPlacementGroupID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), vmssSpec.ProximityPlacementGroupName)),
+		}
+	}
+
 	// Assign Identity to VMSS
 	if vmssSpec.Identity == infrav1.VMIdentitySystemAssigned {
 		vmss.Identity = &compute.VirtualMachineScaleSetIdentity{
@@ -273,12 +299,19 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			return errors.Wrapf(err, "failed to generate scale set update parameters for %s", vmssSpec.Name)
 		}
 		update.VirtualMachineProfile.NetworkProfile = nil
-		if err := s.Client.Update(ctx, s.Scope.ResourceGroup(), vmssSpec.Name, update); err != nil {
+		future, err := s.Client.UpdateAsync(ctx, s.Scope.ResourceGroup(), vmssSpec.Name, update)
+		if err != nil {
 			return errors.Wrapf(err, "cannot update VMSS")
 		}
+
+		// like the creation, the update is resumed on the next reconcile instead of blocking the worker.
+		s.Scope.SetLongRunningOperationState(future)
+		if err := s.checkOperation(ctx, future); err != nil {
+			return err
+		}
 	default:
 		s.Scope.V(2).Info("creating VMSS", "scale set", vmssSpec.Name)
-		err = s.Client.CreateOrUpdate(
+		future, err := s.Client.CreateOrUpdateAsync(
 			ctx,
 			s.Scope.ResourceGroup(),
 			vmssSpec.Name,
@@ -286,8 +319,14 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		if err != nil {
 			return errors.Wrapf(err, "cannot create VMSS")
 		}
-		s.Scope.V(2).Info("successfully created VMSS", "scale set", vmssSpec.Name)
 		s.Scope.SaveK8sVersion()
+
+		// the creation is resumed on the next reconcile instead of blocking the worker until it completes.
+		s.Scope.SetLongRunningOperationState(future)
+		if err := s.checkOperation(ctx, future); err != nil {
+			return err
+		}
+		s.Scope.V(2).Info("successfully created VMSS", "scale set", vmssSpec.Name)
 	}
 
 	// get the VMSS to update status
@@ -303,11 +342,17 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		for i, vm := range existingVMSS.Instances {
 			instanceIDs[i] = vm.InstanceID
 		}
-		if err := s.Client.UpdateInstances(ctx, s.Scope.ResourceGroup(), vmssSpec.Name, instanceIDs); err != nil {
+		future, err := s.Client.UpdateInstancesAsync(ctx, s.Scope.ResourceGroup(), vmssSpec.Name, instanceIDs)
+		if err != nil {
 			return errors.Wrapf(err, "failed to update VMSS %s instances", vmssSpec.Name)
 		}
 		s.Scope.SaveK8sVersion()
 
+		s.Scope.SetLongRunningOperationState(future)
+		if err := s.checkOperation(ctx, future); err != nil {
+			return err
+		}
+
 		// get the VMSS to update status
 		existingVMSS, err = s.getExisting(ctx, vmssSpec.Name)
 		if err != nil {
@@ -331,20 +376,45 @@ func (s *Service) Delete(ctx context.Context) error {
 	defer span.End()
 
 	vmssSpec := s.Scope.ScaleSetSpec()
-	s.Scope.V(2).Info("deleting VMSS", "scale set", vmssSpec.Name)
-	err := s.Client.Delete(ctx, s.Scope.ResourceGroup(), vmssSpec.Name)
-	if err != nil {
-		if azure.ResourceNotFound(err) {
-			// already deleted
-			return nil
+	future := s.Scope.GetLongRunningOperationState()
+	if future == nil || future.Type != infrav1.DeleteFuture {
+		s.Scope.V(2).Info("deleting VMSS", "scale set", vmssSpec.Name)
+		var err error
+		future, err = s.Client.DeleteAsync(ctx, s.Scope.ResourceGroup(), vmssSpec.Name)
+		if err != nil {
+			if azure.ResourceNotFound(err) {
+				// already deleted
+				s.Scope.SetLongRunningOperationState(nil)
+				return nil
+			}
+			return errors.Wrapf(err, "failed to delete VMSS %s in resource group %s", vmssSpec.Name, s.Scope.ResourceGroup())
 		}
-		return errors.Wrapf(err, "failed to delete VMSS %s in resource group %s", vmssSpec.Name, s.Scope.ResourceGroup())
+		s.Scope.SetLongRunningOperationState(future)
+	}
+
+	if err := s.checkOperation(ctx, future); err != nil {
+		return err
 	}
 
 	s.Scope.V(2).Info("successfully deleted VMSS", "scale set", vmssSpec.Name)
 	return nil
 }
 
+// checkOperation polls the in-progress long-running operation of the scale set. It returns an OperationNotDoneError
+// while the operation is running, and clears the operation state once it has completed or failed.
+func (s *Service) checkOperation(ctx context.Context, future *infrav1.Future) error {
+	done, err := s.Client.IsDone(ctx, future)
+	if err != nil {
+		s.Scope.SetLongRunningOperationState(nil)
+		return errors.Wrapf(err, "failed operation type %s on VMSS %s in resource group %s", future.Type, future.Name, future.ResourceGroup)
+	}
+	if !done {
+		return azure.NewOperationNotDoneError(future)
+	}
+	s.Scope.SetLongRunningOperationState(nil)
+	return nil
+}
+
 // generateStorageProfile generates a pointer to a compute.VirtualMachineScaleSetStorageProfile which can utilized for VM creation.
 func (s *Service) generateStorageProfile(vmssSpec azure.ScaleSetSpec, sku resourceskus.SKU) (*compute.VirtualMachineScaleSetStorageProfile, error) {
 	storageProfile := &compute.VirtualMachineScaleSetStorageProfile{
@@ -375,12 +445,24 @@ func (s *Service) generateStorageProfile(vmssSpec azure.ScaleSetSpec, sku resour
 
 	dataDisks := []compute.VirtualMachineScaleSetDataDisk{}
 	for _, disk := range vmssSpec.DataDisks {
-		dataDisks = append(dataDisks, compute.VirtualMachineScaleSetDataDisk{
-			CreateOption: compute.DiskCreateOptionTypesEmpty,
-			DiskSizeGB:   to.Int32Ptr(disk.DiskSizeGB),
-			Lun:          disk.Lun,
-			Name:         to.StringPtr(azure.GenerateDataDiskName(vmssSpec.Name, disk.NameSuffix)),
-		})
+		dataDisk := compute.VirtualMachineScaleSetDataDisk{
+			CreateOption:            compute.DiskCreateOptionTypesEmpty,
+			DiskSizeGB:              to.Int32Ptr(disk.DiskSizeGB),
+			Lun:                     disk.Lun,
+			Name:                    to.StringPtr(azure.GenerateDataDiskName(vmssSpec.Name, disk.NameSuffix)),
+			WriteAcceleratorEnabled: disk.WriteAcceleratorEnabled,
+			DiskIOPSReadWrite:       disk.DiskIOPSReadWrite,
+			DiskMBpsReadWrite:       disk.DiskMBpsReadWrite,
+		}
+		if disk.ManagedDisk != nil {
+			dataDisk.ManagedDisk = &compute.VirtualMachineScaleSetManagedDiskParameters{
+				StorageAccountType: compute.StorageAccountTypes(disk.ManagedDisk.StorageAccountType),
+			}
+			if disk.ManagedDisk.DiskEncryptionSet != nil {
+				dataDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(disk.ManagedDisk.DiskEncryptionSet.ID)}
+			}
+		}
+		dataDisks = append(dataDisks, dataDisk)
 	}
 	storageProfile.DataDisks = &dataDisks
 
@@ -409,6 +491,16 @@ func getVMSSUpdateFromVMSS(vmss compute.VirtualMachineScaleSet) (compute.Virtual
 	return update, err
 }
 
+// getAdditionalCapabilities enables ultra disks on scale sets with ultra data disks. Scale sets are not zonal, so the
+// VM size must support ultra disks in the whole location.
+func getAdditionalCapabilities(vmssSpec azure.ScaleSetSpec, sku resourceskus.SKU) (*compute.AdditionalCapabilities, error) {
+	additionalCapabilities := converters.GetAdditionalCapabilities(vmssSpec.DataDisks)
+	if additionalCapabilities != nil && !sku.HasCapability(resourceskus.UltraSSDAvailable) {
+		return nil, azure.WithTerminalError(errors.Errorf("ultra disks are not supported for VM type %s outside of availability zones", vmssSpec.Size))
+	}
+	return additionalCapabilities, nil
+}
+
 func getSecurityProfile(vmssSpec azure.ScaleSetSpec, sku resourceskus.SKU) (*compute.SecurityProfile, error) {
 	if vmssSpec.SecurityProfile == nil {
 		return nil, nil
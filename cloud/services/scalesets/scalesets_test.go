@@ -60,7 +60,7 @@ func TestNewService(t *testing.T) {
 		Cluster: cluster,
 		AzureCluster: &infrav1.AzureCluster{
 			Spec: infrav1.AzureClusterSpec{
-				Location: "test-location",
+				Location:       "test-location",
 				ResourceGroup:  "my-rg",
 				SubscriptionID: "123",
 				NetworkSpec: infrav1.NetworkSpec{
@@ -279,7 +279,7 @@ func TestReconcileVMSS(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vmss", gomockinternal.DiffEq(compute.VirtualMachineScaleSet{
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vmss", gomockinternal.DiffEq(compute.VirtualMachineScaleSet{
 					Location: to.StringPtr("test-location"),
 					Tags: map[string]*string{
 						"Name": to.StringPtr("my-vmss"),
@@ -448,7 +448,7 @@ func TestReconcileVMSS(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vmss", gomockinternal.DiffEq(compute.VirtualMachineScaleSet{
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vmss", gomockinternal.DiffEq(compute.VirtualMachineScaleSet{
 					Location: to.StringPtr("test-location"),
 					Tags: map[string]*string{
 						"Name": to.StringPtr("my-vmss"),
@@ -612,7 +612,7 @@ func TestReconcileVMSS(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vmss", gomockinternal.DiffEq(compute.VirtualMachineScaleSet{
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vmss", gomockinternal.DiffEq(compute.VirtualMachineScaleSet{
 					Location: to.StringPtr("test-location"),
 					Tags: map[string]*string{
 						"Name": to.StringPtr("my-vmss"),
@@ -758,7 +758,7 @@ func TestReconcileVMSS(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vmss", gomockinternal.DiffEq(compute.VirtualMachineScaleSet{
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vmss", gomockinternal.DiffEq(compute.VirtualMachineScaleSet{
 					Location: to.StringPtr("test-location"),
 					Tags: map[string]*string{
 						"Name": to.StringPtr("my-vmss"),
@@ -961,7 +961,7 @@ func TestReconcileVMSS(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vmss", gomockinternal.DiffEq(compute.VirtualMachineScaleSet{
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vmss", gomockinternal.DiffEq(compute.VirtualMachineScaleSet{
 					Location: to.StringPtr("test-location"),
 					Tags: map[string]*string{
 						"Name": to.StringPtr("my-vmss"),
@@ -1107,7 +1107,7 @@ func TestReconcileVMSS(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vmss", gomock.AssignableToTypeOf(compute.VirtualMachineScaleSet{})).Do(
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vmss", gomock.AssignableToTypeOf(compute.VirtualMachineScaleSet{})).Do(
 					func(_, _, _ interface{}, vmss compute.VirtualMachineScaleSet) {
 						encryptionAtHost := *vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.SecurityProfile.EncryptionAtHost
 						g.Expect(encryptionAtHost).To(Equal(true))
@@ -1138,6 +1138,80 @@ func TestReconcileVMSS(t *testing.T) {
 				s.SetProvisioningState(infrav1.VMStateSucceeded)
 			},
 		},
+		{
+			name:          "can create a vmss in a proximity placement group",
+			expectedError: "",
+			expect: func(g *gomega.WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
+					Name:       "my-vmss",
+					Size:       "VM_SIZE",
+					Capacity:   2,
+					SSHKeyData: "ZmFrZXNzaGtleQo=",
+					OSDisk: infrav1.OSDisk{
+						OSType:     "Linux",
+						DiskSizeGB: 120,
+						ManagedDisk: infrav1.ManagedDisk{
+							StorageAccountType: "Premium_LRS",
+						},
+					},
+					SubnetName:                   "my-subnet",
+					VNetName:                     "my-vnet",
+					VNetResourceGroup:            "my-rg",
+					PublicLBName:                 "capz-lb",
+					PublicLBAddressPoolName:      "backendPool",
+					AcceleratedNetworking:        nil,
+					TerminateNotificationTimeout: to.IntPtr(7),
+					ProximityPlacementGroupName:  "my-pool-ppg",
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AdditionalTags()
+				s.Location().Return("test-location")
+				s.ClusterName().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vmss").
+					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				s.GetVMImage().Return(&infrav1.Image{
+					Marketplace: &infrav1.AzureMarketplaceImage{
+						Publisher: "fake-publisher",
+						Offer:     "my-offer",
+						SKU:       "sku-id",
+						Version:   "1.0",
+					},
+				}, nil)
+				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vmss", gomock.AssignableToTypeOf(compute.VirtualMachineScaleSet{})).Do(
+					func(_, _, _ interface{}, vmss compute.VirtualMachineScaleSet) {
+						g.Expect(vmss.VirtualMachineScaleSetProperties.ProximityPlacementGroup).To(Equal(&compute.SubResource{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-pool-ppg"),
+						}))
+					})
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vmss").
+					Return(compute.VirtualMachineScaleSet{
+						ID:   to.StringPtr("vmss-id"),
+						Name: to.StringPtr("my-vmss"),
+						VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+							ProvisioningState: to.StringPtr("Succeeded"),
+						},
+					}, nil)
+				m.ListInstances(gomockinternal.AContext(), "my-rg", "my-vmss").Return([]compute.VirtualMachineScaleSetVM{
+					{
+						InstanceID: to.StringPtr("id-2"),
+						VirtualMachineScaleSetVMProperties: &compute.VirtualMachineScaleSetVMProperties{
+							ProvisioningState: to.StringPtr("Succeeded"),
+						},
+						ID:   to.StringPtr("id-1"),
+						Name: to.StringPtr("instance-0"),
+					},
+				}, nil)
+				s.SaveK8sVersion()
+				s.NeedsK8sVersionUpdate()
+				s.UpdateInstanceStatuses(gomock.Any(), gomock.Len(1)).Return(nil)
+				s.SetProviderID("azure://vmss-id")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				s.SetProvisioningState(infrav1.VMStateSucceeded)
+			},
+		},
 		{
 			name:          "creating a vmss with encryption at host enabled for unsupported VM type fails",
 			expectedError: "reconcile error occurred that cannot be recovered. Object will not be requeued. The actual error is: encryption at host is not supported for VM type VM_SIZE",
@@ -1159,6 +1233,122 @@ func TestReconcileVMSS(t *testing.T) {
 				}, nil)
 			},
 		},
+		{
+			name:          "can create a vmss with ultra disks",
+			expectedError: "",
+			expect: func(g *gomega.WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
+					Name:       "my-vmss",
+					Size:       "VM_SIZE_ULTRA",
+					Capacity:   2,
+					SSHKeyData: "ZmFrZXNzaGtleQo=",
+					OSDisk: infrav1.OSDisk{
+						OSType:     "Linux",
+						DiskSizeGB: 120,
+						ManagedDisk: infrav1.ManagedDisk{
+							StorageAccountType: "Premium_LRS",
+						},
+					},
+					SubnetName:                   "my-subnet",
+					VNetName:                     "my-vnet",
+					VNetResourceGroup:            "my-rg",
+					PublicLBName:                 "capz-lb",
+					PublicLBAddressPoolName:      "backendPool",
+					AcceleratedNetworking:        nil,
+					TerminateNotificationTimeout: to.IntPtr(7),
+					DataDisks: []infrav1.DataDisk{
+						{
+							NameSuffix:        "my_disk",
+							DiskSizeGB:        128,
+							Lun:               to.Int32Ptr(0),
+							DiskIOPSReadWrite: to.Int64Ptr(4000),
+							DiskMBpsReadWrite: to.Int64Ptr(200),
+							ManagedDisk: &infrav1.ManagedDisk{
+								StorageAccountType: "UltraSSD_LRS",
+							},
+						},
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AdditionalTags()
+				s.Location().Return("test-location")
+				s.ClusterName().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vmss").
+					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				s.GetVMImage().Return(&infrav1.Image{
+					Marketplace: &infrav1.AzureMarketplaceImage{
+						Publisher: "fake-publisher",
+						Offer:     "my-offer",
+						SKU:       "sku-id",
+						Version:   "1.0",
+					},
+				}, nil)
+				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vmss", gomock.AssignableToTypeOf(compute.VirtualMachineScaleSet{})).Do(
+					func(_, _, _ interface{}, vmss compute.VirtualMachineScaleSet) {
+						g.Expect(*vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities.UltraSSDEnabled).To(Equal(true))
+						dataDisk := (*vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.DataDisks)[0]
+						g.Expect(dataDisk.DiskIOPSReadWrite).To(Equal(to.Int64Ptr(4000)))
+						g.Expect(dataDisk.DiskMBpsReadWrite).To(Equal(to.Int64Ptr(200)))
+					})
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vmss").
+					Return(compute.VirtualMachineScaleSet{
+						ID:   to.StringPtr("vmss-id"),
+						Name: to.StringPtr("my-vmss"),
+						VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+							ProvisioningState: to.StringPtr("Succeeded"),
+						},
+					}, nil)
+				m.ListInstances(gomockinternal.AContext(), "my-rg", "my-vmss").Return([]compute.VirtualMachineScaleSetVM{
+					{
+						InstanceID: to.StringPtr("id-2"),
+						VirtualMachineScaleSetVMProperties: &compute.VirtualMachineScaleSetVMProperties{
+							ProvisioningState: to.StringPtr("Succeeded"),
+						},
+						ID:   to.StringPtr("id-1"),
+						Name: to.StringPtr("instance-0"),
+					},
+				}, nil)
+				s.SaveK8sVersion()
+				s.NeedsK8sVersionUpdate()
+				s.UpdateInstanceStatuses(gomock.Any(), gomock.Len(1)).Return(nil)
+				s.SetProviderID("azure://vmss-id")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				s.SetProvisioningState(infrav1.VMStateSucceeded)
+			},
+		},
+		{
+			name:          "creating a vmss with ultra disks for unsupported VM type fails",
+			expectedError: "reconcile error occurred that cannot be recovered. Object will not be requeued. The actual error is: ultra disks are not supported for VM type VM_SIZE outside of availability zones",
+			expect: func(g *gomega.WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
+					Name:       "my-vmss",
+					Size:       "VM_SIZE",
+					Capacity:   2,
+					SSHKeyData: "ZmFrZXNzaGtleQo=",
+					DataDisks: []infrav1.DataDisk{
+						{
+							NameSuffix: "my_disk",
+							DiskSizeGB: 128,
+							Lun:        to.Int32Ptr(0),
+							ManagedDisk: &infrav1.ManagedDisk{
+								StorageAccountType: "UltraSSD_LRS",
+							},
+						},
+					},
+				})
+				s.GetVMImage().Return(&infrav1.Image{
+					Marketplace: &infrav1.AzureMarketplaceImage{
+						Publisher: "fake-publisher",
+						Offer:     "my-offer",
+						SKU:       "sku-id",
+						Version:   "1.0",
+					},
+				}, nil)
+			},
+		},
 		{
 			name:          "scale set already exists",
 			expectedError: "",
@@ -1308,7 +1498,7 @@ func TestReconcileVMSS(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.Update(gomockinternal.AContext(), "my-rg", "my-vmss", gomockinternal.DiffEq(compute.VirtualMachineScaleSetUpdate{
+				m.UpdateAsync(gomockinternal.AContext(), "my-rg", "my-vmss", gomockinternal.DiffEq(compute.VirtualMachineScaleSetUpdate{
 					Tags: map[string]*string{
 						"Name": to.StringPtr("my-vmss"),
 						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
@@ -1367,7 +1557,7 @@ func TestReconcileVMSS(t *testing.T) {
 							},
 						},
 					},
-				})).Return(nil)
+				})).Return(&infrav1.Future{Type: infrav1.PatchFuture, ResourceGroup: "my-rg", Name: "my-vmss", FutureData: "ZmFrZWZ1dHVyZQ=="}, nil)
 				m.Get(gomockinternal.AContext(), "my-rg", "my-vmss").
 					Return(compute.VirtualMachineScaleSet{
 						ID:   to.StringPtr("vmss-id"),
@@ -1476,8 +1666,22 @@ func TestReconcileVMSS(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vmss", gomock.AssignableToTypeOf(compute.VirtualMachineScaleSet{})).
-					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal error"))
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vmss", gomock.AssignableToTypeOf(compute.VirtualMachineScaleSet{})).
+					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal error"))
+			},
+		},
+		{
+			name:          "returns an operation not done error while the vmss creation is in progress",
+			expectedError: "operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *gomega.WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				future := &infrav1.Future{Type: infrav1.PutFuture, ResourceGroup: "my-rg", Name: "my-vmss", FutureData: "ZmFrZWZ1dHVyZQ=="}
+				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
+					Name:     "my-vmss",
+					Size:     "VM_SIZE",
+					Capacity: 3,
+				})
+				s.GetLongRunningOperationState().Return(future)
+				m.IsDone(gomockinternal.AContext(), future).Return(false, nil)
 			},
 		},
 	}
@@ -1494,6 +1698,11 @@ func TestReconcileVMSS(t *testing.T) {
 			clientMock := mock_scalesets.NewMockClient(mockCtrl)
 
 			tc.expect(g, scopeMock.EXPECT(), clientMock.EXPECT())
+			// no long-running operation is in progress unless the test case says otherwise, and any started
+			// operation completes on its first poll.
+			scopeMock.EXPECT().GetLongRunningOperationState().AnyTimes().Return(nil)
+			scopeMock.EXPECT().SetLongRunningOperationState(gomock.Any()).AnyTimes()
+			clientMock.EXPECT().IsDone(gomockinternal.AContext(), gomock.Any()).AnyTimes().Return(true, nil)
 
 			s := &Service{
 				Scope:            scopeMock,
@@ -1529,7 +1738,7 @@ func TestDeleteVMSS(t *testing.T) {
 				})
 				s.ResourceGroup().AnyTimes().Return("my-existing-rg")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				m.Delete(gomockinternal.AContext(), "my-existing-rg", "my-existing-vmss")
+				m.DeleteAsync(gomockinternal.AContext(), "my-existing-rg", "my-existing-vmss")
 			},
 		},
 		{
@@ -1543,8 +1752,8 @@ func TestDeleteVMSS(t *testing.T) {
 				})
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				m.Delete(gomockinternal.AContext(), "my-rg", "my-vmss").
-					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.DeleteAsync(gomockinternal.AContext(), "my-rg", "my-vmss").
+					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
 			},
 		},
 		{
@@ -1558,8 +1767,43 @@ func TestDeleteVMSS(t *testing.T) {
 				})
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				m.Delete(gomockinternal.AContext(), "my-rg", "my-vmss").
-					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+				m.DeleteAsync(gomockinternal.AContext(), "my-rg", "my-vmss").
+					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+		{
+			name:          "vmss deletion in progress",
+			expectedError: "operation type DELETE on Azure resource my-rg/my-vmss is not done",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				future := &infrav1.Future{Type: infrav1.DeleteFuture, ResourceGroup: "my-rg", Name: "my-vmss", FutureData: "ZmFrZWZ1dHVyZQ=="}
+				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
+					Name:     "my-vmss",
+					Size:     "VM_SIZE",
+					Capacity: 3,
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.GetLongRunningOperationState().Return(nil)
+				m.DeleteAsync(gomockinternal.AContext(), "my-rg", "my-vmss").Return(future, nil)
+				s.SetLongRunningOperationState(future)
+				m.IsDone(gomockinternal.AContext(), future).Return(false, nil)
+			},
+		},
+		{
+			name:          "resumes an in-progress vmss deletion",
+			expectedError: "",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				future := &infrav1.Future{Type: infrav1.DeleteFuture, ResourceGroup: "my-rg", Name: "my-vmss", FutureData: "ZmFrZWZ1dHVyZQ=="}
+				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
+					Name:     "my-vmss",
+					Size:     "VM_SIZE",
+					Capacity: 3,
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.GetLongRunningOperationState().Return(future)
+				m.IsDone(gomockinternal.AContext(), future).Return(true, nil)
+				s.SetLongRunningOperationState(nil)
 			},
 		},
 	}
@@ -1575,6 +1819,9 @@ func TestDeleteVMSS(t *testing.T) {
 			clientMock := mock_scalesets.NewMockClient(mockCtrl)
 
 			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+			scopeMock.EXPECT().GetLongRunningOperationState().AnyTimes().Return(nil)
+			scopeMock.EXPECT().SetLongRunningOperationState(gomock.Any()).AnyTimes()
+			clientMock.EXPECT().IsDone(gomockinternal.AContext(), gomock.Any()).AnyTimes().Return(true, nil)
 
 			s := &Service{
 				Scope:  scopeMock,
@@ -1729,5 +1976,32 @@ func getFakeSkus() []compute.ResourceSku {
 				},
 			},
 		},
+		{
+			Name: to.StringPtr("VM_SIZE_ULTRA"),
+			Kind: to.StringPtr(string(resourceskus.VirtualMachines)),
+			Locations: &[]string{
+				"test-location",
+			},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: to.StringPtr("test-location"),
+					Zones:    &[]string{"1"},
+				},
+			},
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{
+					Name:  to.StringPtr(resourceskus.VCPUs),
+					Value: to.StringPtr("4"),
+				},
+				{
+					Name:  to.StringPtr(resourceskus.MemoryGB),
+					Value: to.StringPtr("8"),
+				},
+				{
+					Name:  to.StringPtr(resourceskus.UltraSSDAvailable),
+					Value: to.StringPtr(string(resourceskus.CapabilitySupported)),
+				},
+			},
+		},
 	}
 }
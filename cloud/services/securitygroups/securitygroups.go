@@ -25,6 +25,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -57,14 +58,15 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "securitygroups.Service.Reconcile")
 	defer span.End()
 
-	if !s.Scope.IsVnetManaged() {
-		s.Scope.V(4).Info("Skipping network security group reconcile in custom VNet mode")
-		return nil
-	}
+	// Security groups are only created along with a custom vnet when it is shared with the cluster, e.g. to be
+	// referenced by the subnets the cluster adds to it.
+	vnetManaged := s.Scope.IsVnetManaged()
+	vnetShared := !vnetManaged && s.Scope.Vnet().Tags.HasShared(s.Scope.ClusterName())
 
 	for _, nsgSpec := range s.Scope.NSGSpecs() {
 		securityRules := make([]network.SecurityRule, 0)
 		var etag *string
+		var tags map[string]*string
 
 		existingNSG, err := s.client.Get(ctx, s.Scope.ResourceGroup(), nsgSpec.Name)
 		switch {
@@ -74,6 +76,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			// security group already exists
 			// We append the existing NSG etag to the header to ensure we only apply the updates if the NSG has not been modified.
 			etag = existingNSG.Etag
+			existingTags := converters.MapToTags(existingNSG.Tags)
+			// A security group provided along with a custom vnet may be shared with other clusters, so the rules the
+			// cluster adds to it are recorded, and only removed once no cluster uses them anymore.
+			shared := !vnetManaged && !existingTags.HasOwned(s.Scope.ClusterName())
+			entries := existingTags.GetEntries(s.Scope.ClusterName())
 			// Check if the expected rules are present
 			update := false
 			securityRules = *existingNSG.SecurityRules
@@ -81,6 +88,24 @@ func (s *Service) Reconcile(ctx context.Context) error {
 				if !ruleExists(securityRules, converters.IngresstoSecurityRule(*rule)) {
 					update = true
 					securityRules = append(securityRules, converters.IngresstoSecurityRule(*rule))
+					entries = append(entries, rule.Name)
+				} else if existingTags.HasEntryOfOtherCluster(s.Scope.ClusterName(), rule.Name) {
+					entries = append(entries, rule.Name)
+				}
+			}
+			tags = existingNSG.Tags
+			if shared {
+				newTags := infrav1.Tags{}
+				newTags.Merge(existingTags)
+				newTags.DeleteEntries(s.Scope.ClusterName())
+				newTags.Merge(infrav1.Build(infrav1.BuildParams{
+					ClusterName: s.Scope.ClusterName(),
+					Lifecycle:   infrav1.ResourceLifecycleShared,
+					Entries:     entries,
+				}))
+				if !newTags.Equals(existingTags) {
+					update = true
+					tags = converters.TagsToMap(newTags)
 				}
 			}
 			if !update {
@@ -88,8 +113,16 @@ func (s *Service) Reconcile(ctx context.Context) error {
 				s.Scope.V(2).Info("security group exists and no default rules are missing, skipping update", "security group", nsgSpec.Name)
 				continue
 			}
+		case !vnetManaged && !vnetShared:
+			s.Scope.V(4).Info("Skipping network security group reconcile in custom VNet mode", "security group", nsgSpec.Name)
+			continue
 		default:
 			s.Scope.V(2).Info("creating security group", "security group", nsgSpec.Name)
+			tags = converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+				ClusterName: s.Scope.ClusterName(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Name:        to.StringPtr(nsgSpec.Name),
+			}))
 			for _, rule := range nsgSpec.IngressRules {
 				securityRules = append(securityRules, converters.IngresstoSecurityRule(*rule))
 			}
@@ -101,6 +134,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 				SecurityRules: &securityRules,
 			},
 			Etag: etag,
+			Tags: tags,
 		}
 		err = s.client.CreateOrUpdate(ctx, s.Scope.ResourceGroup(), nsgSpec.Name, sg)
 		if err != nil {
@@ -140,9 +174,31 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "securitygroups.Service.Delete")
 	defer span.End()
 
+	vnetManaged := s.Scope.IsVnetManaged()
 	for _, nsgSpec := range s.Scope.NSGSpecs() {
+		existingNSG, err := s.client.Get(ctx, s.Scope.ResourceGroup(), nsgSpec.Name)
+		if err != nil && azure.ResourceNotFound(err) {
+			// already deleted
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to get NSG %s in %s", nsgSpec.Name, s.Scope.ResourceGroup())
+		}
+
+		tags := converters.MapToTags(existingNSG.Tags)
+		if tags.HasShared(s.Scope.ClusterName()) {
+			if err := s.unshare(ctx, existingNSG); err != nil {
+				return err
+			}
+			continue
+		}
+		if !vnetManaged && !tags.HasOwned(s.Scope.ClusterName()) {
+			s.Scope.V(4).Info("Skipping network security group deletion in custom VNet mode", "security group", nsgSpec.Name)
+			continue
+		}
+
 		s.Scope.V(2).Info("deleting security group", "security group", nsgSpec.Name)
-		err := s.client.Delete(ctx, s.Scope.ResourceGroup(), nsgSpec.Name)
+		err = s.client.Delete(ctx, s.Scope.ResourceGroup(), nsgSpec.Name)
 		if err != nil && azure.ResourceNotFound(err) {
 			// already deleted
 			continue
@@ -155,3 +211,40 @@ func (s *Service) Delete(ctx context.Context) error {
 	}
 	return nil
 }
+
+// unshare removes the rules the cluster added to a shared security group, except the ones other clusters still use,
+// along with the tags of the cluster.
+func (s *Service) unshare(ctx context.Context, nsg network.SecurityGroup) error {
+	tags := converters.MapToTags(nsg.Tags)
+	securityRules := make([]network.SecurityRule, 0)
+	if nsg.SecurityGroupPropertiesFormat != nil && nsg.SecurityRules != nil {
+		for _, rule := range *nsg.SecurityRules {
+			name := to.String(rule.Name)
+			if tags.HasEntry(s.Scope.ClusterName(), name) && !tags.HasEntryOfOtherCluster(s.Scope.ClusterName(), name) {
+				continue
+			}
+			securityRules = append(securityRules, rule)
+		}
+	}
+	delete(tags, infrav1.ClusterTagKey(s.Scope.ClusterName()))
+	tags.DeleteEntries(s.Scope.ClusterName())
+
+	// the other properties of the security group are kept, only the rules are replaced.
+	properties := network.SecurityGroupPropertiesFormat{}
+	if nsg.SecurityGroupPropertiesFormat != nil {
+		properties = *nsg.SecurityGroupPropertiesFormat
+	}
+	properties.SecurityRules = &securityRules
+
+	s.Scope.V(2).Info("removing rules from shared security group", "security group", to.String(nsg.Name))
+	err := s.client.CreateOrUpdate(ctx, s.Scope.ResourceGroup(), to.String(nsg.Name), network.SecurityGroup{
+		Location:                      nsg.Location,
+		SecurityGroupPropertiesFormat: &properties,
+		Etag:                          nsg.Etag,
+		Tags:                          converters.TagsToMap(tags),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove rules from shared security group %s in resource group %s", to.String(nsg.Name), s.Scope.ResourceGroup())
+	}
+	return nil
+}
@@ -76,6 +76,7 @@ func TestReconcileSecurityGroups(t *testing.T) {
 				s.IsVnetManaged().Return(true)
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.Location().AnyTimes().Return("test-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
 				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "nsg-one", gomockinternal.DiffEq(network.SecurityGroup{
@@ -113,6 +114,10 @@ func TestReconcileSecurityGroups(t *testing.T) {
 					},
 					Etag:     nil,
 					Location: to.StringPtr("test-location"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("nsg-one"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
 				}))
 				m.Get(gomockinternal.AContext(), "my-rg", "nsg-two").Return(network.SecurityGroup{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
 				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "nsg-two", gomockinternal.DiffEq(network.SecurityGroup{
@@ -121,6 +126,10 @@ func TestReconcileSecurityGroups(t *testing.T) {
 					},
 					Etag:     nil,
 					Location: to.StringPtr("test-location"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("nsg-two"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
 				}))
 			},
 		}, {
@@ -150,6 +159,7 @@ func TestReconcileSecurityGroups(t *testing.T) {
 				s.IsVnetManaged().AnyTimes().Return(true)
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.Location().AnyTimes().Return("test-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{
 					Response: autorest.Response{},
@@ -224,8 +234,162 @@ func TestReconcileSecurityGroups(t *testing.T) {
 		}, {
 			name: "skipping network security group reconcile in custom VNet mode",
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.NSGSpecs().Return([]azure.NSGSpec{
+					{
+						Name:         "nsg-one",
+						IngressRules: infrav1.IngressRules{},
+					},
+				})
+				s.IsVnetManaged().Return(false)
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ID: "custom-vnet-id", Name: "custom-vnet"})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		}, {
+			name: "security group created in shared custom VNet",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.NSGSpecs().Return([]azure.NSGSpec{
+					{
+						Name:         "nsg-one",
+						IngressRules: infrav1.IngressRules{},
+					},
+				})
+				s.IsVnetManaged().Return(false)
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ID: "custom-vnet-id", Name: "custom-vnet", Tags: infrav1.Tags{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "shared",
+				}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "nsg-one", gomockinternal.DiffEq(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{},
+					},
+					Location: to.StringPtr("test-location"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("nsg-one"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
+				}))
+			},
+		}, {
+			name: "rules are merged into shared security group",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.NSGSpecs().Return([]azure.NSGSpec{
+					{
+						Name: "nsg-one",
+						IngressRules: infrav1.IngressRules{
+							{
+								Name:             "allow_ssh",
+								Protocol:         "Tcp",
+								Priority:         2200,
+								SourcePorts:      to.StringPtr("*"),
+								DestinationPorts: to.StringPtr("22"),
+								Source:           to.StringPtr("*"),
+								Destination:      to.StringPtr("*"),
+							},
+							{
+								Name:             "allow_apiserver",
+								Protocol:         "Tcp",
+								Priority:         2201,
+								SourcePorts:      to.StringPtr("*"),
+								DestinationPorts: to.StringPtr("6443"),
+								Source:           to.StringPtr("*"),
+								Destination:      to.StringPtr("*"),
+							},
+						},
+					},
+				})
+				s.IsVnetManaged().Return(false)
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ID: "custom-vnet-id", Name: "custom-vnet"})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				existingRule := network.SecurityRule{
+					SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+						SourcePortRange:          to.StringPtr("*"),
+						DestinationPortRange:     to.StringPtr("22"),
+						SourceAddressPrefix:      to.StringPtr("*"),
+						DestinationAddressPrefix: to.StringPtr("*"),
+						Protocol:                 network.SecurityRuleProtocolTCP,
+						Direction:                network.SecurityRuleDirectionInbound,
+						Access:                   network.SecurityRuleAccessAllow,
+						Priority:                 to.Int32Ptr(2200),
+					},
+					Name: to.StringPtr("allow_ssh"),
+				}
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{existingRule},
+					},
+					Etag: to.StringPtr("test-etag"),
+					Name: to.StringPtr("nsg-one"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("custom-nsg"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_other-cluster": to.StringPtr("allow_ssh"),
+					},
+				}, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "nsg-one", gomockinternal.DiffEq(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{
+							existingRule,
+							{
+								SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+									Description:              to.StringPtr(""),
+									SourcePortRange:          to.StringPtr("*"),
+									DestinationPortRange:     to.StringPtr("6443"),
+									SourceAddressPrefix:      to.StringPtr("*"),
+									DestinationAddressPrefix: to.StringPtr("*"),
+									Protocol:                 network.SecurityRuleProtocolTCP,
+									Direction:                network.SecurityRuleDirectionInbound,
+									Access:                   network.SecurityRuleAccessAllow,
+									Priority:                 to.Int32Ptr(2201),
+								},
+								Name: to.StringPtr("allow_apiserver"),
+							},
+						},
+					},
+					Etag:     to.StringPtr("test-etag"),
+					Location: to.StringPtr("test-location"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("custom-nsg"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_other-cluster": to.StringPtr("allow_ssh"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster":    to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_my-cluster":    to.StringPtr("allow_apiserver,allow_ssh"),
+					},
+				}))
+			},
+		}, {
+			name: "shared security group is up to date",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.NSGSpecs().Return([]azure.NSGSpec{
+					{
+						Name:         "nsg-one",
+						IngressRules: infrav1.IngressRules{},
+					},
+				})
 				s.IsVnetManaged().Return(false)
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ID: "custom-vnet-id", Name: "custom-vnet"})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{},
+					},
+					Name: to.StringPtr("nsg-one"),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("shared"),
+					},
+				}, nil)
 			},
 		},
 	}
@@ -281,9 +445,13 @@ func TestDeleteSecurityGroups(t *testing.T) {
 						IngressRules: infrav1.IngressRules{},
 					},
 				})
+				s.IsVnetManaged().Return(true)
 				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{Name: to.StringPtr("nsg-one")}, nil)
 				m.Delete(gomockinternal.AContext(), "my-rg", "nsg-one")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-two").Return(network.SecurityGroup{Name: to.StringPtr("nsg-two")}, nil)
 				m.Delete(gomockinternal.AContext(), "my-rg", "nsg-two")
 			},
 		},
@@ -300,11 +468,81 @@ func TestDeleteSecurityGroups(t *testing.T) {
 						IngressRules: infrav1.IngressRules{},
 					},
 				})
+				s.IsVnetManaged().Return(true)
 				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				m.Delete(gomockinternal.AContext(), "my-rg", "nsg-one").
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").
+					Return(network.SecurityGroup{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-two").Return(network.SecurityGroup{Name: to.StringPtr("nsg-two")}, nil)
+				m.Delete(gomockinternal.AContext(), "my-rg", "nsg-two").
 					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
-				m.Delete(gomockinternal.AContext(), "my-rg", "nsg-two")
+			},
+		},
+		{
+			name: "security group not owned in custom VNet mode",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.NSGSpecs().Return([]azure.NSGSpec{
+					{
+						Name:         "nsg-one",
+						IngressRules: infrav1.IngressRules{},
+					},
+				})
+				s.IsVnetManaged().Return(false)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{Name: to.StringPtr("nsg-one")}, nil)
+			},
+		},
+		{
+			name: "rules of the cluster removed from shared security group",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.NSGSpecs().Return([]azure.NSGSpec{
+					{
+						Name:         "nsg-one",
+						IngressRules: infrav1.IngressRules{},
+					},
+				})
+				s.IsVnetManaged().Return(false)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{
+							{Name: to.StringPtr("allow_ssh")},
+							{Name: to.StringPtr("allow_apiserver")},
+							{Name: to.StringPtr("custom_rule")},
+						},
+					},
+					Etag:     to.StringPtr("test-etag"),
+					Location: to.StringPtr("test-location"),
+					Name:     to.StringPtr("nsg-one"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("custom-nsg"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster":    to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_my-cluster":    to.StringPtr("allow_apiserver"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_my-cluster_1":  to.StringPtr("allow_ssh"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_other-cluster": to.StringPtr("allow_ssh"),
+					},
+				}, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "nsg-one", gomockinternal.DiffEq(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{
+							{Name: to.StringPtr("allow_ssh")},
+							{Name: to.StringPtr("custom_rule")},
+						},
+					},
+					Etag:     to.StringPtr("test-etag"),
+					Location: to.StringPtr("test-location"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("custom-nsg"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_other-cluster": to.StringPtr("allow_ssh"),
+					},
+				}))
 			},
 		},
 	}
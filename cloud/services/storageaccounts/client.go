@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageaccounts
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Client wraps go-sdk
+type Client interface {
+	Get(context.Context, string, string) (storage.Account, error)
+	Create(context.Context, string, string, storage.AccountCreateParameters) error
+	Delete(context.Context, string, string) error
+	GetContainer(context.Context, string, string, string) (storage.BlobContainer, error)
+	CreateContainer(context.Context, string, string, string, storage.BlobContainer) error
+	ListServiceSAS(context.Context, string, string, storage.ServiceSasParameters) (string, error)
+}
+
+// AzureClient contains the Azure go-sdk Client
+type AzureClient struct {
+	accounts       storage.AccountsClient
+	blobContainers storage.BlobContainersClient
+}
+
+var _ Client = &AzureClient{}
+
+// NewClient creates a new storage accounts client from subscription ID.
+func NewClient(auth azure.Authorizer) *AzureClient {
+	return &AzureClient{
+		accounts:       newAccountsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		blobContainers: newBlobContainersClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newAccountsClient creates a new storage accounts client from subscription ID.
+func newAccountsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) storage.AccountsClient {
+	accountsClient := storage.NewAccountsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&accountsClient.Client, authorizer)
+	return accountsClient
+}
+
+// newBlobContainersClient creates a new blob containers client from subscription ID.
+func newBlobContainersClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) storage.BlobContainersClient {
+	blobContainersClient := storage.NewBlobContainersClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&blobContainersClient.Client, authorizer)
+	return blobContainersClient
+}
+
+// Get gets the specified storage account.
+func (ac *AzureClient) Get(ctx context.Context, resourceGroupName, accountName string) (storage.Account, error) {
+	ctx, span := tele.Tracer().Start(ctx, "storageaccounts.AzureClient.Get")
+	defer span.End()
+
+	return ac.accounts.GetProperties(ctx, resourceGroupName, accountName, "")
+}
+
+// Create creates a storage account in the specified resource group.
+func (ac *AzureClient) Create(ctx context.Context, resourceGroupName, accountName string, account storage.AccountCreateParameters) error {
+	ctx, span := tele.Tracer().Start(ctx, "storageaccounts.AzureClient.Create")
+	defer span.End()
+
+	future, err := ac.accounts.Create(ctx, resourceGroupName, accountName, account)
+	if err != nil {
+		return err
+	}
+	err = future.WaitForCompletionRef(ctx, ac.accounts.Client)
+	if err != nil {
+		return err
+	}
+	_, err = future.Result(ac.accounts)
+	return err
+}
+
+// Delete deletes the specified storage account.
+func (ac *AzureClient) Delete(ctx context.Context, resourceGroupName, accountName string) error {
+	ctx, span := tele.Tracer().Start(ctx, "storageaccounts.AzureClient.Delete")
+	defer span.End()
+
+	_, err := ac.accounts.Delete(ctx, resourceGroupName, accountName)
+	return err
+}
+
+// GetContainer gets the specified blob container of a storage account.
+func (ac *AzureClient) GetContainer(ctx context.Context, resourceGroupName, accountName, containerName string) (storage.BlobContainer, error) {
+	ctx, span := tele.Tracer().Start(ctx, "storageaccounts.AzureClient.GetContainer")
+	defer span.End()
+
+	return ac.blobContainers.Get(ctx, resourceGroupName, accountName, containerName)
+}
+
+// CreateContainer creates a blob container in a storage account.
+func (ac *AzureClient) CreateContainer(ctx context.Context, resourceGroupName, accountName, containerName string, container storage.BlobContainer) error {
+	ctx, span := tele.Tracer().Start(ctx, "storageaccounts.AzureClient.CreateContainer")
+	defer span.End()
+
+	_, err := ac.blobContainers.Create(ctx, resourceGroupName, accountName, containerName, container)
+	return err
+}
+
+// ListServiceSAS returns a service SAS token of a resource of a storage account, signed with the key of the account.
+func (ac *AzureClient) ListServiceSAS(ctx context.Context, resourceGroupName, accountName string, parameters storage.ServiceSasParameters) (string, error) {
+	ctx, span := tele.Tracer().Start(ctx, "storageaccounts.AzureClient.ListServiceSAS")
+	defer span.End()
+
+	result, err := ac.accounts.ListServiceSAS(ctx, resourceGroupName, accountName, parameters)
+	if err != nil {
+		return "", err
+	}
+	return to.String(result.ServiceSasToken), nil
+}
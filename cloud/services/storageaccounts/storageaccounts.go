@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageaccounts
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// StorageAccountScope defines the scope interface for a storage accounts service.
+type StorageAccountScope interface {
+	logr.Logger
+	azure.ClusterDescriber
+	StorageAccountSpecs() []azure.StorageAccountSpec
+}
+
+// Service provides operations on storage accounts.
+type Service struct {
+	Scope StorageAccountScope
+	Client
+}
+
+// New creates a new storage accounts service.
+func New(scope StorageAccountScope) *Service {
+	return &Service{
+		Scope:  scope,
+		Client: NewClient(scope),
+	}
+}
+
+// Reconcile creates the storage accounts and their blob container when they don't exist yet.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, span := tele.Tracer().Start(ctx, "storageaccounts.Service.Reconcile")
+	defer span.End()
+
+	for _, accountSpec := range s.Scope.StorageAccountSpecs() {
+		_, err := s.Client.Get(ctx, s.Scope.ResourceGroup(), accountSpec.Name)
+		switch {
+		case err != nil && !azure.ResourceNotFound(err):
+			return errors.Wrapf(err, "failed to get storage account %s", accountSpec.Name)
+		case err != nil:
+			s.Scope.V(2).Info("creating storage account", "storage account", accountSpec.Name)
+			account := storage.AccountCreateParameters{
+				Sku:      &storage.Sku{Name: storage.StandardLRS},
+				Kind:     storage.StorageV2,
+				Location: to.StringPtr(s.Scope.Location()),
+				Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+					ClusterName: s.Scope.ClusterName(),
+					Lifecycle:   infrav1.ResourceLifecycleOwned,
+					Name:        to.StringPtr(accountSpec.Name),
+					Role:        to.StringPtr(infrav1.CommonRole),
+					Additional:  s.Scope.AdditionalTags(),
+				})),
+				AccountPropertiesCreateParameters: &storage.AccountPropertiesCreateParameters{
+					EnableHTTPSTrafficOnly: to.BoolPtr(true),
+					AllowBlobPublicAccess:  to.BoolPtr(false),
+					MinimumTLSVersion:      storage.TLS12,
+				},
+			}
+			if err := s.Client.Create(ctx, s.Scope.ResourceGroup(), accountSpec.Name, account); err != nil {
+				return errors.Wrapf(err, "failed to create storage account %s", accountSpec.Name)
+			}
+			s.Scope.V(2).Info("successfully created storage account", "storage account", accountSpec.Name)
+		}
+
+		_, err = s.Client.GetContainer(ctx, s.Scope.ResourceGroup(), accountSpec.Name, accountSpec.ContainerName)
+		switch {
+		case err == nil:
+			continue
+		case !azure.ResourceNotFound(err):
+			return errors.Wrapf(err, "failed to get blob container %s of storage account %s", accountSpec.ContainerName, accountSpec.Name)
+		}
+
+		s.Scope.V(2).Info("creating blob container", "storage account", accountSpec.Name, "container", accountSpec.ContainerName)
+		container := storage.BlobContainer{
+			ContainerProperties: &storage.ContainerProperties{
+				PublicAccess: storage.PublicAccessNone,
+			},
+		}
+		if err := s.Client.CreateContainer(ctx, s.Scope.ResourceGroup(), accountSpec.Name, accountSpec.ContainerName, container); err != nil {
+			return errors.Wrapf(err, "failed to create blob container %s of storage account %s", accountSpec.ContainerName, accountSpec.Name)
+		}
+		s.Scope.V(2).Info("successfully created blob container", "storage account", accountSpec.Name, "container", accountSpec.ContainerName)
+	}
+
+	return nil
+}
+
+// Delete deletes the storage accounts owned by the cluster, along with the blobs they hold.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, span := tele.Tracer().Start(ctx, "storageaccounts.Service.Delete")
+	defer span.End()
+
+	for _, accountSpec := range s.Scope.StorageAccountSpecs() {
+		account, err := s.Client.Get(ctx, s.Scope.ResourceGroup(), accountSpec.Name)
+		if err != nil && azure.ResourceNotFound(err) {
+			// already deleted, or never created as no machine delivered its bootstrap data through a storage blob
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to get storage account %s in resource group %s", accountSpec.Name, s.Scope.ResourceGroup())
+		}
+
+		if !converters.MapToTags(account.Tags).HasOwned(s.Scope.ClusterName()) {
+			s.Scope.V(2).Info("skipping deletion of unmanaged storage account", "storage account", accountSpec.Name)
+			continue
+		}
+
+		s.Scope.V(2).Info("deleting storage account", "storage account", accountSpec.Name)
+		err = s.Client.Delete(ctx, s.Scope.ResourceGroup(), accountSpec.Name)
+		if err != nil && azure.ResourceNotFound(err) {
+			// already deleted
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete storage account %s in resource group %s", accountSpec.Name, s.Scope.ResourceGroup())
+		}
+
+		s.Scope.V(2).Info("successfully deleted storage account", "storage account", accountSpec.Name)
+	}
+
+	return nil
+}
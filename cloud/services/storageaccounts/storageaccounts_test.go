@@ -0,0 +1,227 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageaccounts
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/klog/klogr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/storageaccounts/mock_storageaccounts"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	accountSpec = azure.StorageAccountSpec{Name: "capzboot0123456789abcdef", ContainerName: "bootstrap"}
+	notFound    = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found")
+	ownedTags   = map[string]*string{
+		"Name": to.StringPtr("capzboot0123456789abcdef"),
+		"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+		"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("common"),
+	}
+)
+
+func TestReconcileStorageAccounts(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_storageaccounts.MockStorageAccountScopeMockRecorder, m *mock_storageaccounts.MockClientMockRecorder)
+	}{
+		{
+			name:          "create a storage account and its blob container",
+			expectedError: "",
+			expect: func(s *mock_storageaccounts.MockStorageAccountScopeMockRecorder, m *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.StorageAccountSpecs().Return([]azure.StorageAccountSpec{accountSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("test-location")
+				m.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(storage.Account{}, notFound)
+				m.Create(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef", gomockinternal.DiffEq(storage.AccountCreateParameters{
+					Sku:      &storage.Sku{Name: storage.StandardLRS},
+					Kind:     storage.StorageV2,
+					Location: to.StringPtr("test-location"),
+					Tags:     ownedTags,
+					AccountPropertiesCreateParameters: &storage.AccountPropertiesCreateParameters{
+						EnableHTTPSTrafficOnly: to.BoolPtr(true),
+						AllowBlobPublicAccess:  to.BoolPtr(false),
+						MinimumTLSVersion:      storage.TLS12,
+					},
+				}))
+				m.GetContainer(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef", "bootstrap").Return(storage.BlobContainer{}, notFound)
+				m.CreateContainer(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef", "bootstrap", storage.BlobContainer{
+					ContainerProperties: &storage.ContainerProperties{
+						PublicAccess: storage.PublicAccessNone,
+					},
+				})
+			},
+		},
+		{
+			name:          "storage account and blob container already exist",
+			expectedError: "",
+			expect: func(s *mock_storageaccounts.MockStorageAccountScopeMockRecorder, m *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.StorageAccountSpecs().Return([]azure.StorageAccountSpec{accountSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(storage.Account{Tags: ownedTags}, nil)
+				m.GetContainer(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef", "bootstrap").Return(storage.BlobContainer{}, nil)
+			},
+		},
+		{
+			name:          "do nothing without storage account",
+			expectedError: "",
+			expect: func(s *mock_storageaccounts.MockStorageAccountScopeMockRecorder, m *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.StorageAccountSpecs().Return(nil)
+			},
+		},
+		{
+			name:          "fail to create the storage account",
+			expectedError: "failed to create storage account capzboot0123456789abcdef: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_storageaccounts.MockStorageAccountScopeMockRecorder, m *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.StorageAccountSpecs().Return([]azure.StorageAccountSpec{accountSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("test-location")
+				m.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(storage.Account{}, notFound)
+				m.Create(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef", gomock.AssignableToTypeOf(storage.AccountCreateParameters{})).Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_storageaccounts.NewMockStorageAccountScope(mockCtrl)
+			clientMock := mock_storageaccounts.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				Client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteStorageAccounts(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_storageaccounts.MockStorageAccountScopeMockRecorder, m *mock_storageaccounts.MockClientMockRecorder)
+	}{
+		{
+			name:          "delete an owned storage account",
+			expectedError: "",
+			expect: func(s *mock_storageaccounts.MockStorageAccountScopeMockRecorder, m *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.StorageAccountSpecs().Return([]azure.StorageAccountSpec{accountSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(storage.Account{Tags: ownedTags}, nil)
+				m.Delete(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef")
+			},
+		},
+		{
+			name:          "storage account never created",
+			expectedError: "",
+			expect: func(s *mock_storageaccounts.MockStorageAccountScopeMockRecorder, m *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.StorageAccountSpecs().Return([]azure.StorageAccountSpec{accountSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(storage.Account{}, notFound)
+			},
+		},
+		{
+			name:          "skip an unmanaged storage account",
+			expectedError: "",
+			expect: func(s *mock_storageaccounts.MockStorageAccountScopeMockRecorder, m *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.StorageAccountSpecs().Return([]azure.StorageAccountSpec{accountSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(storage.Account{}, nil)
+			},
+		},
+		{
+			name:          "fail to delete the storage account",
+			expectedError: "failed to delete storage account capzboot0123456789abcdef in resource group my-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_storageaccounts.MockStorageAccountScopeMockRecorder, m *mock_storageaccounts.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.StorageAccountSpecs().Return([]azure.StorageAccountSpec{accountSpec})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(storage.Account{Tags: ownedTags}, nil)
+				m.Delete(gomockinternal.AContext(), "my-rg", "capzboot0123456789abcdef").Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_storageaccounts.NewMockStorageAccountScope(mockCtrl)
+			clientMock := mock_storageaccounts.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				Client: clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
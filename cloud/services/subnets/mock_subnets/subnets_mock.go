@@ -468,6 +468,34 @@ func (mr *MockSubnetScopeMockRecorder) OutboundPoolName(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundPoolName", reflect.TypeOf((*MockSubnetScope)(nil).OutboundPoolName), arg0)
 }
 
+// AvailabilitySetEnabled mocks base method.
+func (m *MockSubnetScope) AvailabilitySetEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailabilitySetEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// AvailabilitySetEnabled indicates an expected call of AvailabilitySetEnabled.
+func (mr *MockSubnetScopeMockRecorder) AvailabilitySetEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilitySetEnabled", reflect.TypeOf((*MockSubnetScope)(nil).AvailabilitySetEnabled))
+}
+
+// ProximityPlacementGroupName mocks base method.
+func (m *MockSubnetScope) ProximityPlacementGroupName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProximityPlacementGroupName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ProximityPlacementGroupName indicates an expected call of ProximityPlacementGroupName.
+func (mr *MockSubnetScopeMockRecorder) ProximityPlacementGroupName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProximityPlacementGroupName", reflect.TypeOf((*MockSubnetScope)(nil).ProximityPlacementGroupName))
+}
+
 // SubnetSpecs mocks base method.
 func (m *MockSubnetScope) SubnetSpecs() []azure.SubnetSpec {
 	m.ctrl.T.Helper()
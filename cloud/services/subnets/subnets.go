@@ -27,6 +27,8 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -41,13 +43,15 @@ type SubnetScope interface {
 type Service struct {
 	Scope SubnetScope
 	Client
+	virtualNetworksClient virtualnetworks.Client
 }
 
 // New creates a new service.
 func New(scope SubnetScope) *Service {
 	return &Service{
-		Scope:  scope,
-		Client: NewClient(scope),
+		Scope:                 scope,
+		Client:                NewClient(scope),
+		virtualNetworksClient: virtualnetworks.NewClient(scope),
 	}
 }
 
@@ -77,10 +81,23 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			subnet.CIDRBlocks = existingSubnet.CIDRBlocks
 			subnet.ID = existingSubnet.ID
 
-		case !s.Scope.IsVnetManaged():
+			// A subnet another cluster added to a shared vnet is only deleted once no cluster uses it anymore.
+			if s.Scope.Vnet().Tags.HasEntryOfOtherCluster(s.Scope.ClusterName(), subnetSpec.Name) {
+				if err := s.addEntry(ctx, subnetSpec.Name); err != nil {
+					return err
+				}
+			}
+
+		case !s.Scope.IsVnetManaged() && !s.isVnetShared():
 			return fmt.Errorf("vnet was provided but subnet %s is missing", subnetSpec.Name)
 
 		default:
+			// The subnet is recorded before being created in a shared vnet so that it is never left behind.
+			if s.isVnetShared() {
+				if err := s.addEntry(ctx, subnetSpec.Name); err != nil {
+					return err
+				}
+			}
 
 			subnetProperties := network.SubnetPropertiesFormat{
 				AddressPrefixes: &subnetSpec.CIDRs,
@@ -132,11 +149,11 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "subnets.Service.Delete")
 	defer span.End()
 
+	if !s.Scope.Vnet().IsManaged(s.Scope.ClusterName()) {
+		return s.deleteEntries(ctx)
+	}
+
 	for _, subnetSpec := range s.Scope.SubnetSpecs() {
-		if !s.Scope.Vnet().IsManaged(s.Scope.ClusterName()) {
-			s.Scope.V(4).Info("Skipping subnets deletion in custom vnet mode")
-			continue
-		}
 		s.Scope.V(2).Info("deleting subnet in vnet", "subnet", subnetSpec.Name, "vnet", subnetSpec.VNetName)
 		err := s.Client.Delete(ctx, s.Scope.Vnet().ResourceGroup, subnetSpec.VNetName, subnetSpec.Name)
 		if err != nil && azure.ResourceNotFound(err) {
@@ -152,6 +169,63 @@ func (s *Service) Delete(ctx context.Context) error {
 	return nil
 }
 
+// isVnetShared returns true if the vnet was provided and is shared with the cluster.
+func (s *Service) isVnetShared() bool {
+	return s.Scope.Vnet().Tags.HasShared(s.Scope.ClusterName())
+}
+
+// addEntry records a subnet as added to the shared vnet by the cluster.
+func (s *Service) addEntry(ctx context.Context, name string) error {
+	vnet := s.Scope.Vnet()
+	if vnet.Tags.HasEntry(s.Scope.ClusterName(), name) {
+		return nil
+	}
+
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.Scope.ClusterName(),
+		Lifecycle:   infrav1.ResourceLifecycleShared,
+		Entries:     append(vnet.Tags.GetEntries(s.Scope.ClusterName()), name),
+	})
+	if err := s.virtualNetworksClient.MergeTags(ctx, vnet.ID, converters.TagsToMap(tags)); err != nil {
+		return errors.Wrapf(err, "failed to record subnet %s in shared vnet %s", name, vnet.Name)
+	}
+	vnet.Tags.Merge(tags)
+	return nil
+}
+
+// deleteEntries deletes the subnets the cluster added to a shared vnet, except the ones other clusters still use. The
+// vnet tags are read again since other clusters may have started using the subnets since the last reconcile.
+func (s *Service) deleteEntries(ctx context.Context) error {
+	vnet := s.Scope.Vnet()
+	existingVnet, err := s.virtualNetworksClient.Get(ctx, vnet.ResourceGroup, vnet.Name)
+	if azure.ResourceNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get vnet %s", vnet.Name)
+	}
+
+	tags := converters.MapToTags(existingVnet.Tags)
+	if !tags.HasShared(s.Scope.ClusterName()) {
+		s.Scope.V(4).Info("Skipping subnets deletion in custom vnet mode")
+		return nil
+	}
+
+	for _, name := range tags.GetEntries(s.Scope.ClusterName()) {
+		if tags.HasEntryOfOtherCluster(s.Scope.ClusterName(), name) {
+			s.Scope.V(2).Info("skipping deletion of subnet used by other clusters", "subnet", name, "vnet", vnet.Name)
+			continue
+		}
+		s.Scope.V(2).Info("deleting subnet in shared vnet", "subnet", name, "vnet", vnet.Name)
+		err := s.Client.Delete(ctx, vnet.ResourceGroup, vnet.Name, name)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to delete subnet %s in resource group %s", name, vnet.ResourceGroup)
+		}
+		s.Scope.V(2).Info("successfully deleted subnet in shared vnet", "subnet", name, "vnet", vnet.Name)
+	}
+	return nil
+}
+
 // getExisting provides information about an existing subnet.
 func (s *Service) getExisting(ctx context.Context, rgName string, spec azure.SubnetSpec) (*infrav1.SubnetSpec, error) {
 	ctx, span := tele.Tracer().Start(ctx, "subnets.Service.getExisting")
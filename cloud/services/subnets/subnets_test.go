@@ -27,6 +27,7 @@ import (
 	"k8s.io/klog/klogr"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/subnets/mock_subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/virtualnetworks/mock_virtualnetworks"
 
 	"github.com/golang/mock/gomock"
 
@@ -451,47 +452,154 @@ func TestDeleteSubnets(t *testing.T) {
 			},
 		},
 		{
-			name:          "skip delete if vnet is managed",
-			expectedError: "",
+			name:          "fail delete subnet",
+			expectedError: "failed to delete subnet my-subnet in resource group my-rg: #: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, m *mock_subnets.MockClientMockRecorder) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.SubnetSpecs().Return([]azure.SubnetSpec{
 					{
 						Name:              "my-subnet",
 						CIDRs:             []string{"10.0.0.0/16"},
-						VNetName:          "custom-vnet",
+						VNetName:          "my-vnet",
 						RouteTableName:    "my-subnet_route_table",
 						SecurityGroupName: "my-sg",
 						Role:              infrav1.SubnetNode,
 					},
 				})
-				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ResourceGroup: "custom-vnet-rg", Name: "custom-vnet", ID: "id1"})
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"})
 				s.ClusterName().AnyTimes().Return("fake-cluster")
 				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Delete(gomockinternal.AContext(), "my-rg", "my-vnet", "my-subnet").Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
 			},
 		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_subnets.NewMockSubnetScope(mockCtrl)
+			clientMock := mock_subnets.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				Client: clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestReconcileSubnetsInSharedVnet(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_subnets.MockSubnetScopeMockRecorder, m *mock_subnets.MockClientMockRecorder, v *mock_virtualnetworks.MockClientMockRecorder)
+	}{
 		{
-			name:          "fail delete subnet",
-			expectedError: "failed to delete subnet my-subnet in resource group my-rg: #: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, m *mock_subnets.MockClientMockRecorder) {
+			name:          "missing subnet is recorded and created",
+			expectedError: "",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, m *mock_subnets.MockClientMockRecorder, v *mock_virtualnetworks.MockClientMockRecorder) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.SubnetSpecs().Return([]azure.SubnetSpec{
 					{
 						Name:              "my-subnet",
 						CIDRs:             []string{"10.0.0.0/16"},
-						VNetName:          "my-vnet",
+						VNetName:          "custom-vnet",
 						RouteTableName:    "my-subnet_route_table",
 						SecurityGroupName: "my-sg",
 						Role:              infrav1.SubnetNode,
 					},
 				})
-				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"})
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ResourceGroup: "custom-vnet-rg", Name: "custom-vnet", ID: "vnet-id", Tags: infrav1.Tags{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_fake-cluster": "shared",
+					"sigs.k8s.io_cluster-api-provider-azure_entries_fake-cluster": "other-subnet",
+				}})
 				s.ClusterName().AnyTimes().Return("fake-cluster")
+				s.SubscriptionID().AnyTimes().Return("123")
 				s.ResourceGroup().AnyTimes().Return("my-rg")
-				m.Delete(gomockinternal.AContext(), "my-rg", "my-vnet", "my-subnet").Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+				s.IsVnetManaged().Return(false)
+				m.Get(gomockinternal.AContext(), "custom-vnet-rg", "custom-vnet", "my-subnet").
+					Return(network.Subnet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				v.MergeTags(gomockinternal.AContext(), "vnet-id", map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_fake-cluster": to.StringPtr("shared"),
+					"sigs.k8s.io_cluster-api-provider-azure_entries_fake-cluster": to.StringPtr("my-subnet,other-subnet"),
+				})
+				m.CreateOrUpdate(gomockinternal.AContext(), "custom-vnet-rg", "custom-vnet", "my-subnet", gomock.AssignableToTypeOf(network.Subnet{}))
+			},
+		},
+		{
+			name:          "subnet of another cluster is recorded",
+			expectedError: "",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, m *mock_subnets.MockClientMockRecorder, v *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.SubnetSpecs().Return([]azure.SubnetSpec{
+					{
+						Name:     "my-subnet",
+						CIDRs:    []string{"10.0.0.0/16"},
+						VNetName: "custom-vnet",
+						Role:     infrav1.SubnetNode,
+					},
+				})
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ResourceGroup: "custom-vnet-rg", Name: "custom-vnet", ID: "vnet-id", Tags: infrav1.Tags{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_fake-cluster":  "shared",
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": "shared",
+					"sigs.k8s.io_cluster-api-provider-azure_entries_other-cluster": "my-subnet",
+				}})
+				s.NodeSubnet().AnyTimes().Return(&infrav1.SubnetSpec{Name: "my-subnet", Role: infrav1.SubnetNode})
+				s.ClusterName().AnyTimes().Return("fake-cluster")
+				m.Get(gomockinternal.AContext(), "custom-vnet-rg", "custom-vnet", "my-subnet").
+					Return(network.Subnet{
+						ID:   to.StringPtr("subnet-id"),
+						Name: to.StringPtr("my-subnet"),
+						SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+							AddressPrefix: to.StringPtr("10.0.0.0/16"),
+						},
+					}, nil)
+				v.MergeTags(gomockinternal.AContext(), "vnet-id", map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_fake-cluster": to.StringPtr("shared"),
+					"sigs.k8s.io_cluster-api-provider-azure_entries_fake-cluster": to.StringPtr("my-subnet"),
+				})
+			},
+		},
+		{
+			name:          "fail to record subnet",
+			expectedError: "failed to record subnet my-subnet in shared vnet custom-vnet: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, m *mock_subnets.MockClientMockRecorder, v *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.SubnetSpecs().Return([]azure.SubnetSpec{
+					{
+						Name:     "my-subnet",
+						CIDRs:    []string{"10.0.0.0/16"},
+						VNetName: "custom-vnet",
+						Role:     infrav1.SubnetNode,
+					},
+				})
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ResourceGroup: "custom-vnet-rg", Name: "custom-vnet", ID: "vnet-id", Tags: infrav1.Tags{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_fake-cluster": "shared",
+				}})
+				s.ClusterName().AnyTimes().Return("fake-cluster")
+				s.IsVnetManaged().Return(false)
+				m.Get(gomockinternal.AContext(), "custom-vnet-rg", "custom-vnet", "my-subnet").
+					Return(network.Subnet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				v.MergeTags(gomockinternal.AContext(), "vnet-id", gomock.Any()).
+					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
 			},
 		},
 	}
+
 	for _, tc := range testcases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -502,12 +610,113 @@ func TestDeleteSubnets(t *testing.T) {
 			defer mockCtrl.Finish()
 			scopeMock := mock_subnets.NewMockSubnetScope(mockCtrl)
 			clientMock := mock_subnets.NewMockClient(mockCtrl)
+			vnetMock := mock_virtualnetworks.NewMockClient(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), vnetMock.EXPECT())
 
 			s := &Service{
-				Scope:  scopeMock,
-				Client: clientMock,
+				Scope:                 scopeMock,
+				Client:                clientMock,
+				virtualNetworksClient: vnetMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteSubnetsInSharedVnet(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_subnets.MockSubnetScopeMockRecorder, m *mock_subnets.MockClientMockRecorder, v *mock_virtualnetworks.MockClientMockRecorder)
+	}{
+		{
+			name:          "skip delete if vnet is not shared with the cluster",
+			expectedError: "",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, m *mock_subnets.MockClientMockRecorder, v *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ResourceGroup: "custom-vnet-rg", Name: "custom-vnet", ID: "id1"})
+				s.ClusterName().AnyTimes().Return("fake-cluster")
+				v.Get(gomockinternal.AContext(), "custom-vnet-rg", "custom-vnet").Return(network.VirtualNetwork{ID: to.StringPtr("id1")}, nil)
+			},
+		},
+		{
+			name:          "delete the subnets recorded for the cluster only",
+			expectedError: "",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, m *mock_subnets.MockClientMockRecorder, v *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ResourceGroup: "custom-vnet-rg", Name: "custom-vnet", ID: "id1"})
+				s.ClusterName().AnyTimes().Return("fake-cluster")
+				v.Get(gomockinternal.AContext(), "custom-vnet-rg", "custom-vnet").Return(network.VirtualNetwork{
+					ID: to.StringPtr("id1"),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_fake-cluster":  to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_fake-cluster":  to.StringPtr("my-subnet,shared-subnet,deleted-subnet"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_other-cluster": to.StringPtr("shared-subnet"),
+					},
+				}, nil)
+				m.Delete(gomockinternal.AContext(), "custom-vnet-rg", "custom-vnet", "my-subnet")
+				m.Delete(gomockinternal.AContext(), "custom-vnet-rg", "custom-vnet", "deleted-subnet").
+					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		},
+		{
+			name:          "vnet already deleted",
+			expectedError: "",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, m *mock_subnets.MockClientMockRecorder, v *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ResourceGroup: "custom-vnet-rg", Name: "custom-vnet", ID: "id1"})
+				s.ClusterName().AnyTimes().Return("fake-cluster")
+				v.Get(gomockinternal.AContext(), "custom-vnet-rg", "custom-vnet").
+					Return(network.VirtualNetwork{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		},
+		{
+			name:          "fail to delete subnet",
+			expectedError: "failed to delete subnet my-subnet in resource group custom-vnet-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, m *mock_subnets.MockClientMockRecorder, v *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ResourceGroup: "custom-vnet-rg", Name: "custom-vnet", ID: "id1"})
+				s.ClusterName().AnyTimes().Return("fake-cluster")
+				v.Get(gomockinternal.AContext(), "custom-vnet-rg", "custom-vnet").Return(network.VirtualNetwork{
+					ID: to.StringPtr("id1"),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_fake-cluster": to.StringPtr("shared"),
+						"sigs.k8s.io_cluster-api-provider-azure_entries_fake-cluster": to.StringPtr("my-subnet"),
+					},
+				}, nil)
+				m.Delete(gomockinternal.AContext(), "custom-vnet-rg", "custom-vnet", "my-subnet").
+					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_subnets.NewMockSubnetScope(mockCtrl)
+			clientMock := mock_subnets.NewMockClient(mockCtrl)
+			vnetMock := mock_virtualnetworks.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), vnetMock.EXPECT())
+
+			s := &Service{
+				Scope:                 scopeMock,
+				Client:                clientMock,
+				virtualNetworksClient: vnetMock,
 			}
 
 			err := s.Delete(context.TODO())
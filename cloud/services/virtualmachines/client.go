@@ -23,15 +23,19 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
 // Client wraps go-sdk
 type Client interface {
 	Get(context.Context, string, string) (compute.VirtualMachine, error)
-	CreateOrUpdate(context.Context, string, string, compute.VirtualMachine) error
-	Delete(context.Context, string, string) error
+	CreateOrUpdateAsync(context.Context, string, string, compute.VirtualMachine) (*infrav1.Future, error)
+	UpdateAsync(context.Context, string, string, compute.VirtualMachineUpdate) (*infrav1.Future, error)
+	DeleteAsync(context.Context, string, string) (*infrav1.Future, error)
+	IsDone(context.Context, *infrav1.Future) (bool, error)
 }
 
 // AzureClient contains the Azure go-sdk Client
@@ -50,8 +54,7 @@ func NewClient(auth azure.Authorizer) *AzureClient {
 // newVirtualMachinesClient creates a new VM client from subscription ID.
 func newVirtualMachinesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachinesClient {
 	vmClient := compute.NewVirtualMachinesClientWithBaseURI(baseURI, subscriptionID)
-	vmClient.Authorizer = authorizer
-	vmClient.AddToUserAgent(azure.UserAgent())
+	azure.SetAutoRestClientDefaults(&vmClient.Client, authorizer)
 	return vmClient
 }
 
@@ -63,38 +66,56 @@ func (ac *AzureClient) Get(ctx context.Context, resourceGroupName, vmName string
 	return ac.virtualmachines.Get(ctx, resourceGroupName, vmName, "")
 }
 
-// CreateOrUpdate the operation to create or update a virtual machine.
-func (ac *AzureClient) CreateOrUpdate(ctx context.Context, resourceGroupName, vmName string, vm compute.VirtualMachine) error {
-	ctx, span := tele.Tracer().Start(ctx, "virtualmachines.AzureClient.CreateOrUpdate")
+// CreateOrUpdateAsync starts the operation to create or update a virtual machine and returns its future
+// without waiting for it to complete.
+func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, resourceGroupName, vmName string, vm compute.VirtualMachine) (*infrav1.Future, error) {
+	ctx, span := tele.Tracer().Start(ctx, "virtualmachines.AzureClient.CreateOrUpdateAsync")
 	defer span.End()
 
 	future, err := ac.virtualmachines.CreateOrUpdate(ctx, resourceGroupName, vmName, vm)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	err = future.WaitForCompletionRef(ctx, ac.virtualmachines.Client)
+	return converters.SDKToFuture(future.Future, infrav1.PutFuture, resourceGroupName, vmName)
+}
+
+// UpdateAsync starts the operation to update the mutable properties of a virtual machine and returns its future
+// without waiting for it to complete.
+func (ac *AzureClient) UpdateAsync(ctx context.Context, resourceGroupName, vmName string, vm compute.VirtualMachineUpdate) (*infrav1.Future, error) {
+	ctx, span := tele.Tracer().Start(ctx, "virtualmachines.AzureClient.UpdateAsync")
+	defer span.End()
+
+	future, err := ac.virtualmachines.Update(ctx, resourceGroupName, vmName, vm)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	_, err = future.Result(ac.virtualmachines)
-	return err
+	return converters.SDKToFuture(future.Future, infrav1.PatchFuture, resourceGroupName, vmName)
 }
 
-// Delete the operation to delete a virtual machine.
-func (ac *AzureClient) Delete(ctx context.Context, resourceGroupName, vmName string) error {
-	ctx, span := tele.Tracer().Start(ctx, "virtualmachines.AzureClient.Delete")
+// DeleteAsync starts the operation to delete a virtual machine and returns its future
+// without waiting for it to complete.
+func (ac *AzureClient) DeleteAsync(ctx context.Context, resourceGroupName, vmName string) (*infrav1.Future, error) {
+	ctx, span := tele.Tracer().Start(ctx, "virtualmachines.AzureClient.DeleteAsync")
 	defer span.End()
 
 	// TODO: pass variable to force the deletion or not
 	// now we are not forcing.
 	future, err := ac.virtualmachines.Delete(ctx, resourceGroupName, vmName, to.BoolPtr(false))
 	if err != nil {
-		return err
+		return nil, err
 	}
-	err = future.WaitForCompletionRef(ctx, ac.virtualmachines.Client)
+	return converters.SDKToFuture(future.Future, infrav1.DeleteFuture, resourceGroupName, vmName)
+}
+
+// IsDone polls a long-running operation once and returns true when it has completed.
+// It returns the error of the operation if it has failed.
+func (ac *AzureClient) IsDone(ctx context.Context, future *infrav1.Future) (bool, error) {
+	ctx, span := tele.Tracer().Start(ctx, "virtualmachines.AzureClient.IsDone")
+	defer span.End()
+
+	sdkFuture, err := converters.FutureToSDK(*future)
 	if err != nil {
-		return err
+		return false, err
 	}
-	_, err = future.Result(ac.virtualmachines)
-	return err
+	return sdkFuture.DoneWithContext(ctx, ac.virtualmachines)
 }
@@ -50,6 +50,9 @@ type VMScope interface {
 	SetProviderID(string)
 	SetAddresses([]corev1.NodeAddress)
 	SetVMState(infrav1.VMState)
+	AvailabilitySet() (string, bool)
+	GetLongRunningOperationState() *infrav1.Future
+	SetLongRunningOperationState(*infrav1.Future)
 }
 
 // Service provides operations on azure resources
@@ -78,7 +81,13 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	defer span.End()
 
 	vmSpec := s.Scope.VMSpec()
-	existingVM, err := s.getExisting(ctx, vmSpec.Name)
+	if future := s.Scope.GetLongRunningOperationState(); future != nil {
+		if err := s.checkOperation(ctx, future); err != nil {
+			return err
+		}
+	}
+
+	vm, existingVM, err := s.getExisting(ctx, vmSpec.Name)
 
 	switch {
 	// VM got deleted outside of capz
@@ -93,6 +102,10 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		s.Scope.SetAnnotation("cluster-api-provider-azure", "true")
 		s.Scope.SetAddresses(existingVM.Addresses)
 		s.Scope.SetVMState(existingVM.State)
+
+		if err := s.update(ctx, vmSpec, vm); err != nil {
+			return err
+		}
 	default:
 		s.Scope.V(2).Info("creating VM", "vm", vmSpec.Name)
 		sku, err := s.resourceSKUCache.Get(ctx, vmSpec.Size, resourceskus.VirtualMachines)
@@ -110,6 +123,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			return err
 		}
 
+		additionalCapabilities, err := s.getAdditionalCapabilities(ctx, vmSpec, sku)
+		if err != nil {
+			return err
+		}
+
 		nicRefs := make([]compute.NetworkInterfaceReference, len(vmSpec.NICNames))
 		for i, nicName := range vmSpec.NICNames {
 			primary := i == 0
@@ -143,14 +161,16 @@ func (s *Service) Reconcile(ctx context.Context) error {
 				Lifecycle:   infrav1.ResourceLifecycleOwned,
 				Name:        to.StringPtr(vmSpec.Name),
 				Role:        to.StringPtr(vmSpec.Role),
+				MachineName: to.StringPtr(vmSpec.Name),
 				Additional:  s.Scope.AdditionalTags(),
 			})),
 			VirtualMachineProperties: &compute.VirtualMachineProperties{
 				HardwareProfile: &compute.HardwareProfile{
 					VMSize: compute.VirtualMachineSizeTypes(vmSpec.Size),
 				},
-				StorageProfile:  storageProfile,
-				SecurityProfile: securityProfile,
+				StorageProfile:         storageProfile,
+				SecurityProfile:        securityProfile,
+				AdditionalCapabilities: additionalCapabilities,
 				OsProfile: &compute.OSProfile{
 					ComputerName:  to.StringPtr(vmSpec.Name),
 					AdminUsername: to.StringPtr(azure.DefaultUserName),
@@ -186,6 +206,24 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			virtualMachine.Zones = &zones
 		}
 
+		if availabilitySetName, ok := s.Scope.AvailabilitySet(); ok {
+			virtualMachine.AvailabilitySet = &compute.SubResource{
+				ID: to.StringPtr(azure.AvailabilitySetID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), availabilitySetName)),
+			}
+		}
+
+		if vmSpec.ProximityPlacementGroupName != "" {
+			virtualMachine.ProximityPlacementGroup = &compute.SubResource{
+				ID: to.StringPtr(azure.ProximityPlacementGroupID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), vmSpec.ProximityPlacementGroupName)),
+			}
+		}
+
+		if vmSpec.DedicatedHostGroupID != "" {
+			virtualMachine.HostGroup = &compute.SubResource{
+				ID: to.StringPtr(vmSpec.DedicatedHostGroupID),
+			}
+		}
+
 		if vmSpec.Identity == infrav1.VMIdentitySystemAssigned {
 			virtualMachine.Identity = &compute.VirtualMachineIdentity{
 				Type: compute.ResourceIdentityTypeSystemAssigned,
@@ -201,10 +239,17 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			}
 		}
 
-		if err := s.Client.CreateOrUpdate(ctx, s.Scope.ResourceGroup(), vmSpec.Name, virtualMachine); err != nil {
+		future, err := s.Client.CreateOrUpdateAsync(ctx, s.Scope.ResourceGroup(), vmSpec.Name, virtualMachine)
+		if err != nil {
 			return errors.Wrapf(err, "failed to create VM %s in resource group %s", vmSpec.Name, s.Scope.ResourceGroup())
 		}
 
+		// the creation is resumed on the next reconcile instead of blocking the worker until it completes.
+		s.Scope.SetLongRunningOperationState(future)
+		if err := s.checkOperation(ctx, future); err != nil {
+			return err
+		}
+
 		s.Scope.V(2).Info("successfully created VM", "vm", vmSpec.Name)
 	}
 
@@ -217,43 +262,232 @@ func (s *Service) Delete(ctx context.Context) error {
 	defer span.End()
 
 	vmSpec := s.Scope.VMSpec()
-	s.Scope.V(2).Info("deleting VM", "vm", vmSpec.Name)
-	err := s.Client.Delete(ctx, s.Scope.ResourceGroup(), vmSpec.Name)
-	if err != nil && azure.ResourceNotFound(err) {
-		// already deleted
+	future := s.Scope.GetLongRunningOperationState()
+	if future == nil || future.Type != infrav1.DeleteFuture {
+		s.Scope.V(2).Info("deleting VM", "vm", vmSpec.Name)
+		var err error
+		future, err = s.Client.DeleteAsync(ctx, s.Scope.ResourceGroup(), vmSpec.Name)
+		if err != nil && azure.ResourceNotFound(err) {
+			// already deleted
+			s.Scope.SetLongRunningOperationState(nil)
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete VM %s in resource group %s", vmSpec.Name, s.Scope.ResourceGroup())
+		}
+		s.Scope.SetLongRunningOperationState(future)
+	}
+
+	if err := s.checkOperation(ctx, future); err != nil {
+		return err
+	}
+
+	s.Scope.V(2).Info("successfully deleted VM", "vm", vmSpec.Name)
+	return nil
+}
+
+// checkOperation polls the in-progress long-running operation of the VM. It returns an OperationNotDoneError
+// while the operation is running, and clears the operation state once it has completed or failed.
+func (s *Service) checkOperation(ctx context.Context, future *infrav1.Future) error {
+	done, err := s.Client.IsDone(ctx, future)
+	if err != nil {
+		s.Scope.SetLongRunningOperationState(nil)
+		return errors.Wrapf(err, "failed operation type %s on VM %s in resource group %s", future.Type, future.Name, future.ResourceGroup)
+	}
+	if !done {
+		return azure.NewOperationNotDoneError(future)
+	}
+	s.Scope.SetLongRunningOperationState(nil)
+	return nil
+}
+
+// update applies the difference between the VM spec and the mutable properties of an existing virtual machine.
+func (s *Service) update(ctx context.Context, vmSpec azure.VMSpec, vm compute.VirtualMachine) error {
+	ctx, span := tele.Tracer().Start(ctx, "virtualmachines.Service.update")
+	defer span.End()
+
+	if vm.VirtualMachineProperties == nil || infrav1.VMState(to.String(vm.ProvisioningState)) != infrav1.VMStateSucceeded {
+		// wait for any in-flight operation to finish before updating the VM.
+		return nil
+	}
+
+	vmUpdate, changed, err := s.getVMUpdate(vmSpec, vm)
+	if err != nil {
+		return errors.Wrapf(err, "failed to compute update for VM %s", vmSpec.Name)
+	}
+	if !changed {
 		return nil
 	}
+
+	s.Scope.V(2).Info("updating VM", "vm", vmSpec.Name)
+	future, err := s.Client.UpdateAsync(ctx, s.Scope.ResourceGroup(), vmSpec.Name, vmUpdate)
 	if err != nil {
-		return errors.Wrapf(err, "failed to delete VM %s in resource group %s", vmSpec.Name, s.Scope.ResourceGroup())
+		return errors.Wrapf(err, "failed to update VM %s in resource group %s", vmSpec.Name, s.Scope.ResourceGroup())
 	}
 
-	s.Scope.V(2).Info("successfully deleted VM", "vm", vmSpec.Name)
+	// like the creation, the update is resumed on the next reconcile instead of blocking the worker.
+	s.Scope.SetLongRunningOperationState(future)
+	if err := s.checkOperation(ctx, future); err != nil {
+		return err
+	}
+	s.Scope.V(2).Info("successfully updated VM", "vm", vmSpec.Name)
 	return nil
 }
 
+// getVMUpdate returns the update for the mutable properties of an existing virtual machine that differ from the VM spec:
+// size, data disk additions, identity and boot diagnostics. It returns false when the VM is up to date.
+func (s *Service) getVMUpdate(vmSpec azure.VMSpec, vm compute.VirtualMachine) (compute.VirtualMachineUpdate, bool, error) {
+	vmUpdate := compute.VirtualMachineUpdate{
+		VirtualMachineProperties: &compute.VirtualMachineProperties{},
+	}
+	changed := false
+
+	// Spot VMs cannot be resized in place.
+	if vm.HardwareProfile != nil && vmSpec.SpotVMOptions == nil && !strings.EqualFold(string(vm.HardwareProfile.VMSize), vmSpec.Size) {
+		vmUpdate.HardwareProfile = &compute.HardwareProfile{
+			VMSize: compute.VirtualMachineSizeTypes(vmSpec.Size),
+		}
+		changed = true
+	}
+
+	if dataDisks, ok := s.getDataDisksUpdate(vmSpec, vm.StorageProfile); ok {
+		vmUpdate.StorageProfile = &compute.StorageProfile{
+			DataDisks: &dataDisks,
+		}
+		changed = true
+	}
+
+	identity, ok, err := getIdentityUpdate(vmSpec, vm.Identity)
+	if err != nil {
+		return vmUpdate, false, err
+	}
+	if ok {
+		vmUpdate.Identity = identity
+		changed = true
+	}
+
+	if vm.DiagnosticsProfile == nil || vm.DiagnosticsProfile.BootDiagnostics == nil || !to.Bool(vm.DiagnosticsProfile.BootDiagnostics.Enabled) {
+		vmUpdate.DiagnosticsProfile = &compute.DiagnosticsProfile{
+			BootDiagnostics: &compute.BootDiagnostics{
+				Enabled: to.BoolPtr(true),
+			},
+		}
+		changed = true
+	}
+
+	return vmUpdate, changed, nil
+}
+
+// getDataDisksUpdate returns the existing data disks followed by the data disks of the spec whose LUN is not in use yet.
+// Existing data disks are never detached.
+func (s *Service) getDataDisksUpdate(vmSpec azure.VMSpec, storageProfile *compute.StorageProfile) ([]compute.DataDisk, bool) {
+	dataDisks := []compute.DataDisk{}
+	luns := make(map[int32]struct{})
+	if storageProfile != nil && storageProfile.DataDisks != nil {
+		for _, disk := range *storageProfile.DataDisks {
+			dataDisks = append(dataDisks, disk)
+			luns[to.Int32(disk.Lun)] = struct{}{}
+		}
+	}
+
+	changed := false
+	for _, disk := range vmSpec.DataDisks {
+		if _, ok := luns[to.Int32(disk.Lun)]; ok {
+			continue
+		}
+		dataDisks = append(dataDisks, s.generateDataDisk(vmSpec.Name, disk))
+		changed = true
+	}
+	return dataDisks, changed
+}
+
+// getIdentityUpdate returns the identity of the VM spec if it differs from the existing identity.
+// User-assigned identities that are no longer in the spec are removed.
+func getIdentityUpdate(vmSpec azure.VMSpec, existing *compute.VirtualMachineIdentity) (*compute.VirtualMachineIdentity, bool, error) {
+	existingType := compute.ResourceIdentityTypeNone
+	if existing != nil && existing.Type != "" {
+		existingType = existing.Type
+	}
+
+	switch vmSpec.Identity {
+	case infrav1.VMIdentitySystemAssigned:
+		if existingType == compute.ResourceIdentityTypeSystemAssigned {
+			return nil, false, nil
+		}
+		return &compute.VirtualMachineIdentity{
+			Type: compute.ResourceIdentityTypeSystemAssigned,
+		}, true, nil
+	case infrav1.VMIdentityUserAssigned:
+		userIdentitiesMap, err := converters.UserAssignedIdentitiesToVMSDK(vmSpec.UserAssignedIdentities)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "failed to assign identity %q", vmSpec.Name)
+		}
+		changed := existingType != compute.ResourceIdentityTypeUserAssigned
+		desired := make(map[string]struct{}, len(userIdentitiesMap))
+		for id := range userIdentitiesMap {
+			desired[userAssignedIdentityKey(id)] = struct{}{}
+		}
+		current := make(map[string]struct{})
+		if existing != nil {
+			for id := range existing.UserAssignedIdentities {
+				current[userAssignedIdentityKey(id)] = struct{}{}
+				if _, ok := desired[userAssignedIdentityKey(id)]; !ok {
+					// a nil value removes the identity from the VM.
+					userIdentitiesMap[id] = nil
+					changed = true
+				}
+			}
+		}
+		for id := range desired {
+			if _, ok := current[id]; !ok {
+				changed = true
+			}
+		}
+		if !changed {
+			return nil, false, nil
+		}
+		return &compute.VirtualMachineIdentity{
+			Type:                   compute.ResourceIdentityTypeUserAssigned,
+			UserAssignedIdentities: userIdentitiesMap,
+		}, true, nil
+	default:
+		if existingType == compute.ResourceIdentityTypeNone {
+			return nil, false, nil
+		}
+		return &compute.VirtualMachineIdentity{
+			Type: compute.ResourceIdentityTypeNone,
+		}, true, nil
+	}
+}
+
+// userAssignedIdentityKey normalizes a user-assigned identity resource ID for comparison.
+func userAssignedIdentityKey(id string) string {
+	return strings.ToLower(strings.TrimPrefix(id, "/"))
+}
+
 // getExisting provides information about a virtual machine.
-func (s *Service) getExisting(ctx context.Context, name string) (*infrav1.VM, error) {
+func (s *Service) getExisting(ctx context.Context, name string) (compute.VirtualMachine, *infrav1.VM, error) {
 	ctx, span := tele.Tracer().Start(ctx, "virtualmachines.Service.getExisting")
 	defer span.End()
 
 	vm, err := s.Client.Get(ctx, s.Scope.ResourceGroup(), name)
 	if err != nil {
-		return nil, err
+		return vm, nil, err
 	}
 
 	convertedVM, err := converters.SDKToVM(vm)
 	if err != nil {
-		return convertedVM, err
+		return vm, convertedVM, err
 	}
 
 	// Discover addresses for NICs associated with the VM
 	// and add them to our converted vm struct
 	addresses, err := s.getAddresses(ctx, vm)
 	if err != nil {
-		return convertedVM, err
+		return vm, convertedVM, err
 	}
 	convertedVM.Addresses = addresses
-	return convertedVM, nil
+	return vm, convertedVM, nil
 }
 
 func (s *Service) generateImagePlan() *compute.Plan {
@@ -399,13 +633,7 @@ func (s *Service) generateStorageProfile(ctx context.Context, vmSpec azure.VMSpe
 
 	dataDisks := []compute.DataDisk{}
 	for _, disk := range vmSpec.DataDisks {
-		dataDisks = append(dataDisks, compute.DataDisk{
-			CreateOption: compute.DiskCreateOptionTypesEmpty,
-			DiskSizeGB:   to.Int32Ptr(disk.DiskSizeGB),
-			Lun:          disk.Lun,
-			Name:         to.StringPtr(azure.GenerateDataDiskName(vmSpec.Name, disk.NameSuffix)),
-			Caching:      compute.CachingTypes(disk.CachingType),
-		})
+		dataDisks = append(dataDisks, s.generateDataDisk(vmSpec.Name, disk))
 	}
 	storageProfile.DataDisks = &dataDisks
 
@@ -424,6 +652,37 @@ func (s *Service) generateStorageProfile(ctx context.Context, vmSpec azure.VMSpe
 	return storageProfile, nil
 }
 
+// generateDataDisk generates a compute.DataDisk which either attaches an existing managed disk by name
+// or creates a new empty managed disk.
+func (s *Service) generateDataDisk(vmName string, disk infrav1.DataDisk) compute.DataDisk {
+	dataDisk := compute.DataDisk{
+		Lun:                     disk.Lun,
+		Name:                    to.StringPtr(azure.DataDiskName(vmName, disk)),
+		Caching:                 compute.CachingTypes(disk.CachingType),
+		WriteAcceleratorEnabled: disk.WriteAcceleratorEnabled,
+	}
+
+	if disk.DiskName != "" {
+		dataDisk.CreateOption = compute.DiskCreateOptionTypesAttach
+		dataDisk.ManagedDisk = &compute.ManagedDiskParameters{
+			ID: to.StringPtr(azure.DiskID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), disk.DiskName)),
+		}
+		return dataDisk
+	}
+
+	dataDisk.CreateOption = compute.DiskCreateOptionTypesEmpty
+	dataDisk.DiskSizeGB = to.Int32Ptr(disk.DiskSizeGB)
+	if disk.ManagedDisk != nil {
+		dataDisk.ManagedDisk = &compute.ManagedDiskParameters{
+			StorageAccountType: compute.StorageAccountTypes(disk.ManagedDisk.StorageAccountType),
+		}
+		if disk.ManagedDisk.DiskEncryptionSet != nil {
+			dataDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(disk.ManagedDisk.DiskEncryptionSet.ID)}
+		}
+	}
+	return dataDisk
+}
+
 // getResourceNameById takes a resource ID like
 // `/subscriptions/$SUB/resourceGroups/$RG/providers/Microsoft.Network/networkInterfaces/$NICNAME`
 // and parses out the string after the last slash.
@@ -433,6 +692,41 @@ func getResourceNameByID(resourceID string) string {
 	return resourceName
 }
 
+// getAdditionalCapabilities enables ultra disks on VMs with ultra data disks, once checked that the VM size supports
+// them in the zone of the VM.
+func (s *Service) getAdditionalCapabilities(ctx context.Context, vmSpec azure.VMSpec, sku resourceskus.SKU) (*compute.AdditionalCapabilities, error) {
+	additionalCapabilities := converters.GetAdditionalCapabilities(vmSpec.DataDisks)
+	if additionalCapabilities == nil {
+		return nil, nil
+	}
+
+	if vmSpec.Zone == "" {
+		if !sku.HasCapability(resourceskus.UltraSSDAvailable) {
+			return nil, azure.WithTerminalError(errors.Errorf("ultra disks are not supported for VM type %s outside of availability zones", vmSpec.Size))
+		}
+		return additionalCapabilities, nil
+	}
+
+	zones, err := s.resourceSKUCache.GetZonesWithVMSize(ctx, vmSpec.Size, s.Scope.Location())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get zones for VM type %s", vmSpec.Size)
+	}
+	available := false
+	for _, zone := range zones {
+		if zone == vmSpec.Zone {
+			available = true
+			break
+		}
+	}
+	if !available {
+		return nil, azure.WithTerminalError(errors.Errorf("VM type %s is not available in zone %s", vmSpec.Size, vmSpec.Zone))
+	}
+	if !sku.HasZonalCapability(resourceskus.UltraSSDAvailable, s.Scope.Location(), vmSpec.Zone) {
+		return nil, azure.WithTerminalError(errors.Errorf("ultra disks are not supported for VM type %s in zone %s", vmSpec.Size, vmSpec.Zone))
+	}
+	return additionalCapabilities, nil
+}
+
 func getSecurityProfile(vmSpec azure.VMSpec, sku resourceskus.SKU) (*compute.SecurityProfile, error) {
 	if vmSpec.SecurityProfile == nil {
 		return nil, nil
@@ -262,7 +262,7 @@ func TestGetExistingVM(t *testing.T) {
 				publicIPsClient:  publicIPMock,
 			}
 
-			result, err := s.getExisting(context.TODO(), tc.vmName)
+			_, result, err := s.getExisting(context.TODO(), tc.vmName)
 			if tc.expectedError != "" {
 				g.Expect(err).To(HaveOccurred())
 				g.Expect(err).To(MatchError(tc.expectedError))
@@ -314,6 +314,7 @@ func TestReconcileVM(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.AdditionalTags()
 				s.Location().Return("test-location")
+				s.AvailabilitySet().Return("", false)
 				s.ClusterName().Return("my-cluster")
 				s.ProviderID().Return("")
 				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").
@@ -327,7 +328,7 @@ func TestReconcileVM(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vm", gomockinternal.DiffEq(compute.VirtualMachine{
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomockinternal.DiffEq(compute.VirtualMachine{
 					VirtualMachineProperties: &compute.VirtualMachineProperties{
 						HardwareProfile: &compute.HardwareProfile{VMSize: "Standard_D2v3"},
 						StorageProfile: &compute.StorageProfile{
@@ -399,6 +400,7 @@ func TestReconcileVM(t *testing.T) {
 					Tags: map[string]*string{
 						"Name": to.StringPtr("my-vm"),
 						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_machine":            to.StringPtr("my-vm"),
 						"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("control-plane"),
 					},
 				}))
@@ -456,6 +458,7 @@ func TestReconcileVM(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.AdditionalTags()
 				s.Location().Return("test-location")
+				s.AvailabilitySet().Return("", false)
 				s.ClusterName().Return("my-cluster")
 				s.ProviderID().Return("")
 				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").
@@ -469,7 +472,7 @@ func TestReconcileVM(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Do(func(_, _, _ interface{}, vm compute.VirtualMachine) {
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Do(func(_, _, _ interface{}, vm compute.VirtualMachine) {
 					g.Expect(vm.Identity.Type).To(Equal(compute.ResourceIdentityTypeSystemAssigned))
 					g.Expect(vm.Identity.UserAssignedIdentities).To(HaveLen(0))
 				})
@@ -506,6 +509,74 @@ func TestReconcileVM(t *testing.T) {
 
 			},
 		},
+		{
+			Name: "can create a vm in an availability set",
+			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
+				s.VMSpec().Return(azure.VMSpec{
+					Name:       "my-vm",
+					Role:       infrav1.Node,
+					NICNames:   []string{"my-nic"},
+					SSHKeyData: "fakesshpublickey",
+					Size:       "Standard_D2v3",
+					Identity:   infrav1.VMIdentityNone,
+					OSDisk:     infrav1.OSDisk{},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AdditionalTags()
+				s.Location().Return("test-location")
+				s.AvailabilitySet().Return("my-cluster_my-md-as", true)
+				s.ClusterName().Return("my-cluster")
+				s.ProviderID().Return("")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").
+					Return(compute.VirtualMachine{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				s.GetVMImage().AnyTimes().Return(&infrav1.Image{
+					Marketplace: &infrav1.AzureMarketplaceImage{
+						Publisher: "fake-publisher",
+						Offer:     "my-offer",
+						SKU:       "sku-id",
+						Version:   "1.0",
+					},
+				}, nil)
+				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Do(func(_, _, _ interface{}, vm compute.VirtualMachine) {
+					g.Expect(vm.Zones).To(BeNil())
+					g.Expect(vm.AvailabilitySet).To(Equal(&compute.SubResource{
+						ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/availabilitySets/my-cluster_my-md-as"),
+					}))
+				})
+			},
+			ExpectedError: "",
+			SetupSKUs: func(svc *Service) {
+				skus := []compute.ResourceSku{
+					{
+						Name: to.StringPtr("Standard_D2v3"),
+						Kind: to.StringPtr(string(resourceskus.VirtualMachines)),
+						Locations: &[]string{
+							"test-location",
+						},
+						LocationInfo: &[]compute.ResourceSkuLocationInfo{
+							{
+								Location: to.StringPtr("test-location"),
+							},
+						},
+						Capabilities: &[]compute.ResourceSkuCapabilities{
+							{
+								Name:  to.StringPtr(resourceskus.VCPUs),
+								Value: to.StringPtr("2"),
+							},
+							{
+								Name:  to.StringPtr(resourceskus.MemoryGB),
+								Value: to.StringPtr("4"),
+							},
+						},
+					},
+				}
+				resourceSkusCache := resourceskus.NewStaticCache(skus)
+				svc.resourceSKUCache = resourceSkusCache
+			},
+		},
 		{
 			Name: "can create a vm with user assigned identity",
 			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
@@ -527,6 +598,7 @@ func TestReconcileVM(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.AdditionalTags()
 				s.Location().Return("test-location")
+				s.AvailabilitySet().Return("", false)
 				s.ClusterName().Return("my-cluster")
 				s.ProviderID().Return("")
 				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").
@@ -540,7 +612,7 @@ func TestReconcileVM(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Do(func(_, _, _ interface{}, vm compute.VirtualMachine) {
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Do(func(_, _, _ interface{}, vm compute.VirtualMachine) {
 					g.Expect(vm.Identity.Type).To(Equal(compute.ResourceIdentityTypeUserAssigned))
 					g.Expect(vm.Identity.UserAssignedIdentities).To(Equal(map[string]*compute.VirtualMachineIdentityUserAssignedIdentitiesValue{"my-user-id": {}}))
 				})
@@ -598,6 +670,7 @@ func TestReconcileVM(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.AdditionalTags()
 				s.Location().Return("test-location")
+				s.AvailabilitySet().Return("", false)
 				s.ClusterName().Return("my-cluster")
 				s.ProviderID().Return("")
 				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").
@@ -611,7 +684,7 @@ func TestReconcileVM(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Do(func(_, _, _ interface{}, vm compute.VirtualMachine) {
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Do(func(_, _, _ interface{}, vm compute.VirtualMachine) {
 					g.Expect(vm.Priority).To(Equal(compute.Spot))
 					g.Expect(vm.EvictionPolicy).To(Equal(compute.Deallocate))
 					g.Expect(vm.BillingProfile).To(BeNil())
@@ -676,6 +749,7 @@ func TestReconcileVM(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.AdditionalTags()
 				s.Location().Return("test-location")
+				s.AvailabilitySet().Return("", false)
 				s.ClusterName().Return("my-cluster")
 				s.ProviderID().Return("")
 				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").
@@ -689,7 +763,7 @@ func TestReconcileVM(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Do(func(_, _, _ interface{}, vm compute.VirtualMachine) {
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Do(func(_, _, _ interface{}, vm compute.VirtualMachine) {
 					g.Expect(vm.VirtualMachineProperties.StorageProfile.OsDisk.ManagedDisk.DiskEncryptionSet.ID).To(Equal(to.StringPtr("my-diskencryptionset-id")))
 
 				})
@@ -743,6 +817,7 @@ func TestReconcileVM(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.AdditionalTags()
 				s.Location().Return("test-location")
+				s.AvailabilitySet().Return("", false)
 				s.ClusterName().Return("my-cluster")
 				s.ProviderID().Return("")
 				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").
@@ -756,7 +831,7 @@ func TestReconcileVM(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Do(func(_, _, _ interface{}, vm compute.VirtualMachine) {
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Do(func(_, _, _ interface{}, vm compute.VirtualMachine) {
 					g.Expect(*vm.VirtualMachineProperties.SecurityProfile.EncryptionAtHost).To(Equal(true))
 
 				})
@@ -797,6 +872,80 @@ func TestReconcileVM(t *testing.T) {
 
 			},
 		},
+		{
+			Name: "can create a vm in a proximity placement group on dedicated hosts",
+			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
+				s.VMSpec().Return(azure.VMSpec{
+					Name:                        "my-vm",
+					Role:                        infrav1.Node,
+					NICNames:                    []string{"my-nic"},
+					SSHKeyData:                  "fakesshpublickey",
+					Size:                        "Standard_D2v3",
+					OSDisk:                      infrav1.OSDisk{},
+					ProximityPlacementGroupName: "my-cluster-ppg",
+					DedicatedHostGroupID:        "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group",
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AdditionalTags()
+				s.Location().Return("test-location")
+				s.AvailabilitySet().Return("", false)
+				s.ClusterName().Return("my-cluster")
+				s.ProviderID().Return("")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").
+					Return(compute.VirtualMachine{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				s.GetVMImage().AnyTimes().Return(&infrav1.Image{
+					Marketplace: &infrav1.AzureMarketplaceImage{
+						Publisher: "fake-publisher",
+						Offer:     "my-offer",
+						SKU:       "sku-id",
+						Version:   "1.0",
+					},
+				}, nil)
+				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Do(func(_, _, _ interface{}, vm compute.VirtualMachine) {
+					g.Expect(vm.VirtualMachineProperties.ProximityPlacementGroup).To(Equal(&compute.SubResource{
+						ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-cluster-ppg"),
+					}))
+					g.Expect(vm.VirtualMachineProperties.HostGroup).To(Equal(&compute.SubResource{
+						ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"),
+					}))
+
+				})
+			},
+			ExpectedError: "",
+			SetupSKUs: func(svc *Service) {
+				skus := []compute.ResourceSku{
+					{
+						Name: to.StringPtr("Standard_D2v3"),
+						Kind: to.StringPtr(string(resourceskus.VirtualMachines)),
+						Locations: &[]string{
+							"test-location",
+						},
+						LocationInfo: &[]compute.ResourceSkuLocationInfo{
+							{
+								Location: to.StringPtr("test-location"),
+								Zones:    &[]string{"1"},
+							},
+						},
+						Capabilities: &[]compute.ResourceSkuCapabilities{
+							{
+								Name:  to.StringPtr(resourceskus.VCPUs),
+								Value: to.StringPtr("2"),
+							},
+							{
+								Name:  to.StringPtr(resourceskus.MemoryGB),
+								Value: to.StringPtr("4"),
+							},
+						},
+					},
+				}
+
+				svc.resourceSKUCache = resourceskus.NewStaticCache(skus)
+
+			},
+		},
 		{
 			Name: "creating a vm with encryption at host enabled for unsupported VM type fails",
 			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
@@ -854,6 +1003,168 @@ func TestReconcileVM(t *testing.T) {
 				svc.resourceSKUCache = resourceskus.NewStaticCache(skus)
 			},
 		},
+		{
+			Name: "can create a vm with ultra disks",
+			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
+				s.VMSpec().Return(azure.VMSpec{
+					Name:       "my-vm",
+					Role:       infrav1.Node,
+					NICNames:   []string{"my-nic"},
+					SSHKeyData: "fakesshpublickey",
+					Size:       "Standard_D2v3",
+					Zone:       "1",
+					OSDisk:     infrav1.OSDisk{},
+					DataDisks: []infrav1.DataDisk{
+						{
+							NameSuffix:        "mydisk",
+							DiskSizeGB:        64,
+							Lun:               to.Int32Ptr(0),
+							CachingType:       "None",
+							DiskIOPSReadWrite: to.Int64Ptr(4000),
+							ManagedDisk: &infrav1.ManagedDisk{
+								StorageAccountType: "UltraSSD_LRS",
+							},
+						},
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.AdditionalTags()
+				s.Location().AnyTimes().Return("test-location")
+				s.AvailabilitySet().Return("", false)
+				s.ClusterName().Return("my-cluster")
+				s.ProviderID().Return("")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").
+					Return(compute.VirtualMachine{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				s.GetVMImage().AnyTimes().Return(&infrav1.Image{
+					Marketplace: &infrav1.AzureMarketplaceImage{
+						Publisher: "fake-publisher",
+						Offer:     "my-offer",
+						SKU:       "sku-id",
+						Version:   "1.0",
+					},
+				}, nil)
+				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Do(func(_, _, _ interface{}, vm compute.VirtualMachine) {
+					g.Expect(*vm.VirtualMachineProperties.AdditionalCapabilities.UltraSSDEnabled).To(Equal(true))
+					g.Expect((*vm.VirtualMachineProperties.StorageProfile.DataDisks)[0].ManagedDisk.StorageAccountType).To(Equal(compute.StorageAccountTypesUltraSSDLRS))
+				})
+			},
+			ExpectedError: "",
+			SetupSKUs: func(svc *Service) {
+				skus := []compute.ResourceSku{
+					{
+						Name:         to.StringPtr("Standard_D2v3"),
+						Kind:         to.StringPtr(string(resourceskus.VirtualMachines)),
+						ResourceType: to.StringPtr(string(resourceskus.VirtualMachines)),
+						Locations: &[]string{
+							"test-location",
+						},
+						LocationInfo: &[]compute.ResourceSkuLocationInfo{
+							{
+								Location: to.StringPtr("test-location"),
+								Zones:    &[]string{"1"},
+								ZoneDetails: &[]compute.ResourceSkuZoneDetails{
+									{
+										Name: &[]string{"1"},
+										Capabilities: &[]compute.ResourceSkuCapabilities{
+											{
+												Name:  to.StringPtr(resourceskus.UltraSSDAvailable),
+												Value: to.StringPtr(string(resourceskus.CapabilitySupported)),
+											},
+										},
+									},
+								},
+							},
+						},
+						Capabilities: &[]compute.ResourceSkuCapabilities{
+							{
+								Name:  to.StringPtr(resourceskus.VCPUs),
+								Value: to.StringPtr("2"),
+							},
+							{
+								Name:  to.StringPtr(resourceskus.MemoryGB),
+								Value: to.StringPtr("4"),
+							},
+						},
+					},
+				}
+
+				svc.resourceSKUCache = resourceskus.NewStaticCache(skus)
+			},
+		},
+		{
+			Name: "creating a vm with ultra disks in a zone without ultra disk support fails",
+			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
+				s.VMSpec().Return(azure.VMSpec{
+					Name:       "my-vm",
+					Role:       infrav1.Node,
+					NICNames:   []string{"my-nic"},
+					SSHKeyData: "fakesshpublickey",
+					Size:       "Standard_D2v3",
+					Zone:       "1",
+					OSDisk:     infrav1.OSDisk{},
+					DataDisks: []infrav1.DataDisk{
+						{
+							NameSuffix:        "mydisk",
+							DiskSizeGB:        64,
+							Lun:               to.Int32Ptr(0),
+							CachingType:       "None",
+							DiskIOPSReadWrite: to.Int64Ptr(4000),
+							ManagedDisk: &infrav1.ManagedDisk{
+								StorageAccountType: "UltraSSD_LRS",
+							},
+						},
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.Location().AnyTimes().Return("test-location")
+				s.GetVMImage().AnyTimes().Return(&infrav1.Image{
+					Marketplace: &infrav1.AzureMarketplaceImage{
+						Publisher: "fake-publisher",
+						Offer:     "my-offer",
+						SKU:       "sku-id",
+						Version:   "1.0",
+					},
+				}, nil)
+				s.ProviderID().Return("")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").
+					Return(compute.VirtualMachine{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+			ExpectedError: "reconcile error occurred that cannot be recovered. Object will not be requeued. The actual error is: ultra disks are not supported for VM type Standard_D2v3 in zone 1",
+			SetupSKUs: func(svc *Service) {
+				skus := []compute.ResourceSku{
+					{
+						Name:         to.StringPtr("Standard_D2v3"),
+						Kind:         to.StringPtr(string(resourceskus.VirtualMachines)),
+						ResourceType: to.StringPtr(string(resourceskus.VirtualMachines)),
+						Locations: &[]string{
+							"test-location",
+						},
+						LocationInfo: &[]compute.ResourceSkuLocationInfo{
+							{
+								Location: to.StringPtr("test-location"),
+								Zones:    &[]string{"1"},
+							},
+						},
+						Capabilities: &[]compute.ResourceSkuCapabilities{
+							{
+								Name:  to.StringPtr(resourceskus.VCPUs),
+								Value: to.StringPtr("2"),
+							},
+							{
+								Name:  to.StringPtr(resourceskus.MemoryGB),
+								Value: to.StringPtr("4"),
+							},
+						},
+					},
+				}
+
+				svc.resourceSKUCache = resourceskus.NewStaticCache(skus)
+			},
+		},
 		{
 			Name: "vm creation fails",
 			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
@@ -875,6 +1186,7 @@ func TestReconcileVM(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.AdditionalTags()
 				s.Location().Return("test-location")
+				s.AvailabilitySet().Return("", false)
 				s.ClusterName().Return("my-cluster")
 				s.ProviderID().Return("")
 				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").
@@ -888,7 +1200,7 @@ func TestReconcileVM(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachine{})).Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
 			},
 			ExpectedError: "failed to create VM my-vm in resource group my-rg: #: Internal Server Error: StatusCode=500",
 			SetupSKUs: func(svc *Service) {
@@ -1166,6 +1478,7 @@ func TestReconcileVM(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.AdditionalTags()
 				s.Location().Return("test-location")
+				s.AvailabilitySet().Return("", false)
 				s.ClusterName().Return("my-cluster")
 				s.ProviderID().Return("")
 				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").
@@ -1179,7 +1492,7 @@ func TestReconcileVM(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vm", gomockinternal.DiffEq(compute.VirtualMachine{
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomockinternal.DiffEq(compute.VirtualMachine{
 					VirtualMachineProperties: &compute.VirtualMachineProperties{
 						HardwareProfile: &compute.HardwareProfile{VMSize: "Standard_D2v3"},
 						StorageProfile: &compute.StorageProfile{
@@ -1254,6 +1567,7 @@ func TestReconcileVM(t *testing.T) {
 					Tags: map[string]*string{
 						"Name": to.StringPtr("my-vm"),
 						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_machine":            to.StringPtr("my-vm"),
 						"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("control-plane"),
 					},
 				}))
@@ -1327,6 +1641,7 @@ func TestReconcileVM(t *testing.T) {
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
 				s.AdditionalTags()
 				s.Location().Return("test-location")
+				s.AvailabilitySet().Return("", false)
 				s.ClusterName().Return("my-cluster")
 				s.ProviderID().Return("")
 				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").
@@ -1341,7 +1656,7 @@ func TestReconcileVM(t *testing.T) {
 					},
 				}, nil)
 				s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vm", gomockinternal.DiffEq(compute.VirtualMachine{
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomockinternal.DiffEq(compute.VirtualMachine{
 					Plan: &compute.Plan{
 						Name:      to.StringPtr("sku-id"),
 						Publisher: to.StringPtr("fake-publisher"),
@@ -1418,6 +1733,7 @@ func TestReconcileVM(t *testing.T) {
 					Tags: map[string]*string{
 						"Name": to.StringPtr("my-vm"),
 						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_machine":            to.StringPtr("my-vm"),
 						"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("control-plane"),
 					},
 				}))
@@ -1453,6 +1769,324 @@ func TestReconcileVM(t *testing.T) {
 				svc.resourceSKUCache = resourceSkusCache
 			},
 		},
+		{
+			Name: "updates mutable properties of an existing vm",
+			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
+				s.VMSpec().Return(azure.VMSpec{
+					Name:     "my-vm",
+					Size:     "Standard_D4v3",
+					Identity: infrav1.VMIdentityUserAssigned,
+					UserAssignedIdentities: []infrav1.UserAssignedIdentity{
+						{ProviderID: "azure:////subscriptions/123/resourcegroups/my-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id-2"},
+					},
+					DataDisks: []infrav1.DataDisk{
+						{
+							NameSuffix:  "mydisk",
+							DiskSizeGB:  64,
+							Lun:         to.Int32Ptr(0),
+							CachingType: "ReadWrite",
+						},
+						{
+							NameSuffix:  "newdisk",
+							DiskSizeGB:  128,
+							Lun:         to.Int32Ptr(1),
+							CachingType: "None",
+						},
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").Return(compute.VirtualMachine{
+					ID:   to.StringPtr("my-id"),
+					Name: to.StringPtr("my-vm"),
+					Identity: &compute.VirtualMachineIdentity{
+						Type: compute.ResourceIdentityTypeUserAssigned,
+						UserAssignedIdentities: map[string]*compute.VirtualMachineIdentityUserAssignedIdentitiesValue{
+							"/subscriptions/123/resourcegroups/my-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id-1": {},
+						},
+					},
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						ProvisioningState: to.StringPtr("Succeeded"),
+						HardwareProfile: &compute.HardwareProfile{
+							VMSize: "Standard_D2v3",
+						},
+						StorageProfile: &compute.StorageProfile{
+							DataDisks: &[]compute.DataDisk{
+								{
+									Lun:  to.Int32Ptr(0),
+									Name: to.StringPtr("my-vm_mydisk"),
+								},
+							},
+						},
+						NetworkProfile: &compute.NetworkProfile{},
+					},
+				}, nil)
+				s.SetProviderID("azure:///my-id")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				s.SetAddresses(gomock.Any())
+				s.SetVMState(infrav1.VMStateSucceeded)
+				m.UpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomockinternal.DiffEq(compute.VirtualMachineUpdate{
+					Identity: &compute.VirtualMachineIdentity{
+						Type: compute.ResourceIdentityTypeUserAssigned,
+						UserAssignedIdentities: map[string]*compute.VirtualMachineIdentityUserAssignedIdentitiesValue{
+							"/subscriptions/123/resourcegroups/my-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id-1": nil,
+							"/subscriptions/123/resourcegroups/my-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id-2": {},
+						},
+					},
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						HardwareProfile: &compute.HardwareProfile{
+							VMSize: "Standard_D4v3",
+						},
+						StorageProfile: &compute.StorageProfile{
+							DataDisks: &[]compute.DataDisk{
+								{
+									Lun:  to.Int32Ptr(0),
+									Name: to.StringPtr("my-vm_mydisk"),
+								},
+								{
+									CreateOption: compute.DiskCreateOptionTypesEmpty,
+									DiskSizeGB:   to.Int32Ptr(128),
+									Lun:          to.Int32Ptr(1),
+									Name:         to.StringPtr("my-vm_newdisk"),
+									Caching:      compute.CachingTypesNone,
+								},
+							},
+						},
+						DiagnosticsProfile: &compute.DiagnosticsProfile{
+							BootDiagnostics: &compute.BootDiagnostics{
+								Enabled: to.BoolPtr(true),
+							},
+						},
+					},
+				}))
+			},
+			ExpectedError: "",
+			SetupSKUs:     func(svc *Service) {},
+		},
+		{
+			Name: "does not update an existing vm that is up to date",
+			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
+				s.VMSpec().Return(azure.VMSpec{
+					Name:     "my-vm",
+					Size:     "Standard_D2v3",
+					Identity: infrav1.VMIdentitySystemAssigned,
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").Return(compute.VirtualMachine{
+					ID:   to.StringPtr("my-id"),
+					Name: to.StringPtr("my-vm"),
+					Identity: &compute.VirtualMachineIdentity{
+						Type: compute.ResourceIdentityTypeSystemAssigned,
+					},
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						ProvisioningState: to.StringPtr("Succeeded"),
+						HardwareProfile: &compute.HardwareProfile{
+							VMSize: "standard_d2v3",
+						},
+						DiagnosticsProfile: &compute.DiagnosticsProfile{
+							BootDiagnostics: &compute.BootDiagnostics{
+								Enabled: to.BoolPtr(true),
+							},
+						},
+						NetworkProfile: &compute.NetworkProfile{},
+					},
+				}, nil)
+				s.SetProviderID("azure:///my-id")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				s.SetAddresses(gomock.Any())
+				s.SetVMState(infrav1.VMStateSucceeded)
+			},
+			ExpectedError: "",
+			SetupSKUs:     func(svc *Service) {},
+		},
+		{
+			Name: "attaches retained and managed data disks to an existing vm",
+			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
+				s.VMSpec().Return(azure.VMSpec{
+					Name:     "my-vm",
+					Size:     "Standard_D2v3",
+					Identity: infrav1.VMIdentitySystemAssigned,
+					DataDisks: []infrav1.DataDisk{
+						{
+							NameSuffix:     "retained",
+							DiskSizeGB:     64,
+							Lun:            to.Int32Ptr(0),
+							CachingType:    "ReadOnly",
+							DeletionPolicy: infrav1.DiskDeletionPolicyRetain,
+							DiskName:       "my-retained-disk",
+						},
+						{
+							NameSuffix:  "premium",
+							DiskSizeGB:  128,
+							Lun:         to.Int32Ptr(1),
+							CachingType: "None",
+							ManagedDisk: &infrav1.ManagedDisk{
+								StorageAccountType: "Premium_LRS",
+								DiskEncryptionSet: &infrav1.DiskEncryptionSetParameters{
+									ID: "my-des-id",
+								},
+							},
+							WriteAcceleratorEnabled: to.BoolPtr(true),
+						},
+					},
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").Return(compute.VirtualMachine{
+					ID:   to.StringPtr("my-id"),
+					Name: to.StringPtr("my-vm"),
+					Identity: &compute.VirtualMachineIdentity{
+						Type: compute.ResourceIdentityTypeSystemAssigned,
+					},
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						ProvisioningState: to.StringPtr("Succeeded"),
+						HardwareProfile: &compute.HardwareProfile{
+							VMSize: "Standard_D2v3",
+						},
+						DiagnosticsProfile: &compute.DiagnosticsProfile{
+							BootDiagnostics: &compute.BootDiagnostics{
+								Enabled: to.BoolPtr(true),
+							},
+						},
+						NetworkProfile: &compute.NetworkProfile{},
+					},
+				}, nil)
+				s.SetProviderID("azure:///my-id")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				s.SetAddresses(gomock.Any())
+				s.SetVMState(infrav1.VMStateSucceeded)
+				m.UpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomockinternal.DiffEq(compute.VirtualMachineUpdate{
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						StorageProfile: &compute.StorageProfile{
+							DataDisks: &[]compute.DataDisk{
+								{
+									CreateOption: compute.DiskCreateOptionTypesAttach,
+									Lun:          to.Int32Ptr(0),
+									Name:         to.StringPtr("my-retained-disk"),
+									Caching:      compute.CachingTypesReadOnly,
+									ManagedDisk: &compute.ManagedDiskParameters{
+										ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/disks/my-retained-disk"),
+									},
+								},
+								{
+									CreateOption: compute.DiskCreateOptionTypesEmpty,
+									DiskSizeGB:   to.Int32Ptr(128),
+									Lun:          to.Int32Ptr(1),
+									Name:         to.StringPtr("my-vm_premium"),
+									Caching:      compute.CachingTypesNone,
+									ManagedDisk: &compute.ManagedDiskParameters{
+										StorageAccountType: compute.StorageAccountTypesPremiumLRS,
+										DiskEncryptionSet: &compute.DiskEncryptionSetParameters{
+											ID: to.StringPtr("my-des-id"),
+										},
+									},
+									WriteAcceleratorEnabled: to.BoolPtr(true),
+								},
+							},
+						},
+					},
+				}))
+			},
+			ExpectedError: "",
+			SetupSKUs:     func(svc *Service) {},
+		},
+		{
+			Name: "does not update an existing vm while it is updating",
+			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
+				s.VMSpec().Return(azure.VMSpec{
+					Name: "my-vm",
+					Size: "Standard_D4v3",
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").Return(compute.VirtualMachine{
+					ID:   to.StringPtr("my-id"),
+					Name: to.StringPtr("my-vm"),
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						ProvisioningState: to.StringPtr("Updating"),
+						HardwareProfile: &compute.HardwareProfile{
+							VMSize: "Standard_D2v3",
+						},
+						NetworkProfile: &compute.NetworkProfile{},
+					},
+				}, nil)
+				s.SetProviderID("azure:///my-id")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				s.SetAddresses(gomock.Any())
+				s.SetVMState(infrav1.VMStateUpdating)
+			},
+			ExpectedError: "",
+			SetupSKUs:     func(svc *Service) {},
+		},
+		{
+			Name: "fails when updating an existing vm fails",
+			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
+				s.VMSpec().Return(azure.VMSpec{
+					Name: "my-vm",
+					Size: "Standard_D4v3",
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").Return(compute.VirtualMachine{
+					ID:   to.StringPtr("my-id"),
+					Name: to.StringPtr("my-vm"),
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						ProvisioningState: to.StringPtr("Succeeded"),
+						HardwareProfile: &compute.HardwareProfile{
+							VMSize: "Standard_D2v3",
+						},
+						NetworkProfile: &compute.NetworkProfile{},
+					},
+				}, nil)
+				s.SetProviderID("azure:///my-id")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				s.SetAddresses(gomock.Any())
+				s.SetVMState(infrav1.VMStateSucceeded)
+				m.UpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachineUpdate{})).
+					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+			ExpectedError: "failed to update VM my-vm in resource group my-rg: #: Internal Server Error: StatusCode=500",
+			SetupSKUs:     func(svc *Service) {},
+		},
+		{
+			Name: "returns an operation not done error while the vm update is in progress",
+			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
+				future := &infrav1.Future{Type: infrav1.PatchFuture, ResourceGroup: "my-rg", Name: "my-vm", FutureData: "ZmFrZWZ1dHVyZQ=="}
+				s.VMSpec().Return(azure.VMSpec{
+					Name: "my-vm",
+					Size: "Standard_D4v3",
+				})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm").Return(compute.VirtualMachine{
+					ID:   to.StringPtr("my-id"),
+					Name: to.StringPtr("my-vm"),
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						ProvisioningState: to.StringPtr("Succeeded"),
+						HardwareProfile: &compute.HardwareProfile{
+							VMSize: "Standard_D2v3",
+						},
+						NetworkProfile: &compute.NetworkProfile{},
+					},
+				}, nil)
+				s.SetProviderID("azure:///my-id")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				s.SetAddresses(gomock.Any())
+				s.SetVMState(infrav1.VMStateSucceeded)
+				m.UpdateAsync(gomockinternal.AContext(), "my-rg", "my-vm", gomock.AssignableToTypeOf(compute.VirtualMachineUpdate{})).Return(future, nil)
+				s.SetLongRunningOperationState(future)
+				m.IsDone(gomockinternal.AContext(), future).Return(false, nil)
+			},
+			ExpectedError: "operation type PATCH on Azure resource my-rg/my-vm is not done",
+			SetupSKUs:     func(svc *Service) {},
+		},
 		{
 			Name: "fails when there is a provider id present, but cannot find vm ",
 			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
@@ -1470,6 +2104,33 @@ func TestReconcileVM(t *testing.T) {
 			ExpectedError: "VM with provider id \"ExistingVM-ProviderID\" has been deleted",
 			SetupSKUs:     func(svc *Service) {},
 		},
+		{
+			Name: "returns an operation not done error while the vm creation is in progress",
+			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
+				future := &infrav1.Future{Type: infrav1.PutFuture, ResourceGroup: "my-rg", Name: "my-vm", FutureData: "ZmFrZWZ1dHVyZQ=="}
+				s.VMSpec().Return(azure.VMSpec{
+					Name: "my-vm",
+				})
+				s.GetLongRunningOperationState().Return(future)
+				m.IsDone(gomockinternal.AContext(), future).Return(false, nil)
+			},
+			ExpectedError: "operation type PUT on Azure resource my-rg/my-vm is not done",
+			SetupSKUs:     func(svc *Service) {},
+		},
+		{
+			Name: "fails when the in-progress vm creation failed",
+			Expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder, mnic *mock_networkinterfaces.MockClientMockRecorder, mpip *mock_publicips.MockClientMockRecorder) {
+				future := &infrav1.Future{Type: infrav1.PutFuture, ResourceGroup: "my-rg", Name: "my-vm", FutureData: "ZmFrZWZ1dHVyZQ=="}
+				s.VMSpec().Return(azure.VMSpec{
+					Name: "my-vm",
+				})
+				s.GetLongRunningOperationState().Return(future)
+				m.IsDone(gomockinternal.AContext(), future).Return(false, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+				s.SetLongRunningOperationState(nil)
+			},
+			ExpectedError: "failed operation type PUT on VM my-vm in resource group my-rg: #: Internal Server Error: StatusCode=500",
+			SetupSKUs:     func(svc *Service) {},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -1486,6 +2147,11 @@ func TestReconcileVM(t *testing.T) {
 			publicIPMock := mock_publicips.NewMockClient(mockCtrl)
 
 			tc.Expect(g, scopeMock.EXPECT(), clientMock.EXPECT(), interfaceMock.EXPECT(), publicIPMock.EXPECT())
+			// no long-running operation is in progress unless the test case says otherwise, and any started
+			// operation completes on its first poll.
+			scopeMock.EXPECT().GetLongRunningOperationState().AnyTimes().Return(nil)
+			scopeMock.EXPECT().SetLongRunningOperationState(gomock.Any()).AnyTimes()
+			clientMock.EXPECT().IsDone(gomockinternal.AContext(), gomock.Any()).AnyTimes().Return(true, nil)
 
 			s := &Service{
 				Scope:            scopeMock,
@@ -1533,7 +2199,7 @@ func TestDeleteVM(t *testing.T) {
 				})
 				s.ResourceGroup().AnyTimes().Return("my-existing-rg")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				m.Delete(gomockinternal.AContext(), "my-existing-rg", "my-existing-vm")
+				m.DeleteAsync(gomockinternal.AContext(), "my-existing-rg", "my-existing-vm")
 			},
 		},
 		{
@@ -1555,8 +2221,8 @@ func TestDeleteVM(t *testing.T) {
 				})
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				m.Delete(gomockinternal.AContext(), "my-rg", "my-vm").
-					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.DeleteAsync(gomockinternal.AContext(), "my-rg", "my-vm").
+					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
 			},
 		},
 		{
@@ -1578,8 +2244,39 @@ func TestDeleteVM(t *testing.T) {
 				})
 				s.ResourceGroup().AnyTimes().Return("my-rg")
 				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
-				m.Delete(gomockinternal.AContext(), "my-rg", "my-vm").
-					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+				m.DeleteAsync(gomockinternal.AContext(), "my-rg", "my-vm").
+					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+		{
+			name:          "vm deletion in progress",
+			expectedError: "operation type DELETE on Azure resource my-rg/my-vm is not done",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder) {
+				future := &infrav1.Future{Type: infrav1.DeleteFuture, ResourceGroup: "my-rg", Name: "my-vm", FutureData: "ZmFrZWZ1dHVyZQ=="}
+				s.VMSpec().Return(azure.VMSpec{
+					Name: "my-vm",
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.GetLongRunningOperationState().Return(nil)
+				m.DeleteAsync(gomockinternal.AContext(), "my-rg", "my-vm").Return(future, nil)
+				s.SetLongRunningOperationState(future)
+				m.IsDone(gomockinternal.AContext(), future).Return(false, nil)
+			},
+		},
+		{
+			name:          "resumes an in-progress vm deletion",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, m *mock_virtualmachines.MockClientMockRecorder) {
+				future := &infrav1.Future{Type: infrav1.DeleteFuture, ResourceGroup: "my-rg", Name: "my-vm", FutureData: "ZmFrZWZ1dHVyZQ=="}
+				s.VMSpec().Return(azure.VMSpec{
+					Name: "my-vm",
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.GetLongRunningOperationState().Return(future)
+				m.IsDone(gomockinternal.AContext(), future).Return(true, nil)
+				s.SetLongRunningOperationState(nil)
 			},
 		},
 	}
@@ -1595,6 +2292,9 @@ func TestDeleteVM(t *testing.T) {
 			clientMock := mock_virtualmachines.NewMockClient(mockCtrl)
 
 			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+			scopeMock.EXPECT().GetLongRunningOperationState().AnyTimes().Return(nil)
+			scopeMock.EXPECT().SetLongRunningOperationState(gomock.Any()).AnyTimes()
+			clientMock.EXPECT().IsDone(gomockinternal.AContext(), gomock.Any()).AnyTimes().Return(true, nil)
 
 			s := &Service{
 				Scope:  scopeMock,
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
 	"github.com/Azure/go-autorest/autorest"
 
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
@@ -32,11 +33,14 @@ type Client interface {
 	CreateOrUpdate(context.Context, string, string, network.VirtualNetwork) error
 	Delete(context.Context, string, string) error
 	CheckIPAddressAvailability(context.Context, string, string, string) (network.IPAddressAvailabilityResult, error)
+	MergeTags(context.Context, string, map[string]*string) error
+	DeleteTags(context.Context, string, map[string]*string) error
 }
 
 // AzureClient contains the Azure go-sdk Client
 type AzureClient struct {
 	virtualnetworks network.VirtualNetworksClient
+	tags            resources.TagsClient
 }
 
 var _ Client = &AzureClient{}
@@ -44,19 +48,27 @@ var _ Client = &AzureClient{}
 // NewClient creates a new VM client from subscription ID.
 func NewClient(auth azure.Authorizer) *AzureClient {
 	c := newVirtualNetworksClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	t := newTagsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
 	return &AzureClient{
 		virtualnetworks: c,
+		tags:            t,
 	}
 }
 
 // newVirtualNetworksClient creates a new vnet client from subscription ID.
 func newVirtualNetworksClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.VirtualNetworksClient {
 	vnetsClient := network.NewVirtualNetworksClientWithBaseURI(baseURI, subscriptionID)
-	vnetsClient.Authorizer = authorizer
-	vnetsClient.AddToUserAgent(azure.UserAgent())
+	azure.SetAutoRestClientDefaults(&vnetsClient.Client, authorizer)
 	return vnetsClient
 }
 
+// newTagsClient creates a new tags client from subscription ID.
+func newTagsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) resources.TagsClient {
+	tagsClient := resources.NewTagsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&tagsClient.Client, authorizer)
+	return tagsClient
+}
+
 // Get gets the specified virtual network by resource group.
 func (ac *AzureClient) Get(ctx context.Context, resourceGroupName, vnetName string) (network.VirtualNetwork, error) {
 	ctx, span := tele.Tracer().Start(ctx, "virtualnetworks.AzureClient.Get")
@@ -106,3 +118,28 @@ func (ac *AzureClient) CheckIPAddressAvailability(ctx context.Context, resourceG
 
 	return ac.virtualnetworks.CheckIPAddressAvailability(ctx, resourceGroupName, vnetName, ip)
 }
+
+// MergeTags adds tags to the virtual network with the given ID, or replaces their values, leaving its other tags and
+// its subnets untouched.
+func (ac *AzureClient) MergeTags(ctx context.Context, vnetID string, tags map[string]*string) error {
+	ctx, span := tele.Tracer().Start(ctx, "virtualnetworks.AzureClient.MergeTags")
+	defer span.End()
+
+	return ac.updateTags(ctx, vnetID, resources.TagsPatchOperationMerge, tags)
+}
+
+// DeleteTags removes tags from the virtual network with the given ID.
+func (ac *AzureClient) DeleteTags(ctx context.Context, vnetID string, tags map[string]*string) error {
+	ctx, span := tele.Tracer().Start(ctx, "virtualnetworks.AzureClient.DeleteTags")
+	defer span.End()
+
+	return ac.updateTags(ctx, vnetID, resources.TagsPatchOperationDelete, tags)
+}
+
+func (ac *AzureClient) updateTags(ctx context.Context, vnetID string, operation resources.TagsPatchOperation, tags map[string]*string) error {
+	_, err := ac.tags.UpdateAtScope(ctx, vnetID, resources.TagsPatchResource{
+		Operation:  operation,
+		Properties: &resources.Tags{Tags: tags},
+	})
+	return err
+}
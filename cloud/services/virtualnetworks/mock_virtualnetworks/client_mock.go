@@ -107,3 +107,31 @@ func (mr *MockClientMockRecorder) CheckIPAddressAvailability(arg0, arg1, arg2, a
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIPAddressAvailability", reflect.TypeOf((*MockClient)(nil).CheckIPAddressAvailability), arg0, arg1, arg2, arg3)
 }
+
+// MergeTags mocks base method.
+func (m *MockClient) MergeTags(arg0 context.Context, arg1 string, arg2 map[string]*string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockClientMockRecorder) MergeTags(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockClient)(nil).MergeTags), arg0, arg1, arg2)
+}
+
+// DeleteTags mocks base method.
+func (m *MockClient) DeleteTags(arg0 context.Context, arg1 string, arg2 map[string]*string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTags", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTags indicates an expected call of DeleteTags.
+func (mr *MockClientMockRecorder) DeleteTags(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTags", reflect.TypeOf((*MockClient)(nil).DeleteTags), arg0, arg1, arg2)
+}
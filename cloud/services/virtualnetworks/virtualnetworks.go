@@ -76,6 +76,9 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		// vnet already exists, cannot update since it's immutable
 		if !existingVnet.IsManaged(s.Scope.ClusterName()) {
 			s.Scope.V(2).Info("Working on custom VNet", "vnet-id", existingVnet.ID)
+			if err := s.share(ctx, existingVnet); err != nil {
+				return err
+			}
 		}
 		existingVnet.DeepCopyInto(s.Scope.Vnet())
 
@@ -115,7 +118,7 @@ func (s *Service) Delete(ctx context.Context) error {
 	vnetSpec := s.Scope.VNetSpec()
 	if !s.Scope.Vnet().IsManaged(s.Scope.ClusterName()) {
 		s.Scope.V(4).Info("Skipping VNet deletion in custom vnet mode")
-		return nil
+		return s.unshare(ctx, vnetSpec)
 	}
 
 	s.Scope.V(2).Info("deleting VNet", "VNet", vnetSpec.Name)
@@ -132,6 +135,53 @@ func (s *Service) Delete(ctx context.Context) error {
 	return nil
 }
 
+// share tags a custom virtual network as shared with the cluster. Other clusters may share it too, so its tags are
+// merged rather than replaced, and the subnets the cluster adds to it are recorded by the subnets service.
+func (s *Service) share(ctx context.Context, vnet *infrav1.VnetSpec) error {
+	if vnet.Tags.HasShared(s.Scope.ClusterName()) {
+		return nil
+	}
+
+	s.Scope.V(2).Info("tagging custom VNet as shared", "VNet", vnet.Name)
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.Scope.ClusterName(),
+		Lifecycle:   infrav1.ResourceLifecycleShared,
+	})
+	if err := s.Client.MergeTags(ctx, vnet.ID, converters.TagsToMap(tags)); err != nil {
+		return errors.Wrapf(err, "failed to tag VNet %s as shared", vnet.Name)
+	}
+	vnet.Tags.Merge(tags)
+	return nil
+}
+
+// unshare removes the tags of the cluster from a custom virtual network it shares, once the subnets it added have been
+// deleted.
+func (s *Service) unshare(ctx context.Context, spec azure.VNetSpec) error {
+	existingVnet, err := s.getExisting(ctx, spec)
+	if azure.ResourceNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !existingVnet.Tags.HasShared(s.Scope.ClusterName()) {
+		return nil
+	}
+
+	tags := infrav1.Tags{}
+	keys := append([]string{infrav1.ClusterTagKey(s.Scope.ClusterName())}, existingVnet.Tags.EntriesTagKeys(s.Scope.ClusterName())...)
+	for _, key := range keys {
+		if value, ok := existingVnet.Tags[key]; ok {
+			tags[key] = value
+		}
+	}
+	s.Scope.V(2).Info("removing shared tags from custom VNet", "VNet", spec.Name)
+	if err := s.Client.DeleteTags(ctx, existingVnet.ID, converters.TagsToMap(tags)); err != nil {
+		return errors.Wrapf(err, "failed to remove shared tags from VNet %s", spec.Name)
+	}
+	return nil
+}
+
 // getExisting provides information about an existing virtual network.
 func (s *Service) getExisting(ctx context.Context, spec azure.VNetSpec) (*infrav1.VnetSpec, error) {
 	ctx, span := tele.Tracer().Start(ctx, "virtualnetworks.Service.getExisting")
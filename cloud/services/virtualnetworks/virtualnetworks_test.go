@@ -182,6 +182,53 @@ func TestReconcileVnet(t *testing.T) {
 							"Name": to.StringPtr("my-custom-vnet"),
 						},
 					}, nil)
+				m.MergeTags(gomockinternal.AContext(), "azure/custom-vnet/id", map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_fake-cluster": to.StringPtr("shared"),
+				})
+			},
+		},
+		{
+			name:          "unmanaged vnet already shared with the cluster",
+			expectedError: "",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ClusterName().AnyTimes().Return("fake-cluster")
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{Name: "custom-vnet"})
+				s.VNetSpec().Return(azure.VNetSpec{
+					ResourceGroup: "custom-vnet-rg",
+					Name:          "custom-vnet",
+					CIDRs:         []string{"10.0.0.0/16"},
+				})
+				m.Get(gomockinternal.AContext(), "custom-vnet-rg", "custom-vnet").
+					Return(network.VirtualNetwork{
+						ID:   to.StringPtr("azure/custom-vnet/id"),
+						Name: to.StringPtr("custom-vnet"),
+						Tags: map[string]*string{
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_fake-cluster":  to.StringPtr("shared"),
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": to.StringPtr("shared"),
+						},
+					}, nil)
+			},
+		},
+		{
+			name:          "fail to tag unmanaged vnet as shared",
+			expectedError: "failed to tag VNet custom-vnet as shared: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ClusterName().AnyTimes().Return("fake-cluster")
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{Name: "custom-vnet"})
+				s.VNetSpec().Return(azure.VNetSpec{
+					ResourceGroup: "custom-vnet-rg",
+					Name:          "custom-vnet",
+					CIDRs:         []string{"10.0.0.0/16"},
+				})
+				m.Get(gomockinternal.AContext(), "custom-vnet-rg", "custom-vnet").
+					Return(network.VirtualNetwork{
+						ID:   to.StringPtr("azure/custom-vnet/id"),
+						Name: to.StringPtr("custom-vnet"),
+					}, nil)
+				m.MergeTags(gomockinternal.AContext(), "azure/custom-vnet/id", gomock.Any()).
+					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
 			},
 		},
 		{
@@ -333,6 +380,57 @@ func TestDeleteVnet(t *testing.T) {
 					Name:          "my-vnet",
 					CIDRs:         []string{"10.0.0.0/16"},
 				})
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vnet").
+					Return(network.VirtualNetwork{
+						ID:   to.StringPtr("azure/custom-vnet/id"),
+						Name: to.StringPtr("my-vnet"),
+					}, nil)
+			},
+		},
+		{
+			name:          "unmanaged vnet shared with the cluster",
+			expectedError: "",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ClusterName().AnyTimes().Return("fake-cluster")
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ResourceGroup: "my-rg", Name: "my-vnet", ID: "azure/custom-vnet/id"})
+				s.VNetSpec().Return(azure.VNetSpec{
+					ResourceGroup: "my-rg",
+					Name:          "my-vnet",
+					CIDRs:         []string{"10.0.0.0/16"},
+				})
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vnet").
+					Return(network.VirtualNetwork{
+						ID:   to.StringPtr("azure/custom-vnet/id"),
+						Name: to.StringPtr("my-vnet"),
+						Tags: map[string]*string{
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_fake-cluster":   to.StringPtr("shared"),
+							"sigs.k8s.io_cluster-api-provider-azure_entries_fake-cluster":   to.StringPtr("my-subnet"),
+							"sigs.k8s.io_cluster-api-provider-azure_entries_fake-cluster_1": to.StringPtr("my-other-subnet"),
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster":  to.StringPtr("shared"),
+						},
+					}, nil)
+				m.DeleteTags(gomockinternal.AContext(), "azure/custom-vnet/id", map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_fake-cluster":   to.StringPtr("shared"),
+					"sigs.k8s.io_cluster-api-provider-azure_entries_fake-cluster":   to.StringPtr("my-subnet"),
+					"sigs.k8s.io_cluster-api-provider-azure_entries_fake-cluster_1": to.StringPtr("my-other-subnet"),
+				})
+			},
+		},
+		{
+			name:          "unmanaged vnet already deleted",
+			expectedError: "",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_virtualnetworks.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ClusterName().AnyTimes().Return("fake-cluster")
+				s.Vnet().AnyTimes().Return(&infrav1.VnetSpec{ResourceGroup: "my-rg", Name: "my-vnet", ID: "azure/custom-vnet/id"})
+				s.VNetSpec().Return(azure.VNetSpec{
+					ResourceGroup: "my-rg",
+					Name:          "my-vnet",
+					CIDRs:         []string{"10.0.0.0/16"},
+				})
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vnet").
+					Return(network.VirtualNetwork{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
 			},
 		},
 		{
@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmextensions
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest"
+
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client wraps go-sdk
+type client interface {
+	Get(context.Context, string, string, string) (compute.VirtualMachineExtension, error)
+	CreateOrUpdate(context.Context, string, string, string, compute.VirtualMachineExtension) error
+	Delete(context.Context, string, string, string) error
+	GetScaleSetExtension(context.Context, string, string, string) (compute.VirtualMachineScaleSetExtension, error)
+	CreateOrUpdateScaleSetExtension(context.Context, string, string, string, compute.VirtualMachineScaleSetExtension) error
+	DeleteScaleSetExtension(context.Context, string, string, string) error
+}
+
+// azureClient contains the Azure go-sdk Client
+type azureClient struct {
+	vmextensions   compute.VirtualMachineExtensionsClient
+	vmssextensions compute.VirtualMachineScaleSetExtensionsClient
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new VM extensions client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	return &azureClient{
+		vmextensions:   newVirtualMachineExtensionsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		vmssextensions: newVirtualMachineScaleSetExtensionsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newVirtualMachineExtensionsClient creates a new VM extensions client from subscription ID.
+func newVirtualMachineExtensionsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachineExtensionsClient {
+	vmextensionsClient := compute.NewVirtualMachineExtensionsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&vmextensionsClient.Client, authorizer)
+	return vmextensionsClient
+}
+
+// newVirtualMachineScaleSetExtensionsClient creates a new VMSS extensions client from subscription ID.
+func newVirtualMachineScaleSetExtensionsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachineScaleSetExtensionsClient {
+	vmssextensionsClient := compute.NewVirtualMachineScaleSetExtensionsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&vmssextensionsClient.Client, authorizer)
+	return vmssextensionsClient
+}
+
+// Get gets the specified extension of a VM.
+func (ac *azureClient) Get(ctx context.Context, resourceGroupName, vmName, name string) (compute.VirtualMachineExtension, error) {
+	ctx, span := tele.Tracer().Start(ctx, "vmextensions.AzureClient.Get")
+	defer span.End()
+
+	return ac.vmextensions.Get(ctx, resourceGroupName, vmName, name, "")
+}
+
+// CreateOrUpdate installs or updates an extension of a VM.
+func (ac *azureClient) CreateOrUpdate(ctx context.Context, resourceGroupName, vmName, name string, extension compute.VirtualMachineExtension) error {
+	ctx, span := tele.Tracer().Start(ctx, "vmextensions.AzureClient.CreateOrUpdate")
+	defer span.End()
+
+	future, err := ac.vmextensions.CreateOrUpdate(ctx, resourceGroupName, vmName, name, extension)
+	if err != nil {
+		return err
+	}
+	err = future.WaitForCompletionRef(ctx, ac.vmextensions.Client)
+	if err != nil {
+		return err
+	}
+	_, err = future.Result(ac.vmextensions)
+	return err
+}
+
+// Delete uninstalls an extension of a VM.
+func (ac *azureClient) Delete(ctx context.Context, resourceGroupName, vmName, name string) error {
+	ctx, span := tele.Tracer().Start(ctx, "vmextensions.AzureClient.Delete")
+	defer span.End()
+
+	future, err := ac.vmextensions.Delete(ctx, resourceGroupName, vmName, name)
+	if err != nil {
+		return err
+	}
+	err = future.WaitForCompletionRef(ctx, ac.vmextensions.Client)
+	if err != nil {
+		return err
+	}
+	_, err = future.Result(ac.vmextensions)
+	return err
+}
+
+// GetScaleSetExtension gets the specified extension of a VM scale set.
+func (ac *azureClient) GetScaleSetExtension(ctx context.Context, resourceGroupName, vmssName, name string) (compute.VirtualMachineScaleSetExtension, error) {
+	ctx, span := tele.Tracer().Start(ctx, "vmextensions.AzureClient.GetScaleSetExtension")
+	defer span.End()
+
+	return ac.vmssextensions.Get(ctx, resourceGroupName, vmssName, name, "")
+}
+
+// CreateOrUpdateScaleSetExtension adds or updates an extension in the model of a VM scale set.
+func (ac *azureClient) CreateOrUpdateScaleSetExtension(ctx context.Context, resourceGroupName, vmssName, name string, extension compute.VirtualMachineScaleSetExtension) error {
+	ctx, span := tele.Tracer().Start(ctx, "vmextensions.AzureClient.CreateOrUpdateScaleSetExtension")
+	defer span.End()
+
+	future, err := ac.vmssextensions.CreateOrUpdate(ctx, resourceGroupName, vmssName, name, extension)
+	if err != nil {
+		return err
+	}
+	err = future.WaitForCompletionRef(ctx, ac.vmssextensions.Client)
+	if err != nil {
+		return err
+	}
+	_, err = future.Result(ac.vmssextensions)
+	return err
+}
+
+// DeleteScaleSetExtension removes an extension from the model of a VM scale set.
+func (ac *azureClient) DeleteScaleSetExtension(ctx context.Context, resourceGroupName, vmssName, name string) error {
+	ctx, span := tele.Tracer().Start(ctx, "vmextensions.AzureClient.DeleteScaleSetExtension")
+	defer span.End()
+
+	future, err := ac.vmssextensions.Delete(ctx, resourceGroupName, vmssName, name)
+	if err != nil {
+		return err
+	}
+	err = future.WaitForCompletionRef(ctx, ac.vmssextensions.Client)
+	if err != nil {
+		return err
+	}
+	_, err = future.Result(ac.vmssextensions)
+	return err
+}
@@ -0,0 +1,384 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmextensions
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/scalesets"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// VMExtensionScope defines the scope interface for a VM extensions service.
+type VMExtensionScope interface {
+	logr.Logger
+	azure.ClusterDescriber
+	VMExtensionsSpecs() []azure.VMExtensionsSpec
+	GetVMExtensionProtectedSettings(context.Context, azure.VMExtensionSpec) (string, error)
+	AnnotationJSON(string) (map[string]interface{}, error)
+	UpdateAnnotationJSON(string, map[string]interface{}) error
+}
+
+// Service provides operations on azure resources
+type Service struct {
+	Scope VMExtensionScope
+	client
+	virtualMachineScaleSetClient scalesets.Client
+}
+
+// New creates a new service.
+func New(scope VMExtensionScope) *Service {
+	return &Service{
+		Scope:                        scope,
+		client:                       newClient(scope),
+		virtualMachineScaleSetClient: scalesets.NewClient(scope),
+	}
+}
+
+// extension is the desired configuration of a VM extension.
+type extension struct {
+	azure.VMExtensionSpec
+	settings          map[string]interface{}
+	protectedSettings map[string]interface{}
+}
+
+// Reconcile installs the VM extensions of the VM or scale set, updates the ones that drifted from their spec and
+// uninstalls the ones removed from the spec since the last reconciliation.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, span := tele.Tracer().Start(ctx, "vmextensions.Service.Reconcile")
+	defer span.End()
+
+	for _, extensionsSpec := range s.Scope.VMExtensionsSpecs() {
+		if extensionsSpec.ResourceType != azure.VirtualMachine && extensionsSpec.ResourceType != azure.VirtualMachineScaleSet {
+			return errors.Errorf("unexpected resource type %q. Expected one of [%s, %s]", extensionsSpec.ResourceType,
+				azure.VirtualMachine, azure.VirtualMachineScaleSet)
+		}
+		if err := s.reconcileExtensions(ctx, extensionsSpec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) reconcileExtensions(ctx context.Context, extensionsSpec azure.VMExtensionsSpec) error {
+	ctx, span := tele.Tracer().Start(ctx, "vmextensions.Service.reconcileExtensions")
+	defer span.End()
+
+	isScaleSet := extensionsSpec.ResourceType == azure.VirtualMachineScaleSet
+
+	lastApplied, err := s.Scope.AnnotationJSON(extensionsSpec.Annotation)
+	if err != nil {
+		return err
+	}
+
+	desired := make([]extension, 0, len(extensionsSpec.Extensions))
+	for _, extensionSpec := range extensionsSpec.Extensions {
+		ext, err := s.getExtension(ctx, extensionSpec)
+		if err != nil {
+			return err
+		}
+		desired = append(desired, ext)
+	}
+
+	// The automatic repairs are disabled before the Application Health extension feeding them may be uninstalled.
+	if isScaleSet && extensionsSpec.AutomaticRepairsGracePeriod == nil {
+		if err := s.reconcileAutomaticRepairs(ctx, extensionsSpec); err != nil {
+			return err
+		}
+	}
+
+	// The annotation is updated as the extensions are installed and uninstalled, so that an error leaves it tracking
+	// the extensions actually applied.
+	modelChanged := false
+	for name := range lastApplied {
+		if hasExtension(desired, name) {
+			continue
+		}
+		s.Scope.V(2).Info("uninstalling VM extension", "vm extension", name, "resource type", extensionsSpec.ResourceType, "name", extensionsSpec.MachineName)
+		if err := s.delete(ctx, extensionsSpec, name); err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to uninstall VM extension %s", name)
+		}
+		delete(lastApplied, name)
+		if err := s.Scope.UpdateAnnotationJSON(extensionsSpec.Annotation, lastApplied); err != nil {
+			return err
+		}
+		modelChanged = isScaleSet
+		s.Scope.V(2).Info("successfully uninstalled VM extension", "vm extension", name)
+	}
+
+	for _, ext := range desired {
+		hash, err := ext.hash()
+		if err != nil {
+			return errors.Wrapf(err, "failed to hash VM extension %s", ext.Name)
+		}
+		upToDate, err := s.isUpToDate(ctx, extensionsSpec, ext)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get VM extension %s", ext.Name)
+		}
+		// protected settings are never returned by Azure, so their changes are only found from the annotation.
+		if upToDate && lastApplied[ext.Name] == hash {
+			continue
+		}
+
+		s.Scope.V(2).Info("installing VM extension", "vm extension", ext.Name, "resource type", extensionsSpec.ResourceType, "name", extensionsSpec.MachineName)
+		if err := s.createOrUpdate(ctx, extensionsSpec, ext); err != nil {
+			return errors.Wrapf(err, "failed to install VM extension %s", ext.Name)
+		}
+		lastApplied[ext.Name] = hash
+		if err := s.Scope.UpdateAnnotationJSON(extensionsSpec.Annotation, lastApplied); err != nil {
+			return err
+		}
+		modelChanged = isScaleSet
+		s.Scope.V(2).Info("successfully installed VM extension", "vm extension", ext.Name)
+	}
+
+	if isScaleSet && extensionsSpec.AutomaticRepairsGracePeriod != nil {
+		if err := s.reconcileAutomaticRepairs(ctx, extensionsSpec); err != nil {
+			return err
+		}
+	}
+
+	if modelChanged {
+		return s.upgradeInstances(ctx, extensionsSpec)
+	}
+	return nil
+}
+
+// getExtension returns the desired configuration of a VM extension, with its settings and protected settings.
+func (s *Service) getExtension(ctx context.Context, extensionSpec azure.VMExtensionSpec) (extension, error) {
+	ext := extension{VMExtensionSpec: extensionSpec}
+	if extensionSpec.Settings != "" {
+		if err := json.Unmarshal([]byte(extensionSpec.Settings), &ext.settings); err != nil {
+			return ext, azure.WithTerminalError(errors.Wrapf(err, "settings of VM extension %s should be a JSON object", extensionSpec.Name))
+		}
+	}
+
+	protectedSettings, err := s.Scope.GetVMExtensionProtectedSettings(ctx, extensionSpec)
+	if err != nil {
+		return ext, err
+	}
+	if protectedSettings != "" {
+		if err := json.Unmarshal([]byte(protectedSettings), &ext.protectedSettings); err != nil {
+			return ext, errors.Errorf("protected settings of VM extension %s should be a JSON object", extensionSpec.Name)
+		}
+	}
+	return ext, nil
+}
+
+// isUpToDate returns true if the extension is installed with the publisher, type, version and settings of its spec.
+func (s *Service) isUpToDate(ctx context.Context, extensionsSpec azure.VMExtensionsSpec, ext extension) (bool, error) {
+	var publisher, extensionType, version *string
+	var settings interface{}
+	if extensionsSpec.ResourceType == azure.VirtualMachineScaleSet {
+		existing, err := s.client.GetScaleSetExtension(ctx, s.Scope.ResourceGroup(), extensionsSpec.MachineName, ext.Name)
+		if azure.ResourceNotFound(err) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		if existing.VirtualMachineScaleSetExtensionProperties == nil {
+			return false, nil
+		}
+		// the extension type is read from the properties, as the Type of the extension itself is its resource type.
+		properties := existing.VirtualMachineScaleSetExtensionProperties
+		publisher, extensionType, version, settings = properties.Publisher, properties.Type, properties.TypeHandlerVersion, properties.Settings
+	} else {
+		existing, err := s.client.Get(ctx, s.Scope.ResourceGroup(), extensionsSpec.MachineName, ext.Name)
+		if azure.ResourceNotFound(err) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		if existing.VirtualMachineExtensionProperties == nil {
+			return false, nil
+		}
+		properties := existing.VirtualMachineExtensionProperties
+		publisher, extensionType, version, settings = properties.Publisher, properties.Type, properties.TypeHandlerVersion, properties.Settings
+	}
+
+	return to.String(publisher) == ext.Publisher &&
+		to.String(extensionType) == ext.Type &&
+		to.String(version) == ext.Version &&
+		settingsEqual(settings, ext.settings), nil
+}
+
+func (s *Service) createOrUpdate(ctx context.Context, extensionsSpec azure.VMExtensionsSpec, ext extension) error {
+	// nil maps are omitted rather than sent as empty settings.
+	var settings, protectedSettings interface{}
+	if ext.settings != nil {
+		settings = ext.settings
+	}
+	if ext.protectedSettings != nil {
+		protectedSettings = ext.protectedSettings
+	}
+
+	if extensionsSpec.ResourceType == azure.VirtualMachineScaleSet {
+		return s.client.CreateOrUpdateScaleSetExtension(ctx, s.Scope.ResourceGroup(), extensionsSpec.MachineName, ext.Name, compute.VirtualMachineScaleSetExtension{
+			Name: to.StringPtr(ext.Name),
+			VirtualMachineScaleSetExtensionProperties: &compute.VirtualMachineScaleSetExtensionProperties{
+				Publisher:               to.StringPtr(ext.Publisher),
+				Type:                    to.StringPtr(ext.Type),
+				TypeHandlerVersion:      to.StringPtr(ext.Version),
+				AutoUpgradeMinorVersion: to.BoolPtr(true),
+				Settings:                settings,
+				ProtectedSettings:       protectedSettings,
+			},
+		})
+	}
+	return s.client.CreateOrUpdate(ctx, s.Scope.ResourceGroup(), extensionsSpec.MachineName, ext.Name, compute.VirtualMachineExtension{
+		Location: to.StringPtr(s.Scope.Location()),
+		VirtualMachineExtensionProperties: &compute.VirtualMachineExtensionProperties{
+			Publisher:               to.StringPtr(ext.Publisher),
+			Type:                    to.StringPtr(ext.Type),
+			TypeHandlerVersion:      to.StringPtr(ext.Version),
+			AutoUpgradeMinorVersion: to.BoolPtr(true),
+			Settings:                settings,
+			ProtectedSettings:       protectedSettings,
+		},
+	})
+}
+
+func (s *Service) delete(ctx context.Context, extensionsSpec azure.VMExtensionsSpec, name string) error {
+	if extensionsSpec.ResourceType == azure.VirtualMachineScaleSet {
+		return s.client.DeleteScaleSetExtension(ctx, s.Scope.ResourceGroup(), extensionsSpec.MachineName, name)
+	}
+	return s.client.Delete(ctx, s.Scope.ResourceGroup(), extensionsSpec.MachineName, name)
+}
+
+// reconcileAutomaticRepairs enables or disables the automatic repairs of a scale set. They are left to the VM
+// extensions service, rather than the scale sets one, as Azure only enables them once the scale set has an
+// Application Health extension. Like the upgrade of the instances, the update is started without waiting for it to
+// complete, as the scale set model reflects the new policy right away.
+func (s *Service) reconcileAutomaticRepairs(ctx context.Context, extensionsSpec azure.VMExtensionsSpec) error {
+	ctx, span := tele.Tracer().Start(ctx, "vmextensions.Service.reconcileAutomaticRepairs")
+	defer span.End()
+
+	vmss, err := s.virtualMachineScaleSetClient.Get(ctx, s.Scope.ResourceGroup(), extensionsSpec.MachineName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get VMSS %s", extensionsSpec.MachineName)
+	}
+
+	enabled, gracePeriod := false, ""
+	if vmss.VirtualMachineScaleSetProperties != nil && vmss.AutomaticRepairsPolicy != nil && to.Bool(vmss.AutomaticRepairsPolicy.Enabled) {
+		enabled, gracePeriod = true, to.String(vmss.AutomaticRepairsPolicy.GracePeriod)
+	}
+	policy := &compute.AutomaticRepairsPolicy{Enabled: to.BoolPtr(false)}
+	if extensionsSpec.AutomaticRepairsGracePeriod != nil {
+		policy = &compute.AutomaticRepairsPolicy{
+			Enabled:     to.BoolPtr(true),
+			GracePeriod: to.StringPtr(fmt.Sprintf("PT%dM", *extensionsSpec.AutomaticRepairsGracePeriod)),
+		}
+	}
+	if enabled == to.Bool(policy.Enabled) && gracePeriod == to.String(policy.GracePeriod) {
+		return nil
+	}
+
+	s.Scope.V(2).Info("updating automatic repairs", "scale set", extensionsSpec.MachineName, "enabled", to.Bool(policy.Enabled))
+	update := compute.VirtualMachineScaleSetUpdate{
+		VirtualMachineScaleSetUpdateProperties: &compute.VirtualMachineScaleSetUpdateProperties{
+			AutomaticRepairsPolicy: policy,
+		},
+	}
+	if _, err := s.virtualMachineScaleSetClient.UpdateAsync(ctx, s.Scope.ResourceGroup(), extensionsSpec.MachineName, update); err != nil {
+		return errors.Wrapf(err, "failed to update automatic repairs of VMSS %s", extensionsSpec.MachineName)
+	}
+	return nil
+}
+
+// upgradeInstances starts the upgrade of the instances of a scale set to the model with the latest extensions,
+// without waiting for it to complete.
+func (s *Service) upgradeInstances(ctx context.Context, extensionsSpec azure.VMExtensionsSpec) error {
+	ctx, span := tele.Tracer().Start(ctx, "vmextensions.Service.upgradeInstances")
+	defer span.End()
+
+	instances, err := s.virtualMachineScaleSetClient.ListInstances(ctx, s.Scope.ResourceGroup(), extensionsSpec.MachineName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list instances of VMSS %s", extensionsSpec.MachineName)
+	}
+	instanceIDs := []string{}
+	for _, instance := range instances {
+		if instance.VirtualMachineScaleSetVMProperties != nil && !to.Bool(instance.LatestModelApplied) {
+			instanceIDs = append(instanceIDs, to.String(instance.InstanceID))
+		}
+	}
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+
+	s.Scope.V(2).Info("upgrading instances to the latest VM extensions", "scale set", extensionsSpec.MachineName, "instances", len(instanceIDs))
+	if _, err := s.virtualMachineScaleSetClient.UpdateInstancesAsync(ctx, s.Scope.ResourceGroup(), extensionsSpec.MachineName, instanceIDs); err != nil {
+		return errors.Wrapf(err, "failed to update VMSS %s instances", extensionsSpec.MachineName)
+	}
+	return nil
+}
+
+// Delete is a no-op as the VM extensions get deleted as part of VM or VMSS deletion.
+func (s *Service) Delete(ctx context.Context) error {
+	_, span := tele.Tracer().Start(ctx, "vmextensions.Service.Delete")
+	defer span.End()
+
+	return nil
+}
+
+// hash returns a hash of the whole configuration of the extension, including its protected settings.
+func (e extension) hash() (string, error) {
+	b, err := json.Marshal(struct {
+		Publisher         string                 `json:"publisher"`
+		Type              string                 `json:"type"`
+		Version           string                 `json:"version"`
+		Settings          map[string]interface{} `json:"settings,omitempty"`
+		ProtectedSettings map[string]interface{} `json:"protectedSettings,omitempty"`
+	}{e.Publisher, e.Type, e.Version, e.settings, e.protectedSettings})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func hasExtension(extensions []extension, name string) bool {
+	for _, ext := range extensions {
+		if ext.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// settingsEqual returns true if the settings returned by Azure match the desired ones, no settings being equal to
+// empty ones.
+func settingsEqual(existing interface{}, desired map[string]interface{}) bool {
+	existingMap, ok := existing.(map[string]interface{})
+	if existing != nil && !ok {
+		return false
+	}
+	if len(existingMap) == 0 && len(desired) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(existingMap, desired)
+}
@@ -0,0 +1,360 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmextensions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/klog/klogr"
+
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/scalesets/mock_scalesets"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/vmextensions/mock_vmextensions"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	monitorSpec = azure.VMExtensionSpec{
+		Name:                        "monitor",
+		Publisher:                   "Microsoft.Azure.Monitor",
+		Type:                        "AzureMonitorLinuxAgent",
+		Version:                     "1.5",
+		Settings:                    `{"workspaceId": "my-workspace"}`,
+		ProtectedSettingsSecretName: "monitor-secret",
+		ProtectedSettingsSecretKey:  "settings",
+	}
+	monitorProtectedSettings = `{"workspaceKey": "my-key"}`
+	healthSpec               = azure.VMExtensionSpec{
+		Name:      "ApplicationHealthLinux",
+		Publisher: "Microsoft.ManagedServices",
+		Type:      "ApplicationHealthLinux",
+		Version:   "1.0",
+		Settings:  `{"protocol": "http", "port": 80, "requestPath": "/healthz"}`,
+	}
+)
+
+func monitorExtension(version string) compute.VirtualMachineExtension {
+	return compute.VirtualMachineExtension{
+		Location: to.StringPtr("test-location"),
+		VirtualMachineExtensionProperties: &compute.VirtualMachineExtensionProperties{
+			Publisher:               to.StringPtr("Microsoft.Azure.Monitor"),
+			Type:                    to.StringPtr("AzureMonitorLinuxAgent"),
+			TypeHandlerVersion:      to.StringPtr(version),
+			AutoUpgradeMinorVersion: to.BoolPtr(true),
+			Settings:                map[string]interface{}{"workspaceId": "my-workspace"},
+			ProtectedSettings:       map[string]interface{}{"workspaceKey": "my-key"},
+		},
+	}
+}
+
+func healthExtension() compute.VirtualMachineScaleSetExtension {
+	return compute.VirtualMachineScaleSetExtension{
+		Name: to.StringPtr("ApplicationHealthLinux"),
+		VirtualMachineScaleSetExtensionProperties: &compute.VirtualMachineScaleSetExtensionProperties{
+			Publisher:               to.StringPtr("Microsoft.ManagedServices"),
+			Type:                    to.StringPtr("ApplicationHealthLinux"),
+			TypeHandlerVersion:      to.StringPtr("1.0"),
+			AutoUpgradeMinorVersion: to.BoolPtr(true),
+			Settings:                map[string]interface{}{"protocol": "http", "port": float64(80), "requestPath": "/healthz"},
+		},
+	}
+}
+
+// hashOf returns the hash tracked in the annotation for an extension spec and its protected settings.
+func hashOf(g *WithT, spec azure.VMExtensionSpec, protectedSettings string) string {
+	ext := extension{VMExtensionSpec: spec}
+	if spec.Settings != "" {
+		g.Expect(json.Unmarshal([]byte(spec.Settings), &ext.settings)).To(Succeed())
+	}
+	if protectedSettings != "" {
+		g.Expect(json.Unmarshal([]byte(protectedSettings), &ext.protectedSettings)).To(Succeed())
+	}
+	hash, err := ext.hash()
+	g.Expect(err).NotTo(HaveOccurred())
+	return hash
+}
+
+func TestReconcileVMExtensions(t *testing.T) {
+	g := NewWithT(t)
+	monitorHash := hashOf(g, monitorSpec, monitorProtectedSettings)
+	healthHash := hashOf(g, healthSpec, "")
+
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, m *mock_vmextensions.MockclientMockRecorder, v *mock_scalesets.MockClientMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "install a VM extension with protected settings",
+			expectedError: "",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, m *mock_vmextensions.MockclientMockRecorder, v *mock_scalesets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				s.VMExtensionsSpecs().Return([]azure.VMExtensionsSpec{
+					{
+						MachineName:  "my-vm",
+						ResourceType: azure.VirtualMachine,
+						Extensions:   []azure.VMExtensionSpec{monitorSpec},
+						Annotation:   "my-annotation",
+					},
+				})
+				s.AnnotationJSON("my-annotation").Return(map[string]interface{}{}, nil)
+				s.GetVMExtensionProtectedSettings(gomockinternal.AContext(), monitorSpec).Return(monitorProtectedSettings, nil)
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm", "monitor").Return(compute.VirtualMachineExtension{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"))
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vm", "monitor", gomockinternal.DiffEq(monitorExtension("1.5")))
+				s.UpdateAnnotationJSON("my-annotation", map[string]interface{}{"monitor": monitorHash})
+			},
+		},
+		{
+			name:          "VM extension unchanged",
+			expectedError: "",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, m *mock_vmextensions.MockclientMockRecorder, v *mock_scalesets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.VMExtensionsSpecs().Return([]azure.VMExtensionsSpec{
+					{
+						MachineName:  "my-vm",
+						ResourceType: azure.VirtualMachine,
+						Extensions:   []azure.VMExtensionSpec{monitorSpec},
+						Annotation:   "my-annotation",
+					},
+				})
+				s.AnnotationJSON("my-annotation").Return(map[string]interface{}{"monitor": monitorHash}, nil)
+				s.GetVMExtensionProtectedSettings(gomockinternal.AContext(), monitorSpec).Return(monitorProtectedSettings, nil)
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm", "monitor").Return(monitorExtension("1.5"), nil)
+			},
+		},
+		{
+			name:          "VM extension drifted from its spec",
+			expectedError: "",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, m *mock_vmextensions.MockclientMockRecorder, v *mock_scalesets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				s.VMExtensionsSpecs().Return([]azure.VMExtensionsSpec{
+					{
+						MachineName:  "my-vm",
+						ResourceType: azure.VirtualMachine,
+						Extensions:   []azure.VMExtensionSpec{monitorSpec},
+						Annotation:   "my-annotation",
+					},
+				})
+				s.AnnotationJSON("my-annotation").Return(map[string]interface{}{"monitor": monitorHash}, nil)
+				s.GetVMExtensionProtectedSettings(gomockinternal.AContext(), monitorSpec).Return(monitorProtectedSettings, nil)
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm", "monitor").Return(monitorExtension("1.4"), nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vm", "monitor", gomockinternal.DiffEq(monitorExtension("1.5")))
+				s.UpdateAnnotationJSON("my-annotation", map[string]interface{}{"monitor": monitorHash})
+			},
+		},
+		{
+			name:          "VM extension protected settings changed",
+			expectedError: "",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, m *mock_vmextensions.MockclientMockRecorder, v *mock_scalesets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				s.VMExtensionsSpecs().Return([]azure.VMExtensionsSpec{
+					{
+						MachineName:  "my-vm",
+						ResourceType: azure.VirtualMachine,
+						Extensions:   []azure.VMExtensionSpec{monitorSpec},
+						Annotation:   "my-annotation",
+					},
+				})
+				s.AnnotationJSON("my-annotation").Return(map[string]interface{}{"monitor": "previous-hash"}, nil)
+				s.GetVMExtensionProtectedSettings(gomockinternal.AContext(), monitorSpec).Return(monitorProtectedSettings, nil)
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm", "monitor").Return(monitorExtension("1.5"), nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vm", "monitor", gomockinternal.DiffEq(monitorExtension("1.5")))
+				s.UpdateAnnotationJSON("my-annotation", map[string]interface{}{"monitor": monitorHash})
+			},
+		},
+		{
+			name:          "uninstall a VM extension removed from the spec",
+			expectedError: "",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, m *mock_vmextensions.MockclientMockRecorder, v *mock_scalesets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.VMExtensionsSpecs().Return([]azure.VMExtensionsSpec{
+					{
+						MachineName:  "my-vm",
+						ResourceType: azure.VirtualMachine,
+						Annotation:   "my-annotation",
+					},
+				})
+				s.AnnotationJSON("my-annotation").Return(map[string]interface{}{"monitor": monitorHash}, nil)
+				m.Delete(gomockinternal.AContext(), "my-rg", "my-vm", "monitor")
+				s.UpdateAnnotationJSON("my-annotation", map[string]interface{}{})
+			},
+		},
+		{
+			name:          "error installing a VM extension",
+			expectedError: "failed to install VM extension monitor: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, m *mock_vmextensions.MockclientMockRecorder, v *mock_scalesets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				s.VMExtensionsSpecs().Return([]azure.VMExtensionsSpec{
+					{
+						MachineName:  "my-vm",
+						ResourceType: azure.VirtualMachine,
+						Extensions:   []azure.VMExtensionSpec{monitorSpec},
+						Annotation:   "my-annotation",
+					},
+				})
+				s.AnnotationJSON("my-annotation").Return(map[string]interface{}{}, nil)
+				s.GetVMExtensionProtectedSettings(gomockinternal.AContext(), monitorSpec).Return(monitorProtectedSettings, nil)
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vm", "monitor").Return(compute.VirtualMachineExtension{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"))
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-vm", "monitor", gomock.Any()).Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+		{
+			name:          "install the Application Health extension of a scale set with automatic repairs",
+			expectedError: "",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, m *mock_vmextensions.MockclientMockRecorder, v *mock_scalesets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.VMExtensionsSpecs().Return([]azure.VMExtensionsSpec{
+					{
+						MachineName:                 "my-vmss",
+						ResourceType:                azure.VirtualMachineScaleSet,
+						Extensions:                  []azure.VMExtensionSpec{healthSpec},
+						Annotation:                  "my-annotation",
+						AutomaticRepairsGracePeriod: to.IntPtr(30),
+					},
+				})
+				s.AnnotationJSON("my-annotation").Return(map[string]interface{}{}, nil)
+				s.GetVMExtensionProtectedSettings(gomockinternal.AContext(), healthSpec)
+				m.GetScaleSetExtension(gomockinternal.AContext(), "my-rg", "my-vmss", "ApplicationHealthLinux").Return(compute.VirtualMachineScaleSetExtension{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"))
+				m.CreateOrUpdateScaleSetExtension(gomockinternal.AContext(), "my-rg", "my-vmss", "ApplicationHealthLinux", gomockinternal.DiffEq(healthExtension()))
+				s.UpdateAnnotationJSON("my-annotation", map[string]interface{}{"ApplicationHealthLinux": healthHash})
+				v.Get(gomockinternal.AContext(), "my-rg", "my-vmss").Return(compute.VirtualMachineScaleSet{
+					VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{},
+				}, nil)
+				v.UpdateAsync(gomockinternal.AContext(), "my-rg", "my-vmss", compute.VirtualMachineScaleSetUpdate{
+					VirtualMachineScaleSetUpdateProperties: &compute.VirtualMachineScaleSetUpdateProperties{
+						AutomaticRepairsPolicy: &compute.AutomaticRepairsPolicy{
+							Enabled:     to.BoolPtr(true),
+							GracePeriod: to.StringPtr("PT30M"),
+						},
+					},
+				})
+				v.ListInstances(gomockinternal.AContext(), "my-rg", "my-vmss").Return([]compute.VirtualMachineScaleSetVM{
+					{
+						InstanceID: to.StringPtr("0"),
+						VirtualMachineScaleSetVMProperties: &compute.VirtualMachineScaleSetVMProperties{
+							LatestModelApplied: to.BoolPtr(false),
+						},
+					},
+					{
+						InstanceID: to.StringPtr("1"),
+						VirtualMachineScaleSetVMProperties: &compute.VirtualMachineScaleSetVMProperties{
+							LatestModelApplied: to.BoolPtr(true),
+						},
+					},
+				}, nil)
+				v.UpdateInstancesAsync(gomockinternal.AContext(), "my-rg", "my-vmss", []string{"0"})
+			},
+		},
+		{
+			name:          "disable the automatic repairs before uninstalling the Application Health extension",
+			expectedError: "",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, m *mock_vmextensions.MockclientMockRecorder, v *mock_scalesets.MockClientMockRecorder) {
+				s.V(gomock.AssignableToTypeOf(2)).AnyTimes().Return(klogr.New())
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.VMExtensionsSpecs().Return([]azure.VMExtensionsSpec{
+					{
+						MachineName:  "my-vmss",
+						ResourceType: azure.VirtualMachineScaleSet,
+						Annotation:   "my-annotation",
+					},
+				})
+				s.AnnotationJSON("my-annotation").Return(map[string]interface{}{"ApplicationHealthLinux": healthHash}, nil)
+				gomock.InOrder(
+					v.Get(gomockinternal.AContext(), "my-rg", "my-vmss").Return(compute.VirtualMachineScaleSet{
+						VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+							AutomaticRepairsPolicy: &compute.AutomaticRepairsPolicy{
+								Enabled:     to.BoolPtr(true),
+								GracePeriod: to.StringPtr("PT30M"),
+							},
+						},
+					}, nil),
+					v.UpdateAsync(gomockinternal.AContext(), "my-rg", "my-vmss", compute.VirtualMachineScaleSetUpdate{
+						VirtualMachineScaleSetUpdateProperties: &compute.VirtualMachineScaleSetUpdateProperties{
+							AutomaticRepairsPolicy: &compute.AutomaticRepairsPolicy{
+								Enabled: to.BoolPtr(false),
+							},
+						},
+					}),
+					m.DeleteScaleSetExtension(gomockinternal.AContext(), "my-rg", "my-vmss", "ApplicationHealthLinux"),
+					s.UpdateAnnotationJSON("my-annotation", map[string]interface{}{}),
+					v.ListInstances(gomockinternal.AContext(), "my-rg", "my-vmss"),
+				)
+			},
+		},
+		{
+			name:          "unexpected resource type",
+			expectedError: "unexpected resource type \"Disk\". Expected one of [VirtualMachine, VirtualMachineScaleSet]",
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, m *mock_vmextensions.MockclientMockRecorder, v *mock_scalesets.MockClientMockRecorder) {
+				s.VMExtensionsSpecs().Return([]azure.VMExtensionsSpec{
+					{
+						MachineName:  "my-disk",
+						ResourceType: "Disk",
+						Annotation:   "my-annotation",
+					},
+				})
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_vmextensions.NewMockVMExtensionScope(mockCtrl)
+			clientMock := mock_vmextensions.NewMockclient(mockCtrl)
+			scaleSetsMock := mock_scalesets.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), scaleSetsMock.EXPECT())
+
+			s := &Service{
+				Scope:                        scopeMock,
+				client:                       clientMock,
+				virtualMachineScaleSetClient: scaleSetsMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -25,6 +25,14 @@ type PublicIPSpec struct {
 	Name    string
 	DNSName string
 	IsIPv6  bool
+	// ID is the resource ID of an existing public IP to adopt instead of creating one.
+	ID string
+}
+
+// PublicIPPrefixSpec defines the specification for a Public IP prefix.
+type PublicIPPrefixSpec struct {
+	Name         string
+	PrefixLength int32
 }
 
 // NICSpec defines the specification for a Network Interface.
@@ -40,6 +48,7 @@ type NICSpec struct {
 	PublicLBNATRuleName       string
 	InternalLBName            string
 	InternalLBAddressPoolName string
+	AdditionalLBAddressPools  []LBBackendPoolSpec
 	PublicIPName              string
 	VMSize                    string
 	AcceleratedNetworking     *bool
@@ -47,21 +56,43 @@ type NICSpec struct {
 	EnableIPForwarding        bool
 }
 
+// LBBackendPoolSpec defines the specification for the membership in a backend pool of an additional load balancer.
+type LBBackendPoolSpec struct {
+	LoadBalancerName string
+	PoolName         string
+}
+
 // DiskSpec defines the specification for a Disk.
 type DiskSpec struct {
 	Name string
+	// DiskSizeGB is the desired size of a data disk. It is zero for the OS disk, which is never resized.
+	DiskSizeGB int32
+	// DiskIOPSReadWrite and DiskMBpsReadWrite are the desired performance targets of an ultra data disk.
+	DiskIOPSReadWrite *int64
+	DiskMBpsReadWrite *int64
+	DeletionPolicy    infrav1.DiskDeletionPolicy
+	// MachineName is the name of the machine the disk is attached to.
+	MachineName string
 }
 
 // LBSpec defines the specification for a Load Balancer.
 type LBSpec struct {
-	Name              string
-	Role              string
-	Type              infrav1.LBType
-	SKU               infrav1.SKU
-	SubnetName        string
-	BackendPoolName   string
-	FrontendIPConfigs []infrav1.FrontendIP
-	APIServerPort     int32
+	Name                   string
+	Role                   string
+	Type                   infrav1.LBType
+	SKU                    infrav1.SKU
+	SubnetName             string
+	BackendPoolName        string
+	FrontendIPConfigs      []infrav1.FrontendIP
+	APIServerPort          int32
+	BackendPools           []infrav1.BackendPool
+	Probes                 []infrav1.LoadBalancerProbe
+	Rules                  []infrav1.LoadBalancingRule
+	HealthProbe            *infrav1.HealthProbe
+	IdleTimeoutInMinutes   *int32
+	EnableTCPReset         *bool
+	EnableFloatingIP       *bool
+	AllocatedOutboundPorts *int32
 }
 
 // RouteTableRole defines the unique role of a route table.
@@ -71,6 +102,7 @@ type RouteTableRole string
 type RouteTableSpec struct {
 	Name   string
 	Subnet *infrav1.SubnetSpec
+	Routes []infrav1.Route
 }
 
 // InboundNatSpec defines the specification for an inbound NAT rule.
@@ -101,6 +133,10 @@ type RoleAssignmentSpec struct {
 	MachineName  string
 	Name         string
 	ResourceType string
+	// RoleDefinitionID is the resource ID of the role to assign. Defaults to the built-in Contributor role when empty.
+	RoleDefinitionID string
+	// Scope is the resource ID the role is assigned on. Defaults to the subscription when empty.
+	Scope string
 }
 
 // ResourceType defines the type azure resource being reconciled.
@@ -136,6 +172,9 @@ type VMSpec struct {
 	UserAssignedIdentities []infrav1.UserAssignedIdentity
 	SpotVMOptions          *infrav1.SpotVMOptions
 	SecurityProfile        *infrav1.SecurityProfile
+	// ProximityPlacementGroupName is the proximity placement group of the VM in the cluster resource group, if any.
+	ProximityPlacementGroupName string
+	DedicatedHostGroupID        string
 }
 
 // BastionSpec defines the specification for bastion host.
@@ -159,12 +198,33 @@ type ScaleSetSpec struct {
 	VNetResourceGroup            string
 	PublicLBName                 string
 	PublicLBAddressPoolName      string
+	AdditionalLBAddressPools     []LBBackendPoolSpec
 	AcceleratedNetworking        *bool
 	TerminateNotificationTimeout *int
 	Identity                     infrav1.VMIdentity
 	UserAssignedIdentities       []infrav1.UserAssignedIdentity
 	SecurityProfile              *infrav1.SecurityProfile
 	SpotVMOptions                *infrav1.SpotVMOptions
+	// ProximityPlacementGroupName is the proximity placement group of the scale set in the cluster resource group, if any.
+	ProximityPlacementGroupName string
+}
+
+// ProximityPlacementGroupSpec defines the specification for a proximity placement group.
+type ProximityPlacementGroupSpec struct {
+	Name string
+}
+
+// StorageAccountSpec defines the specification for a storage account and its blob container.
+type StorageAccountSpec struct {
+	Name          string
+	ContainerName string
+}
+
+// BootstrapDataSpec defines the specification for the blob holding the bootstrap data of a VM.
+type BootstrapDataSpec struct {
+	StorageAccountName string
+	ContainerName      string
+	BlobName           string
 }
 
 // TagsSpec defines the specification for a set of tags.
@@ -174,11 +234,51 @@ type TagsSpec struct {
 	Annotation string
 }
 
+// VMExtensionsSpec defines the specification for the extensions of a VM or of a scale set.
+type VMExtensionsSpec struct {
+	MachineName  string
+	ResourceType string
+	Extensions   []VMExtensionSpec
+	// Annotation tracks the extensions applied last, so that the extensions removed from the spec are uninstalled.
+	Annotation string
+	// AutomaticRepairsGracePeriod enables the automatic repairs of a scale set, fed by its Application Health
+	// extension, with a grace period in minutes.
+	AutomaticRepairsGracePeriod *int
+}
+
+// VMExtensionSpec defines the specification for a VM extension.
+type VMExtensionSpec struct {
+	Name      string
+	Publisher string
+	Type      string
+	Version   string
+	// Settings is the JSON object of the public settings.
+	Settings string
+	// ProtectedSettingsSecretName and ProtectedSettingsSecretKey reference the JSON object of the protected settings.
+	ProtectedSettingsSecretName string
+	ProtectedSettingsSecretKey  string
+}
+
+// PrivateLinkSpec defines the specification for a Private Link Service and its private endpoints.
+type PrivateLinkSpec struct {
+	Name                 string
+	LBName               string
+	FrontendIPConfigName string
+	SubnetName           string
+	VNetName             string
+	VNetResourceGroup    string
+	AllowedSubscriptions []string
+	PrivateEndpoints     []*infrav1.PrivateEndpointSpec
+}
+
 // PrivateDNSSpec defines the specification for a private DNS zone.
 type PrivateDNSSpec struct {
-	ZoneName          string
-	VNetName          string
-	VNetResourceGroup string
-	LinkName          string
-	Records           []infrav1.AddressRecord
+	ZoneName           string
+	ZoneResourceGroup  string
+	ZoneSubscriptionID string
+	ExistingZone       bool
+	VNetName           string
+	VNetResourceGroup  string
+	LinkName           string
+	Records            []infrav1.AddressRecord
 }
@@ -42,7 +42,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -53,6 +55,7 @@ type AzureClusterReconciler struct {
 	Log              logr.Logger
 	Recorder         record.EventRecorder
 	ReconcileTimeout time.Duration
+	SKUCaches        *resourceskus.Caches
 }
 
 // SetupWithManager initializes this controller with a manager.
@@ -202,10 +205,38 @@ func (r *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterSco
 		}
 	}
 
-	err := newAzureClusterReconciler(clusterScope).Reconcile(ctx)
+	reportSkippedServices(r.Recorder, azureCluster)
+
+	err := azure.ClassifyError(newAzureClusterReconciler(clusterScope, r.SKUCaches.Get(clusterScope, clusterScope.Location())).Reconcile(ctx))
 	if err != nil {
+		// An operation of a service, such as the creation of a load balancer, was started but has not completed yet,
+		// check on it again on the next reconcile.
+		var operationNotDoneError azure.OperationNotDoneError
+		if errors.As(err, &operationNotDoneError) {
+			clusterScope.V(2).Info("cluster operation in progress", "type", operationNotDoneError.Future.Type, "service", operationNotDoneError.Future.ServiceName, "name", operationNotDoneError.Future.Name)
+			conditions.MarkFalse(azureCluster, infrav1.NetworkInfrastructureReadyCondition, infrav1.NetworkInfrastructureProvisioningReason, clusterv1.ConditionSeverityInfo, "")
+			return reconcile.Result{RequeueAfter: azure.DefaultOperationPollingRequeue}, nil
+		}
+
 		wrappedErr := errors.Wrap(err, "failed to reconcile cluster services")
 		r.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "ClusterReconcilerNormalFailed", wrappedErr.Error())
+
+		// Handle transient and terminal errors
+		var reconcileError azure.ReconcileError
+		if errors.As(err, &reconcileError) {
+			if reconcileError.IsTerminal() {
+				clusterScope.Error(err, "failed to reconcile AzureCluster", "name", clusterScope.ClusterName())
+				conditions.MarkFalse(azureCluster, infrav1.NetworkInfrastructureReadyCondition, infrav1.NetworkInfrastructureFailedReason, clusterv1.ConditionSeverityError, err.Error())
+				return reconcile.Result{}, nil
+			}
+
+			if reconcileError.IsTransient() {
+				clusterScope.Error(err, "failed to reconcile AzureCluster", "name", clusterScope.ClusterName())
+				conditions.MarkFalse(azureCluster, infrav1.NetworkInfrastructureReadyCondition, infrav1.NetworkInfrastructureFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+				return reconcile.Result{RequeueAfter: reconcileError.RequeueAfter()}, nil
+			}
+		}
+
 		return reconcile.Result{}, wrappedErr
 	}
 
@@ -230,14 +261,25 @@ func (r *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterSco
 
 	azureCluster := clusterScope.AzureCluster
 	conditions.MarkFalse(azureCluster, infrav1.NetworkInfrastructureReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+	reportSkippedServices(r.Recorder, azureCluster)
 	if err := clusterScope.PatchObject(ctx); err != nil {
 		return reconcile.Result{}, err
 	}
 
-	if err := newAzureClusterReconciler(clusterScope).Delete(ctx); err != nil {
+	if err := azure.ClassifyError(newAzureClusterReconciler(clusterScope, r.SKUCaches.Get(clusterScope, clusterScope.Location())).Delete(ctx)); err != nil {
+		var operationNotDoneError azure.OperationNotDoneError
+		if errors.As(err, &operationNotDoneError) {
+			clusterScope.V(2).Info("cluster operation in progress", "type", operationNotDoneError.Future.Type, "service", operationNotDoneError.Future.ServiceName, "name", operationNotDoneError.Future.Name)
+			return reconcile.Result{RequeueAfter: azure.DefaultOperationPollingRequeue}, nil
+		}
+
 		wrappedErr := errors.Wrapf(err, "error deleting AzureCluster %s/%s", azureCluster.Namespace, azureCluster.Name)
 		r.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "ClusterReconcilerDeleteFailed", wrappedErr.Error())
 		conditions.MarkFalse(azureCluster, infrav1.NetworkInfrastructureReadyCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		var reconcileError azure.ReconcileError
+		if errors.As(err, &reconcileError) && reconcileError.IsTransient() {
+			return reconcile.Result{RequeueAfter: reconcileError.RequeueAfter()}, nil
+		}
 		return reconcile.Result{}, wrappedErr
 	}
 
@@ -21,48 +21,61 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/privatedns"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/loadbalancers"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/privatelinks"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/proximityplacementgroups"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/securitygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/storageaccounts"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/subnets"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/virtualnetworks"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
 // azureClusterReconciler is the reconciler called by the AzureCluster controller
 type azureClusterReconciler struct {
-	scope            *scope.ClusterScope
-	groupsSvc        azure.Service
-	vnetSvc          azure.Service
-	securityGroupSvc azure.Service
-	routeTableSvc    azure.Service
-	subnetsSvc       azure.Service
-	publicIPSvc      azure.Service
-	loadBalancerSvc  azure.Service
-	privateDNSSvc    azure.Service
-	skuCache         *resourceskus.Cache
+	scope                      *scope.ClusterScope
+	groupsSvc                  azure.Service
+	vnetSvc                    azure.Service
+	securityGroupSvc           azure.Service
+	routeTableSvc              azure.Service
+	subnetsSvc                 azure.Service
+	publicIPSvc                azure.Service
+	loadBalancerSvc            azure.Service
+	privateDNSSvc              azure.Service
+	privateLinkSvc             azure.Service
+	proximityPlacementGroupSvc azure.Service
+	storageAccountSvc          azure.Service
+	skuCache                   *resourceskus.Cache
+	skippedServices            sets.String
 }
 
 // newAzureClusterReconciler populates all the services based on input scope
-func newAzureClusterReconciler(scope *scope.ClusterScope) *azureClusterReconciler {
+func newAzureClusterReconciler(scope *scope.ClusterScope, skuCache *resourceskus.Cache) *azureClusterReconciler {
 	return &azureClusterReconciler{
-		scope:            scope,
-		groupsSvc:        groups.New(scope),
-		vnetSvc:          virtualnetworks.New(scope),
-		securityGroupSvc: securitygroups.New(scope),
-		routeTableSvc:    routetables.New(scope),
-		subnetsSvc:       subnets.New(scope),
-		publicIPSvc:      publicips.New(scope),
-		loadBalancerSvc:  loadbalancers.New(scope),
-		privateDNSSvc:    privatedns.New(scope),
-		skuCache:         resourceskus.NewCache(scope, scope.Location()),
+		scope:                      scope,
+		groupsSvc:                  groups.New(scope),
+		vnetSvc:                    virtualnetworks.New(scope),
+		securityGroupSvc:           securitygroups.New(scope),
+		routeTableSvc:              routetables.New(scope),
+		subnetsSvc:                 subnets.New(scope),
+		publicIPSvc:                publicips.New(scope),
+		loadBalancerSvc:            loadbalancers.New(scope),
+		privateDNSSvc:              privatedns.New(scope),
+		privateLinkSvc:             privatelinks.New(scope),
+		proximityPlacementGroupSvc: proximityplacementgroups.New(scope),
+		storageAccountSvc:          storageaccounts.New(scope),
+		skuCache:                   skuCache,
+		skippedServices:            skippedServices(scope.AzureCluster),
 	}
 }
 
@@ -78,39 +91,25 @@ func (r *azureClusterReconciler) Reconcile(ctx context.Context) error {
 	r.scope.SetDNSName()
 	r.scope.SetControlPlaneIngressRules()
 
-	if err := r.groupsSvc.Reconcile(ctx); err != nil {
-		return errors.Wrapf(err, "failed to reconcile resource group")
-	}
-
-	if err := r.vnetSvc.Reconcile(ctx); err != nil {
-		return errors.Wrapf(err, "failed to reconcile virtual network")
-	}
-
-	if err := r.securityGroupSvc.Reconcile(ctx); err != nil {
-		return errors.Wrapf(err, "failed to reconcile network security group")
-	}
-
-	if err := r.routeTableSvc.Reconcile(ctx); err != nil {
-		return errors.Wrapf(err, "failed to reconcile route table")
-	}
-
-	if err := r.subnetsSvc.Reconcile(ctx); err != nil {
-		return errors.Wrapf(err, "failed to reconcile subnet")
-	}
-
-	if err := r.publicIPSvc.Reconcile(ctx); err != nil {
-		return errors.Wrapf(err, "failed to reconcile public IP")
-	}
-
-	if err := r.loadBalancerSvc.Reconcile(ctx); err != nil {
-		return errors.Wrapf(err, "failed to reconcile load balancer")
-	}
-
-	if err := r.privateDNSSvc.Reconcile(ctx); err != nil {
-		return errors.Wrapf(err, "failed to reconcile private dns")
+	if !r.skippedServices.Has("groups") {
+		if err := r.groupsSvc.Reconcile(ctx); err != nil {
+			return errors.Wrapf(err, "failed to reconcile resource group")
+		}
 	}
 
-	return nil
+	// The services below only depend on each other as described by the graph, so that independent resources such as
+	// security groups, route tables and public IPs are reconciled concurrently.
+	return reconciler.RunGraph(ctx, reconciler.DefaultMaxConcurrentServices,
+		reconcileStep(r.skippedServices, "virtualnetworks", r.vnetSvc, "failed to reconcile virtual network"),
+		reconcileStep(r.skippedServices, "securitygroups", r.securityGroupSvc, "failed to reconcile network security group", "virtualnetworks"),
+		reconcileStep(r.skippedServices, "routetables", r.routeTableSvc, "failed to reconcile route table", "virtualnetworks"),
+		reconcileStep(r.skippedServices, "subnets", r.subnetsSvc, "failed to reconcile subnet", "securitygroups", "routetables"),
+		reconcileStep(r.skippedServices, "publicips", r.publicIPSvc, "failed to reconcile public IP"),
+		reconcileStep(r.skippedServices, "loadbalancers", r.loadBalancerSvc, "failed to reconcile load balancer", "subnets", "publicips"),
+		reconcileStep(r.skippedServices, "privatelinks", r.privateLinkSvc, "failed to reconcile private link", "loadbalancers"),
+		reconcileStep(r.skippedServices, "privatedns", r.privateDNSSvc, "failed to reconcile private dns", "virtualnetworks", "privatelinks"),
+		reconcileStep(r.skippedServices, "proximityplacementgroups", r.proximityPlacementGroupSvc, "failed to reconcile proximity placement group"),
+	)
 }
 
 // Delete reconciles all the services in pre determined order
@@ -118,38 +117,55 @@ func (r *azureClusterReconciler) Delete(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "controllers.azureClusterReconciler.Delete")
 	defer span.End()
 
-	if err := r.groupsSvc.Delete(ctx); err != nil {
-		if errors.Is(err, azure.ErrNotOwned) {
-			if err := r.privateDNSSvc.Delete(ctx); err != nil {
-				return errors.Wrapf(err, "failed to delete private dns")
-			}
-
-			if err := r.loadBalancerSvc.Delete(ctx); err != nil {
-				return errors.Wrapf(err, "failed to delete load balancer")
-			}
-
-			if err := r.publicIPSvc.Delete(ctx); err != nil {
-				return errors.Wrapf(err, "failed to delete public IP")
-			}
-
-			if err := r.subnetsSvc.Delete(ctx); err != nil {
-				return errors.Wrapf(err, "failed to delete subnet")
-			}
-
-			if err := r.routeTableSvc.Delete(ctx); err != nil {
-				return errors.Wrapf(err, "failed to delete route table")
-			}
+	// A custom vnet shared with the cluster usually lives outside of its resource group, so the subnets the cluster
+	// added to it are released first, after the load balancers using them, as they may reference security groups and
+	// route tables of the resource group. The route tables are dissociated from the subnets provided with the vnet.
+	if r.scope.Vnet().Tags.HasShared(r.scope.ClusterName()) {
+		if err := reconciler.RunGraph(ctx, reconciler.DefaultMaxConcurrentServices,
+			deleteStep(r.skippedServices, "privatelinks", r.privateLinkSvc, "failed to delete private link"),
+			deleteStep(r.skippedServices, "loadbalancers", r.loadBalancerSvc, "failed to delete load balancer", "privatelinks"),
+			deleteStep(r.skippedServices, "subnets", r.subnetsSvc, "failed to delete subnet", "loadbalancers"),
+			deleteStep(r.skippedServices, "routetables", r.routeTableSvc, "failed to delete route table", "subnets"),
+			deleteStep(r.skippedServices, "virtualnetworks", r.vnetSvc, "failed to delete virtual network", "routetables"),
+		); err != nil {
+			return err
+		}
+	}
 
-			if err := r.securityGroupSvc.Delete(ctx); err != nil {
-				return errors.Wrapf(err, "failed to delete network security group")
-			}
+	// The records and the virtual network link of the cluster in an existing private DNS zone live outside of the
+	// resource group, so they are removed before it is deleted.
+	skipped := r.skippedServices
+	if dnsSpec := r.scope.PrivateDNSSpec(); dnsSpec != nil && dnsSpec.ExistingZone && !skipped.Has("privatedns") {
+		if err := r.privateDNSSvc.Delete(ctx); err != nil {
+			return errors.Wrap(err, "failed to delete private dns")
+		}
+		skipped = skipped.Union(sets.NewString("privatedns"))
+	}
 
-			if err := r.vnetSvc.Delete(ctx); err != nil {
-				return errors.Wrapf(err, "failed to delete virtual network")
-			}
-		} else {
+	// A skipped resource group is handled like one not owned by the cluster, deleting its resources individually.
+	err := azure.ErrNotOwned
+	if !skipped.Has("groups") {
+		err = r.groupsSvc.Delete(ctx)
+	}
+	if err != nil {
+		if !errors.Is(err, azure.ErrNotOwned) {
 			return errors.Wrapf(err, "failed to delete resource group")
 		}
+
+		// The resource group is not owned by the cluster so its resources are deleted individually, each one
+		// after the resources referencing it.
+		return reconciler.RunGraph(ctx, reconciler.DefaultMaxConcurrentServices,
+			deleteStep(skipped, "privatedns", r.privateDNSSvc, "failed to delete private dns"),
+			deleteStep(skipped, "privatelinks", r.privateLinkSvc, "failed to delete private link"),
+			deleteStep(skipped, "loadbalancers", r.loadBalancerSvc, "failed to delete load balancer", "privatelinks"),
+			deleteStep(skipped, "publicips", r.publicIPSvc, "failed to delete public IP", "loadbalancers"),
+			deleteStep(skipped, "subnets", r.subnetsSvc, "failed to delete subnet", "loadbalancers"),
+			deleteStep(skipped, "routetables", r.routeTableSvc, "failed to delete route table", "subnets"),
+			deleteStep(skipped, "securitygroups", r.securityGroupSvc, "failed to delete network security group", "subnets"),
+			deleteStep(skipped, "virtualnetworks", r.vnetSvc, "failed to delete virtual network", "privatedns", "routetables", "securitygroups"),
+			deleteStep(skipped, "proximityplacementgroups", r.proximityPlacementGroupSvc, "failed to delete proximity placement group"),
+			deleteStep(skipped, "storageaccounts", r.storageAccountSvc, "failed to delete storage account"),
+		)
 	}
 
 	return nil
@@ -24,7 +24,11 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/mocks"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
@@ -32,63 +36,159 @@ import (
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 )
 
-type expect func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder)
+type expect func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder, pl *mocks.MockServiceMockRecorder, ppg *mocks.MockServiceMockRecorder, sa *mocks.MockServiceMockRecorder)
+
+var internalAPIServerLB = infrav1.LoadBalancerSpec{
+	Name:        "my-cluster-internal-lb",
+	Type:        infrav1.Internal,
+	FrontendIPs: []infrav1.FrontendIP{{Name: "my-cluster-internal-lb-frontEnd", PrivateIPAddress: "10.0.0.100"}},
+}
 
 func TestAzureClusterReconcilerDelete(t *testing.T) {
 	cases := map[string]struct {
-		expectedError string
-		expect        expect
+		expectedError   string
+		skippedServices sets.String
+		vnet            infrav1.VnetSpec
+		apiServerLB     infrav1.LoadBalancerSpec
+		privateDNSZone  *infrav1.PrivateDNSZoneSpec
+		expect          expect
 	}{
 		"Resource Group is deleted successfully": {
 			expectedError: "",
-			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder) {
+			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder, pl *mocks.MockServiceMockRecorder, ppg *mocks.MockServiceMockRecorder, sa *mocks.MockServiceMockRecorder) {
 				gomock.InOrder(
 					grp.Delete(gomockinternal.AContext()).Return(nil))
 			},
 		},
 		"Resource Group delete fails": {
 			expectedError: "failed to delete resource group: internal error",
-			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder) {
+			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder, pl *mocks.MockServiceMockRecorder, ppg *mocks.MockServiceMockRecorder, sa *mocks.MockServiceMockRecorder) {
 				gomock.InOrder(
 					grp.Delete(gomockinternal.AContext()).Return(errors.New("internal error")))
 			},
 		},
 		"Resource Group not owned by cluster": {
 			expectedError: "",
-			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder) {
-				gomock.InOrder(
-					grp.Delete(gomockinternal.AContext()).Return(azure.ErrNotOwned),
-					dns.Delete(gomockinternal.AContext()),
-					lb.Delete(gomockinternal.AContext()),
-					pip.Delete(gomockinternal.AContext()),
-					sn.Delete(gomockinternal.AContext()),
-					rt.Delete(gomockinternal.AContext()),
-					sg.Delete(gomockinternal.AContext()),
-					vnet.Delete(gomockinternal.AContext()),
-				)
+			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder, pl *mocks.MockServiceMockRecorder, ppg *mocks.MockServiceMockRecorder, sa *mocks.MockServiceMockRecorder) {
+				grp.Delete(gomockinternal.AContext()).Return(azure.ErrNotOwned)
+				ppg.Delete(gomockinternal.AContext())
+				sa.Delete(gomockinternal.AContext())
+				dnsDelete := dns.Delete(gomockinternal.AContext())
+				plDelete := pl.Delete(gomockinternal.AContext())
+				lbDelete := lb.Delete(gomockinternal.AContext()).After(plDelete)
+				pip.Delete(gomockinternal.AContext()).After(lbDelete)
+				snDelete := sn.Delete(gomockinternal.AContext()).After(lbDelete)
+				rtDelete := rt.Delete(gomockinternal.AContext()).After(snDelete)
+				sgDelete := sg.Delete(gomockinternal.AContext()).After(snDelete)
+				vnet.Delete(gomockinternal.AContext()).After(dnsDelete).After(rtDelete).After(sgDelete)
+			},
+		},
+		"Skipped services are not deleted": {
+			expectedError:   "",
+			skippedServices: sets.NewString("groups", "securitygroups", "loadbalancers"),
+			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder, pl *mocks.MockServiceMockRecorder, ppg *mocks.MockServiceMockRecorder, sa *mocks.MockServiceMockRecorder) {
+				dnsDelete := dns.Delete(gomockinternal.AContext())
+				pl.Delete(gomockinternal.AContext())
+				ppg.Delete(gomockinternal.AContext())
+				sa.Delete(gomockinternal.AContext())
+				pip.Delete(gomockinternal.AContext())
+				snDelete := sn.Delete(gomockinternal.AContext())
+				rtDelete := rt.Delete(gomockinternal.AContext()).After(snDelete)
+				vnet.Delete(gomockinternal.AContext()).After(dnsDelete).After(rtDelete)
 			},
 		},
 		"Load Balancer delete fails": {
 			expectedError: "failed to delete load balancer: some error happened",
-			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder) {
-				gomock.InOrder(
-					grp.Delete(gomockinternal.AContext()).Return(azure.ErrNotOwned),
-					dns.Delete(gomockinternal.AContext()),
-					lb.Delete(gomockinternal.AContext()).Return(errors.New("some error happened")),
-				)
+			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder, pl *mocks.MockServiceMockRecorder, ppg *mocks.MockServiceMockRecorder, sa *mocks.MockServiceMockRecorder) {
+				grp.Delete(gomockinternal.AContext()).Return(azure.ErrNotOwned)
+				ppg.Delete(gomockinternal.AContext())
+				sa.Delete(gomockinternal.AContext())
+				dns.Delete(gomockinternal.AContext())
+				plDelete := pl.Delete(gomockinternal.AContext())
+				lb.Delete(gomockinternal.AContext()).After(plDelete).Return(errors.New("some error happened"))
 			},
 		},
 		"Route table delete fails": {
 			expectedError: "failed to delete route table: some error happened",
-			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder) {
+			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder, pl *mocks.MockServiceMockRecorder, ppg *mocks.MockServiceMockRecorder, sa *mocks.MockServiceMockRecorder) {
+				grp.Delete(gomockinternal.AContext()).Return(azure.ErrNotOwned)
+				ppg.Delete(gomockinternal.AContext())
+				sa.Delete(gomockinternal.AContext())
+				dns.Delete(gomockinternal.AContext())
+				plDelete := pl.Delete(gomockinternal.AContext())
+				lbDelete := lb.Delete(gomockinternal.AContext()).After(plDelete)
+				pip.Delete(gomockinternal.AContext()).After(lbDelete)
+				snDelete := sn.Delete(gomockinternal.AContext()).After(lbDelete)
+				rt.Delete(gomockinternal.AContext()).After(snDelete).Return(errors.New("some error happened"))
+				sg.Delete(gomockinternal.AContext()).After(snDelete)
+			},
+		},
+		"Subnets of shared vnet are released before the resource group": {
+			expectedError: "",
+			vnet: infrav1.VnetSpec{
+				ID:   "my-vnet-id",
+				Name: "my-vnet",
+				Tags: infrav1.Tags{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "shared"},
+			},
+			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder, pl *mocks.MockServiceMockRecorder, ppg *mocks.MockServiceMockRecorder, sa *mocks.MockServiceMockRecorder) {
 				gomock.InOrder(
-					grp.Delete(gomockinternal.AContext()).Return(azure.ErrNotOwned),
-					dns.Delete(gomockinternal.AContext()),
+					pl.Delete(gomockinternal.AContext()),
 					lb.Delete(gomockinternal.AContext()),
-					pip.Delete(gomockinternal.AContext()),
 					sn.Delete(gomockinternal.AContext()),
-					rt.Delete(gomockinternal.AContext()).Return(errors.New("some error happened")),
-				)
+					rt.Delete(gomockinternal.AContext()),
+					vnet.Delete(gomockinternal.AContext()),
+					grp.Delete(gomockinternal.AContext()).Return(nil))
+			},
+		},
+		"Records of an existing private DNS zone are deleted before the resource group": {
+			expectedError:  "",
+			apiServerLB:    internalAPIServerLB,
+			privateDNSZone: &infrav1.PrivateDNSZoneSpec{Name: "example.com", ResourceGroup: "dns-rg"},
+			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder, pl *mocks.MockServiceMockRecorder, ppg *mocks.MockServiceMockRecorder, sa *mocks.MockServiceMockRecorder) {
+				gomock.InOrder(
+					dns.Delete(gomockinternal.AContext()),
+					grp.Delete(gomockinternal.AContext()).Return(nil))
+			},
+		},
+		"Records of an existing private DNS zone are deleted once when the resource group is not owned": {
+			expectedError:  "",
+			apiServerLB:    internalAPIServerLB,
+			privateDNSZone: &infrav1.PrivateDNSZoneSpec{Name: "example.com", ResourceGroup: "dns-rg"},
+			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder, pl *mocks.MockServiceMockRecorder, ppg *mocks.MockServiceMockRecorder, sa *mocks.MockServiceMockRecorder) {
+				dnsDelete := dns.Delete(gomockinternal.AContext())
+				grp.Delete(gomockinternal.AContext()).After(dnsDelete).Return(azure.ErrNotOwned)
+				ppg.Delete(gomockinternal.AContext())
+				sa.Delete(gomockinternal.AContext())
+				plDelete := pl.Delete(gomockinternal.AContext())
+				lbDelete := lb.Delete(gomockinternal.AContext()).After(plDelete)
+				pip.Delete(gomockinternal.AContext()).After(lbDelete)
+				snDelete := sn.Delete(gomockinternal.AContext()).After(lbDelete)
+				rtDelete := rt.Delete(gomockinternal.AContext()).After(snDelete)
+				sgDelete := sg.Delete(gomockinternal.AContext()).After(snDelete)
+				vnet.Delete(gomockinternal.AContext()).After(rtDelete).After(sgDelete)
+			},
+		},
+		"Existing private DNS zone delete fails": {
+			expectedError:  "failed to delete private dns: dns error",
+			apiServerLB:    internalAPIServerLB,
+			privateDNSZone: &infrav1.PrivateDNSZoneSpec{Name: "example.com", ResourceGroup: "dns-rg"},
+			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder, pl *mocks.MockServiceMockRecorder, ppg *mocks.MockServiceMockRecorder, sa *mocks.MockServiceMockRecorder) {
+				dns.Delete(gomockinternal.AContext()).Return(errors.New("dns error"))
+			},
+		},
+		"Private DNS and route table delete fail": {
+			expectedError: "[failed to delete private dns: dns error, failed to delete route table: route table error]",
+			expect: func(grp *mocks.MockServiceMockRecorder, vnet *mocks.MockServiceMockRecorder, sg *mocks.MockServiceMockRecorder, rt *mocks.MockServiceMockRecorder, sn *mocks.MockServiceMockRecorder, pip *mocks.MockServiceMockRecorder, lb *mocks.MockServiceMockRecorder, dns *mocks.MockServiceMockRecorder, pl *mocks.MockServiceMockRecorder, ppg *mocks.MockServiceMockRecorder, sa *mocks.MockServiceMockRecorder) {
+				grp.Delete(gomockinternal.AContext()).Return(azure.ErrNotOwned)
+				ppg.Delete(gomockinternal.AContext())
+				sa.Delete(gomockinternal.AContext())
+				dns.Delete(gomockinternal.AContext()).Return(errors.New("dns error"))
+				plDelete := pl.Delete(gomockinternal.AContext())
+				lbDelete := lb.Delete(gomockinternal.AContext()).After(plDelete)
+				pip.Delete(gomockinternal.AContext()).After(lbDelete)
+				snDelete := sn.Delete(gomockinternal.AContext()).After(lbDelete)
+				rt.Delete(gomockinternal.AContext()).After(snDelete).Return(errors.New("route table error"))
+				sg.Delete(gomockinternal.AContext()).After(snDelete)
 			},
 		},
 	}
@@ -109,20 +209,35 @@ func TestAzureClusterReconcilerDelete(t *testing.T) {
 			publicIPMock := mocks.NewMockService(mockCtrl)
 			lbMock := mocks.NewMockService(mockCtrl)
 			dnsMock := mocks.NewMockService(mockCtrl)
+			plMock := mocks.NewMockService(mockCtrl)
+			ppgMock := mocks.NewMockService(mockCtrl)
+			saMock := mocks.NewMockService(mockCtrl)
 
-			tc.expect(groupsMock.EXPECT(), vnetMock.EXPECT(), sgMock.EXPECT(), rtMock.EXPECT(), subnetsMock.EXPECT(), publicIPMock.EXPECT(), lbMock.EXPECT(), dnsMock.EXPECT())
+			tc.expect(groupsMock.EXPECT(), vnetMock.EXPECT(), sgMock.EXPECT(), rtMock.EXPECT(), subnetsMock.EXPECT(), publicIPMock.EXPECT(), lbMock.EXPECT(), dnsMock.EXPECT(), plMock.EXPECT(), ppgMock.EXPECT(), saMock.EXPECT())
 
 			r := &azureClusterReconciler{
-				scope:            &scope.ClusterScope{},
-				groupsSvc:        groupsMock,
-				vnetSvc:          vnetMock,
-				securityGroupSvc: sgMock,
-				routeTableSvc:    rtMock,
-				subnetsSvc:       subnetsMock,
-				publicIPSvc:      publicIPMock,
-				loadBalancerSvc:  lbMock,
-				privateDNSSvc:    dnsMock,
-				skuCache:         resourceskus.NewStaticCache([]compute.ResourceSku{}),
+				scope: &scope.ClusterScope{
+					Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							NetworkSpec:    infrav1.NetworkSpec{Vnet: tc.vnet, APIServerLB: tc.apiServerLB},
+							PrivateDNSZone: tc.privateDNSZone,
+						},
+					},
+				},
+				groupsSvc:                  groupsMock,
+				vnetSvc:                    vnetMock,
+				securityGroupSvc:           sgMock,
+				routeTableSvc:              rtMock,
+				subnetsSvc:                 subnetsMock,
+				publicIPSvc:                publicIPMock,
+				loadBalancerSvc:            lbMock,
+				privateDNSSvc:              dnsMock,
+				privateLinkSvc:             plMock,
+				proximityPlacementGroupSvc: ppgMock,
+				storageAccountSvc:          saMock,
+				skuCache:                   resourceskus.NewStaticCache([]compute.ResourceSku{}),
+				skippedServices:            tc.skippedServices,
 			}
 
 			err := r.Delete(context.TODO())
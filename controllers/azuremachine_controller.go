@@ -44,6 +44,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -54,6 +55,7 @@ type AzureMachineReconciler struct {
 	Log              logr.Logger
 	Recorder         record.EventRecorder
 	ReconcileTimeout time.Duration
+	SKUCaches        *resourceskus.Caches
 }
 
 // SetupWithManager initializes this controller with a manager.
@@ -263,9 +265,11 @@ func (r *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineSco
 		}
 	}
 
-	ams := newAzureMachineService(machineScope, clusterScope)
+	reportSkippedServices(r.Recorder, machineScope.AzureMachine)
 
-	err := ams.Reconcile(ctx)
+	ams := newAzureMachineService(machineScope, r.SKUCaches.Get(clusterScope, clusterScope.Location()))
+
+	err := azure.ClassifyError(ams.Reconcile(ctx))
 	if err != nil {
 
 		// This means that a VM was created and managed by this controller, but is not present anymore.
@@ -279,14 +283,38 @@ func (r *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineSco
 			return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile AzureMachine")
 		}
 
+		// The VM operation was started but has not completed yet, check on it again on the next reconcile.
+		var operationNotDoneError azure.OperationNotDoneError
+		if errors.As(err, &operationNotDoneError) {
+			machineScope.V(2).Info("VM operation in progress", "type", operationNotDoneError.Future.Type, "name", operationNotDoneError.Future.Name)
+			reason := infrav1.VMNCreatingReason
+			if machineScope.ProviderID() != "" {
+				reason = infrav1.VMNUpdatingReason
+			}
+			conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, reason, clusterv1.ConditionSeverityInfo, "")
+			machineScope.SetNotReady()
+			return reconcile.Result{RequeueAfter: azure.DefaultOperationPollingRequeue}, nil
+		}
+
 		// Handle transient and terminal errors
 		var reconcileError azure.ReconcileError
 		if errors.As(err, &reconcileError) {
 			r.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, "ReconcileError", errors.Wrapf(err, "failed to reconcile AzureMachine").Error())
-			conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, infrav1.VMProvisionFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			reason := infrav1.VMProvisionFailedReason
+			if errors.As(err, &azure.BootstrapDataTooLargeError{}) {
+				reason = infrav1.BootstrapDataTooLargeReason
+			}
+			conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, reason, clusterv1.ConditionSeverityError, err.Error())
 
 			if reconcileError.IsTerminal() {
 				machineScope.Error(err, "failed to reconcile AzureMachine", "name", machineScope.Name())
+				if machineScope.ProviderID() == "" {
+					machineScope.SetFailureReason(capierrors.CreateMachineError)
+				} else {
+					machineScope.SetFailureReason(capierrors.UpdateMachineError)
+				}
+				machineScope.SetFailureMessage(err)
+				machineScope.SetNotReady()
 				return reconcile.Result{}, nil
 			}
 
@@ -344,13 +372,23 @@ func (r *AzureMachineReconciler) reconcileDelete(ctx context.Context, machineSco
 	machineScope.Info("Handling deleted AzureMachine")
 
 	conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+	reportSkippedServices(r.Recorder, machineScope.AzureMachine)
 	if err := machineScope.PatchObject(ctx); err != nil {
 		return reconcile.Result{}, err
 	}
 
 	if ShouldDeleteIndividualResources(ctx, clusterScope) {
 		machineScope.Info("Deleting AzureMachine")
-		if err := newAzureMachineService(machineScope, clusterScope).Delete(ctx); err != nil {
+		if err := azure.ClassifyError(newAzureMachineService(machineScope, r.SKUCaches.Get(clusterScope, clusterScope.Location())).Delete(ctx)); err != nil {
+			if azure.IsOperationNotDoneError(err) {
+				machineScope.V(2).Info("VM deletion in progress", "name", machineScope.Name())
+				return reconcile.Result{RequeueAfter: azure.DefaultOperationPollingRequeue}, nil
+			}
+			var reconcileError azure.ReconcileError
+			if errors.As(err, &reconcileError) && reconcileError.IsTransient() {
+				machineScope.Error(err, "failed to delete AzureMachine", "name", machineScope.Name())
+				return reconcile.Result{RequeueAfter: reconcileError.RequeueAfter()}, nil
+			}
 			r.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, "Error deleting AzureMachine", errors.Wrapf(err, "error deleting AzureMachine %s/%s", clusterScope.Namespace(), clusterScope.ClusterName()).Error())
 			conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 			return reconcile.Result{}, errors.Wrapf(err, "error deleting AzureMachine %s/%s", clusterScope.Namespace(), clusterScope.ClusterName())
@@ -19,20 +19,26 @@ package controllers
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/storageaccounts"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/inboundnatrules"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/roleassignments"
 
-	"github.com/pkg/errors"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/availabilitysets"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/bootstrapdata"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/disks"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/networkinterfaces"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/virtualmachines"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/vmextensions"
 )
 
 // azureMachineService is the group of services called by the AzureMachine controller.
@@ -40,26 +46,34 @@ type azureMachineService struct {
 	networkInterfacesSvc azure.Service
 	inboundNatRulesSvc   azure.Service
 	virtualMachinesSvc   azure.Service
+	availabilitySetsSvc  azure.Service
 	roleAssignmentsSvc   azure.Service
 	disksSvc             azure.Service
 	publicIPsSvc         azure.Service
 	tagsSvc              azure.Service
+	vmExtensionsSvc      azure.Service
+	storageAccountsSvc   azure.Service
+	bootstrapDataSvc     azure.Service
 	skuCache             *resourceskus.Cache
+	skippedServices      sets.String
 }
 
 // newAzureMachineService populates all the services based on input scope.
-func newAzureMachineService(machineScope *scope.MachineScope, clusterScope *scope.ClusterScope) *azureMachineService {
-	cache := resourceskus.NewCache(clusterScope, clusterScope.Location())
-
+func newAzureMachineService(machineScope *scope.MachineScope, cache *resourceskus.Cache) *azureMachineService {
 	return &azureMachineService{
 		inboundNatRulesSvc:   inboundnatrules.New(machineScope),
 		networkInterfacesSvc: networkinterfaces.New(machineScope, cache),
 		virtualMachinesSvc:   virtualmachines.New(machineScope, cache),
+		availabilitySetsSvc:  availabilitysets.New(machineScope, cache),
 		roleAssignmentsSvc:   roleassignments.New(machineScope),
 		disksSvc:             disks.New(machineScope),
 		publicIPsSvc:         publicips.New(machineScope),
 		tagsSvc:              tags.New(machineScope),
+		vmExtensionsSvc:      vmextensions.New(machineScope),
+		storageAccountsSvc:   storageaccounts.New(machineScope),
+		bootstrapDataSvc:     bootstrapdata.New(machineScope),
 		skuCache:             cache,
+		skippedServices:      skippedServices(machineScope.AzureMachine),
 	}
 }
 
@@ -68,31 +82,21 @@ func (s *azureMachineService) Reconcile(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "controllers.azureMachineService.Reconcile")
 	defer span.End()
 
-	if err := s.publicIPsSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "failed to create public IP")
-	}
-
-	if err := s.inboundNatRulesSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "failed to create inbound NAT rule")
-	}
-
-	if err := s.networkInterfacesSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "failed to create network interface")
-	}
-
-	if err := s.virtualMachinesSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "failed to create virtual machine")
-	}
-
-	if err := s.roleAssignmentsSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "unable to create role assignment")
-	}
-
-	if err := s.tagsSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "unable to update tags")
-	}
-
-	return nil
+	// Independent resources, such as the public IP, the inbound NAT rule and the availability set, are reconciled
+	// concurrently; every service only waits for the resources it references.
+	return reconciler.RunGraph(ctx, reconciler.DefaultMaxConcurrentServices,
+		reconcileStep(s.skippedServices, "publicips", s.publicIPsSvc, "failed to create public IP"),
+		reconcileStep(s.skippedServices, "inboundnatrules", s.inboundNatRulesSvc, "failed to create inbound NAT rule"),
+		reconcileStep(s.skippedServices, "networkinterfaces", s.networkInterfacesSvc, "failed to create network interface", "publicips", "inboundnatrules"),
+		reconcileStep(s.skippedServices, "availabilitysets", s.availabilitySetsSvc, "failed to create availability set"),
+		reconcileStep(s.skippedServices, "storageaccounts", s.storageAccountsSvc, "failed to create storage account"),
+		reconcileStep(s.skippedServices, "bootstrapdata", s.bootstrapDataSvc, "failed to upload bootstrap data", "storageaccounts"),
+		reconcileStep(s.skippedServices, "virtualmachines", s.virtualMachinesSvc, "failed to create virtual machine", "networkinterfaces", "availabilitysets", "bootstrapdata"),
+		reconcileStep(s.skippedServices, "disks", s.disksSvc, "failed to reconcile disks", "virtualmachines"),
+		reconcileStep(s.skippedServices, "roleassignments", s.roleAssignmentsSvc, "unable to create role assignment", "virtualmachines"),
+		reconcileStep(s.skippedServices, "tags", s.tagsSvc, "unable to update tags", "virtualmachines"),
+		reconcileStep(s.skippedServices, "vmextensions", s.vmExtensionsSvc, "failed to reconcile VM extensions", "virtualmachines"),
+	)
 }
 
 // Delete deletes all the services in pre determined order
@@ -100,25 +104,15 @@ func (s *azureMachineService) Delete(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "controllers.azureMachineService.Delete")
 	defer span.End()
 
-	if err := s.virtualMachinesSvc.Delete(ctx); err != nil {
-		return errors.Wrapf(err, "failed to delete machine")
-	}
-
-	if err := s.networkInterfacesSvc.Delete(ctx); err != nil {
-		return errors.Wrapf(err, "failed to delete network interface")
-	}
-
-	if err := s.inboundNatRulesSvc.Delete(ctx); err != nil {
-		return errors.Wrapf(err, "failed to delete inbound NAT rule")
-	}
-
-	if err := s.publicIPsSvc.Delete(ctx); err != nil {
-		return errors.Wrap(err, "failed to delete public IPs")
-	}
-
-	if err := s.disksSvc.Delete(ctx); err != nil {
-		return errors.Wrap(err, "failed to delete OS disk")
-	}
-
-	return nil
+	// Every resource is deleted once the resources referencing it are gone, independent ones concurrently.
+	return reconciler.RunGraph(ctx, reconciler.DefaultMaxConcurrentServices,
+		deleteStep(s.skippedServices, "roleassignments", s.roleAssignmentsSvc, "failed to delete role assignments"),
+		deleteStep(s.skippedServices, "virtualmachines", s.virtualMachinesSvc, "failed to delete machine", "roleassignments"),
+		deleteStep(s.skippedServices, "networkinterfaces", s.networkInterfacesSvc, "failed to delete network interface", "virtualmachines"),
+		deleteStep(s.skippedServices, "inboundnatrules", s.inboundNatRulesSvc, "failed to delete inbound NAT rule", "networkinterfaces"),
+		deleteStep(s.skippedServices, "publicips", s.publicIPsSvc, "failed to delete public IPs", "networkinterfaces"),
+		deleteStep(s.skippedServices, "disks", s.disksSvc, "failed to delete OS disk", "virtualmachines"),
+		deleteStep(s.skippedServices, "availabilitysets", s.availabilitySetsSvc, "failed to delete availability set", "virtualmachines"),
+		deleteStep(s.skippedServices, "bootstrapdata", s.bootstrapDataSvc, "failed to delete bootstrap data"),
+	)
 }
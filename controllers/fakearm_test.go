@@ -0,0 +1,201 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test/fakearm"
+)
+
+var _ = Describe("Reconcilers against a fake Azure Resource Manager", func() {
+	It("should create an AzureCluster and its AzureMachine, then delete the AzureCluster", func() {
+		ctx := context.Background()
+		name := test.RandomName("fakearm", 10)
+		groupID := fmt.Sprintf("/subscriptions/123/resourceGroups/%s", name)
+
+		// Read through an uncached client, so that the objects created below are visible right away.
+		c, err := client.New(testEnv.Config, client.Options{Scheme: testEnv.GetScheme()})
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		}
+		azureCluster := &infrav1.AzureCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: infrav1.AzureClusterSpec{
+				Location:       fakearm.DefaultLocation,
+				SubscriptionID: "123",
+			},
+		}
+		azureCluster.Default()
+		bootstrap := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-bootstrap-data", Namespace: "default"},
+			Data:       map[string][]byte{"value": []byte("#cloud-config")},
+		}
+		machine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					clusterv1.ClusterLabelName:             name,
+					clusterv1.MachineControlPlaneLabelName: "",
+				},
+			},
+			Spec: clusterv1.MachineSpec{
+				ClusterName: name,
+				Version:     pointer.StringPtr("v1.19.1"),
+				Bootstrap:   clusterv1.Bootstrap{DataSecretName: pointer.StringPtr(bootstrap.Name)},
+			},
+		}
+		azureMachine := &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       infrav1.AzureMachineSpec{VMSize: "Standard_D2s_v3"},
+		}
+		azureMachine.Default()
+		for _, obj := range []runtime.Object{cluster, azureCluster, bootstrap, machine, azureMachine} {
+			Expect(c.Create(ctx, obj)).To(Succeed())
+		}
+		defer func() {
+			// Nothing deletes the virtual machine, which goes away with the resource group, so drop its finalizer.
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, azureMachine)).To(Succeed())
+			azureMachine.Finalizers = nil
+			Expect(c.Update(ctx, azureMachine)).To(Succeed())
+			for _, obj := range []runtime.Object{cluster, bootstrap, machine, azureMachine} {
+				Expect(c.Delete(ctx, obj)).To(Succeed())
+			}
+		}()
+
+		clusterReconciler := &AzureClusterReconciler{
+			Client:   c,
+			Log:      testEnv.Log,
+			Recorder: testEnv.GetEventRecorderFor("azurecluster-reconciler"),
+		}
+		machineReconciler := &AzureMachineReconciler{
+			Client:   c,
+			Log:      testEnv.Log,
+			Recorder: testEnv.GetEventRecorderFor("azuremachine-reconciler"),
+		}
+		// newClusterScope returns a cluster scope for the latest AzureCluster, as the controllers create on each reconcile.
+		newClusterScope := func() *scope.ClusterScope {
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, azureCluster)).To(Succeed())
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				AzureClients: scope.AzureClients{
+					Authorizer:              arm.Authorizer(),
+					ResourceManagerEndpoint: arm.URL,
+				},
+				Client:       c,
+				Logger:       testEnv.Log,
+				Cluster:      cluster,
+				AzureCluster: azureCluster,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return clusterScope
+		}
+		// reconcileCluster runs one reconcile of the AzureCluster and persists its changes.
+		reconcileCluster := func() time.Duration {
+			clusterScope := newClusterScope()
+			result, err := clusterReconciler.reconcileNormal(ctx, clusterScope)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterScope.Close(ctx)).To(Succeed())
+			return result.RequeueAfter
+		}
+		// reconcileMachine runs one reconcile of the AzureMachine and persists its changes.
+		reconcileMachine := func() time.Duration {
+			clusterScope := newClusterScope()
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, azureMachine)).To(Succeed())
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       c,
+				Logger:       testEnv.Log,
+				Machine:      machine,
+				AzureMachine: azureMachine,
+				ClusterScope: clusterScope,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			result, err := machineReconciler.reconcileNormal(ctx, machineScope, clusterScope)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(machineScope.Close(ctx)).To(Succeed())
+			return result.RequeueAfter
+		}
+
+		By("creating the cluster infrastructure")
+		// The load balancers are still being created after the first reconcile, which tracks their operations.
+		Expect(reconcileCluster()).To(Equal(azure.DefaultOperationPollingRequeue))
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, azureCluster)).To(Succeed())
+		Expect(azureCluster.Finalizers).To(ContainElement(infrav1.ClusterFinalizer))
+		Expect(azureCluster.Status.LongRunningOperationStates).NotTo(BeEmpty())
+
+		Expect(reconcileCluster()).To(BeZero())
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, azureCluster)).To(Succeed())
+		Expect(azureCluster.Status.LongRunningOperationStates).To(BeEmpty())
+		Expect(azureCluster.Status.FailureDomains).To(HaveLen(3))
+		Expect(azureCluster.Status.Ready).To(BeTrue())
+
+		vnet, ok := arm.Resource(azure.VNetID("123", name, name+"-vnet"))
+		Expect(ok).To(BeTrue())
+		Expect(vnet["properties"].(map[string]interface{})["subnets"]).To(HaveLen(2))
+
+		By("creating the virtual machine")
+		cluster.Status.InfrastructureReady = true
+		// The virtual machine is still being created after the first reconcile, which tracks the operation.
+		Expect(reconcileMachine()).To(Equal(azure.DefaultOperationPollingRequeue))
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, azureMachine)).To(Succeed())
+		Expect(azureMachine.Finalizers).To(ContainElement(infrav1.MachineFinalizer))
+		Expect(azureMachine.Status.LongRunningOperationState).NotTo(BeNil())
+
+		Expect(reconcileMachine()).To(BeZero())
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, azureMachine)).To(Succeed())
+		Expect(azureMachine.Status.LongRunningOperationState).To(BeNil())
+		Expect(*azureMachine.Spec.ProviderID).To(HaveSuffix(fmt.Sprintf("%s/providers/Microsoft.Compute/virtualMachines/%s", groupID, name)))
+		Expect(azureMachine.Status.VMState).NotTo(BeNil())
+		Expect(*azureMachine.Status.VMState).To(Equal(infrav1.VMStateSucceeded))
+		Expect(azureMachine.Status.Ready).To(BeTrue())
+		Expect(azureMachine.Status.Addresses).NotTo(BeEmpty())
+
+		_, ok = arm.Resource(azure.NetworkInterfaceID("123", name, name+"-nic"))
+		Expect(ok).To(BeTrue())
+
+		By("deleting the cluster infrastructure")
+		Expect(c.Delete(ctx, azureCluster)).To(Succeed())
+		clusterScope := newClusterScope()
+		result, err := clusterReconciler.reconcileDelete(ctx, clusterScope)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(clusterScope.Close(ctx)).To(Succeed())
+		Expect(arm.ResourceIDs()).NotTo(ContainElement(HavePrefix(groupID)))
+		Eventually(func() bool {
+			err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &infrav1.AzureCluster{})
+			return apierrors.IsNotFound(err)
+		}).Should(BeTrue())
+	})
+})
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/groups"
@@ -35,9 +36,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	capiv1exp "sigs.k8s.io/cluster-api/exp/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -381,9 +385,67 @@ func ShouldDeleteIndividualResources(ctx context.Context, clusterScope *scope.Cl
 	if clusterScope.Cluster.DeletionTimestamp.IsZero() {
 		return true
 	}
+	// The resource group is kept if its deletion is skipped, so every resource must be deleted individually.
+	if skippedServices(clusterScope.AzureCluster).Has("groups") {
+		return true
+	}
 	grpSvc := groups.New(clusterScope)
 	managed, err := grpSvc.IsGroupManaged(ctx)
 	// Since this is a best effort attempt to speed up delete, we don't fail the delete if we can't get the RG status.
 	// Instead, take the long way and delete all resources one by one.
 	return err != nil || !managed
 }
+
+// skippedServices returns the names of the Azure services listed in the SkipReconcileServicesAnnotation of obj.
+func skippedServices(obj metav1.Object) sets.String {
+	skipped := sets.NewString()
+	for _, name := range strings.Split(obj.GetAnnotations()[infrav1.SkipReconcileServicesAnnotation], ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			skipped.Insert(name)
+		}
+	}
+	return skipped
+}
+
+// reportSkippedServices surfaces the services skipped as requested by the SkipReconcileServicesAnnotation in the
+// conditions and events of obj, so that a skip is never forgotten.
+func reportSkippedServices(recorder record.EventRecorder, obj conditions.Setter) {
+	skipped := skippedServices(obj)
+	if skipped.Len() == 0 {
+		conditions.Delete(obj, infrav1.ServicesReconciledCondition)
+		return
+	}
+	msg := fmt.Sprintf("reconciliation of services %s is skipped as requested by annotation %s", strings.Join(skipped.List(), ", "), infrav1.SkipReconcileServicesAnnotation)
+	conditions.MarkFalse(obj, infrav1.ServicesReconciledCondition, infrav1.ServicesSkippedReason, clusterv1.ConditionSeverityWarning, msg)
+	recorder.Event(obj, corev1.EventTypeWarning, infrav1.ServicesSkippedReason, msg)
+}
+
+// reconcileStep returns a reconcile graph step reconciling the given service, wrapping its error with msg. The step
+// succeeds without calling the service if its name is in skipped.
+func reconcileStep(skipped sets.String, name string, svc azure.Service, msg string, dependsOn ...string) reconciler.Step {
+	return reconciler.Step{
+		Name:      name,
+		DependsOn: dependsOn,
+		Run: func(ctx context.Context) error {
+			if skipped.Has(name) {
+				return nil
+			}
+			return errors.Wrap(svc.Reconcile(ctx), msg)
+		},
+	}
+}
+
+// deleteStep returns a reconcile graph step deleting the given service, wrapping its error with msg. The step
+// succeeds without calling the service if its name is in skipped.
+func deleteStep(skipped sets.String, name string, svc azure.Service, msg string, dependsOn ...string) reconciler.Step {
+	return reconciler.Step{
+		Name:      name,
+		DependsOn: dependsOn,
+		Run: func(ctx context.Context) error {
+			if skipped.Has(name) {
+				return nil
+			}
+			return errors.Wrap(svc.Delete(ctx), msg)
+		},
+	}
+}
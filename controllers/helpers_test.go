@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -42,6 +43,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/internal/test/mock_log"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestAzureClusterToAzureMachinesMapper(t *testing.T) {
@@ -83,6 +85,57 @@ func TestAzureClusterToAzureMachinesMapper(t *testing.T) {
 	g.Expect(requests).To(HaveLen(2))
 }
 
+func TestSkippedServices(t *testing.T) {
+	cases := map[string]struct {
+		annotations map[string]string
+		expected    []string
+	}{
+		"no annotation": {
+			annotations: nil,
+			expected:    []string{},
+		},
+		"empty annotation": {
+			annotations: map[string]string{infrav1.SkipReconcileServicesAnnotation: ""},
+			expected:    []string{},
+		},
+		"list of services": {
+			annotations: map[string]string{infrav1.SkipReconcileServicesAnnotation: "securitygroups, LoadBalancers,,"},
+			expected:    []string{"loadbalancers", "securitygroups"},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			obj := &infrav1.AzureCluster{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			g.Expect(skippedServices(obj).List()).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestReportSkippedServices(t *testing.T) {
+	g := NewWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	machine := &infrav1.AzureMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{infrav1.SkipReconcileServicesAnnotation: "tags,disks"},
+		},
+	}
+
+	reportSkippedServices(recorder, machine)
+	g.Expect(conditions.IsFalse(machine, infrav1.ServicesReconciledCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(machine, infrav1.ServicesReconciledCondition)).To(Equal(infrav1.ServicesSkippedReason))
+	g.Expect(conditions.GetMessage(machine, infrav1.ServicesReconciledCondition)).To(ContainSubstring("disks, tags"))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring("reconciliation of services disks, tags is skipped")))
+
+	// removing the annotation removes the condition
+	machine.Annotations = nil
+	reportSkippedServices(recorder, machine)
+	g.Expect(conditions.Has(machine, infrav1.ServicesReconciledCondition)).To(BeFalse())
+	g.Expect(recorder.Events).NotTo(Receive())
+}
+
 func TestGetCloudProviderConfig(t *testing.T) {
 	g := NewWithT(t)
 
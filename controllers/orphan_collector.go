@@ -0,0 +1,218 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/orphans"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/metrics"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// OrphanCollector periodically looks for Azure resources tagged as owned by clusters, or dedicated to machines, which
+// no longer exist, e.g. resources left behind by a deletion that failed halfway. Orphaned resources are reported
+// through events on the AzureCluster whose resource group they are in and through metrics, and only deleted when
+// Delete is set.
+type OrphanCollector struct {
+	Client   client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	// Interval is the time between two passes of the collector.
+	Interval time.Duration
+	// Delete enables the deletion of the orphaned resources.
+	Delete bool
+
+	// azureClients overrides the Azure clients of the cluster scopes, e.g. to use a fake Azure Resource Manager.
+	azureClients scope.AzureClients
+}
+
+// SetupWithManager adds the collector to the manager, which starts it once elected leader.
+func (c *OrphanCollector) SetupWithManager(mgr ctrl.Manager) error {
+	if c.Interval <= 0 {
+		return errors.New("orphaned resource collector interval must be positive")
+	}
+	return mgr.Add(c)
+}
+
+// Start runs the collector until the stop channel is closed.
+func (c *OrphanCollector) Start(stop <-chan struct{}) error {
+	wait.Until(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), reconciler.DefaultLoopTimeout)
+		defer cancel()
+		if err := c.collect(ctx); err != nil {
+			c.Log.Error(err, "failed to collect orphaned Azure resources")
+		}
+	}, c.Interval, stop)
+	return nil
+}
+
+// owners lists the clusters and machines which exist in the management cluster.
+type owners struct {
+	clusters sets.String
+	machines sets.String
+}
+
+// ClusterExists returns true if a cluster with the given name exists in any namespace.
+func (o owners) ClusterExists(name string) bool {
+	return o.clusters.Has(name)
+}
+
+// MachineExists returns true if an AzureMachine with the given name exists in the cluster.
+func (o owners) MachineExists(cluster, name string) bool {
+	return o.machines.Has(cluster + "/" + name)
+}
+
+// collect runs a single pass of the collector over the resource groups of all the AzureClusters.
+func (c *OrphanCollector) collect(ctx context.Context) error {
+	ctx, span := tele.Tracer().Start(ctx, "controllers.OrphanCollector.collect")
+	defer span.End()
+
+	azureClusters := &infrav1.AzureClusterList{}
+	if err := c.Client.List(ctx, azureClusters); err != nil {
+		return errors.Wrap(err, "failed to list AzureClusters")
+	}
+
+	metrics.OrphanedAzureResources.Reset()
+	var errs []error
+	scanned := sets.NewString()
+	for i := range azureClusters.Items {
+		azureCluster := &azureClusters.Items[i]
+		// Resources of clusters being deleted are expected to disappear soon.
+		if !azureCluster.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		cluster, err := util.GetOwnerCluster(ctx, c.Client, azureCluster.ObjectMeta)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if cluster == nil || annotations.IsPaused(cluster, azureCluster) {
+			continue
+		}
+
+		clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+			AzureClients: c.azureClients,
+			Client:       c.Client,
+			Logger:       c.Log.WithValues("namespace", azureCluster.Namespace, "azureCluster", azureCluster.Name),
+			Cluster:      cluster,
+			AzureCluster: azureCluster,
+		})
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to create scope for AzureCluster %s/%s", azureCluster.Namespace, azureCluster.Name))
+			continue
+		}
+
+		// Several clusters may share a resource group.
+		key := clusterScope.SubscriptionID() + "/" + clusterScope.ResourceGroup()
+		if scanned.Has(key) {
+			continue
+		}
+		scanned.Insert(key)
+
+		if err := c.collectResourceGroup(ctx, clusterScope); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+// listOwners lists the Clusters and AzureMachines of the management cluster.
+func (c *OrphanCollector) listOwners(ctx context.Context) (orphans.Owners, error) {
+	current := owners{clusters: sets.NewString(), machines: sets.NewString()}
+
+	clusters := &clusterv1.ClusterList{}
+	if err := c.Client.List(ctx, clusters); err != nil {
+		return current, errors.Wrap(err, "failed to list Clusters")
+	}
+	for _, cluster := range clusters.Items {
+		current.clusters.Insert(cluster.Name)
+	}
+
+	azureMachines := &infrav1.AzureMachineList{}
+	if err := c.Client.List(ctx, azureMachines); err != nil {
+		return current, errors.Wrap(err, "failed to list AzureMachines")
+	}
+	for _, azureMachine := range azureMachines.Items {
+		current.machines.Insert(azureMachine.Labels[clusterv1.ClusterLabelName] + "/" + azureMachine.Name)
+	}
+
+	return current, nil
+}
+
+// collectResourceGroup reports, and optionally deletes, the orphaned resources of the resource group of a cluster.
+func (c *OrphanCollector) collectResourceGroup(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	svc := orphans.New(clusterScope)
+	// Resources created since the previous pass may belong to machines which are still being created.
+	found, err := svc.Find(ctx, clusterScope.ResourceGroup(), c.listOwners, time.Now().Add(-c.Interval))
+	if err != nil {
+		return err
+	}
+
+	for _, orphan := range found {
+		metrics.OrphanedAzureResources.WithLabelValues(clusterScope.ResourceGroup(), orphan.Type).Inc()
+		clusterScope.Info("found orphaned Azure resource", "resource", orphan.ID, "owner", orphan.Owner())
+		c.Recorder.Eventf(clusterScope.AzureCluster, corev1.EventTypeWarning, "OrphanedAzureResource",
+			"Azure resource %s is owned by %s which no longer exists", orphan.ID, orphan.Owner())
+	}
+	if !c.Delete {
+		return nil
+	}
+
+	// An owner recreated with the same name adopts the resources left by its predecessor, so the owners are checked
+	// again right before deleting anything.
+	current, err := c.listOwners(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, orphan := range found {
+		if !orphan.Deletable() || orphan.OwnedBy(current) {
+			continue
+		}
+		if err := svc.Delete(ctx, orphan); err != nil {
+			c.Recorder.Eventf(clusterScope.AzureCluster, corev1.EventTypeWarning, "OrphanedAzureResourceDeleteFailed", err.Error())
+			errs = append(errs, err)
+			continue
+		}
+		metrics.OrphanedAzureResourcesDeletedTotal.WithLabelValues(orphan.Type).Inc()
+		c.Recorder.Eventf(clusterScope.AzureCluster, corev1.EventTypeNormal, "OrphanedAzureResourceDeleted",
+			"deleted orphaned Azure resource %s", orphan.ID)
+	}
+
+	return kerrors.NewAggregate(errs)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/klogr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test/fakearm"
+)
+
+const (
+	orphanRG      = "/subscriptions/123/resourceGroups/my-rg"
+	orphanVNet    = orphanRG + "/providers/Microsoft.Network/virtualNetworks/my-vnet"
+	orphanNIC     = orphanRG + "/providers/Microsoft.Network/networkInterfaces/my-machine-nic"
+	orphanOldVM   = orphanRG + "/providers/Microsoft.Compute/virtualMachines/old-machine"
+	orphanOldNIC  = orphanRG + "/providers/Microsoft.Network/networkInterfaces/old-machine-nic"
+	orphanOldIP   = orphanRG + "/providers/Microsoft.Network/publicIPAddresses/old-cluster-ip"
+	orphanStorage = orphanRG + "/providers/Microsoft.Storage/storageAccounts/oldclusterdiag"
+)
+
+func newOrphanTestServer() *fakearm.Server {
+	arm := fakearm.NewServer(fakearm.WithPollsBeforeDone(0))
+	owned := func(cluster, machine string) map[string]interface{} {
+		tags := map[string]interface{}{infrav1.ClusterTagKey(cluster): string(infrav1.ResourceLifecycleOwned)}
+		if machine != "" {
+			tags[infrav1.NameAzureClusterAPIMachine] = machine
+		}
+		return map[string]interface{}{"location": fakearm.DefaultLocation, "tags": tags}
+	}
+	arm.SetResource(orphanRG, map[string]interface{}{"location": fakearm.DefaultLocation})
+	arm.SetResource(orphanVNet, owned("my-cluster", ""))
+	arm.SetResource(orphanNIC, owned("my-cluster", "my-machine"))
+	arm.SetResource(orphanOldVM, owned("my-cluster", "old-machine"))
+	arm.SetResource(orphanOldNIC, owned("my-cluster", "old-machine"))
+	arm.SetResource(orphanOldIP, owned("old-cluster", ""))
+	arm.SetResource(orphanStorage, owned("old-cluster", ""))
+	return arm
+}
+
+func newOrphanCollector(arm *fakearm.Server, delete bool) (*OrphanCollector, *record.FakeRecorder, error) {
+	scheme, err := newScheme()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+	}
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Cluster",
+					Name:       "my-cluster",
+				},
+			},
+		},
+		Spec: infrav1.AzureClusterSpec{
+			Location:       fakearm.DefaultLocation,
+			ResourceGroup:  "my-rg",
+			SubscriptionID: "123",
+		},
+	}
+	azureMachine := &infrav1.AzureMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-machine",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterLabelName: "my-cluster"},
+		},
+	}
+
+	recorder := record.NewFakeRecorder(32)
+	return &OrphanCollector{
+		Client:   fake.NewFakeClientWithScheme(scheme, cluster, azureCluster, azureMachine),
+		Log:      klogr.New(),
+		Recorder: recorder,
+		Delete:   delete,
+		azureClients: scope.AzureClients{
+			Authorizer:              arm.Authorizer(),
+			ResourceManagerEndpoint: arm.URL,
+		},
+	}, recorder, nil
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestOrphanCollectorReportsOrphans(t *testing.T) {
+	g := NewWithT(t)
+	arm := newOrphanTestServer()
+	defer arm.Close()
+
+	collector, recorder, err := newOrphanCollector(arm, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(collector.collect(context.TODO())).To(Succeed())
+
+	g.Expect(drainEvents(recorder)).To(ConsistOf(
+		"Warning OrphanedAzureResource Azure resource "+orphanOldVM+" is owned by machine old-machine of cluster my-cluster which no longer exists",
+		"Warning OrphanedAzureResource Azure resource "+orphanOldNIC+" is owned by machine old-machine of cluster my-cluster which no longer exists",
+		"Warning OrphanedAzureResource Azure resource "+orphanOldIP+" is owned by cluster old-cluster which no longer exists",
+		"Warning OrphanedAzureResource Azure resource "+orphanStorage+" is owned by cluster old-cluster which no longer exists",
+	))
+	g.Expect(arm.ResourceIDs()).To(HaveLen(7))
+}
+
+func TestOrphanCollectorDeletesOrphans(t *testing.T) {
+	g := NewWithT(t)
+	arm := newOrphanTestServer()
+	defer arm.Close()
+
+	collector, recorder, err := newOrphanCollector(arm, true)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(collector.collect(context.TODO())).To(Succeed())
+
+	events := drainEvents(recorder)
+	g.Expect(events).To(ContainElement("Normal OrphanedAzureResourceDeleted deleted orphaned Azure resource " + orphanOldVM))
+	g.Expect(events).To(ContainElement("Normal OrphanedAzureResourceDeleted deleted orphaned Azure resource " + orphanOldNIC))
+	g.Expect(events).To(ContainElement("Normal OrphanedAzureResourceDeleted deleted orphaned Azure resource " + orphanOldIP))
+
+	// Resources of types the collector does not know how to delete are only reported.
+	g.Expect(arm.ResourceIDs()).To(ConsistOf(orphanRG, orphanVNet, orphanNIC, orphanStorage))
+}
+
+func TestOrphanCollectorSkipsRecentResources(t *testing.T) {
+	g := NewWithT(t)
+	arm := newOrphanTestServer()
+	defer arm.Close()
+	arm.SetCreatedTime(orphanOldIP, time.Now().Add(-2*time.Hour))
+
+	collector, recorder, err := newOrphanCollector(arm, true)
+	g.Expect(err).NotTo(HaveOccurred())
+	collector.Interval = time.Hour
+	g.Expect(collector.collect(context.TODO())).To(Succeed())
+
+	// Resources created during the last interval may belong to machines which are still being created.
+	g.Expect(drainEvents(recorder)).To(ConsistOf(
+		"Warning OrphanedAzureResource Azure resource "+orphanOldIP+" is owned by cluster old-cluster which no longer exists",
+		"Normal OrphanedAzureResourceDeleted deleted orphaned Azure resource "+orphanOldIP,
+	))
+	g.Expect(arm.ResourceIDs()).To(ConsistOf(orphanRG, orphanVNet, orphanNIC, orphanOldVM, orphanOldNIC, orphanStorage))
+}
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/envtest/printer"
 
 	"sigs.k8s.io/cluster-api-provider-azure/internal/test/env"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test/fakearm"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -35,6 +36,8 @@ import (
 
 var (
 	testEnv *env.TestEnvironment
+	// arm is a fake Azure Resource Manager the tests can point their scopes at.
+	arm *fakearm.Server
 )
 
 func TestAPIs(t *testing.T) {
@@ -48,6 +51,7 @@ func TestAPIs(t *testing.T) {
 var _ = BeforeSuite(func(done Done) {
 	By("bootstrapping test environment")
 	testEnv = env.NewTestEnvironment()
+	arm = fakearm.NewServer(fakearm.WithPollsBeforeDone(2))
 
 	Expect((&AzureClusterReconciler{
 		Client:   testEnv,
@@ -81,6 +85,9 @@ var _ = BeforeSuite(func(done Done) {
 }, 60)
 
 var _ = AfterSuite(func() {
+	if arm != nil {
+		arm.Close()
+	}
 	if testEnv != nil {
 		By("tearing down the test environment")
 		Expect(testEnv.Stop()).To(Succeed())
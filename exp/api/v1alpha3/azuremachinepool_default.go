@@ -47,5 +47,6 @@ func (amp *AzureMachinePool) SetIdentityDefaults() {
 		if amp.Spec.RoleAssignmentName == "" {
 			amp.Spec.RoleAssignmentName = string(uuid.NewUUID())
 		}
+		infrav1.SetRoleAssignmentDefaults(amp.Spec.RoleAssignments)
 	}
 }
@@ -24,6 +24,12 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 )
 
+const (
+	// ApplicationHealthExtensionName is the name of the Application Health extension installed on the instances of an
+	// AzureMachinePool with ApplicationHealth.
+	ApplicationHealthExtensionName = "ApplicationHealthLinux"
+)
+
 type (
 	// AzureMachineTemplate defines the template for an AzureMachine.
 	AzureMachineTemplate struct {
@@ -66,6 +72,11 @@ type (
 		// SpotVMOptions allows the ability to specify the Machine should use a Spot VM
 		// +optional
 		SpotVMOptions *infrav1.SpotVMOptions `json:"spotVMOptions,omitempty"`
+
+		// LoadBalancerBackendPools is a list of backend pools of the cluster's additional load balancers
+		// the Virtual Machines of the pool should join.
+		// +optional
+		LoadBalancerBackendPools []infrav1.LoadBalancerBackendPoolReference `json:"loadBalancerBackendPools,omitempty"`
 	}
 
 	// AzureMachinePoolSpec defines the desired state of AzureMachinePool
@@ -111,6 +122,53 @@ type (
 		// If not specified, a random GUID will be generated.
 		// +optional
 		RoleAssignmentName string `json:"roleAssignmentName,omitempty"`
+
+		// RoleAssignments is the list of roles to assign to a system assigned identity.
+		// When empty, the identity is assigned the Contributor role on the subscription using RoleAssignmentName.
+		// +optional
+		RoleAssignments []infrav1.RoleAssignment `json:"roleAssignments,omitempty"`
+
+		// ProximityPlacementGroup places the scale set in a proximity placement group created for the machine pool,
+		// instead of the proximity placement group of the cluster.
+		// +optional
+		ProximityPlacementGroup *infrav1.ProximityPlacementGroup `json:"proximityPlacementGroup,omitempty"`
+
+		// VMExtensions are the VM extensions installed on the instances of the scale set. Extensions removed from the
+		// list are uninstalled.
+		// +optional
+		VMExtensions []infrav1.VMExtension `json:"vmExtensions,omitempty"`
+
+		// ApplicationHealth installs the Application Health extension on the instances of the scale set, which reports
+		// the health of every instance from a probe of an application endpoint.
+		// +optional
+		ApplicationHealth *ApplicationHealth `json:"applicationHealth,omitempty"`
+
+		// BootstrapData defines how the bootstrap data is passed to the instances of the scale set. Only the CustomData
+		// delivery is supported, as the instances of a scale set are created long after its model.
+		// +optional
+		BootstrapData *infrav1.BootstrapData `json:"bootstrapData,omitempty"`
+	}
+
+	// ApplicationHealth defines the probe of the Application Health extension.
+	ApplicationHealth struct {
+		// Protocol is the protocol of the probe. A tcp probe only checks that the port accepts connections.
+		// +kubebuilder:validation:Enum=http;https;tcp
+		Protocol string `json:"protocol"`
+
+		// Port is the port of the application probed on every instance.
+		// +kubebuilder:validation:Minimum=1
+		// +kubebuilder:validation:Maximum=65535
+		Port int32 `json:"port"`
+
+		// RequestPath is the path of an http or https probe. The instance is healthy when the path responds with 200.
+		// +optional
+		RequestPath string `json:"requestPath,omitempty"`
+
+		// AutomaticRepairsGracePeriod enables the automatic repairs of the scale set, which replaces the instances
+		// reported unhealthy, once they had the grace period to become healthy after a state change.
+		// allowed values are between 30 and 90 (mins)
+		// +optional
+		AutomaticRepairsGracePeriod *int `json:"automaticRepairsGracePeriod,omitempty"`
 	}
 
 	// AzureMachinePoolStatus defines the observed state of AzureMachinePool
@@ -176,6 +234,11 @@ type (
 		// Conditions defines current service state of the AzureMachinePool.
 		// +optional
 		Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+		// LongRunningOperationState saves the state for an Azure long running operations so it can be continued on the
+		// next reconciliation loop.
+		// +optional
+		LongRunningOperationState *infrav1.Future `json:"longRunningOperationState,omitempty"`
 	}
 
 	// AzureMachinePoolInstanceStatus provides status information for each instance in the VMSS
@@ -85,6 +85,12 @@ func (amp *AzureMachinePool) Validate(old runtime.Object) error {
 		amp.ValidateSSHKey,
 		amp.ValidateUserAssignedIdentity,
 		amp.ValidateSystemAssignedIdentity(old),
+		amp.ValidateRoleAssignments(old),
+		amp.ValidateProximityPlacementGroup(old),
+		amp.ValidateVMExtensions,
+		amp.ValidateApplicationHealth,
+		amp.ValidateBootstrapData,
+		amp.ValidateDataDisks,
 	}
 
 	var errs []error
@@ -172,3 +178,129 @@ func (amp *AzureMachinePool) ValidateSystemAssignedIdentity(old runtime.Object)
 		return nil
 	}
 }
+
+// ValidateRoleAssignments validates the role assignments of a system-assigned identity
+func (amp *AzureMachinePool) ValidateRoleAssignments(old runtime.Object) func() error {
+	return func() error {
+		var oldRoleAssignments []infrav1.RoleAssignment
+		if old != nil {
+			oldMachinePool, ok := old.(*AzureMachinePool)
+			if !ok {
+				return fmt.Errorf("unexpected type for old azure machine pool object. Expected: %q, Got: %q",
+					"AzureMachinePool", reflect.TypeOf(old))
+			}
+			oldRoleAssignments = oldMachinePool.Spec.RoleAssignments
+		}
+
+		fldPath := field.NewPath("roleAssignments")
+		if errs := infrav1.ValidateRoleAssignments(amp.Spec.Identity, oldRoleAssignments, amp.Spec.RoleAssignments, fldPath); len(errs) > 0 {
+			return kerrors.NewAggregate(errs.ToAggregate().Errors())
+		}
+
+		return nil
+	}
+}
+
+// ValidateProximityPlacementGroup validates the proximity placement group, which can't change once the scale set is
+// created.
+func (amp *AzureMachinePool) ValidateProximityPlacementGroup(old runtime.Object) func() error {
+	return func() error {
+		fldPath := field.NewPath("proximityPlacementGroup")
+		if old != nil {
+			oldMachinePool, ok := old.(*AzureMachinePool)
+			if !ok {
+				return fmt.Errorf("unexpected type for old azure machine pool object. Expected: %q, Got: %q",
+					"AzureMachinePool", reflect.TypeOf(old))
+			}
+			if !reflect.DeepEqual(oldMachinePool.Spec.ProximityPlacementGroup, amp.Spec.ProximityPlacementGroup) {
+				return field.Invalid(fldPath, amp.Spec.ProximityPlacementGroup, "field is immutable")
+			}
+		}
+
+		if amp.Spec.ProximityPlacementGroup != nil {
+			if errs := infrav1.ValidateProximityPlacementGroup(*amp.Spec.ProximityPlacementGroup, fldPath); len(errs) > 0 {
+				return kerrors.NewAggregate(errs.ToAggregate().Errors())
+			}
+		}
+
+		return nil
+	}
+}
+
+// ValidateVMExtensions validates the VM extensions, which can't use the name of the Application Health extension.
+func (amp *AzureMachinePool) ValidateVMExtensions() error {
+	fldPath := field.NewPath("vmExtensions")
+	errs := infrav1.ValidateVMExtensions(amp.Spec.VMExtensions, fldPath)
+	if amp.Spec.ApplicationHealth != nil {
+		for i, extension := range amp.Spec.VMExtensions {
+			if extension.Name == ApplicationHealthExtensionName {
+				errs = append(errs, field.Invalid(fldPath.Index(i).Child("name"), extension.Name,
+					"name is reserved for the Application Health extension installed by applicationHealth"))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return kerrors.NewAggregate(errs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// ValidateApplicationHealth validates the probe of the Application Health extension and the automatic repairs grace
+// period, between 30 and 90 minutes.
+func (amp *AzureMachinePool) ValidateApplicationHealth() error {
+	health := amp.Spec.ApplicationHealth
+	if health == nil {
+		return nil
+	}
+	fldPath := field.NewPath("applicationHealth")
+	var errs field.ErrorList
+	switch health.Protocol {
+	case "http", "https":
+		if health.RequestPath == "" {
+			errs = append(errs, field.Required(fldPath.Child("requestPath"), "requestPath is required for http and https probes"))
+		}
+	case "tcp":
+		if health.RequestPath != "" {
+			errs = append(errs, field.Invalid(fldPath.Child("requestPath"), health.RequestPath, "requestPath can't be set for tcp probes"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("protocol"), health.Protocol, []string{"http", "https", "tcp"}))
+	}
+	if health.Port < 1 || health.Port > 65535 {
+		errs = append(errs, field.Invalid(fldPath.Child("port"), health.Port, "port should be between 1 and 65535"))
+	}
+	if gracePeriod := health.AutomaticRepairsGracePeriod; gracePeriod != nil && (*gracePeriod < 30 || *gracePeriod > 90) {
+		errs = append(errs, field.Invalid(fldPath.Child("automaticRepairsGracePeriod"), *gracePeriod, "grace period should be between 30 and 90 minutes"))
+	}
+	if len(errs) > 0 {
+		return kerrors.NewAggregate(errs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// ValidateBootstrapData validates the bootstrap data options. The instances of a scale set are created long after its
+// model, so the bootstrap data can't be delivered through a storage blob with a short-lived URL.
+func (amp *AzureMachinePool) ValidateBootstrapData() error {
+	if amp.Spec.BootstrapData != nil && amp.Spec.BootstrapData.Delivery == infrav1.BootstrapDataDeliveryStorageBlob {
+		return field.NotSupported(field.NewPath("bootstrapData", "delivery"), amp.Spec.BootstrapData.Delivery,
+			[]string{string(infrav1.BootstrapDataDeliveryCustomData)})
+	}
+	return nil
+}
+
+// ValidateDataDisks validates the data disks of the scale set model. They are created and deleted with each instance,
+// so they can't attach an existing disk nor be retained.
+func (amp *AzureMachinePool) ValidateDataDisks() error {
+	fldPath := field.NewPath("template", "dataDisks")
+	errs := infrav1.ValidateSharedDataDisks(amp.Spec.Template.DataDisks, fldPath)
+	for i, disk := range amp.Spec.Template.DataDisks {
+		if disk.DeletionPolicy == infrav1.DiskDeletionPolicyRetain {
+			errs = append(errs, field.NotSupported(fldPath.Index(i).Child("deletionPolicy"), disk.DeletionPolicy,
+				[]string{string(infrav1.DiskDeletionPolicyDelete)}))
+		}
+	}
+	if len(errs) > 0 {
+		return kerrors.NewAggregate(errs.ToAggregate().Errors())
+	}
+	return nil
+}
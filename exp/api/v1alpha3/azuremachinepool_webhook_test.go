@@ -108,6 +108,87 @@ func TestAzureMachinePool_ValidateCreate(t *testing.T) {
 			amp:     createMachinePoolWithUserAssignedIdentity(t, []string{}),
 			wantErr: true,
 		},
+		{
+			name:    "azuremachinepool with proximity placement group",
+			amp:     createMachinePoolWithProximityPlacementGroup(t, &infrav1.ProximityPlacementGroup{Name: "my-pool-ppg"}),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with invalid proximity placement group name",
+			amp:     createMachinePoolWithProximityPlacementGroup(t, &infrav1.ProximityPlacementGroup{Name: "-my-pool-ppg"}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with VM extension",
+			amp:     createMachinePoolWithVMExtensions(t, []infrav1.VMExtension{{Name: "monitor", Publisher: "Microsoft.Azure.Monitor", Type: "AzureMonitorLinuxAgent", Version: "1.5"}}, nil),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with VM extension missing its version",
+			amp:     createMachinePoolWithVMExtensions(t, []infrav1.VMExtension{{Name: "monitor", Publisher: "Microsoft.Azure.Monitor", Type: "AzureMonitorLinuxAgent"}}, nil),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with http application health",
+			amp:     createMachinePoolWithVMExtensions(t, nil, &ApplicationHealth{Protocol: "http", Port: 80, RequestPath: "/healthz", AutomaticRepairsGracePeriod: to.IntPtr(30)}),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with tcp application health",
+			amp:     createMachinePoolWithVMExtensions(t, nil, &ApplicationHealth{Protocol: "tcp", Port: 22}),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with http application health missing its request path",
+			amp:     createMachinePoolWithVMExtensions(t, nil, &ApplicationHealth{Protocol: "http", Port: 80}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with tcp application health with a request path",
+			amp:     createMachinePoolWithVMExtensions(t, nil, &ApplicationHealth{Protocol: "tcp", Port: 22, RequestPath: "/healthz"}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with application health on an invalid port",
+			amp:     createMachinePoolWithVMExtensions(t, nil, &ApplicationHealth{Protocol: "tcp", Port: 0}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with wrong automatic repairs grace period",
+			amp:     createMachinePoolWithVMExtensions(t, nil, &ApplicationHealth{Protocol: "tcp", Port: 22, AutomaticRepairsGracePeriod: to.IntPtr(10)}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with VM extension named after the application health extension",
+			amp: createMachinePoolWithVMExtensions(t, []infrav1.VMExtension{{Name: ApplicationHealthExtensionName, Publisher: "Microsoft.ManagedServices", Type: "ApplicationHealthLinux", Version: "1.0"}},
+				&ApplicationHealth{Protocol: "tcp", Port: 22}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with gzip compressed bootstrap data",
+			amp:     createMachinePoolWithBootstrapData(t, &infrav1.BootstrapData{Compression: infrav1.BootstrapDataCompressionGzip}),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with bootstrap data delivered through a storage blob",
+			amp:     createMachinePoolWithBootstrapData(t, &infrav1.BootstrapData{Delivery: infrav1.BootstrapDataDeliveryStorageBlob}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with data disk deleted with its instance",
+			amp:     createMachinePoolWithDataDisks(t, []infrav1.DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, DeletionPolicy: infrav1.DiskDeletionPolicyDelete}}),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with retained data disk",
+			amp:     createMachinePoolWithDataDisks(t, []infrav1.DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, DeletionPolicy: infrav1.DiskDeletionPolicyRetain}}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with existing data disk",
+			amp:     createMachinePoolWithDataDisks(t, []infrav1.DataDisk{{NameSuffix: "disk-1", DiskSizeGB: 64, DiskName: "my-old-machine_disk-1"}}),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -154,6 +235,18 @@ func TestAzureMachinePool_ValidateUpdate(t *testing.T) {
 			amp:     createMachinePoolWithSystemAssignedIdentity(t, string(uuid.NewUUID())),
 			wantErr: true,
 		},
+		{
+			name:    "azuremachinepool with proximity placement group unchanged",
+			oldAMP:  createMachinePoolWithProximityPlacementGroup(t, &infrav1.ProximityPlacementGroup{}),
+			amp:     createMachinePoolWithProximityPlacementGroup(t, &infrav1.ProximityPlacementGroup{}),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with proximity placement group added",
+			oldAMP:  createMachinePoolWithProximityPlacementGroup(t, nil),
+			amp:     createMachinePoolWithProximityPlacementGroup(t, &infrav1.ProximityPlacementGroup{}),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -270,6 +363,41 @@ func createMachinePoolWithUserAssignedIdentity(t *testing.T, providerIds []strin
 	}
 }
 
+func createMachinePoolWithProximityPlacementGroup(t *testing.T, ppg *infrav1.ProximityPlacementGroup) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			ProximityPlacementGroup: ppg,
+		},
+	}
+}
+
+func createMachinePoolWithVMExtensions(t *testing.T, extensions []infrav1.VMExtension, health *ApplicationHealth) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			VMExtensions:      extensions,
+			ApplicationHealth: health,
+		},
+	}
+}
+
+func createMachinePoolWithBootstrapData(t *testing.T, bootstrapData *infrav1.BootstrapData) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			BootstrapData: bootstrapData,
+		},
+	}
+}
+
+func createMachinePoolWithDataDisks(t *testing.T, dataDisks []infrav1.DataDisk) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			Template: AzureMachineTemplate{
+				DataDisks: dataDisks,
+			},
+		},
+	}
+}
+
 func generateSSHPublicKey(b64Enconded bool) string {
 	privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
 	publicRsaKey, _ := ssh.NewPublicKey(&privateKey.PublicKey)
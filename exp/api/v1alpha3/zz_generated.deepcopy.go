@@ -27,6 +27,26 @@ import (
 	"sigs.k8s.io/cluster-api/errors"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationHealth) DeepCopyInto(out *ApplicationHealth) {
+	*out = *in
+	if in.AutomaticRepairsGracePeriod != nil {
+		in, out := &in.AutomaticRepairsGracePeriod, &out.AutomaticRepairsGracePeriod
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationHealth.
+func (in *ApplicationHealth) DeepCopy() *ApplicationHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureMachinePool) DeepCopyInto(out *AzureMachinePool) {
 	*out = *in
@@ -127,6 +147,33 @@ func (in *AzureMachinePoolSpec) DeepCopyInto(out *AzureMachinePoolSpec) {
 		*out = make([]apiv1alpha3.UserAssignedIdentity, len(*in))
 		copy(*out, *in)
 	}
+	if in.RoleAssignments != nil {
+		in, out := &in.RoleAssignments, &out.RoleAssignments
+		*out = make([]apiv1alpha3.RoleAssignment, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProximityPlacementGroup != nil {
+		in, out := &in.ProximityPlacementGroup, &out.ProximityPlacementGroup
+		*out = new(apiv1alpha3.ProximityPlacementGroup)
+		**out = **in
+	}
+	if in.VMExtensions != nil {
+		in, out := &in.VMExtensions, &out.VMExtensions
+		*out = make([]apiv1alpha3.VMExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ApplicationHealth != nil {
+		in, out := &in.ApplicationHealth, &out.ApplicationHealth
+		*out = new(ApplicationHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BootstrapData != nil {
+		in, out := &in.BootstrapData, &out.BootstrapData
+		*out = new(apiv1alpha3.BootstrapData)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolSpec.
@@ -175,6 +222,11 @@ func (in *AzureMachinePoolStatus) DeepCopyInto(out *AzureMachinePoolStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LongRunningOperationState != nil {
+		in, out := &in.LongRunningOperationState, &out.LongRunningOperationState
+		*out = new(apiv1alpha3.Future)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolStatus.
@@ -223,6 +275,11 @@ func (in *AzureMachineTemplate) DeepCopyInto(out *AzureMachineTemplate) {
 		*out = new(apiv1alpha3.SpotVMOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LoadBalancerBackendPools != nil {
+		in, out := &in.LoadBalancerBackendPools, &out.LoadBalancerBackendPools
+		*out = make([]apiv1alpha3.LoadBalancerBackendPoolReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachineTemplate.
@@ -33,6 +33,7 @@ import (
 	capiv1exp "sigs.k8s.io/cluster-api/exp/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -45,6 +46,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/resourceskus"
 	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
@@ -59,6 +61,7 @@ type (
 		Scheme           *runtime.Scheme
 		Recorder         record.EventRecorder
 		ReconcileTimeout time.Duration
+		SKUCaches        *resourceskus.Caches
 	}
 
 	// annotationReaderWriter provides an interface to read and write annotations
@@ -255,16 +258,44 @@ func (r *AzureMachinePoolReconciler) reconcileNormal(ctx context.Context, machin
 		return reconcile.Result{}, nil
 	}
 
-	ams := newAzureMachinePoolService(machinePoolScope, clusterScope)
+	ams := newAzureMachinePoolService(machinePoolScope, r.SKUCaches.Get(clusterScope, clusterScope.Location()))
 
-	err := ams.Reconcile(ctx)
+	err := azure.ClassifyError(ams.Reconcile(ctx))
 	if err != nil {
 
+		// The scale set operation was started but has not completed yet, check on it again on the next reconcile.
+		var operationNotDoneError azure.OperationNotDoneError
+		if errors.As(err, &operationNotDoneError) {
+			machinePoolScope.V(2).Info("Scale Set operation in progress", "type", operationNotDoneError.Future.Type, "name", operationNotDoneError.Future.Name)
+			switch {
+			case operationNotDoneError.Future.Type == infrav1.DeleteFuture:
+				machinePoolScope.SetProvisioningState(infrav1.VMStateDeleting)
+			case machinePoolScope.ProviderID() == "":
+				machinePoolScope.SetProvisioningState(infrav1.VMStateCreating)
+			default:
+				machinePoolScope.SetProvisioningState(infrav1.VMStateUpdating)
+			}
+			machinePoolScope.SetNotReady()
+			return reconcile.Result{RequeueAfter: azure.DefaultOperationPollingRequeue}, nil
+		}
+
 		// Handle transient and terminal errors
 		var reconcileError azure.ReconcileError
 		if errors.As(err, &reconcileError) {
 			if reconcileError.IsTerminal() {
 				machinePoolScope.Error(err, "failed to reconcile AzureMachinePool", "name", machinePoolScope.Name())
+				reason := infrav1.ScaleSetProvisionFailedReason
+				if errors.As(err, &azure.BootstrapDataTooLargeError{}) {
+					reason = infrav1.BootstrapDataTooLargeReason
+				}
+				conditions.MarkFalse(machinePoolScope.AzureMachinePool, infrav1.ScaleSetRunningCondition, reason, clusterv1.ConditionSeverityError, err.Error())
+				if machinePoolScope.ProviderID() == "" {
+					machinePoolScope.SetFailureReason(capierrors.CreateMachineError)
+				} else {
+					machinePoolScope.SetFailureReason(capierrors.UpdateMachineError)
+				}
+				machinePoolScope.SetFailureMessage(err)
+				machinePoolScope.SetNotReady()
 				return reconcile.Result{}, nil
 			}
 
@@ -282,6 +313,7 @@ func (r *AzureMachinePoolReconciler) reconcileNormal(ctx context.Context, machin
 	switch machinePoolScope.ProvisioningState() {
 	case infrav1.VMStateSucceeded:
 		machinePoolScope.V(2).Info("Scale Set is running", "id", machinePoolScope.ProviderID())
+		conditions.MarkTrue(machinePoolScope.AzureMachinePool, infrav1.ScaleSetRunningCondition)
 		machinePoolScope.SetReady()
 	case infrav1.VMStateCreating:
 		machinePoolScope.V(2).Info("Scale Set is creating", "id", machinePoolScope.ProviderID())
@@ -298,6 +330,7 @@ func (r *AzureMachinePoolReconciler) reconcileNormal(ctx context.Context, machin
 		r.Recorder.Eventf(machinePoolScope.AzureMachinePool, corev1.EventTypeWarning, "UnexpectedVMDeletion", "Unexpected Azure scale set deletion")
 		machinePoolScope.SetNotReady()
 	case infrav1.VMStateFailed:
+		conditions.MarkFalse(machinePoolScope.AzureMachinePool, infrav1.ScaleSetRunningCondition, infrav1.ScaleSetProvisionFailedReason, clusterv1.ConditionSeverityWarning, "")
 		machinePoolScope.SetNotReady()
 		machinePoolScope.Error(errors.New("Failed to create or update scale set"), "Scale Set is in failed state", "id", machinePoolScope.ProviderID())
 		r.Recorder.Eventf(machinePoolScope.AzureMachinePool, corev1.EventTypeWarning, "FailedVMState", "Azure scale set is in failed state")
@@ -324,7 +357,17 @@ func (r *AzureMachinePoolReconciler) reconcileDelete(ctx context.Context, machin
 	machinePoolScope.Info("Handling deleted AzureMachinePool")
 
 	if infracontroller.ShouldDeleteIndividualResources(ctx, clusterScope) {
-		if err := newAzureMachinePoolService(machinePoolScope, clusterScope).Delete(ctx); err != nil {
+		if err := azure.ClassifyError(newAzureMachinePoolService(machinePoolScope, r.SKUCaches.Get(clusterScope, clusterScope.Location())).Delete(ctx)); err != nil {
+			if azure.IsOperationNotDoneError(err) {
+				machinePoolScope.V(2).Info("Scale Set deletion in progress", "name", machinePoolScope.Name())
+				machinePoolScope.SetProvisioningState(infrav1.VMStateDeleting)
+				return reconcile.Result{RequeueAfter: azure.DefaultOperationPollingRequeue}, nil
+			}
+			var reconcileError azure.ReconcileError
+			if errors.As(err, &reconcileError) && reconcileError.IsTransient() {
+				machinePoolScope.Error(err, "failed to delete AzureMachinePool", "name", machinePoolScope.Name())
+				return reconcile.Result{RequeueAfter: reconcileError.RequeueAfter()}, nil
+			}
 			return reconcile.Result{}, errors.Wrapf(err, "error deleting AzureCluster %s/%s", clusterScope.Namespace(), clusterScope.ClusterName())
 		}
 	}
@@ -21,6 +21,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/mocks"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/resourceskus"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -65,11 +66,13 @@ func Test_newAzureMachinePoolService(t *testing.T) {
 		},
 	}
 
-	subject := newAzureMachinePoolService(mps, cs)
+	subject := newAzureMachinePoolService(mps, resourceskus.NewCache(cs, cs.Location()))
 	g := NewWithT(t)
 	g.Expect(subject).NotTo(BeNil())
 	g.Expect(subject.virtualMachinesScaleSetSvc).NotTo(BeNil())
 	g.Expect(subject.skuCache).NotTo(BeNil())
+	g.Expect(subject.proximityPlacementGroupSvc).NotTo(BeNil())
+	g.Expect(subject.vmExtensionsSvc).NotTo(BeNil())
 }
 
 func newScheme(g *GomegaWithT) *runtime.Scheme {
@@ -23,9 +23,11 @@ import (
 
 	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/proximityplacementgroups"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/roleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/scalesets"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/vmextensions"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -34,15 +36,18 @@ type azureMachinePoolService struct {
 	virtualMachinesScaleSetSvc azure.Service
 	skuCache                   *resourceskus.Cache
 	roleAssignmentsSvc         azure.Service
+	proximityPlacementGroupSvc azure.Service
+	vmExtensionsSvc            azure.Service
 }
 
 // newAzureMachinePoolService populates all the services based on input scope.
-func newAzureMachinePoolService(machinePoolScope *scope.MachinePoolScope, clusterScope *scope.ClusterScope) *azureMachinePoolService {
-	cache := resourceskus.NewCache(clusterScope, clusterScope.Location())
+func newAzureMachinePoolService(machinePoolScope *scope.MachinePoolScope, cache *resourceskus.Cache) *azureMachinePoolService {
 	return &azureMachinePoolService{
 		virtualMachinesScaleSetSvc: scalesets.NewService(machinePoolScope, cache),
 		skuCache:                   cache,
 		roleAssignmentsSvc:         roleassignments.New(machinePoolScope),
+		proximityPlacementGroupSvc: proximityplacementgroups.New(machinePoolScope),
+		vmExtensionsSvc:            vmextensions.New(machinePoolScope),
 	}
 }
 
@@ -51,10 +56,18 @@ func (s *azureMachinePoolService) Reconcile(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "controllers.azureMachinePoolService.Reconcile")
 	defer span.End()
 
+	if err := s.proximityPlacementGroupSvc.Reconcile(ctx); err != nil {
+		return errors.Wrap(err, "failed to reconcile proximity placement group")
+	}
+
 	if err := s.virtualMachinesScaleSetSvc.Reconcile(ctx); err != nil {
 		return errors.Wrapf(err, "failed to create scale set")
 	}
 
+	if err := s.vmExtensionsSvc.Reconcile(ctx); err != nil {
+		return errors.Wrap(err, "failed to reconcile VM extensions")
+	}
+
 	if err := s.roleAssignmentsSvc.Reconcile(ctx); err != nil {
 		return errors.Wrap(err, "unable to create role assignment")
 	}
@@ -67,8 +80,16 @@ func (s *azureMachinePoolService) Delete(ctx context.Context) error {
 	ctx, span := tele.Tracer().Start(ctx, "controllers.azureMachinePoolService.Delete")
 	defer span.End()
 
+	if err := s.roleAssignmentsSvc.Delete(ctx); err != nil {
+		return errors.Wrap(err, "failed to delete role assignments")
+	}
+
 	if err := s.virtualMachinesScaleSetSvc.Delete(ctx); err != nil {
 		return errors.Wrapf(err, "failed to delete scale set")
 	}
+
+	if err := s.proximityPlacementGroupSvc.Delete(ctx); err != nil {
+		return errors.Wrap(err, "failed to delete proximity placement group")
+	}
 	return nil
 }
@@ -0,0 +1,217 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	capiv1exp "sigs.k8s.io/cluster-api/exp/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/cloud"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test/fakearm"
+)
+
+var _ = Describe("AzureMachinePoolReconciler against a fake Azure Resource Manager", func() {
+	It("should create and delete the scale set of an AzureMachinePool", func() {
+		ctx := context.Background()
+		name := test.RandomName("fakearm", 10)
+
+		// Read through an uncached client, so that the objects created below are visible right away.
+		c, err := client.New(testEnv.Config, client.Options{Scheme: testEnv.GetScheme()})
+		Expect(err).NotTo(HaveOccurred())
+
+		arm.SetResource(fmt.Sprintf("/subscriptions/123/resourceGroups/%s", name), map[string]interface{}{"location": fakearm.DefaultLocation})
+		vmssID := fmt.Sprintf("/subscriptions/123/resourceGroups/%s/providers/Microsoft.Compute/virtualMachineScaleSets/%s", name, name)
+
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		}
+		azureCluster := &infrav1.AzureCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: infrav1.AzureClusterSpec{
+				Location:       fakearm.DefaultLocation,
+				SubscriptionID: "123",
+			},
+		}
+		azureCluster.Default()
+		bootstrap := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-bootstrap-data", Namespace: "default"},
+			Data:       map[string][]byte{"value": []byte("#cloud-config")},
+		}
+		machinePool := &capiv1exp.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1.ClusterLabelName: name},
+			},
+			Spec: capiv1exp.MachinePoolSpec{
+				ClusterName: name,
+				Replicas:    pointer.Int32Ptr(2),
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						ClusterName: name,
+						Version:     pointer.StringPtr("v1.19.1"),
+						Bootstrap:   clusterv1.Bootstrap{DataSecretName: pointer.StringPtr(bootstrap.Name)},
+					},
+				},
+			},
+		}
+		azureMachinePool := &infrav1exp.AzureMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: infrav1exp.AzureMachinePoolSpec{
+				Location: fakearm.DefaultLocation,
+				Template: infrav1exp.AzureMachineTemplate{VMSize: "Standard_D2s_v3"},
+			},
+		}
+		azureMachinePool.Default()
+		// The test environment doubles as the workload cluster, whose nodes are listed to report the instances.
+		workloadKubeconfig := newKubeconfigSecret(cluster, testEnv.Config.Host)
+		for _, obj := range []runtime.Object{cluster, azureCluster, bootstrap, workloadKubeconfig, machinePool, azureMachinePool} {
+			Expect(c.Create(ctx, obj)).To(Succeed())
+		}
+		defer func() {
+			for _, obj := range []runtime.Object{cluster, azureCluster, bootstrap, workloadKubeconfig, machinePool} {
+				Expect(c.Delete(ctx, obj)).To(Succeed())
+			}
+		}()
+		cluster.Status.InfrastructureReady = true
+
+		reconciler := &AzureMachinePoolReconciler{
+			Client:   c,
+			Log:      testEnv.Log,
+			Recorder: testEnv.GetEventRecorderFor("azuremachinepool-reconciler"),
+		}
+		// reconcileOnce runs one reconcile of the machine pool with fresh scopes, as the controller does, and persists the
+		// changes to the AzureMachinePool.
+		reconcileOnce := func(reconcileFunc func(context.Context, *scope.MachinePoolScope, *scope.ClusterScope) (reconcile.Result, error)) reconcile.Result {
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, azureMachinePool)).To(Succeed())
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				AzureClients: scope.AzureClients{
+					Authorizer:              arm.Authorizer(),
+					ResourceManagerEndpoint: arm.URL,
+				},
+				Client:       c,
+				Logger:       testEnv.Log,
+				Cluster:      cluster,
+				AzureCluster: azureCluster,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			machinePoolScope, err := scope.NewMachinePoolScope(scope.MachinePoolScopeParams{
+				Client:           c,
+				Logger:           testEnv.Log,
+				MachinePool:      machinePool,
+				AzureMachinePool: azureMachinePool,
+				ClusterScope:     clusterScope,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := reconcileFunc(ctx, machinePoolScope, clusterScope)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(machinePoolScope.Close(ctx)).To(Succeed())
+			return result
+		}
+
+		By("creating the scale set")
+		// The scale set is still being created after the first reconcile, which tracks its operation.
+		result := reconcileOnce(reconciler.reconcileNormal)
+		Expect(result.RequeueAfter).To(Equal(azure.DefaultOperationPollingRequeue))
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, azureMachinePool)).To(Succeed())
+		Expect(azureMachinePool.Finalizers).To(ContainElement(capiv1exp.MachinePoolFinalizer))
+		Expect(azureMachinePool.Status.LongRunningOperationState).NotTo(BeNil())
+		Expect(azureMachinePool.Status.ProvisioningState).NotTo(BeNil())
+		Expect(*azureMachinePool.Status.ProvisioningState).To(Equal(infrav1.VMStateCreating))
+
+		// The scale set is provisioned, but the machine pool is still updating until the nodes of its instances are ready.
+		reconcileOnce(reconciler.reconcileNormal)
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, azureMachinePool)).To(Succeed())
+		Expect(azureMachinePool.Status.LongRunningOperationState).To(BeNil())
+		Expect(*azureMachinePool.Status.ProvisioningState).To(Equal(infrav1.VMStateUpdating))
+		Expect(azureMachinePool.Spec.ProviderID).To(HaveSuffix(vmssID))
+		Expect(azureMachinePool.Status.Instances).To(HaveLen(2))
+
+		By("reporting the nodes of the instances as ready")
+		for _, instance := range azureMachinePool.Status.Instances {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s", name, instance.InstanceID)},
+				Spec:       corev1.NodeSpec{ProviderID: instance.ProviderID},
+			}
+			Expect(c.Create(ctx, node)).To(Succeed())
+			defer func() {
+				Expect(c.Delete(ctx, node)).To(Succeed())
+			}()
+			node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+			Expect(c.Status().Update(ctx, node)).To(Succeed())
+		}
+
+		reconcileOnce(reconciler.reconcileNormal)
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, azureMachinePool)).To(Succeed())
+		Expect(*azureMachinePool.Status.ProvisioningState).To(Equal(infrav1.VMStateSucceeded))
+		Expect(azureMachinePool.Status.Replicas).To(BeEquivalentTo(2))
+		Expect(azureMachinePool.Status.Ready).To(BeTrue())
+
+		_, ok := arm.Resource(vmssID)
+		Expect(ok).To(BeTrue())
+
+		By("deleting the scale set")
+		Expect(c.Delete(ctx, azureMachinePool)).To(Succeed())
+		// The scale set is still being deleted after the first reconcile.
+		result = reconcileOnce(reconciler.reconcileDelete)
+		Expect(result.RequeueAfter).To(Equal(azure.DefaultOperationPollingRequeue))
+
+		reconcileOnce(reconciler.reconcileDelete)
+		_, ok = arm.Resource(vmssID)
+		Expect(ok).To(BeFalse())
+		Eventually(func() bool {
+			err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &infrav1exp.AzureMachinePool{})
+			return apierrors.IsNotFound(err)
+		}).Should(BeTrue())
+	})
+})
+
+// newKubeconfigSecret returns the kubeconfig secret of a cluster served by the API server at host. The test
+// environment serves its API without authentication, so the kubeconfig only needs the server address.
+func newKubeconfigSecret(cluster *clusterv1.Cluster, host string) *corev1.Secret {
+	data := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: %[1]s
+  cluster:
+    server: %[2]s
+contexts:
+- name: %[1]s
+  context:
+    cluster: %[1]s
+current-context: %[1]s
+`, cluster.Name, host)
+	return kubeconfig.GenerateSecret(cluster, []byte(data))
+}
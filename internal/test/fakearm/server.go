@@ -0,0 +1,1005 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakearm provides an in-process fake of the Azure Resource Manager API, so that the Azure services and
+// controllers can be exercised end to end with the real Azure SDK clients but without an Azure subscription.
+//
+// The fake implements the generic ARM resource semantics used by the groups, networking, compute, privatedns and
+// containerservice clients: resources are stored in memory as JSON documents keyed by resource ID, PUT creates or
+// replaces them, PATCH merges them, DELETE removes them and their children, and long-running operations are
+// simulated through the Azure-AsyncOperation header, reporting InProgress a configurable number of times before
+// succeeding.
+package fakearm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+)
+
+const (
+	// DefaultLocation is the location of the resource SKUs served by default.
+	DefaultLocation = "westus2"
+
+	operationsPath   = "/fakearm/operations/"
+	resourceGroups   = "resourcegroups"
+	resourceGroupTyp = "Microsoft.Resources/resourceGroups"
+	tagsType         = "microsoft.resources/tags"
+
+	statusInProgress = "InProgress"
+	statusSucceeded  = "Succeeded"
+)
+
+// Server is an in-process fake Azure Resource Manager.
+type Server struct {
+	*httptest.Server
+
+	mu              sync.Mutex
+	resources       map[string]*resource
+	operations      map[string]*operation
+	injected        []injectedError
+	skus            []compute.ResourceSku
+	pollsBeforeDone int
+	nextOperation   int
+	nextIP          int
+	requests        int
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithPollsBeforeDone sets the number of times a long-running operation reports InProgress before succeeding. With
+// zero, resources are created, updated and deleted synchronously.
+func WithPollsBeforeDone(polls int) Option {
+	return func(s *Server) {
+		s.pollsBeforeDone = polls
+	}
+}
+
+// WithResourceSKUs sets the compute resource SKUs returned by the fake.
+func WithResourceSKUs(skus ...compute.ResourceSku) Option {
+	return func(s *Server) {
+		s.skus = skus
+	}
+}
+
+// resource is a stored ARM resource.
+type resource struct {
+	// id is the resource ID as first sent by the client.
+	id   string
+	body map[string]interface{}
+	// created is the time the resource was created, returned when listing resources with $expand=createdTime.
+	created time.Time
+}
+
+// operation is a simulated long-running operation.
+type operation struct {
+	remaining int
+	// complete is called with the server lock held once the operation succeeds.
+	complete func()
+}
+
+// injectedError is an error returned once in place of the response to a request.
+type injectedError struct {
+	method     string
+	path       string
+	statusCode int
+	code       string
+}
+
+// NewServer starts a fake Azure Resource Manager. Callers must Close it when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		resources:       map[string]*resource{},
+		operations:      map[string]*operation{},
+		skus:            DefaultResourceSKUs(DefaultLocation),
+		pollsBeforeDone: 1,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// DefaultResourceSKUs returns virtual machine SKUs available in the three availability zones of a location.
+func DefaultResourceSKUs(location string) []compute.ResourceSku {
+	var skus []compute.ResourceSku
+	for _, size := range []struct {
+		name, vCPUs, memory string
+	}{
+		{name: "Standard_D2s_v3", vCPUs: "2", memory: "8"},
+		{name: "Standard_D4s_v3", vCPUs: "4", memory: "16"},
+	} {
+		skus = append(skus, compute.ResourceSku{
+			Name:         to.StringPtr(size.name),
+			ResourceType: to.StringPtr("virtualMachines"),
+			Locations:    &[]string{location},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: to.StringPtr(location),
+					Zones:    &[]string{"1", "2", "3"},
+				},
+			},
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr("vCPUs"), Value: to.StringPtr(size.vCPUs)},
+				{Name: to.StringPtr("MemoryGB"), Value: to.StringPtr(size.memory)},
+				{Name: to.StringPtr("AcceleratedNetworkingEnabled"), Value: to.StringPtr("True")},
+				{Name: to.StringPtr("PremiumIO"), Value: to.StringPtr("True")},
+			},
+		})
+	}
+	return skus
+}
+
+// Authorizer returns an authorizer suitable for the Azure clients talking to the fake.
+func (s *Server) Authorizer() autorest.Authorizer {
+	return autorest.NullAuthorizer{}
+}
+
+// Resource returns a copy of the stored resource with the given ID.
+func (s *Server) Resource(id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.get(id)
+}
+
+// SetResource stores a resource as if it had been created out of band.
+func (s *Server) SetResource(id string, body map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body = deepCopy(body)
+	s.setIdentity(id, body)
+	if typeOf(body) == "" {
+		body["type"] = resourceTypeFromID(id)
+	}
+	properties(body)["provisioningState"] = statusSucceeded
+	s.resources[strings.ToLower(id)] = &resource{id: id, body: body, created: time.Now()}
+}
+
+// SetCreatedTime overrides the creation time of a stored resource.
+func (s *Server) SetCreatedTime(id string, created time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.resources[strings.ToLower(id)]; ok {
+		r.created = created
+	}
+}
+
+// ResourceIDs returns the sorted IDs of all the stored resources.
+func (s *Server) ResourceIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.resources))
+	for _, r := range s.resources {
+		ids = append(ids, r.id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Requests returns the number of requests served so far, including long-running operation polling requests.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// InjectError makes the next request with the given method and resource ID fail with an ARM error.
+func (s *Server) InjectError(method, id string, statusCode int, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.injected = append(s.injected, injectedError{
+		method:     method,
+		path:       strings.ToLower(strings.TrimSuffix(id, "/")),
+		statusCode: statusCode,
+		code:       code,
+	})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	// Clients which build URLs from full resource IDs, such as the generic resources client, send a double leading
+	// slash which ARM tolerates.
+	path := "/" + strings.TrimLeft(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if s.popInjectedError(w, r.Method, path) {
+		return
+	}
+
+	if strings.HasPrefix(path, operationsPath) {
+		s.serveOperation(w, strings.TrimPrefix(path, operationsPath))
+		return
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") {
+		writeError(w, http.StatusNotFound, "InvalidRequestUri", "the request URI %q is not supported", path)
+		return
+	}
+
+	lowerPath := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(lowerPath, "/providers/microsoft.compute/skus") && len(segments) == 5:
+		s.serveSKUs(w, r)
+		return
+	case len(segments) == 3 && strings.EqualFold(segments[2], resourceGroups):
+		s.serveCollection(w, r, path, resourceGroupTyp)
+		return
+	case len(segments) == 4 && strings.EqualFold(segments[2], resourceGroups):
+		s.serveResource(w, r, path, resourceGroupTyp)
+		return
+	case len(segments) == 5 && strings.EqualFold(segments[2], resourceGroups) && strings.EqualFold(segments[4], "resources"):
+		s.serveResourceGroupResources(w, r, "/"+strings.Join(segments[:4], "/"))
+		return
+	}
+
+	providers := -1
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "providers") && i+2 < len(segments) {
+			providers = i
+		}
+	}
+	if providers < 0 {
+		writeError(w, http.StatusNotFound, "InvalidRequestUri", "the request URI %q is not supported", path)
+		return
+	}
+
+	rest := segments[providers+2:]
+	resourceType := resourceTypeFromID(path)
+
+	if strings.EqualFold(resourceType, tagsType) && len(rest) == 2 {
+		s.serveTags(w, r, "/"+strings.Join(segments[:providers], "/"), path)
+		return
+	}
+
+	if len(rest)%2 == 1 {
+		if r.Method == http.MethodPost && len(rest) > 1 {
+			s.serveAction(w, r, "/"+strings.Join(segments[:len(segments)-1], "/"), rest[len(rest)-1])
+			return
+		}
+		s.serveCollection(w, r, path, resourceType)
+		return
+	}
+	s.serveResource(w, r, path, resourceType)
+}
+
+// popInjectedError writes and forgets the first injected error matching the request, if any.
+func (s *Server) popInjectedError(w http.ResponseWriter, method, path string) bool {
+	for i, inj := range s.injected {
+		if inj.method == method && inj.path == strings.ToLower(path) {
+			s.injected = append(s.injected[:i], s.injected[i+1:]...)
+			writeError(w, inj.statusCode, inj.code, "injected error for %s %s", method, path)
+			return true
+		}
+	}
+	return false
+}
+
+// serveResource handles the requests targeting a single resource.
+func (s *Server) serveResource(w http.ResponseWriter, r *http.Request, id, resourceType string) {
+	switch r.Method {
+	case http.MethodGet:
+		body, ok := s.get(id)
+		if !ok {
+			writeNotFound(w, id)
+			return
+		}
+		writeJSON(w, http.StatusOK, body)
+	case http.MethodHead:
+		if _, ok := s.get(id); !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPut:
+		s.put(w, r, id, resourceType)
+	case http.MethodPatch:
+		s.patch(w, r, id)
+	case http.MethodDelete:
+		s.delete(w, id)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "method %s is not supported", r.Method)
+	}
+}
+
+func (s *Server) put(w http.ResponseWriter, r *http.Request, id, resourceType string) {
+	body := map[string]interface{}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err.Error() != "EOF" {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", "failed to parse the request body: %v", err)
+		return
+	}
+
+	parent, name, child := s.inlineParent(id)
+	if child != "" && parent == nil {
+		writeError(w, http.StatusNotFound, "ParentResourceNotFound", "the parent of resource %q was not found", id)
+		return
+	}
+	if child == "" && !s.parentExists(id) {
+		writeError(w, http.StatusNotFound, "ResourceGroupNotFound", "the resource group of resource %q was not found", id)
+		return
+	}
+
+	_, exists := s.get(id)
+	status := http.StatusCreated
+	state := "Creating"
+	if exists {
+		status = http.StatusOK
+		state = "Updating"
+	}
+	if s.pollsBeforeDone == 0 || resourceType == resourceGroupTyp {
+		state = statusSucceeded
+	}
+
+	s.setIdentity(id, body)
+	body["type"] = resourceType
+	properties(body)["provisioningState"] = state
+	s.simulate(id, body)
+
+	if child != "" {
+		upsertInline(parent, child, name, body)
+	} else {
+		created := time.Now()
+		if r, ok := s.resources[strings.ToLower(id)]; ok {
+			created = r.created
+		}
+		s.resources[strings.ToLower(id)] = &resource{id: id, body: body, created: created}
+	}
+
+	if state == statusSucceeded {
+		writeJSON(w, status, body)
+		return
+	}
+	s.writeAsync(w, status, body, func() {
+		if current, ok := s.get(id); ok {
+			s.setProvisioningState(id, current, statusSucceeded)
+		}
+	})
+}
+
+func (s *Server) patch(w http.ResponseWriter, r *http.Request, id string) {
+	current, ok := s.get(id)
+	if !ok {
+		writeNotFound(w, id)
+		return
+	}
+	update := map[string]interface{}{}
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", "failed to parse the request body: %v", err)
+		return
+	}
+	merge(current, update)
+	properties(current)["provisioningState"] = statusSucceeded
+	s.store(id, current)
+	writeJSON(w, http.StatusOK, current)
+}
+
+func (s *Server) delete(w http.ResponseWriter, id string) {
+	current, ok := s.get(id)
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	remove := func() { s.remove(id) }
+	if s.pollsBeforeDone == 0 {
+		remove()
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	s.setProvisioningState(id, current, "Deleting")
+	s.writeAsync(w, http.StatusAccepted, nil, remove)
+}
+
+// serveCollection lists the resources of a type, either the inline children of a parent resource or all the
+// resources of the type within a subscription or resource group.
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, path, resourceType string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "method %s is not supported on collections", r.Method)
+		return
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	child := segments[len(segments)-1]
+	parentID := "/" + strings.Join(segments[:len(segments)-1], "/")
+	if strings.Count(resourceType, "/") > 1 {
+		parent, ok := s.get(parentID)
+		if !ok {
+			writeNotFound(w, parentID)
+			return
+		}
+		items, _ := properties(parent)[childKey(parent, child)].([]interface{})
+		if items == nil {
+			items = []interface{}{}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"value": items})
+		return
+	}
+
+	scope := strings.ToLower(parentID)
+	if i := strings.LastIndex(scope, "/providers/"); i >= 0 {
+		scope = scope[:i]
+	}
+	items := []interface{}{}
+	for _, key := range s.sortedKeys() {
+		res := s.resources[key]
+		if strings.HasPrefix(key, scope+"/") && strings.EqualFold(typeOf(res.body), resourceType) {
+			items = append(items, deepCopy(res.body))
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"value": items})
+}
+
+// serveResourceGroupResources lists the top-level resources of a resource group.
+func (s *Server) serveResourceGroupResources(w http.ResponseWriter, r *http.Request, groupID string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "method %s is not supported on collections", r.Method)
+		return
+	}
+	if _, ok := s.get(groupID); !ok {
+		writeError(w, http.StatusNotFound, "ResourceGroupNotFound", "the resource group %q was not found", groupID)
+		return
+	}
+
+	prefix := strings.ToLower(groupID) + "/providers/"
+	items := []interface{}{}
+	for _, key := range s.sortedKeys() {
+		if !strings.HasPrefix(key, prefix) || strings.Count(strings.TrimPrefix(key, prefix), "/") != 2 {
+			continue
+		}
+		res := s.resources[key]
+		item := map[string]interface{}{
+			"id":       res.body["id"],
+			"name":     res.body["name"],
+			"type":     res.body["type"],
+			"location": res.body["location"],
+			"tags":     res.body["tags"],
+		}
+		if strings.Contains(r.URL.Query().Get("$expand"), "createdTime") {
+			item["createdTime"] = res.created.UTC().Format(time.RFC3339Nano)
+		}
+		items = append(items, item)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"value": items})
+}
+
+// serveAction handles POST actions on a resource, e.g. listClusterAdminCredential on a managed cluster.
+func (s *Server) serveAction(w http.ResponseWriter, r *http.Request, id, action string) {
+	if _, ok := s.get(id); !ok {
+		writeNotFound(w, id)
+		return
+	}
+	switch strings.ToLower(action) {
+	case "listclusteradmincredential", "listclusterusercredential":
+		kubeconfig := base64.StdEncoding.EncodeToString([]byte("apiVersion: v1\nkind: Config\n"))
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"kubeconfigs": []interface{}{
+				map[string]interface{}{"name": "clusterAdmin", "value": kubeconfig},
+			},
+		})
+	default:
+		if s.pollsBeforeDone == 0 {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		s.writeAsync(w, http.StatusAccepted, nil, func() {})
+	}
+}
+
+// serveTags handles the tags of a resource, which ARM exposes as a Microsoft.Resources/tags extension resource.
+func (s *Server) serveTags(w http.ResponseWriter, r *http.Request, scope, id string) {
+	target, ok := s.get(scope)
+	if !ok {
+		writeNotFound(w, scope)
+		return
+	}
+	tags, _ := target["tags"].(map[string]interface{})
+	if tags == nil {
+		tags = map[string]interface{}{}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPatch:
+		req := struct {
+			Operation  string `json:"operation"`
+			Properties struct {
+				Tags map[string]interface{} `json:"tags"`
+			} `json:"properties"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", "failed to parse the request body: %v", err)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPut || strings.EqualFold(req.Operation, "Replace"):
+			tags = req.Properties.Tags
+		case strings.EqualFold(req.Operation, "Merge"):
+			for k, v := range req.Properties.Tags {
+				tags[k] = v
+			}
+		case strings.EqualFold(req.Operation, "Delete"):
+			for k := range req.Properties.Tags {
+				delete(tags, k)
+			}
+		default:
+			writeError(w, http.StatusBadRequest, "InvalidTagOperation", "unsupported tag operation %q", req.Operation)
+			return
+		}
+		target["tags"] = tags
+		s.store(scope, target)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "method %s is not supported on tags", r.Method)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":         id,
+		"name":       "default",
+		"type":       "Microsoft.Resources/tags",
+		"properties": map[string]interface{}{"tags": tags},
+	})
+}
+
+func (s *Server) serveSKUs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "method %s is not supported on resource SKUs", r.Method)
+		return
+	}
+	writeJSON(w, http.StatusOK, compute.ResourceSkusResult{Value: &s.skus})
+}
+
+// serveOperation reports the status of a long-running operation, completing it once it was polled enough times.
+func (s *Server) serveOperation(w http.ResponseWriter, name string) {
+	op, ok := s.operations[name]
+	if !ok {
+		writeNotFound(w, operationsPath+name)
+		return
+	}
+	status := statusInProgress
+	if op.remaining > 0 {
+		op.remaining--
+	}
+	if op.remaining == 0 {
+		if op.complete != nil {
+			op.complete()
+			op.complete = nil
+		}
+		status = statusSucceeded
+	}
+	w.Header().Set(autorest.HeaderRetryAfter, "0")
+	writeJSON(w, http.StatusOK, map[string]interface{}{"name": name, "status": status})
+}
+
+// writeAsync starts a long-running operation and writes its initial response.
+func (s *Server) writeAsync(w http.ResponseWriter, status int, body map[string]interface{}, complete func()) {
+	s.nextOperation++
+	name := strconv.Itoa(s.nextOperation)
+	s.operations[name] = &operation{remaining: s.pollsBeforeDone, complete: complete}
+
+	w.Header().Set("Azure-AsyncOperation", s.URL+operationsPath+name)
+	w.Header().Set(autorest.HeaderRetryAfter, "0")
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	writeJSON(w, status, body)
+}
+
+// get returns a copy of a resource, looking it up in the properties of its parent for inline child resources.
+func (s *Server) get(id string) (map[string]interface{}, bool) {
+	parent, name, child := s.inlineParent(id)
+	if child != "" {
+		if parent == nil {
+			return nil, false
+		}
+		items, _ := properties(parent)[childKey(parent, child)].([]interface{})
+		if i := indexOf(items, name); i >= 0 {
+			return deepCopy(items[i].(map[string]interface{})), true
+		}
+		return nil, false
+	}
+	r, ok := s.resources[strings.ToLower(id)]
+	if !ok {
+		return nil, false
+	}
+	return deepCopy(r.body), true
+}
+
+// store replaces a resource, or its inline representation in its parent.
+func (s *Server) store(id string, body map[string]interface{}) {
+	parent, name, child := s.inlineParent(id)
+	if child != "" {
+		if parent != nil {
+			upsertInline(parent, child, name, body)
+		}
+		return
+	}
+	if r, ok := s.resources[strings.ToLower(id)]; ok {
+		r.body = body
+	}
+}
+
+// remove deletes a resource along with the resources nested under it.
+func (s *Server) remove(id string) {
+	parent, name, child := s.inlineParent(id)
+	if child != "" {
+		if parent != nil {
+			key := childKey(parent, child)
+			items, _ := properties(parent)[key].([]interface{})
+			if i := indexOf(items, name); i >= 0 {
+				properties(parent)[key] = append(items[:i], items[i+1:]...)
+			}
+		}
+		return
+	}
+	key := strings.ToLower(id)
+	for k := range s.resources {
+		if k == key || strings.HasPrefix(k, key+"/") {
+			delete(s.resources, k)
+		}
+	}
+}
+
+func (s *Server) setProvisioningState(id string, body map[string]interface{}, state string) {
+	properties(body)["provisioningState"] = state
+	s.store(id, body)
+}
+
+// inlineParent returns the stored body of the parent of a child resource such as a subnet, along with the child
+// name and collection. Child resources are stored inline in the properties of their parent, as ARM returns them.
+// The collection is empty for top-level resources.
+func (s *Server) inlineParent(id string) (map[string]interface{}, string, string) {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+	providers := -1
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "providers") && i+2 < len(segments) {
+			providers = i
+		}
+	}
+	if providers < 0 || len(segments)-providers <= 4 {
+		return nil, "", ""
+	}
+	parentID := strings.ToLower("/" + strings.Join(segments[:len(segments)-2], "/"))
+	name := segments[len(segments)-1]
+	child := segments[len(segments)-2]
+	if r, ok := s.resources[parentID]; ok {
+		return r.body, name, child
+	}
+	// Nested children, e.g. the properties of a scale set instance, are looked up in the inline parent.
+	grandparent, parentName, parentChild := s.inlineParent(parentID)
+	if grandparent == nil {
+		return nil, name, child
+	}
+	items, _ := properties(grandparent)[childKey(grandparent, parentChild)].([]interface{})
+	if i := indexOf(items, parentName); i >= 0 {
+		return items[i].(map[string]interface{}), name, child
+	}
+	return nil, name, child
+}
+
+// parentExists returns true if the resource group and the parent resource of a top-level resource exist.
+func (s *Server) parentExists(id string) bool {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+	if len(segments) < 4 || !strings.EqualFold(segments[2], resourceGroups) {
+		return true
+	}
+	if len(segments) == 4 {
+		return true
+	}
+	rg := strings.ToLower("/" + strings.Join(segments[:4], "/"))
+	if _, ok := s.resources[rg]; !ok {
+		return false
+	}
+	// Extension resources, e.g. role assignments, require the resource they extend.
+	for i := len(segments) - 3; i > 4; i-- {
+		if strings.EqualFold(segments[i], "providers") {
+			_, ok := s.get("/" + strings.Join(segments[:i], "/"))
+			return ok
+		}
+	}
+	return true
+}
+
+// setIdentity sets the id and name of a resource body and of its inline child resources.
+func (s *Server) setIdentity(id string, body map[string]interface{}) {
+	body["id"] = id
+	body["name"] = id[strings.LastIndex(id, "/")+1:]
+	for key, value := range properties(body) {
+		items, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, item := range items {
+			child, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, ok := child["name"].(string)
+			if !ok {
+				continue
+			}
+			child["id"] = id + "/" + key + "/" + name
+		}
+	}
+}
+
+// simulate fills in the read-only properties Azure sets when a resource is created.
+func (s *Server) simulate(id string, body map[string]interface{}) {
+	props := properties(body)
+	switch strings.ToLower(typeOf(body)) {
+	case "microsoft.network/networkinterfaces":
+		for _, item := range items(props, "ipConfigurations") {
+			ipProps := properties(item)
+			if ip, _ := ipProps["privateIPAddress"].(string); ip == "" {
+				ipProps["privateIPAddress"] = s.allocateIP("10.0.0.")
+			}
+		}
+		if _, ok := props["macAddress"]; !ok {
+			props["macAddress"] = "00-0D-3A-00-00-00"
+		}
+	case "microsoft.network/publicipaddresses":
+		if ip, _ := props["ipAddress"].(string); ip == "" {
+			props["ipAddress"] = s.allocateIP("20.0.0.")
+		}
+		if dns, ok := props["dnsSettings"].(map[string]interface{}); ok {
+			if label, _ := dns["domainNameLabel"].(string); label != "" && dns["fqdn"] == nil {
+				location, _ := body["location"].(string)
+				dns["fqdn"] = fmt.Sprintf("%s.%s.cloudapp.azure.com", label, location)
+			}
+		}
+	case "microsoft.network/virtualnetworks":
+		ensureArrays(props, "subnets", "virtualNetworkPeerings")
+	case "microsoft.network/loadbalancers":
+		ensureArrays(props, "frontendIPConfigurations", "backendAddressPools", "loadBalancingRules", "probes",
+			"inboundNatRules", "outboundRules")
+		for _, item := range items(props, "frontendIPConfigurations") {
+			ipProps := properties(item)
+			if _, ok := ipProps["subnet"]; !ok {
+				continue
+			}
+			if ip, _ := ipProps["privateIPAddress"].(string); ip == "" {
+				ipProps["privateIPAddress"] = s.allocateIP("10.0.0.")
+			}
+		}
+	case "microsoft.compute/virtualmachines":
+		if _, ok := props["vmId"]; !ok {
+			props["vmId"] = fakeUUID(id)
+		}
+	case "microsoft.compute/virtualmachinescalesets":
+		if _, ok := props["uniqueId"]; !ok {
+			props["uniqueId"] = fakeUUID(id)
+		}
+		s.simulateScaleSetInstances(id, body)
+	case "microsoft.containerservice/managedclusters":
+		location, _ := body["location"].(string)
+		prefix, _ := props["dnsPrefix"].(string)
+		props["fqdn"] = fmt.Sprintf("%s.hcp.%s.azmk8s.io", prefix, location)
+		if _, ok := props["nodeResourceGroup"]; !ok {
+			segments := strings.Split(strings.Trim(id, "/"), "/")
+			props["nodeResourceGroup"] = fmt.Sprintf("MC_%s_%s_%s", segments[3], segments[len(segments)-1], location)
+		}
+	}
+}
+
+// simulateScaleSetInstances creates the instances of a scale set according to its capacity.
+func (s *Server) simulateScaleSetInstances(id string, body map[string]interface{}) {
+	sku, _ := body["sku"].(map[string]interface{})
+	capacity, _ := sku["capacity"].(float64)
+	name := id[strings.LastIndex(id, "/")+1:]
+
+	instances := []interface{}{}
+	for i := 0; i < int(capacity); i++ {
+		instanceID := strconv.Itoa(i)
+		instances = append(instances, map[string]interface{}{
+			"id":         id + "/virtualMachines/" + instanceID,
+			"name":       fmt.Sprintf("%s_%d", name, i),
+			"instanceId": instanceID,
+			"location":   body["location"],
+			"properties": map[string]interface{}{
+				"latestModelApplied": true,
+				"provisioningState":  statusSucceeded,
+				"osProfile": map[string]interface{}{
+					"computerName": fmt.Sprintf("%s%06d", name, i),
+				},
+			},
+		})
+	}
+	properties(body)["virtualMachines"] = instances
+}
+
+func (s *Server) allocateIP(prefix string) string {
+	s.nextIP++
+	return fmt.Sprintf("%s%d", prefix, s.nextIP+3)
+}
+
+func (s *Server) sortedKeys() []string {
+	keys := make([]string, 0, len(s.resources))
+	for k := range s.resources {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// properties returns the properties of a resource body, adding them if missing.
+func properties(body map[string]interface{}) map[string]interface{} {
+	props, ok := body["properties"].(map[string]interface{})
+	if !ok {
+		props = map[string]interface{}{}
+		body["properties"] = props
+	}
+	return props
+}
+
+// resourceTypeFromID returns the type of the resource with the given ID, e.g. Microsoft.Network/virtualNetworks/subnets.
+func resourceTypeFromID(id string) string {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+	providers := -1
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "providers") && i+2 < len(segments) {
+			providers = i
+		}
+	}
+	if providers < 0 {
+		return resourceGroupTyp
+	}
+	types := []string{segments[providers+1]}
+	for i := providers + 2; i < len(segments); i += 2 {
+		types = append(types, segments[i])
+	}
+	return strings.Join(types, "/")
+}
+
+// typeOf returns the resource type of a resource body.
+func typeOf(body map[string]interface{}) string {
+	t, _ := body["type"].(string)
+	return t
+}
+
+// items returns the objects of an array property.
+func items(props map[string]interface{}, key string) []map[string]interface{} {
+	list, _ := props[key].([]interface{})
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if m, ok := item.(map[string]interface{}); ok {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// ensureArrays sets the missing collections of a resource to empty arrays, as ARM returns them.
+func ensureArrays(props map[string]interface{}, keys ...string) {
+	for _, key := range keys {
+		if _, ok := props[key].([]interface{}); !ok {
+			props[key] = []interface{}{}
+		}
+	}
+}
+
+// upsertInline adds or replaces an inline child resource in the properties of its parent.
+func upsertInline(parent map[string]interface{}, child, name string, body map[string]interface{}) {
+	props := properties(parent)
+	child = childKey(parent, child)
+	list, _ := props[child].([]interface{})
+	if i := indexOf(list, name); i >= 0 {
+		list[i] = body
+	} else {
+		list = append(list, body)
+	}
+	props[child] = list
+}
+
+// childKey returns the property holding the inline child resources of a collection, matching its name case
+// insensitively as ARM does.
+func childKey(parent map[string]interface{}, child string) string {
+	for key := range properties(parent) {
+		if strings.EqualFold(key, child) {
+			return key
+		}
+	}
+	return child
+}
+
+// indexOf returns the index of the inline child resource with the given name or instance ID, or -1.
+func indexOf(list []interface{}, name string) int {
+	for i, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if n, _ := m["name"].(string); strings.EqualFold(n, name) {
+			return i
+		}
+		if n, _ := m["instanceId"].(string); n != "" && n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// merge applies a JSON merge patch to a resource body.
+func merge(dst, patch map[string]interface{}) {
+	for k, v := range patch {
+		if v == nil {
+			delete(dst, k)
+			continue
+		}
+		src, ok := v.(map[string]interface{})
+		existing, isMap := dst[k].(map[string]interface{})
+		if ok && isMap {
+			merge(existing, src)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+func deepCopy(body map[string]interface{}) map[string]interface{} {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		panic(err)
+	}
+	out := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// fakeUUID returns a stable UUID-formatted identifier derived from a resource ID.
+func fakeUUID(id string) string {
+	var h uint64 = 14695981039346656037
+	for _, c := range []byte(strings.ToLower(id)) {
+		h ^= uint64(c)
+		h *= 1099511628211
+	}
+	hex := fmt.Sprintf("%016x%016x", h, h^0x5bd1e995)
+	return fmt.Sprintf("%s-%s-%s-%s-%s", hex[0:8], hex[8:12], hex[12:16], hex[16:20], hex[20:32])
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeNotFound(w http.ResponseWriter, id string) {
+	writeError(w, http.StatusNotFound, "ResourceNotFound", "the resource %q was not found", id)
+}
+
+// writeError writes an error in the format returned by Azure Resource Manager.
+func writeError(w http.ResponseWriter, status int, code, format string, args ...interface{}) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": fmt.Sprintf(format, args...),
+		},
+	})
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakearm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-30/compute"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2020-02-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	tagsapi "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+const subscriptionID = "123"
+
+func newResourceGroup(g *WithT, s *Server, name string) {
+	groups := resources.NewGroupsClientWithBaseURI(s.URL, subscriptionID)
+	groups.Authorizer = s.Authorizer()
+	_, err := groups.CreateOrUpdate(context.TODO(), name, resources.Group{Location: to.StringPtr(DefaultLocation)})
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestResourceGroupLifecycle(t *testing.T) {
+	g := NewWithT(t)
+	s := NewServer()
+	defer s.Close()
+	ctx := context.TODO()
+
+	groups := resources.NewGroupsClientWithBaseURI(s.URL, subscriptionID)
+	groups.Authorizer = s.Authorizer()
+	vnets := network.NewVirtualNetworksClientWithBaseURI(s.URL, subscriptionID)
+	vnets.Authorizer = s.Authorizer()
+	subnets := network.NewSubnetsClientWithBaseURI(s.URL, subscriptionID)
+	subnets.Authorizer = s.Authorizer()
+
+	group, err := groups.CreateOrUpdate(ctx, "my-rg", resources.Group{
+		Location: to.StringPtr(DefaultLocation),
+		Tags:     map[string]*string{"owner": to.StringPtr("me")},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(to.String(group.ID)).To(Equal("/subscriptions/123/resourcegroups/my-rg"))
+	g.Expect(to.String(group.Properties.ProvisioningState)).To(Equal("Succeeded"))
+
+	future, err := vnets.CreateOrUpdate(ctx, "my-rg", "my-vnet", network.VirtualNetwork{
+		Location: to.StringPtr(DefaultLocation),
+		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+			AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+			Subnets: &[]network.Subnet{
+				{
+					Name:                   to.StringPtr("my-subnet"),
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{AddressPrefix: to.StringPtr("10.0.0.0/16")},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(future.WaitForCompletionRef(ctx, vnets.Client)).To(Succeed())
+	vnet, err := future.Result(vnets)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(to.String(vnet.ProvisioningState)).To(Equal("Succeeded"))
+
+	subnet, err := subnets.Get(ctx, "my-rg", "my-vnet", "my-subnet", "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(to.String(subnet.ID)).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet"))
+	g.Expect(to.String(subnet.AddressPrefix)).To(Equal("10.0.0.0/16"))
+
+	list, err := vnets.List(ctx, "my-rg")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(list.Values()).To(HaveLen(1))
+
+	deleteFuture, err := groups.Delete(ctx, "my-rg")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(deleteFuture.WaitForCompletionRef(ctx, groups.Client)).To(Succeed())
+
+	_, err = vnets.Get(ctx, "my-rg", "my-vnet", "")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.(autorest.DetailedError).StatusCode).To(Equal(http.StatusNotFound))
+	g.Expect(s.ResourceIDs()).To(BeEmpty())
+}
+
+func TestLongRunningOperation(t *testing.T) {
+	g := NewWithT(t)
+	s := NewServer(WithPollsBeforeDone(2))
+	defer s.Close()
+	ctx := context.TODO()
+	newResourceGroup(g, s, "my-rg")
+
+	vms := compute.NewVirtualMachinesClientWithBaseURI(s.URL, subscriptionID)
+	vms.Authorizer = s.Authorizer()
+
+	future, err := vms.CreateOrUpdate(ctx, "my-rg", "my-vm", compute.VirtualMachine{
+		Location:                 to.StringPtr(DefaultLocation),
+		VirtualMachineProperties: &compute.VirtualMachineProperties{},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	vm, err := vms.Get(ctx, "my-rg", "my-vm", "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(to.String(vm.ProvisioningState)).To(Equal("Creating"))
+
+	done, err := future.DoneWithContext(ctx, vms)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeFalse())
+	done, err = future.DoneWithContext(ctx, vms)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeTrue())
+
+	vm, err = future.Result(vms)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(to.String(vm.ProvisioningState)).To(Equal("Succeeded"))
+	g.Expect(to.String(vm.VMID)).NotTo(BeEmpty())
+}
+
+func TestParentNotFound(t *testing.T) {
+	g := NewWithT(t)
+	s := NewServer(WithPollsBeforeDone(0))
+	defer s.Close()
+	ctx := context.TODO()
+
+	ips := network.NewPublicIPAddressesClientWithBaseURI(s.URL, subscriptionID)
+	ips.Authorizer = s.Authorizer()
+	_, err := ips.CreateOrUpdate(ctx, "missing-rg", "my-ip", network.PublicIPAddress{Location: to.StringPtr(DefaultLocation)})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.(autorest.DetailedError).StatusCode).To(Equal(http.StatusNotFound))
+
+	newResourceGroup(g, s, "my-rg")
+	future, err := ips.CreateOrUpdate(ctx, "my-rg", "my-ip", network.PublicIPAddress{
+		Location: to.StringPtr(DefaultLocation),
+		PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+			DNSSettings: &network.PublicIPAddressDNSSettings{DomainNameLabel: to.StringPtr("my-cluster")},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	ip, err := future.Result(ips)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(to.String(ip.IPAddress)).NotTo(BeEmpty())
+	g.Expect(to.String(ip.DNSSettings.Fqdn)).To(Equal("my-cluster.westus2.cloudapp.azure.com"))
+}
+
+func TestInjectError(t *testing.T) {
+	g := NewWithT(t)
+	s := NewServer()
+	defer s.Close()
+	ctx := context.TODO()
+	newResourceGroup(g, s, "my-rg")
+
+	groups := resources.NewGroupsClientWithBaseURI(s.URL, subscriptionID)
+	groups.Authorizer = s.Authorizer()
+	s.InjectError(http.MethodGet, "/subscriptions/123/resourceGroups/my-rg", http.StatusBadRequest, "InvalidParameter")
+
+	_, err := groups.Get(ctx, "my-rg")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.(autorest.DetailedError).StatusCode).To(Equal(http.StatusBadRequest))
+
+	_, err = groups.Get(ctx, "my-rg")
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestTags(t *testing.T) {
+	g := NewWithT(t)
+	s := NewServer()
+	defer s.Close()
+	ctx := context.TODO()
+	newResourceGroup(g, s, "my-rg")
+
+	tags := tagsapi.NewTagsClientWithBaseURI(s.URL, subscriptionID)
+	tags.Authorizer = s.Authorizer()
+	scope := "/subscriptions/123/resourceGroups/my-rg"
+
+	_, err := tags.CreateOrUpdateAtScope(ctx, scope, tagsapi.TagsResource{
+		Properties: &tagsapi.Tags{Tags: map[string]*string{"a": to.StringPtr("1"), "b": to.StringPtr("2")}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = tags.UpdateAtScope(ctx, scope, tagsapi.TagsPatchResource{
+		Operation:  tagsapi.TagsPatchOperationDelete,
+		Properties: &tagsapi.Tags{Tags: map[string]*string{"a": to.StringPtr("1")}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	result, err := tags.GetAtScope(ctx, scope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.Properties.Tags).To(Equal(map[string]*string{"b": to.StringPtr("2")}))
+
+	group, ok := s.Resource(scope)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(group["tags"]).To(Equal(map[string]interface{}{"b": "2"}))
+}
+
+func TestManagedClusterCredentials(t *testing.T) {
+	g := NewWithT(t)
+	s := NewServer()
+	defer s.Close()
+	ctx := context.TODO()
+	newResourceGroup(g, s, "my-rg")
+
+	clusters := containerservice.NewManagedClustersClientWithBaseURI(s.URL, subscriptionID)
+	clusters.Authorizer = s.Authorizer()
+
+	future, err := clusters.CreateOrUpdate(ctx, "my-rg", "my-aks", containerservice.ManagedCluster{
+		Location:                 to.StringPtr(DefaultLocation),
+		ManagedClusterProperties: &containerservice.ManagedClusterProperties{DNSPrefix: to.StringPtr("my-aks")},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(future.WaitForCompletionRef(ctx, clusters.Client)).To(Succeed())
+
+	cluster, err := clusters.Get(ctx, "my-rg", "my-aks")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(to.String(cluster.Fqdn)).To(Equal("my-aks.hcp.westus2.azmk8s.io"))
+	g.Expect(to.String(cluster.NodeResourceGroup)).To(Equal("MC_my-rg_my-aks_westus2"))
+
+	credentials, err := clusters.ListClusterAdminCredentials(ctx, "my-rg", "my-aks")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*credentials.Kubeconfigs).To(HaveLen(1))
+	g.Expect(string(*(*credentials.Kubeconfigs)[0].Value)).To(ContainSubstring("kind: Config"))
+}
+
+func TestScaleSetInstances(t *testing.T) {
+	g := NewWithT(t)
+	s := NewServer(WithPollsBeforeDone(0))
+	defer s.Close()
+	ctx := context.TODO()
+	newResourceGroup(g, s, "my-rg")
+
+	scaleSets := compute.NewVirtualMachineScaleSetsClientWithBaseURI(s.URL, subscriptionID)
+	scaleSets.Authorizer = s.Authorizer()
+	instances := compute.NewVirtualMachineScaleSetVMsClientWithBaseURI(s.URL, subscriptionID)
+	instances.Authorizer = s.Authorizer()
+
+	_, err := scaleSets.CreateOrUpdate(ctx, "my-rg", "my-vmss", compute.VirtualMachineScaleSet{
+		Location: to.StringPtr(DefaultLocation),
+		Sku:      &compute.Sku{Name: to.StringPtr("Standard_D2s_v3"), Capacity: to.Int64Ptr(2)},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	list, err := instances.List(ctx, "my-rg", "my-vmss", "", "", "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(list.Values()).To(HaveLen(2))
+
+	instance, err := instances.Get(ctx, "my-rg", "my-vmss", "1", "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(to.String(instance.Name)).To(Equal("my-vmss_1"))
+	g.Expect(to.Bool(instance.LatestModelApplied)).To(BeTrue())
+}
+
+func TestResourceSKUs(t *testing.T) {
+	g := NewWithT(t)
+	s := NewServer()
+	defer s.Close()
+
+	skus := compute.NewResourceSkusClientWithBaseURI(s.URL, subscriptionID)
+	skus.Authorizer = s.Authorizer()
+	result, err := skus.List(context.TODO(), "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.Values()).To(HaveLen(2))
+	g.Expect(to.String(result.Values()[0].Name)).To(Equal("Standard_D2s_v3"))
+}
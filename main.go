@@ -48,6 +48,7 @@ import (
 
 	infrav1alpha2 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha2"
 	infrav1alpha3 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-azure/cloud/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1alpha3exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1alpha3"
 	infrav1controllersexp "sigs.k8s.io/cluster-api-provider-azure/exp/controllers"
@@ -89,6 +90,9 @@ var (
 	webhookPort                 int
 	reconcileTimeout            time.Duration
 	enableTracing               bool
+	skuCacheTTL                 time.Duration
+	orphanGCInterval            time.Duration
+	orphanGCDelete              bool
 )
 
 // InitFlags initializes all command-line flags.
@@ -176,6 +180,25 @@ func InitFlags(fs *pflag.FlagSet) {
 		"Enable Jaeger tracing to an agent running as a sidecar to the controller.",
 	)
 
+	fs.DurationVar(&skuCacheTTL,
+		"sku-cache-ttl",
+		resourceskus.DefaultCacheTTL,
+		"The duration resource SKUs are cached for before being listed again from Azure (e.g. 1h)",
+	)
+
+	fs.DurationVar(&orphanGCInterval,
+		"orphan-gc-interval",
+		0,
+		"The interval at which Azure resources owned by deleted clusters or machines are looked for (e.g. 1h), disabled by default",
+	)
+
+	fs.BoolVar(
+		&orphanGCDelete,
+		"orphan-gc-delete",
+		false,
+		"Delete the orphaned Azure resources found by the collector instead of only reporting them.",
+	)
+
 	feature.MutableGates.AddFlag(fs)
 }
 
@@ -242,11 +265,15 @@ func main() {
 	record.InitFromRecorder(mgr.GetEventRecorderFor("azure-controller"))
 
 	if webhookPort == 0 {
+		// share the resource SKU caches across all reconcilers to avoid listing SKUs on every reconcile.
+		skuCaches := resourceskus.NewCaches(skuCacheTTL)
+
 		if err = (&controllers.AzureMachineReconciler{
 			Client:           mgr.GetClient(),
 			Log:              ctrl.Log.WithName("controllers").WithName("AzureMachine"),
 			Recorder:         mgr.GetEventRecorderFor("azuremachine-reconciler"),
 			ReconcileTimeout: reconcileTimeout,
+			SKUCaches:        skuCaches,
 		}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: azureMachineConcurrency}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AzureMachine")
 			os.Exit(1)
@@ -256,6 +283,7 @@ func main() {
 			Log:              ctrl.Log.WithName("controllers").WithName("AzureCluster"),
 			Recorder:         mgr.GetEventRecorderFor("azurecluster-reconciler"),
 			ReconcileTimeout: reconcileTimeout,
+			SKUCaches:        skuCaches,
 		}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: azureClusterConcurrency}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AzureCluster")
 			os.Exit(1)
@@ -278,6 +306,18 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "AzureJSONMachine")
 			os.Exit(1)
 		}
+		if orphanGCInterval > 0 {
+			if err = (&controllers.OrphanCollector{
+				Client:   mgr.GetClient(),
+				Log:      ctrl.Log.WithName("controllers").WithName("OrphanCollector"),
+				Recorder: mgr.GetEventRecorderFor("orphan-collector"),
+				Interval: orphanGCInterval,
+				Delete:   orphanGCDelete,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "OrphanCollector")
+				os.Exit(1)
+			}
+		}
 		// just use CAPI MachinePool feature flag rather than create a new one
 		setupLog.V(1).Info(fmt.Sprintf("%+v\n", feature.Gates))
 		if feature.Gates.Enabled(capifeature.MachinePool) {
@@ -286,6 +326,7 @@ func main() {
 				Log:              ctrl.Log.WithName("controllers").WithName("AzureMachinePool"),
 				Recorder:         mgr.GetEventRecorderFor("azuremachinepool-reconciler"),
 				ReconcileTimeout: reconcileTimeout,
+				SKUCaches:        skuCaches,
 			}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: azureMachinePoolConcurrency}); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "AzureMachinePool")
 				os.Exit(1)
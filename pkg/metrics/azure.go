@@ -0,0 +1,249 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// rateLimitRemainingHeaderPrefix is the prefix of the Azure Resource Manager headers reporting the number of
+	// requests remaining before throttling, e.g. x-ms-ratelimit-remaining-subscription-reads.
+	rateLimitRemainingHeaderPrefix = "X-Ms-Ratelimit-Remaining-"
+	// rateLimitRemainingResourceHeader reports the remaining requests of the resource provider throttling policies,
+	// e.g. "Microsoft.Compute/HighCostGet3Min;139,Microsoft.Compute/HighCostGet30Min;699".
+	rateLimitRemainingResourceHeader = rateLimitRemainingHeaderPrefix + "Resource"
+	// unknownResourceType is the service label of requests whose URL does not identify an Azure resource type.
+	unknownResourceType = "unknown"
+
+	// The operation label of a request tells apart what the request targets, since a resource type and HTTP method
+	// alone don't, e.g. a GET of a virtual machine from a list of them or from the polling of its creation.
+	operationItem       = "item"
+	operationCollection = "collection"
+	operationAction     = "action"
+	operationAsync      = "async-operation"
+	operationUnknown    = "unknown"
+)
+
+var (
+	// AzureAPIRequestsTotal counts the requests sent to the Azure APIs.
+	AzureAPIRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capz_azure_api_requests_total",
+			Help: "Total number of requests sent to the Azure APIs, by service, operation, HTTP method and response status code.",
+		},
+		[]string{"service", "operation", "method", "code"},
+	)
+
+	// AzureAPIRequestDuration observes the latency of the requests sent to the Azure APIs.
+	AzureAPIRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "capz_azure_api_request_duration_seconds",
+			Help:    "Latency of the requests sent to the Azure APIs, by service, operation, HTTP method and response status code.",
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"service", "operation", "method", "code"},
+	)
+
+	// AzureAPIRateLimitRemaining reports the number of requests remaining before Azure Resource Manager throttles
+	// the subscription, as reported by the x-ms-ratelimit-remaining-* response headers.
+	AzureAPIRateLimitRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capz_azure_api_ratelimit_remaining",
+			Help: "Number of requests remaining before the Azure APIs throttle the subscription, by subscription and rate limit.",
+		},
+		[]string{"subscription", "limit"},
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		AzureAPIRequestsTotal,
+		AzureAPIRequestDuration,
+		AzureAPIRateLimitRemaining,
+	)
+}
+
+// WithAzureAPIMetrics returns a SendDecorator recording the count, latency, status code and remaining rate limits of
+// every request sent to the Azure APIs. Retries are sent through the decorated sender again, so each attempt is
+// recorded individually.
+func WithAzureAPIMetrics() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := s.Do(r)
+			observe(r, resp, err, time.Since(start))
+			return resp, err
+		})
+	}
+}
+
+// observe records the metrics of a single Azure API request.
+func observe(r *http.Request, resp *http.Response, err error, duration time.Duration) {
+	service := unknownResourceType
+	op := operationUnknown
+	subscription := ""
+	if r.URL != nil {
+		service = resourceType(r.URL.Path)
+		op = operation(r.Method, r.URL.Path)
+		subscription = subscriptionID(r.URL.Path)
+	}
+
+	code := "error"
+	if resp != nil {
+		code = strconv.Itoa(resp.StatusCode)
+	} else if err == nil {
+		code = "unknown"
+	}
+
+	AzureAPIRequestsTotal.WithLabelValues(service, op, r.Method, code).Inc()
+	AzureAPIRequestDuration.WithLabelValues(service, op, r.Method, code).Observe(duration.Seconds())
+
+	if resp == nil || subscription == "" {
+		return
+	}
+	for header, values := range resp.Header {
+		if !strings.HasPrefix(header, rateLimitRemainingHeaderPrefix) || len(values) == 0 {
+			continue
+		}
+		if header == rateLimitRemainingResourceHeader {
+			observeResourceRateLimits(subscription, values[0])
+			continue
+		}
+		remaining, err := strconv.ParseFloat(values[0], 64)
+		if err != nil {
+			continue
+		}
+		limit := strings.ToLower(strings.TrimPrefix(header, rateLimitRemainingHeaderPrefix))
+		AzureAPIRateLimitRemaining.WithLabelValues(subscription, limit).Set(remaining)
+	}
+}
+
+// observeResourceRateLimits records the remaining requests of each resource provider throttling policy listed in the
+// x-ms-ratelimit-remaining-resource header.
+func observeResourceRateLimits(subscription, value string) {
+	for _, policy := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(policy), ";")
+		if len(parts) != 2 {
+			continue
+		}
+		remaining, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			continue
+		}
+		AzureAPIRateLimitRemaining.WithLabelValues(subscription, "resource:"+parts[0]).Set(remaining)
+	}
+}
+
+// resourceType returns the Azure resource type targeted by a request path, e.g.
+// "Microsoft.Network/virtualNetworks/subnets" for a subnet or "Microsoft.Compute/locations/operations" for a
+// long-running operation polling request.
+func resourceType(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	providers := lastProviders(segments)
+	if providers < 0 {
+		for _, segment := range segments {
+			if strings.EqualFold(segment, "resourcegroups") {
+				return "Microsoft.Resources/resourceGroups"
+			}
+		}
+		return unknownResourceType
+	}
+
+	types := []string{segments[providers+1]}
+	for i := providers + 2; i < len(segments); i += 2 {
+		types = append(types, segments[i])
+	}
+	return strings.Join(types, "/")
+}
+
+// operation classifies what a request targets from its method and path: a single resource ("item"), a list of
+// resources ("collection"), an action such as restarting a virtual machine ("action"), or the status of a
+// long-running operation being polled ("async-operation").
+func operation(method, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	var rest []string
+	if providers := lastProviders(segments); providers >= 0 {
+		// The segments after the resource provider namespace alternate between resource types and names.
+		rest = segments[providers+2:]
+		for i := 0; i < len(rest); i += 2 {
+			if isOperationStatusType(rest[i]) {
+				return operationAsync
+			}
+		}
+	} else {
+		for i, segment := range segments {
+			if strings.EqualFold(segment, "subscriptions") && i+1 < len(segments) {
+				rest = segments[i+2:]
+				break
+			}
+		}
+	}
+
+	switch {
+	case len(rest) == 0:
+		return operationUnknown
+	case len(rest)%2 == 0:
+		return operationItem
+	case method == http.MethodPost && len(rest) > 1:
+		return operationAction
+	default:
+		return operationCollection
+	}
+}
+
+// isOperationStatusType returns true for the resource types Azure serves the status of long-running operations under,
+// e.g. "Microsoft.Network/locations/operations" or "Microsoft.Compute/locations/operationResults".
+func isOperationStatusType(resourceType string) bool {
+	switch strings.ToLower(resourceType) {
+	case "operations", "operationresults", "operationstatuses", "asyncoperations":
+		return true
+	}
+	return false
+}
+
+// lastProviders returns the index of the last "providers" segment of a path followed by a resource provider
+// namespace and type, or -1. Extension resources, e.g. role assignments, are nested under a second one.
+func lastProviders(segments []string) int {
+	providers := -1
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "providers") && i+2 < len(segments) {
+			providers = i
+		}
+	}
+	return providers
+}
+
+// subscriptionID returns the ID of the subscription targeted by a request path, or an empty string.
+func subscriptionID(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "subscriptions") && i+1 < len(segments) {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestResourceType(t *testing.T) {
+	cases := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{
+			name:     "virtual machine",
+			path:     "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm",
+			expected: "Microsoft.Compute/virtualMachines",
+		},
+		{
+			name:     "subnet",
+			path:     "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet",
+			expected: "Microsoft.Network/virtualNetworks/subnets",
+		},
+		{
+			name:     "list of scale set instances",
+			path:     "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/my-vmss/virtualMachines",
+			expected: "Microsoft.Compute/virtualMachineScaleSets/virtualMachines",
+		},
+		{
+			name:     "long-running operation status",
+			path:     "/subscriptions/123/providers/Microsoft.Compute/locations/westus2/operations/456",
+			expected: "Microsoft.Compute/locations/operations",
+		},
+		{
+			name:     "role assignment scoped to a virtual machine",
+			path:     "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm/providers/Microsoft.Authorization/roleAssignments/789",
+			expected: "Microsoft.Authorization/roleAssignments",
+		},
+		{
+			name:     "resource group",
+			path:     "/subscriptions/123/resourcegroups/my-rg",
+			expected: "Microsoft.Resources/resourceGroups",
+		},
+		{
+			name:     "unknown",
+			path:     "/",
+			expected: "unknown",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			g.Expect(resourceType(c.path)).To(gomega.Equal(c.expected))
+		})
+	}
+}
+
+func TestOperation(t *testing.T) {
+	cases := []struct {
+		name     string
+		method   string
+		path     string
+		expected string
+	}{
+		{
+			name:     "virtual machine",
+			method:   http.MethodGet,
+			path:     "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm",
+			expected: "item",
+		},
+		{
+			name:     "list of scale set instances",
+			method:   http.MethodGet,
+			path:     "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/my-vmss/virtualMachines",
+			expected: "collection",
+		},
+		{
+			name:     "resource SKUs",
+			method:   http.MethodGet,
+			path:     "/subscriptions/123/providers/Microsoft.Compute/skus",
+			expected: "collection",
+		},
+		{
+			name:     "scale set instances upgrade",
+			method:   http.MethodPost,
+			path:     "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/my-vmss/manualupgrade",
+			expected: "action",
+		},
+		{
+			name:     "long-running operation status",
+			method:   http.MethodGet,
+			path:     "/subscriptions/123/providers/Microsoft.Compute/locations/westus2/operations/456",
+			expected: "async-operation",
+		},
+		{
+			name:     "long-running operation result",
+			method:   http.MethodGet,
+			path:     "/subscriptions/123/providers/Microsoft.Network/locations/westus2/operationResults/456",
+			expected: "async-operation",
+		},
+		{
+			name:     "role assignment scoped to a virtual machine",
+			method:   http.MethodPut,
+			path:     "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm/providers/Microsoft.Authorization/roleAssignments/789",
+			expected: "item",
+		},
+		{
+			name:     "resource group",
+			method:   http.MethodDelete,
+			path:     "/subscriptions/123/resourcegroups/my-rg",
+			expected: "item",
+		},
+		{
+			name:     "resources of a resource group",
+			method:   http.MethodGet,
+			path:     "/subscriptions/123/resourcegroups/my-rg/resources",
+			expected: "collection",
+		},
+		{
+			name:     "unknown",
+			method:   http.MethodGet,
+			path:     "/",
+			expected: "unknown",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			g.Expect(operation(c.method, c.path)).To(gomega.Equal(c.expected))
+		})
+	}
+}
+
+func TestWithAzureAPIMetrics(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	req := &http.Request{
+		Method: http.MethodGet,
+		URL:    &url.URL{Path: "/subscriptions/sub-metrics/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-lb"},
+	}
+	header := http.Header{}
+	header.Set("x-ms-ratelimit-remaining-subscription-reads", "11999")
+	header.Set("x-ms-ratelimit-remaining-resource", "Microsoft.Network/HighCostGet3Min;139, Microsoft.Network/HighCostGet30Min;699")
+	sender := autorest.DecorateSender(autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Request: r}, nil
+	}), WithAzureAPIMetrics())
+
+	_, err := sender.Do(req)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	g.Expect(testutil.ToFloat64(AzureAPIRequestsTotal.WithLabelValues("Microsoft.Network/loadBalancers", "item", http.MethodGet, "200"))).To(gomega.Equal(float64(1)))
+	duration := &dto.Metric{}
+	g.Expect(AzureAPIRequestDuration.WithLabelValues("Microsoft.Network/loadBalancers", "item", http.MethodGet, "200").(prometheus.Histogram).Write(duration)).To(gomega.Succeed())
+	g.Expect(duration.GetHistogram().GetSampleCount()).To(gomega.Equal(uint64(1)))
+	g.Expect(testutil.ToFloat64(AzureAPIRateLimitRemaining.WithLabelValues("sub-metrics", "subscription-reads"))).To(gomega.Equal(float64(11999)))
+	g.Expect(testutil.ToFloat64(AzureAPIRateLimitRemaining.WithLabelValues("sub-metrics", "resource:Microsoft.Network/HighCostGet3Min"))).To(gomega.Equal(float64(139)))
+	g.Expect(testutil.ToFloat64(AzureAPIRateLimitRemaining.WithLabelValues("sub-metrics", "resource:Microsoft.Network/HighCostGet30Min"))).To(gomega.Equal(float64(699)))
+}
+
+func TestWithAzureAPIMetricsSendError(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	req := &http.Request{
+		Method: http.MethodPut,
+		URL:    &url.URL{Path: "/subscriptions/sub-errors/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-ip"},
+	}
+	sender := autorest.DecorateSender(autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection reset")
+	}), WithAzureAPIMetrics())
+
+	_, err := sender.Do(req)
+	g.Expect(err).To(gomega.MatchError("connection reset"))
+	g.Expect(testutil.ToFloat64(AzureAPIRequestsTotal.WithLabelValues("Microsoft.Network/publicIPAddresses", "item", http.MethodPut, "error"))).To(gomega.Equal(float64(1)))
+}
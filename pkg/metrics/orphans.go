@@ -0,0 +1,50 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// OrphanedAzureResources reports the number of Azure resources owned by clusters or machines that no longer
+	// exist, as found by the last pass of the orphaned resource collector.
+	OrphanedAzureResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capz_orphaned_azure_resources",
+			Help: "Number of Azure resources owned by clusters or machines that no longer exist, by resource group and resource type.",
+		},
+		[]string{"resource_group", "type"},
+	)
+
+	// OrphanedAzureResourcesDeletedTotal counts the orphaned Azure resources deleted by the collector.
+	OrphanedAzureResourcesDeletedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capz_orphaned_azure_resources_deleted_total",
+			Help: "Total number of orphaned Azure resources deleted, by resource type.",
+		},
+		[]string{"type"},
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		OrphanedAzureResources,
+		OrphanedAzureResourcesDeletedTotal,
+	)
+}
@@ -25,6 +25,8 @@ const (
 	DefaultLoopTimeout = 90 * time.Minute
 	// DefaultMappingTimeout is the default timeout for a controller request mapping func
 	DefaultMappingTimeout = 60 * time.Second
+	// DefaultMaxConcurrentServices is the default number of Azure services reconciled concurrently for a single object
+	DefaultMaxConcurrentServices = 4
 )
 
 // DefaultedLoopTimeout will default the timeout if it is zero valued
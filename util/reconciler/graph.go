@@ -0,0 +1,179 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Step is a named unit of work of a reconcile graph.
+type Step struct {
+	// Name uniquely identifies the step within the graph.
+	Name string
+	// DependsOn is the list of step names that must complete successfully before this step runs.
+	DependsOn []string
+	// Run does the work of the step.
+	Run func(ctx context.Context) error
+}
+
+// RunGraph runs the steps of a dependency graph, running independent steps concurrently with at most maxParallel
+// steps in flight. A step only runs once all of its dependencies have succeeded; steps depending, directly or not,
+// on a failed step are skipped. Errors from all failed steps are aggregated in the order the steps were given.
+func RunGraph(ctx context.Context, maxParallel int, steps ...Step) error {
+	if maxParallel < 1 {
+		maxParallel = 1
+	}
+
+	index := make(map[string]int, len(steps))
+	for i, step := range steps {
+		if _, ok := index[step.Name]; ok {
+			return errors.Errorf("duplicate step %q in reconcile graph", step.Name)
+		}
+		index[step.Name] = i
+	}
+
+	pending := make([]int, len(steps))
+	blocked := make([]bool, len(steps))
+	dependents := make([][]int, len(steps))
+	for i, step := range steps {
+		for _, dep := range step.DependsOn {
+			j, ok := index[dep]
+			if !ok {
+				return errors.Errorf("step %q depends on unknown step %q", step.Name, dep)
+			}
+			pending[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	type result struct {
+		step int
+		err  error
+	}
+
+	var (
+		ready    []int
+		running  int
+		finished int
+		errs     = make([]error, len(steps))
+		results  = make(chan result)
+	)
+	for i := range steps {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	// complete releases the dependents of a step which either ran or was skipped.
+	var complete func(i int, failed bool)
+	complete = func(i int, failed bool) {
+		finished++
+		for _, d := range dependents[i] {
+			pending[d]--
+			blocked[d] = blocked[d] || failed
+			if pending[d] > 0 {
+				continue
+			}
+			if blocked[d] {
+				complete(d, true)
+				continue
+			}
+			ready = append(ready, d)
+		}
+	}
+
+	for len(ready) > 0 || running > 0 {
+		for len(ready) > 0 && running < maxParallel {
+			i := ready[0]
+			ready = ready[1:]
+			running++
+			go func(i int) {
+				results <- result{step: i, err: steps[i].Run(ctx)}
+			}(i)
+		}
+
+		res := <-results
+		running--
+		errs[res.step] = res.err
+		complete(res.step, res.err != nil)
+	}
+
+	if finished < len(steps) {
+		return errors.New("reconcile graph contains a dependency cycle")
+	}
+
+	return newAggregate(errs)
+}
+
+// aggregate is an error made of the errors of several independent steps. Unlike the apimachinery aggregate, it
+// supports errors.Is and errors.As so callers can inspect the errors of all the failed steps.
+type aggregate []error
+
+// newAggregate returns nil if there are no errors, the error itself if there is only one, or an aggregate.
+func newAggregate(errs []error) error {
+	var agg aggregate
+	for _, err := range errs {
+		if err != nil {
+			agg = append(agg, err)
+		}
+	}
+	switch len(agg) {
+	case 0:
+		return nil
+	case 1:
+		return agg[0]
+	default:
+		return agg
+	}
+}
+
+// Error returns the messages of all the aggregated errors.
+func (agg aggregate) Error() string {
+	msgs := make([]string, len(agg))
+	for i, err := range agg {
+		msgs[i] = err.Error()
+	}
+	return "[" + strings.Join(msgs, ", ") + "]"
+}
+
+// Is returns true if any of the aggregated errors matches the target.
+func (agg aggregate) Is(target error) bool {
+	for _, err := range agg {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first aggregated error that matches the target, and if so, sets the target to that error value.
+func (agg aggregate) As(target interface{}) bool {
+	for _, err := range agg {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returns the aggregated errors.
+func (agg aggregate) Errors() []error {
+	return []error(agg)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+type recorder struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (r *recorder) step(name string, err error, deps ...string) reconciler.Step {
+	return reconciler.Step{
+		Name:      name,
+		DependsOn: deps,
+		Run: func(_ context.Context) error {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.order = append(r.order, name)
+			return err
+		},
+	}
+}
+
+func (r *recorder) ran() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.order...)
+}
+
+func indexOf(list []string, s string) int {
+	for i, item := range list {
+		if item == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestRunGraphRespectsDependencies(t *testing.T) {
+	g := gomega.NewWithT(t)
+	r := &recorder{}
+
+	err := reconciler.RunGraph(context.TODO(), 3,
+		r.step("group", nil),
+		r.step("vnet", nil, "group"),
+		r.step("nsg", nil, "vnet"),
+		r.step("routetable", nil, "vnet"),
+		r.step("subnets", nil, "nsg", "routetable"),
+		r.step("publicips", nil, "group"),
+		r.step("lb", nil, "subnets", "publicips"),
+	)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	order := r.ran()
+	g.Expect(order).To(gomega.HaveLen(7))
+	g.Expect(indexOf(order, "group")).To(gomega.BeNumerically("<", indexOf(order, "vnet")))
+	g.Expect(indexOf(order, "group")).To(gomega.BeNumerically("<", indexOf(order, "publicips")))
+	g.Expect(indexOf(order, "nsg")).To(gomega.BeNumerically("<", indexOf(order, "subnets")))
+	g.Expect(indexOf(order, "routetable")).To(gomega.BeNumerically("<", indexOf(order, "subnets")))
+	g.Expect(indexOf(order, "subnets")).To(gomega.BeNumerically("<", indexOf(order, "lb")))
+	g.Expect(indexOf(order, "publicips")).To(gomega.BeNumerically("<", indexOf(order, "lb")))
+}
+
+func TestRunGraphSkipsDependentsOfFailedSteps(t *testing.T) {
+	g := gomega.NewWithT(t)
+	r := &recorder{}
+
+	err := reconciler.RunGraph(context.TODO(), 2,
+		r.step("a", errors.New("a failed")),
+		r.step("b", nil, "a"),
+		r.step("c", nil, "b"),
+		r.step("d", errors.New("d failed")),
+		r.step("e", nil),
+	)
+	g.Expect(err).To(gomega.MatchError("[a failed, d failed]"))
+	g.Expect(r.ran()).To(gomega.ConsistOf("a", "d", "e"))
+}
+
+func TestRunGraphReturnsSingleErrorUnwrapped(t *testing.T) {
+	g := gomega.NewWithT(t)
+	sentinel := errors.New("not owned")
+	r := &recorder{}
+
+	err := reconciler.RunGraph(context.TODO(), 2,
+		r.step("a", errors.Wrap(sentinel, "failed to delete a")),
+		r.step("b", nil),
+	)
+	g.Expect(err).To(gomega.MatchError("failed to delete a: not owned"))
+	g.Expect(errors.Is(err, sentinel)).To(gomega.BeTrue())
+}
+
+type stepError struct {
+	step string
+}
+
+func (e stepError) Error() string {
+	return "step " + e.step + " failed"
+}
+
+func TestRunGraphAggregateSupportsIsAndAs(t *testing.T) {
+	g := gomega.NewWithT(t)
+	sentinel := errors.New("sentinel")
+	r := &recorder{}
+
+	err := reconciler.RunGraph(context.TODO(), 2,
+		r.step("a", errors.Wrap(stepError{step: "a"}, "failed")),
+		r.step("b", errors.Wrap(sentinel, "failed")),
+	)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(errors.Is(err, sentinel)).To(gomega.BeTrue())
+
+	var target stepError
+	g.Expect(errors.As(err, &target)).To(gomega.BeTrue())
+	g.Expect(target.step).To(gomega.Equal("a"))
+}
+
+func TestRunGraphBoundsParallelism(t *testing.T) {
+	g := gomega.NewWithT(t)
+	var inFlight, maxInFlight int32
+
+	var steps []reconciler.Step
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		steps = append(steps, reconciler.Step{
+			Name: name,
+			Run: func(_ context.Context) error {
+				current := atomic.AddInt32(&inFlight, 1)
+				for {
+					observed := atomic.LoadInt32(&maxInFlight)
+					if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&inFlight, -1)
+				return nil
+			},
+		})
+	}
+
+	g.Expect(reconciler.RunGraph(context.TODO(), 2, steps...)).To(gomega.Succeed())
+	g.Expect(atomic.LoadInt32(&maxInFlight)).To(gomega.BeNumerically("<=", 2))
+	g.Expect(atomic.LoadInt32(&maxInFlight)).To(gomega.BeNumerically(">", 1))
+}
+
+func TestRunGraphInvalidGraphs(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Steps         []reconciler.Step
+		ExpectedError string
+	}{
+		{
+			Name: "unknown dependency",
+			Steps: []reconciler.Step{
+				{Name: "a", DependsOn: []string{"b"}, Run: func(_ context.Context) error { return nil }},
+			},
+			ExpectedError: `step "a" depends on unknown step "b"`,
+		},
+		{
+			Name: "duplicate step",
+			Steps: []reconciler.Step{
+				{Name: "a", Run: func(_ context.Context) error { return nil }},
+				{Name: "a", Run: func(_ context.Context) error { return nil }},
+			},
+			ExpectedError: `duplicate step "a" in reconcile graph`,
+		},
+		{
+			Name: "cycle",
+			Steps: []reconciler.Step{
+				{Name: "a", DependsOn: []string{"b"}, Run: func(_ context.Context) error { return nil }},
+				{Name: "b", DependsOn: []string{"a"}, Run: func(_ context.Context) error { return nil }},
+			},
+			ExpectedError: "reconcile graph contains a dependency cycle",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			g.Expect(reconciler.RunGraph(context.TODO(), 2, c.Steps...)).To(gomega.MatchError(c.ExpectedError))
+		})
+	}
+}